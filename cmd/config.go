@@ -10,12 +10,22 @@ import (
 	"github.com/spf13/viper"
 
 	"watchtower/cmd/watchtower/httpserver"
+	"watchtower/internal/core/cloud/infrastructure/localfs"
+	"watchtower/internal/core/cloud/infrastructure/notify"
 	"watchtower/internal/core/cloud/infrastructure/s3"
 	"watchtower/internal/process"
+	"watchtower/internal/support/task/infrastructure/clamd"
+	"watchtower/internal/support/task/infrastructure/cmdparser"
 	"watchtower/internal/support/task/infrastructure/docparser"
 	"watchtower/internal/support/task/infrastructure/docsearch"
+	"watchtower/internal/support/task/infrastructure/llm"
+	"watchtower/internal/support/task/infrastructure/neo4j"
+	"watchtower/internal/support/task/infrastructure/ner"
+	"watchtower/internal/support/task/infrastructure/piiscan"
 	"watchtower/internal/support/task/infrastructure/redis"
 	"watchtower/internal/support/task/infrastructure/rmq"
+	"watchtower/internal/support/task/infrastructure/routing"
+	"watchtower/internal/support/task/infrastructure/tika"
 )
 
 type Config struct {
@@ -30,8 +40,12 @@ type ServerConfig struct {
 	Http httpserver.Config `mapstructure:"http"`
 }
 
+// StorageConfig of object storage. Backend chooses between s3 and localfs.
 type StorageConfig struct {
-	S3 s3.Config `mapstructure:"s3"`
+	Backend string         `mapstructure:"backend"`
+	S3      s3.Config      `mapstructure:"s3"`
+	LocalFS localfs.Config `mapstructure:"localfs"`
+	Events  notify.Config  `mapstructure:"events"`
 }
 
 type TaskConfig struct {
@@ -49,8 +63,16 @@ type TaskQueueConfig struct {
 }
 
 type ProcessorConfig struct {
+	Recognizer routing.Config   `mapstructure:"recognizer"`
 	DocParser  docparser.Config `mapstructure:"docparser"`
+	Command    cmdparser.Config `mapstructure:"command"`
+	Tika       tika.Config      `mapstructure:"tika"`
 	DocStorage docsearch.Config `mapstructure:"docstorage"`
+	Entities   ner.Config       `mapstructure:"entities"`
+	Graph      neo4j.Config     `mapstructure:"graph"`
+	Summarizer llm.Config       `mapstructure:"summarizer"`
+	Pii        piiscan.Config   `mapstructure:"pii"`
+	Clamd      clamd.Config     `mapstructure:"clamd"`
 }
 
 const (
@@ -106,31 +128,114 @@ func setupEnv(viperInst *viper.Viper) {
 
 	//nolint
 	envMappings := map[string]string{
-		"orchestrator.semaphore_size":       "ORCHESTRATOR__SEMAPHORE_SIZE",
-		"otlp.app_name":                     "OTLP__APP_NAME",
-		"otlp.logger.level":                 "OTLP__LOGGER__LEVEL",
-		"otlp.logger.address":               "OTLP__LOGGER__ADDRESS",
-		"otlp.logger.enable_loki":           "OTLP__LOGGER__ENABLE_LOKI",
-		"otlp.tracer.address":               "OTLP__TRACER__ADDRESS",
-		"otlp.tracer.enable_jaeger":         "OTLP__TRACER__ENABLE_JAEGER",
-		"server.http.address":               "SERVER__HTTP__ADDRESS",
-		"storage.s3.address":                "STORAGE__S3__ADDRESS",
-		"storage.s3.access_id":              "STORAGE__S3__ACCESS_ID",
-		"storage.s3.secret_key":             "STORAGE__S3__SECRET_KEY",
-		"storage.s3.enable_ssl":             "STORAGE__S3__ENABLE_SSL",
-		"storage.s3.token":                  "STORAGE__S3__TOKEN",
-		"task.storage.redis.address":        "TASK__STORAGE__REDIS__ADDRESS",
-		"task.storage.redis.username":       "TASK__STORAGE__REDIS__USERNAME",
-		"task.storage.redis.password":       "TASK__STORAGE__REDIS__PASSWORD",
-		"task.storage.redis.expired":        "TASK__STORAGE__REDIS__EXPIRED",
-		"task.queue.rmq.address":            "TASK__QUEUE__RMQ__ADDRESS",
-		"task.queue.rmq.exchange":           "TASK__QUEUE__RMQ__EXCHANGE",
-		"task.queue.rmq.routing_key":        "TASK__QUEUE__RMQ__ROUTING_KEY",
-		"task.queue.rmq.queue":              "TASK__QUEUE__RMQ__QUEUE",
-		"task.processor.docstorage.address": "TASK__PROCESSOR__DOCSTORAGE__ADDRESS",
-		"task.processor.docstorage.timeout": "TASK__PROCESSOR__DOCSTORAGE__TIMEOUT",
-		"task.processor.docparser.address":  "TASK__PROCESSOR__DOCPARSER__ADDRESS",
-		"task.processor.docparser.timeout":  "TASK__PROCESSOR__DOCPARSER__TIMEOUT",
+		"orchestrator.low_confidence_threshold":             "ORCHESTRATOR__LOW_CONFIDENCE_THRESHOLD",
+		"orchestrator.semaphore_size":                       "ORCHESTRATOR__SEMAPHORE_SIZE",
+		"orchestrator.knowledge_graph.enabled":              "ORCHESTRATOR__KNOWLEDGE_GRAPH__ENABLED",
+		"orchestrator.knowledge_graph.buckets":              "ORCHESTRATOR__KNOWLEDGE_GRAPH__BUCKETS",
+		"orchestrator.summary.enabled":                      "ORCHESTRATOR__SUMMARY__ENABLED",
+		"orchestrator.summary.buckets":                      "ORCHESTRATOR__SUMMARY__BUCKETS",
+		"orchestrator.summary.taxonomy":                     "ORCHESTRATOR__SUMMARY__TAXONOMY",
+		"orchestrator.pii.enabled":                          "ORCHESTRATOR__PII__ENABLED",
+		"orchestrator.pii.buckets":                          "ORCHESTRATOR__PII__BUCKETS",
+		"orchestrator.pii.action":                           "ORCHESTRATOR__PII__ACTION",
+		"orchestrator.antivirus.enabled":                    "ORCHESTRATOR__ANTIVIRUS__ENABLED",
+		"orchestrator.antivirus.buckets":                    "ORCHESTRATOR__ANTIVIRUS__BUCKETS",
+		"orchestrator.antivirus.scan_on_upload":             "ORCHESTRATOR__ANTIVIRUS__SCAN_ON_UPLOAD",
+		"orchestrator.antivirus.quarantine_prefix":          "ORCHESTRATOR__ANTIVIRUS__QUARANTINE_PREFIX",
+		"orchestrator.dedup.enabled":                        "ORCHESTRATOR__DEDUP__ENABLED",
+		"orchestrator.dedup.buckets":                        "ORCHESTRATOR__DEDUP__BUCKETS",
+		"orchestrator.dedup.max_distance":                   "ORCHESTRATOR__DEDUP__MAX_DISTANCE",
+		"orchestrator.dedup.action":                         "ORCHESTRATOR__DEDUP__ACTION",
+		"orchestrator.post_process.enabled":                 "ORCHESTRATOR__POST_PROCESS__ENABLED",
+		"orchestrator.post_process.buckets":                 "ORCHESTRATOR__POST_PROCESS__BUCKETS",
+		"orchestrator.post_process.processed_prefix":        "ORCHESTRATOR__POST_PROCESS__PROCESSED_PREFIX",
+		"orchestrator.post_process.unrecognized_prefix":     "ORCHESTRATOR__POST_PROCESS__UNRECOGNIZED_PREFIX",
+		"orchestrator.post_process.error_metadata_key":      "ORCHESTRATOR__POST_PROCESS__ERROR_METADATA_KEY",
+		"orchestrator.watcher.enabled":                      "ORCHESTRATOR__WATCHER__ENABLED",
+		"orchestrator.watcher.interval":                     "ORCHESTRATOR__WATCHER__INTERVAL",
+		"orchestrator.events.enabled":                       "ORCHESTRATOR__EVENTS__ENABLED",
+		"orchestrator.events.buckets":                       "ORCHESTRATOR__EVENTS__BUCKETS",
+		"orchestrator.events.source":                        "ORCHESTRATOR__EVENTS__SOURCE",
+		"orchestrator.events.prefixes":                      "ORCHESTRATOR__EVENTS__PREFIXES",
+		"orchestrator.events.suffixes":                      "ORCHESTRATOR__EVENTS__SUFFIXES",
+		"orchestrator.events.webhook_token":                 "ORCHESTRATOR__EVENTS__WEBHOOK_TOKEN",
+		"orchestrator.poller.enabled":                       "ORCHESTRATOR__POLLER__ENABLED",
+		"orchestrator.poller.buckets":                       "ORCHESTRATOR__POLLER__BUCKETS",
+		"orchestrator.poller.prefixes":                      "ORCHESTRATOR__POLLER__PREFIXES",
+		"orchestrator.poller.interval":                      "ORCHESTRATOR__POLLER__INTERVAL",
+		"orchestrator.poller.lock_ttl":                      "ORCHESTRATOR__POLLER__LOCK_TTL",
+		"orchestrator.poller.process_existing":              "ORCHESTRATOR__POLLER__PROCESS_EXISTING",
+		"orchestrator.uploads.enabled":                      "ORCHESTRATOR__UPLOADS__ENABLED",
+		"orchestrator.uploads.max_size":                     "ORCHESTRATOR__UPLOADS__MAX_SIZE",
+		"orchestrator.uploads.part_size":                    "ORCHESTRATOR__UPLOADS__PART_SIZE",
+		"orchestrator.uploads.expiration":                   "ORCHESTRATOR__UPLOADS__EXPIRATION",
+		"orchestrator.uploads.cleanup_interval":             "ORCHESTRATOR__UPLOADS__CLEANUP_INTERVAL",
+		"orchestrator.presigned_uploads.enabled":            "ORCHESTRATOR__PRESIGNED_UPLOADS__ENABLED",
+		"orchestrator.presigned_uploads.max_size":           "ORCHESTRATOR__PRESIGNED_UPLOADS__MAX_SIZE",
+		"orchestrator.presigned_uploads.expiration":         "ORCHESTRATOR__PRESIGNED_UPLOADS__EXPIRATION",
+		"orchestrator.presigned_uploads.completion_timeout": "ORCHESTRATOR__PRESIGNED_UPLOADS__COMPLETION_TIMEOUT",
+		"orchestrator.archive.enabled":                      "ORCHESTRATOR__ARCHIVE__ENABLED",
+		"orchestrator.archive.buckets":                      "ORCHESTRATOR__ARCHIVE__BUCKETS",
+		"orchestrator.archive.target_prefix":                "ORCHESTRATOR__ARCHIVE__TARGET_PREFIX",
+		"orchestrator.archive.max_entries":                  "ORCHESTRATOR__ARCHIVE__MAX_ENTRIES",
+		"orchestrator.archive.max_expanded_size":            "ORCHESTRATOR__ARCHIVE__MAX_EXPANDED_SIZE",
+		"orchestrator.archive.keep_original":                "ORCHESTRATOR__ARCHIVE__KEEP_ORIGINAL",
+		"otlp.app_name":                                     "OTLP__APP_NAME",
+		"otlp.logger.level":                                 "OTLP__LOGGER__LEVEL",
+		"otlp.logger.address":                               "OTLP__LOGGER__ADDRESS",
+		"otlp.logger.enable_loki":                           "OTLP__LOGGER__ENABLE_LOKI",
+		"otlp.tracer.address":                               "OTLP__TRACER__ADDRESS",
+		"otlp.tracer.enable_jaeger":                         "OTLP__TRACER__ENABLE_JAEGER",
+		"server.http.address":                               "SERVER__HTTP__ADDRESS",
+		"storage.backend":                                   "STORAGE__BACKEND",
+		"storage.localfs.root_dir":                          "STORAGE__LOCALFS__ROOT_DIR",
+		"storage.localfs.public_url":                        "STORAGE__LOCALFS__PUBLIC_URL",
+		"storage.localfs.sign_key":                          "STORAGE__LOCALFS__SIGN_KEY",
+		"storage.s3.address":                                "STORAGE__S3__ADDRESS",
+		"storage.s3.access_id":                              "STORAGE__S3__ACCESS_ID",
+		"storage.s3.secret_key":                             "STORAGE__S3__SECRET_KEY",
+		"storage.s3.enable_ssl":                             "STORAGE__S3__ENABLE_SSL",
+		"storage.s3.token":                                  "STORAGE__S3__TOKEN",
+		"storage.events.address":                            "STORAGE__EVENTS__ADDRESS",
+		"storage.events.queue":                              "STORAGE__EVENTS__QUEUE",
+		"task.storage.redis.address":                        "TASK__STORAGE__REDIS__ADDRESS",
+		"task.storage.redis.username":                       "TASK__STORAGE__REDIS__USERNAME",
+		"task.storage.redis.password":                       "TASK__STORAGE__REDIS__PASSWORD",
+		"task.storage.redis.expired":                        "TASK__STORAGE__REDIS__EXPIRED",
+		"task.queue.rmq.address":                            "TASK__QUEUE__RMQ__ADDRESS",
+		"task.queue.rmq.exchange":                           "TASK__QUEUE__RMQ__EXCHANGE",
+		"task.queue.rmq.routing_key":                        "TASK__QUEUE__RMQ__ROUTING_KEY",
+		"task.queue.rmq.queue":                              "TASK__QUEUE__RMQ__QUEUE",
+		"task.processor.docstorage.address":                 "TASK__PROCESSOR__DOCSTORAGE__ADDRESS",
+		"task.processor.docstorage.timeout":                 "TASK__PROCESSOR__DOCSTORAGE__TIMEOUT",
+		"task.processor.recognizer.fallback":                "TASK__PROCESSOR__RECOGNIZER__FALLBACK",
+		"task.processor.command.timeout":                    "TASK__PROCESSOR__COMMAND__TIMEOUT",
+		"task.processor.command.max_output_size":            "TASK__PROCESSOR__COMMAND__MAX_OUTPUT_SIZE",
+		"task.processor.tika.address":                       "TASK__PROCESSOR__TIKA__ADDRESS",
+		"task.processor.tika.timeout":                       "TASK__PROCESSOR__TIKA__TIMEOUT",
+		"task.processor.tika.extract_metadata":              "TASK__PROCESSOR__TIKA__EXTRACT_METADATA",
+		"task.processor.docparser.address":                  "TASK__PROCESSOR__DOCPARSER__ADDRESS",
+		"task.processor.docparser.timeout":                  "TASK__PROCESSOR__DOCPARSER__TIMEOUT",
+		"task.processor.entities.address":                   "TASK__PROCESSOR__ENTITIES__ADDRESS",
+		"task.processor.entities.timeout":                   "TASK__PROCESSOR__ENTITIES__TIMEOUT",
+		"task.processor.graph.address":                      "TASK__PROCESSOR__GRAPH__ADDRESS",
+		"task.processor.graph.database":                     "TASK__PROCESSOR__GRAPH__DATABASE",
+		"task.processor.graph.username":                     "TASK__PROCESSOR__GRAPH__USERNAME",
+		"task.processor.graph.password":                     "TASK__PROCESSOR__GRAPH__PASSWORD",
+		"task.processor.graph.timeout":                      "TASK__PROCESSOR__GRAPH__TIMEOUT",
+		"task.processor.clamd.network":                      "TASK__PROCESSOR__CLAMD__NETWORK",
+		"task.processor.clamd.address":                      "TASK__PROCESSOR__CLAMD__ADDRESS",
+		"task.processor.clamd.timeout":                      "TASK__PROCESSOR__CLAMD__TIMEOUT",
+		"task.processor.clamd.chunk_size":                   "TASK__PROCESSOR__CLAMD__CHUNK_SIZE",
+		"task.processor.summarizer.address":                 "TASK__PROCESSOR__SUMMARIZER__ADDRESS",
+		"task.processor.summarizer.api_key":                 "TASK__PROCESSOR__SUMMARIZER__API_KEY",
+		"task.processor.summarizer.model":                   "TASK__PROCESSOR__SUMMARIZER__MODEL",
+		"task.processor.summarizer.max_tokens":              "TASK__PROCESSOR__SUMMARIZER__MAX_TOKENS",
+		"task.processor.summarizer.timeout":                 "TASK__PROCESSOR__SUMMARIZER__TIMEOUT",
+		"task.processor.summarizer.chunk_size":              "TASK__PROCESSOR__SUMMARIZER__CHUNK_SIZE",
+		"task.processor.summarizer.max_chunks":              "TASK__PROCESSOR__SUMMARIZER__MAX_CHUNKS",
+		"task.processor.summarizer.chunk_prompt":            "TASK__PROCESSOR__SUMMARIZER__CHUNK_PROMPT",
+		"task.processor.summarizer.summary_prompt":          "TASK__PROCESSOR__SUMMARIZER__SUMMARY_PROMPT",
 	}
 
 	var bindErr error
@@ -3,23 +3,62 @@ package form
 import (
 	"time"
 
+	"github.com/google/uuid"
+
 	cloud "watchtower/internal/core/cloud/domain"
 	task "watchtower/internal/support/task/domain"
 )
 
 // TaskSchema example
 type TaskSchema struct {
-	ID             string    `json:"id"`
-	BucketID       string    `json:"bucket_id"`
-	ObjectID       string    `json:"object_id"`
-	ObjectDataSize int       `json:"object_data_size"`
-	StatusText     string    `json:"status_text"`
-	Status         int       `json:"status"`
-	CreatedAt      time.Time `json:"created_at"`
-	ModifiedAt     time.Time `json:"modified_at"`
+	ID             string         `json:"id"`
+	BucketID       string         `json:"bucket_id"`
+	ObjectID       string         `json:"object_id"`
+	ObjectDataSize int            `json:"object_data_size"`
+	StatusText     string         `json:"status_text"`
+	Status         int            `json:"status"`
+	CreatedAt      time.Time      `json:"created_at"`
+	ModifiedAt     time.Time      `json:"modified_at"`
+	ParentID       string         `json:"parent_id,omitempty"`
+	Children       []string       `json:"children,omitempty"`
+	Summary        string         `json:"summary,omitempty"`
+	Labels         []string       `json:"labels,omitempty"`
+	PiiCounts      map[string]int `json:"pii_counts,omitempty"`
+	Fingerprint    string         `json:"fingerprint,omitempty"`
+	Duplicate      *DuplicateForm `json:"duplicate,omitempty"`
+	Location       string         `json:"location,omitempty"`
+	VersionID      string         `json:"version_id,omitempty"`
+}
+
+// DuplicateForm example
+type DuplicateForm struct {
+	ObjectID   string `json:"object_id"`
+	DocumentID string `json:"document_id"`
+	Distance   int    `json:"distance"`
+	Linked     bool   `json:"linked"`
 }
 
 func TaskFromDomain(task task.Task) TaskSchema {
+	var parentID string
+	if task.ParentID != uuid.Nil {
+		parentID = task.ParentID.String()
+	}
+
+	var children []string
+	for _, childID := range task.Children {
+		children = append(children, childID.String())
+	}
+
+	var duplicate *DuplicateForm
+	if task.Duplicate != nil {
+		duplicate = &DuplicateForm{
+			ObjectID:   task.Duplicate.ObjectID,
+			DocumentID: task.Duplicate.DocumentID,
+			Distance:   task.Duplicate.Distance,
+			Linked:     task.Duplicate.Linked,
+		}
+	}
+
 	return TaskSchema{
 		ID:             task.ID.String(),
 		BucketID:       task.BucketID,
@@ -29,6 +68,34 @@ func TaskFromDomain(task task.Task) TaskSchema {
 		Status:         int(task.Status),
 		CreatedAt:      task.CreatedAt,
 		ModifiedAt:     task.ModifiedAt,
+		ParentID:       parentID,
+		Children:       children,
+		Summary:        task.Summary,
+		Labels:         task.Labels,
+		PiiCounts:      task.PiiCounts,
+		Fingerprint:    task.Fingerprint,
+		Duplicate:      duplicate,
+		Location:       task.Location,
+		VersionID:      task.VersionID,
+	}
+}
+
+// WatcherSchema example
+type WatcherSchema struct {
+	ID         string    `json:"id"`
+	BucketID   string    `json:"bucket"`
+	Prefix     string    `json:"prefix" example:"some-directory/"`
+	CreatedAt  time.Time `json:"created_at"`
+	LastScanAt time.Time `json:"last_scan_at"`
+}
+
+func WatcherFromDomain(watcher task.Watcher) WatcherSchema {
+	return WatcherSchema{
+		ID:         watcher.ID.String(),
+		BucketID:   watcher.BucketID,
+		Prefix:     watcher.Prefix,
+		CreatedAt:  watcher.CreatedAt,
+		LastScanAt: watcher.LastScanAt,
 	}
 }
 
@@ -47,16 +114,52 @@ func BucketFromDomain(bucket cloud.Bucket) BucketSchema {
 	}
 }
 
+// BucketConfigSchema example
+type BucketConfigSchema struct {
+	Versioning *bool               `json:"versioning"`
+	Lifecycle  []LifecycleRuleForm `json:"lifecycle"`
+	ObjectLock *ObjectLockForm     `json:"object_lock"`
+	QuotaBytes *int64              `json:"quota_bytes"`
+}
+
+func BucketConfigFromDomain(config cloud.BucketConfig) BucketConfigSchema {
+	configDto := BucketConfigSchema{
+		Versioning: config.Versioning,
+		Lifecycle:  make([]LifecycleRuleForm, len(config.Lifecycle)),
+		QuotaBytes: config.QuotaBytes,
+	}
+
+	for index, rule := range config.Lifecycle {
+		configDto.Lifecycle[index] = LifecycleRuleForm(rule)
+	}
+
+	if config.ObjectLock != nil {
+		lock := ObjectLockForm(*config.ObjectLock)
+		configDto.ObjectLock = &lock
+	}
+
+	return configDto
+}
+
 // ObjectSchema example
 type ObjectSchema struct {
-	Name         string    `json:"name"`
-	Path         string    `json:"path"`
-	Checksum     string    `json:"checksum"`
-	ContentType  string    `json:"content_type"`
-	Expired      time.Time `json:"expired"`
-	LastModified time.Time `json:"last_modified"`
-	Size         int64     `json:"size"`
-	IsDirectory  bool      `json:"is_directory"`
+	Name         string            `json:"name"`
+	Path         string            `json:"path"`
+	Checksum     string            `json:"checksum"`
+	ContentType  string            `json:"content_type"`
+	Expired      time.Time         `json:"expired"`
+	LastModified time.Time         `json:"last_modified"`
+	Size         int64             `json:"size"`
+	IsDirectory  bool              `json:"is_directory"`
+	Metadata     map[string]string `json:"metadata,omitempty"`
+	Tags         map[string]string `json:"tags,omitempty"`
+	VersionID    string            `json:"version_id,omitempty"`
+}
+
+// ObjectsPageSchema example
+type ObjectsPageSchema struct {
+	Objects   []ObjectSchema `json:"objects"`
+	NextToken string         `json:"next_token" example:"cGF0aDp0ZXN0LWZvbGRlci90ZXN0LWZpbGUuZG9jeA"`
 }
 
 func ObjectFromDomain(object cloud.Object) ObjectSchema {
@@ -69,5 +172,52 @@ func ObjectFromDomain(object cloud.Object) ObjectSchema {
 		LastModified: object.LastModified,
 		Size:         object.Size,
 		IsDirectory:  object.IsDirectory,
+		Metadata:     object.Metadata,
+		Tags:         object.Tags,
+		VersionID:    object.VersionID,
+	}
+}
+
+// ObjectVersionSchema example
+type ObjectVersionSchema struct {
+	VersionID      string    `json:"version_id" example:"3HL4kqtJlcpXroDTDmJ+rmSpXd3dIbrHY+MTRCxf3vjVBH40Nrjfkd"`
+	ETag           string    `json:"etag" example:"9e107d9d372bb6826bd81d3542a419d6"`
+	LastModified   time.Time `json:"last_modified"`
+	Size           int64     `json:"size" example:"1024"`
+	IsLatest       bool      `json:"is_latest" example:"true"`
+	IsDeleteMarker bool      `json:"is_delete_marker" example:"false"`
+}
+
+func ObjectVersionFromDomain(version cloud.ObjectVersion) ObjectVersionSchema {
+	return ObjectVersionSchema(version)
+}
+
+// ObjectTagsSchema example
+type ObjectTagsSchema struct {
+	FilePath string            `json:"file_path" example:"test-file.docx"`
+	Tags     map[string]string `json:"tags"`
+}
+
+// SearchErrorSchema example
+type SearchErrorSchema struct {
+	Error string `json:"error" example:"failed to search bucket files"`
+}
+
+// PresignedUploadSchema example
+type PresignedUploadSchema struct {
+	Method    string            `json:"method" example:"PUT"`
+	URL       string            `json:"url"`
+	Headers   map[string]string `json:"headers,omitempty"`
+	FormData  map[string]string `json:"form_data,omitempty"`
+	ExpiresAt time.Time         `json:"expires_at"`
+}
+
+func PresignedUploadFromDomain(presigned cloud.PresignedUpload) PresignedUploadSchema {
+	return PresignedUploadSchema{
+		Method:    presigned.Method,
+		URL:       presigned.URL.String(),
+		Headers:   presigned.Headers,
+		FormData:  presigned.FormData,
+		ExpiresAt: presigned.ExpiresAt,
 	}
 }
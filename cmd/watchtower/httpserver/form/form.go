@@ -1,5 +1,7 @@
 package form
 
+import "time"
+
 // AddDirectoryToWatcherForm example
 type AddDirectoryToWatcherForm struct {
 	BucketName string `json:"bucket" example:"test-folder"`
@@ -8,7 +10,31 @@ type AddDirectoryToWatcherForm struct {
 
 // CreateBucketForm example
 type CreateBucketForm struct {
-	BucketName string `json:"bucket_name" example:"test-bucket"`
+	BucketName string            `json:"bucket_name" example:"test-bucket"`
+	Config     *BucketConfigForm `json:"config,omitempty"`
+}
+
+// BucketConfigForm example
+type BucketConfigForm struct {
+	Versioning *bool               `json:"versioning,omitempty" example:"true"`
+	Lifecycle  []LifecycleRuleForm `json:"lifecycle,omitempty"`
+	ObjectLock *ObjectLockForm     `json:"object_lock,omitempty"`
+	QuotaBytes *int64              `json:"quota_bytes,omitempty" example:"10737418240"`
+}
+
+// LifecycleRuleForm example
+type LifecycleRuleForm struct {
+	ID                       string `json:"id,omitempty" example:"expire-tmp"`
+	Prefix                   string `json:"prefix" example:"tmp/"`
+	ExpirationDays           int    `json:"expiration_days,omitempty" example:"7"`
+	NoncurrentExpirationDays int    `json:"noncurrent_expiration_days,omitempty" example:"30"`
+}
+
+// ObjectLockForm example
+type ObjectLockForm struct {
+	Enabled       bool   `json:"enabled" example:"true"`
+	Mode          string `json:"mode,omitempty" example:"GOVERNANCE" enums:"GOVERNANCE,COMPLIANCE"`
+	RetentionDays int    `json:"retention_days,omitempty" example:"30"`
 }
 
 // MoveFilesForm example
@@ -34,11 +60,43 @@ type ShareFileForm struct {
 	ExpiredSecs int32  `json:"expired_secs" example:"3600"`
 }
 
+// CreateUploadURLForm example
+type CreateUploadURLForm struct {
+	FilePath    string            `json:"file_path" example:"incoming/test-file.docx"`
+	Method      string            `json:"method" example:"PUT"`
+	ContentType string            `json:"content_type" example:"application/msword"`
+	Size        int64             `json:"size" example:"1024"`
+	Checksum    string            `json:"checksum" example:"9e107d9d372bb6826bd81d3542a419d6"`
+	Metadata    map[string]string `json:"metadata,omitempty"`
+}
+
+// CompleteUploadForm example
+type CompleteUploadForm struct {
+	FilePath string `json:"file_path" example:"incoming/test-file.docx"`
+}
+
 // GetFilesForm example
 type GetFilesForm struct {
-	DirectoryName string `json:"directory" example:"test-folder/"`
-	Limit         int32  `json:"limit" example:"10"`
-	Offset        int32  `json:"offset" example:"0"`
+	DirectoryName     string `json:"directory" example:"test-folder/"`
+	Limit             int32  `json:"limit" example:"10"`
+	Offset            int32  `json:"offset" example:"0"`
+	ContinuationToken string `json:"continuation_token" example:""`
+	SortBy            string `json:"sort_by" example:"name" enums:"name,size,modified"`
+	SortDesc          bool   `json:"sort_desc" example:"false"`
+}
+
+// SearchFilesForm example
+type SearchFilesForm struct {
+	Prefix         string            `json:"prefix" example:"test-folder/"`
+	Glob           string            `json:"glob" example:"*.docx"`
+	Regex          string            `json:"regex" example:""`
+	MinSize        *int64            `json:"min_size" example:"1024"`
+	MaxSize        *int64            `json:"max_size" example:"10485760"`
+	ModifiedAfter  *time.Time        `json:"modified_after" example:"2025-01-01T00:00:00Z"`
+	ModifiedBefore *time.Time        `json:"modified_before" example:"2026-01-01T00:00:00Z"`
+	ContentType    string            `json:"content_type" example:"application/*"`
+	Metadata       map[string]string `json:"metadata"`
+	Limit          int               `json:"limit" example:"100"`
 }
 
 // GetFileAttributesForm example
@@ -46,10 +104,42 @@ type GetFileAttributesForm struct {
 	FilePath string `json:"file_path" example:"test-file.docx"`
 }
 
+// SetFileTagsForm example
+type SetFileTagsForm struct {
+	FilePath string            `json:"file_path" example:"test-file.docx"`
+	Tags     map[string]string `json:"tags"`
+	Reindex  bool              `json:"reindex" example:"true"`
+}
+
+// DeleteFileTagsForm example
+type DeleteFileTagsForm struct {
+	FilePath string `json:"file_path" example:"test-file.docx"`
+	Reindex  bool   `json:"reindex" example:"true"`
+}
+
+// ReplaceFileMetadataForm example
+type ReplaceFileMetadataForm struct {
+	FilePath string            `json:"file_path" example:"test-file.docx"`
+	Metadata map[string]string `json:"metadata"`
+	Reindex  bool              `json:"reindex" example:"false"`
+}
+
+// FileVersionsForm example
+type FileVersionsForm struct {
+	FilePath string `json:"file_path" example:"test-file.docx"`
+}
+
+// FileVersionForm example
+type FileVersionForm struct {
+	FilePath  string `json:"file_path" example:"test-file.docx"`
+	VersionID string `json:"version_id" example:"3HL4kqtJlcpXroDTDmJ+rmSpXd3dIbrHY+MTRCxf3vjVBH40Nrjfkd"`
+}
+
 // CopyFileForm example
 type CopyFileForm struct {
 	SrcPath    string `json:"src_path" example:"old-test-document.docx"`
 	DstPath    string `json:"dst_path" example:"test-document.docx"`
+	DstBucket  string `json:"dst_bucket,omitempty" example:"archive-bucket"`
 	WithRemove bool   `json:"with_remove" example:"true"`
 }
 
@@ -1,10 +1,12 @@
 package httpserver
 
 import (
+	"encoding/base64"
 	"fmt"
 	"log/slog"
 	"mime/multipart"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/gofiber/fiber/v2"
@@ -36,6 +38,26 @@ func ExtractTaskIDParameter(eCtx *fiber.Ctx) (uuid.UUID, error) {
 	return taskID, nil
 }
 
+func ExtractWatcherIDParameter(eCtx *fiber.Ctx) (uuid.UUID, error) {
+	watcherIDParam := eCtx.Params("watcher_id")
+	if watcherIDParam == "" {
+		err := fmt.Errorf("watcher_id parameter is required")
+		return uuid.Nil, err
+	}
+
+	return uuid.Parse(watcherIDParam)
+}
+
+func ExtractUploadIDParameter(eCtx *fiber.Ctx) (uuid.UUID, error) {
+	uploadIDParam := eCtx.Params("upload_id")
+	if uploadIDParam == "" {
+		err := fmt.Errorf("upload_id parameter is required")
+		return uuid.Nil, err
+	}
+
+	return uuid.Parse(uploadIDParam)
+}
+
 func ExtractTaskStatusParameter(eCtx *fiber.Ctx) (int, error) {
 	statusParam := eCtx.Query("status")
 	status, err := strconv.Atoi(statusParam)
@@ -61,6 +83,49 @@ func ExtractFilePrefixParameter(eCtx *fiber.Ctx) string {
 	return eCtx.FormValue("prefix", "./")
 }
 
+// UserMetadataPrefix is a prefix of headers and form fields which
+// values are stored as user metadata of uploaded object.
+const UserMetadataPrefix = "x-meta-"
+
+func ExtractContentTypeParameter(eCtx *fiber.Ctx, fileForm *multipart.FileHeader) string {
+	contentType := eCtx.FormValue("content_type")
+	if contentType == "" {
+		contentType = fileForm.Header.Get(fiber.HeaderContentType)
+	}
+
+	// Generic binary type is sent by most clients by default,
+	// so leave it empty to let the storage detect actual type.
+	if contentType == fiber.MIMEOctetStream {
+		return ""
+	}
+
+	return contentType
+}
+
+func ExtractUserMetadata(eCtx *fiber.Ctx, multipartForm *multipart.Form) map[string]string {
+	metadata := make(map[string]string)
+	for key, values := range eCtx.GetReqHeaders() {
+		metaKey, found := cutUserMetadataPrefix(key)
+		if found && len(values) > 0 {
+			metadata[metaKey] = values[0]
+		}
+	}
+
+	for key, values := range multipartForm.Value {
+		metaKey, found := cutUserMetadataPrefix(key)
+		if found && len(values) > 0 {
+			metadata[metaKey] = values[0]
+		}
+	}
+
+	return metadata
+}
+
+func cutUserMetadataPrefix(key string) (string, bool) {
+	metaKey, found := strings.CutPrefix(strings.ToLower(key), UserMetadataPrefix)
+	return metaKey, found && metaKey != ""
+}
+
 func ExtractMultipartForm(eCtx *fiber.Ctx) (*multipart.Form, error) {
 	multipartForm, err := eCtx.MultipartForm()
 	if err != nil {
@@ -90,3 +155,26 @@ func ExtractExpiredDatetime(eCtx *fiber.Ctx) (*time.Time, error) {
 
 	return &timeVal, nil
 }
+
+// ParseUploadMetadata decodes tus Upload-Metadata header which is comma
+// separated list of keys with base64 encoded values. Keys are lower-cased,
+// value may be omitted for keys without value.
+func ParseUploadMetadata(header string) (map[string]string, error) {
+	metadata := make(map[string]string)
+	for _, pair := range strings.Split(header, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+
+		key, encoded, _ := strings.Cut(pair, " ")
+		value, err := base64.StdEncoding.DecodeString(strings.TrimSpace(encoded))
+		if err != nil {
+			return nil, fmt.Errorf("invalid upload metadata value of %s: %w", key, err)
+		}
+
+		metadata[strings.ToLower(key)] = string(value)
+	}
+
+	return metadata, nil
+}
@@ -53,6 +53,15 @@ const (
 //
 // @tag.name share
 // @tag.description Share files by URL API
+//
+// @tag.name watchers
+// @tag.description CRUD APIs to manage watched bucket directories
+//
+// @tag.name events
+// @tag.description Storage event notifications API
+//
+// @tag.name uploads
+// @tag.description Resumable uploads API by tus protocol
 type Server struct {
 	tracer trace.Tracer
 
@@ -94,6 +103,10 @@ func SetupServer(otlpConfig otlp_go.OtlpConfig, state *process.Orchestrator) *Se
 	serverApp.CreateTasksGroup(v1Api)
 	serverApp.CreateStorageBucketsGroup(v1Api)
 	serverApp.CreateStorageObjectsGroup(v1Api)
+	serverApp.CreateWatchersGroup(v1Api)
+	serverApp.CreateEventsGroup(v1Api)
+	serverApp.CreateShareGroup(v1Api)
+	serverApp.CreateUploadsGroup(v1Api)
 
 	return serverApp
 }
@@ -2,6 +2,8 @@ package httpserver
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
 
 	"github.com/gofiber/fiber/v2"
 	"go.opentelemetry.io/otel/attribute"
@@ -9,12 +11,15 @@ import (
 	"go.opentelemetry.io/otel/trace"
 
 	"watchtower/cmd/watchtower/httpserver/form"
+	"watchtower/internal/core/cloud/domain"
 )
 
 func (s *Server) CreateStorageBucketsGroup(group fiber.Router) {
 	group.Get("/cloud/buckets", s.GetBuckets)
 	group.Put("/cloud/bucket", s.CreateBucket)
 	group.Delete("/cloud/:bucket", s.RemoveBucket)
+	group.Get("/cloud/:bucket/config", s.GetBucketConfig)
+	group.Put("/cloud/:bucket/config", s.SetBucketConfig)
 }
 
 // GetBuckets
@@ -50,7 +55,8 @@ func (s *Server) GetBuckets(eCtx *fiber.Ctx) error {
 
 // CreateBucket
 // @Summary Create new bucket into cloud
-// @Description Create new bucket into cloud
+// @Description Create new bucket into cloud. Optional config sets versioning, lifecycle rules,
+// @Description object lock and quota of bucket, object lock may be enabled on bucket creation only.
 // @ID create-bucket
 // @Tags buckets
 // @Accept  json
@@ -60,6 +66,7 @@ func (s *Server) GetBuckets(eCtx *fiber.Ctx) error {
 // @Failure	400 {object} form.BadRequestError "Bad Request error"
 // @Failure	500 {object} form.InternalServerError "Internal server error"
 // @Failure	503 {object} form.ServerUnavailableError "Server does not available"
+// @Failure	501 {object} form.InternalServerError "Config is not supported by storage"
 // @Router /api/v1/cloud/bucket [put]
 func (s *Server) CreateBucket(eCtx *fiber.Ctx) error {
 	ctx := eCtx.UserContext()
@@ -90,11 +97,12 @@ func (s *Server) CreateBucket(eCtx *fiber.Ctx) error {
 		return eCtx.Status(fiber.StatusOK).SendString("bucket already exists")
 	}
 
-	err = objStorage.CreateBucket(ctx, jsonForm.BucketName)
+	config := bucketConfigFromForm(jsonForm.Config)
+	err = objStorage.CreateBucketWithConfig(ctx, jsonForm.BucketName, config)
 	if err != nil {
 		span.SetStatus(codes.Error, err.Error())
 		span.RecordError(err)
-		return eCtx.Status(fiber.StatusInternalServerError).SendString(err.Error())
+		return eCtx.Status(bucketConfigErrorStatus(err)).SendString(err.Error())
 	}
 
 	return eCtx.Status(fiber.StatusCreated).SendString("Ok")
@@ -152,3 +160,157 @@ func (s *Server) RemoveBucket(eCtx *fiber.Ctx) error {
 
 	return eCtx.Status(fiber.StatusOK).SendString("Ok")
 }
+
+// GetBucketConfig
+// @Summary Get bucket config
+// @Description Get versioning, lifecycle rules, object lock and quota of bucket.
+// @Description Settings unsupported by storage are null.
+// @ID get-bucket-config
+// @Tags buckets
+// @Produce  json
+// @Param bucket path string true "Bucket name"
+// @Success 200 {object} form.BucketConfigSchema "Bucket config"
+// @Failure	400 {object} form.BadRequestError "Bad Request error"
+// @Failure	404 {object} form.NotFoundError "Bucket not found"
+// @Failure	500 {object} form.InternalServerError "Internal server error"
+// @Failure	501 {object} form.InternalServerError "Config is not supported by storage"
+// @Failure	503 {object} form.ServerUnavailableError "Server does not available"
+// @Router /api/v1/cloud/{bucket}/config [get]
+func (s *Server) GetBucketConfig(eCtx *fiber.Ctx) error {
+	ctx := eCtx.UserContext()
+
+	span := trace.SpanFromContext(ctx)
+
+	bucket, err := ExtractBucketParameter(eCtx)
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		span.RecordError(err)
+		return eCtx.Status(fiber.StatusBadRequest).SendString(err.Error())
+	}
+
+	span.SetAttributes(attribute.String("bucket", bucket))
+
+	objStorage := s.state.GetObjectStorage()
+	exists, err := objStorage.IsBucketExists(ctx, bucket)
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		span.RecordError(err)
+		return eCtx.Status(fiber.StatusInternalServerError).SendString(err.Error())
+	}
+
+	if !exists {
+		err = fmt.Errorf("specified bucket %s does not exist", bucket)
+		span.SetStatus(codes.Error, err.Error())
+		span.RecordError(err)
+		return eCtx.Status(fiber.StatusNotFound).SendString(err.Error())
+	}
+
+	config, err := objStorage.GetBucketConfig(ctx, bucket)
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		span.RecordError(err)
+		return eCtx.Status(bucketConfigErrorStatus(err)).SendString(err.Error())
+	}
+
+	return eCtx.Status(fiber.StatusOK).JSON(form.BucketConfigFromDomain(config))
+}
+
+// SetBucketConfig
+// @Summary Update bucket config
+// @Description Update versioning, lifecycle rules, object lock retention and quota of bucket.
+// @Description Omitted settings are left unchanged, lifecycle replaces all rules of bucket.
+// @ID set-bucket-config
+// @Tags buckets
+// @Accept  json
+// @Produce json
+// @Param bucket path string true "Bucket name"
+// @Param jsonQuery body form.BucketConfigForm true "Changed bucket settings"
+// @Success 200 {object} form.Success "Ok"
+// @Failure	400 {object} form.BadRequestError "Bad Request error"
+// @Failure	404 {object} form.NotFoundError "Bucket not found"
+// @Failure	500 {object} form.InternalServerError "Internal server error"
+// @Failure	501 {object} form.InternalServerError "Config is not supported by storage"
+// @Failure	503 {object} form.ServerUnavailableError "Server does not available"
+// @Router /api/v1/cloud/{bucket}/config [put]
+func (s *Server) SetBucketConfig(eCtx *fiber.Ctx) error {
+	ctx := eCtx.UserContext()
+
+	span := trace.SpanFromContext(ctx)
+
+	bucket, err := ExtractBucketParameter(eCtx)
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		span.RecordError(err)
+		return eCtx.Status(fiber.StatusBadRequest).SendString(err.Error())
+	}
+
+	span.SetAttributes(attribute.String("bucket", bucket))
+
+	var jsonForm form.BucketConfigForm
+	err = json.Unmarshal(eCtx.Body(), &jsonForm)
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		span.RecordError(err)
+		return eCtx.Status(fiber.StatusBadRequest).SendString(err.Error())
+	}
+
+	objStorage := s.state.GetObjectStorage()
+	exists, err := objStorage.IsBucketExists(ctx, bucket)
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		span.RecordError(err)
+		return eCtx.Status(fiber.StatusInternalServerError).SendString(err.Error())
+	}
+
+	if !exists {
+		err = fmt.Errorf("specified bucket %s does not exist", bucket)
+		span.SetStatus(codes.Error, err.Error())
+		span.RecordError(err)
+		return eCtx.Status(fiber.StatusNotFound).SendString(err.Error())
+	}
+
+	err = objStorage.SetBucketConfig(ctx, bucket, bucketConfigFromForm(&jsonForm))
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		span.RecordError(err)
+		return eCtx.Status(bucketConfigErrorStatus(err)).SendString(err.Error())
+	}
+
+	return eCtx.Status(fiber.StatusOK).SendString("Ok")
+}
+
+func bucketConfigFromForm(configForm *form.BucketConfigForm) *domain.BucketConfig {
+	if configForm == nil {
+		return nil
+	}
+
+	config := &domain.BucketConfig{
+		Versioning: configForm.Versioning,
+		QuotaBytes: configForm.QuotaBytes,
+	}
+
+	if configForm.Lifecycle != nil {
+		config.Lifecycle = make([]domain.LifecycleRule, len(configForm.Lifecycle))
+		for index, rule := range configForm.Lifecycle {
+			config.Lifecycle[index] = domain.LifecycleRule(rule)
+		}
+	}
+
+	if configForm.ObjectLock != nil {
+		lock := domain.ObjectLockConfig(*configForm.ObjectLock)
+		config.ObjectLock = &lock
+	}
+
+	return config
+}
+
+func bucketConfigErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, domain.ErrInvalidBucketConfig):
+		return fiber.StatusBadRequest
+	case errors.Is(err, domain.ErrBucketConfigNotSupported):
+		return fiber.StatusNotImplemented
+	default:
+		return fiber.StatusInternalServerError
+	}
+}
@@ -0,0 +1,67 @@
+package httpserver
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"watchtower/internal/core/cloud/infrastructure/notify"
+	"watchtower/internal/process"
+)
+
+func (s *Server) CreateEventsGroup(group fiber.Router) {
+	group.Post("/events/s3", s.ReceiveStorageEvents)
+}
+
+// ReceiveStorageEvents
+// @Summary Receive S3 event notifications
+// @Description Receive s3:ObjectCreated and s3:ObjectRemoved notifications sent by MinIO webhook target
+// @ID receive-storage-events
+// @Tags events
+// @Accept  json
+// @Produce json
+// @Param Authorization header string false "Webhook auth token"
+// @Param jsonQuery body object true "S3 event notification"
+// @Success 200 {object} form.Success "Ok"
+// @Failure	400 {object} form.BadRequestError "Bad Request error"
+// @Failure	401 {object} form.BadRequestError "Invalid webhook token"
+// @Failure	404 {object} form.NotFoundError "Events webhook is disabled"
+// @Failure	500 {object} form.InternalServerError "Internal server error"
+// @Failure	503 {object} form.ServerUnavailableError "Server does not available"
+// @Router /api/v1/events/s3 [post]
+func (s *Server) ReceiveStorageEvents(eCtx *fiber.Ctx) error {
+	ctx := eCtx.UserContext()
+
+	span := trace.SpanFromContext(ctx)
+
+	if err := s.state.AuthorizeEventsWebhook(eCtx.Get(fiber.HeaderAuthorization)); err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		span.RecordError(err)
+		if errors.Is(err, process.ErrEventUnauthorized) {
+			return eCtx.Status(fiber.StatusUnauthorized).SendString(err.Error())
+		}
+		return eCtx.Status(fiber.StatusNotFound).SendString(err.Error())
+	}
+
+	events, err := notify.ParseEvents(eCtx.Body())
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		span.RecordError(err)
+		return eCtx.Status(fiber.StatusBadRequest).SendString(err.Error())
+	}
+
+	span.SetAttributes(attribute.Int("events", len(events)))
+
+	for index := range events {
+		if err = s.state.HandleObjectEvent(ctx, &events[index]); err != nil {
+			span.SetStatus(codes.Error, err.Error())
+			span.RecordError(err)
+			return eCtx.Status(fiber.StatusInternalServerError).SendString(err.Error())
+		}
+	}
+
+	return eCtx.Status(fiber.StatusOK).SendString("Ok")
+}
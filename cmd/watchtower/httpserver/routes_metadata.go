@@ -0,0 +1,245 @@
+package httpserver
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path"
+
+	"github.com/gofiber/fiber/v2"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"watchtower/cmd/watchtower/httpserver/form"
+	"watchtower/internal/core/cloud/domain"
+)
+
+// GetFileTags
+// @Summary Get file tags
+// @Description Get tags of file
+// @ID get-file-tags
+// @Tags files
+// @Accept  json
+// @Produce json
+// @Param bucket path string true "Bucket name of file"
+// @Param jsonQuery body form.GetFileAttributesForm true "File to get tags"
+// @Success 200 {object} form.ObjectTagsSchema "Tags of file"
+// @Failure	400 {object} form.BadRequestError "Bad Request error"
+// @Failure	500 {object} form.InternalServerError "Internal server error"
+// @Failure	503 {object} form.ServerUnavailableError "Server does not available"
+// @Router /api/v1/cloud/{bucket}/file/tags [post]
+func (s *Server) GetFileTags(eCtx *fiber.Ctx) error {
+	ctx := eCtx.UserContext()
+
+	span := trace.SpanFromContext(ctx)
+
+	bucket, err := ExtractBucketParameter(eCtx)
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		span.RecordError(err)
+		return eCtx.Status(fiber.StatusBadRequest).SendString(err.Error())
+	}
+
+	span.SetAttributes(attribute.String("bucket", bucket))
+
+	var jsonForm form.GetFileAttributesForm
+	err = json.Unmarshal(eCtx.Body(), &jsonForm)
+	if err == nil && jsonForm.FilePath == "" {
+		err = fmt.Errorf("file_path is required")
+	}
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		span.RecordError(err)
+		return eCtx.Status(fiber.StatusBadRequest).SendString(err.Error())
+	}
+
+	objectStorage := s.state.GetObjectStorage()
+	tags, err := objectStorage.GetObjectTags(ctx, bucket, jsonForm.FilePath)
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		span.RecordError(err)
+		return eCtx.Status(fiber.StatusInternalServerError).SendString(err.Error())
+	}
+
+	tagsDto := form.ObjectTagsSchema{FilePath: jsonForm.FilePath, Tags: tags}
+	return eCtx.Status(fiber.StatusOK).JSON(tagsDto)
+}
+
+// SetFileTags
+// @Summary Set file tags
+// @Description Replace all tags of file. Up to 10 tags are allowed, key length is up to 128
+// @Description and value length is up to 256 characters. Indexed document keeps previous tags
+// @Description until file is reindexed, pass reindex to create processing task of file.
+// @ID set-file-tags
+// @Tags files
+// @Accept  json
+// @Produce json
+// @Param bucket path string true "Bucket name of file"
+// @Param jsonQuery body form.SetFileTagsForm true "File tags"
+// @Success 200 {object} form.Success "Ok"
+// @Success 201 {object} form.TaskSchema "Task of reindexed file"
+// @Failure	400 {object} form.BadRequestError "Bad Request error"
+// @Failure	500 {object} form.InternalServerError "Internal server error"
+// @Failure	503 {object} form.ServerUnavailableError "Server does not available"
+// @Router /api/v1/cloud/{bucket}/file/tags [put]
+func (s *Server) SetFileTags(eCtx *fiber.Ctx) error {
+	ctx := eCtx.UserContext()
+
+	span := trace.SpanFromContext(ctx)
+
+	bucket, err := ExtractBucketParameter(eCtx)
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		span.RecordError(err)
+		return eCtx.Status(fiber.StatusBadRequest).SendString(err.Error())
+	}
+
+	span.SetAttributes(attribute.String("bucket", bucket))
+
+	var jsonForm form.SetFileTagsForm
+	err = json.Unmarshal(eCtx.Body(), &jsonForm)
+	if err == nil && jsonForm.FilePath == "" {
+		err = fmt.Errorf("file_path is required")
+	}
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		span.RecordError(err)
+		return eCtx.Status(fiber.StatusBadRequest).SendString(err.Error())
+	}
+
+	objectStorage := s.state.GetObjectStorage()
+	err = objectStorage.SetObjectTags(ctx, bucket, jsonForm.FilePath, jsonForm.Tags)
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		span.RecordError(err)
+		if errors.Is(err, domain.ErrInvalidObjectTags) {
+			return eCtx.Status(fiber.StatusBadRequest).SendString(err.Error())
+		}
+		return eCtx.Status(fiber.StatusInternalServerError).SendString(err.Error())
+	}
+
+	return s.reindexFile(eCtx, bucket, jsonForm.FilePath, jsonForm.Reindex)
+}
+
+// DeleteFileTags
+// @Summary Delete file tags
+// @Description Delete all tags of file, pass reindex to create processing task of file.
+// @ID delete-file-tags
+// @Tags files
+// @Accept  json
+// @Produce json
+// @Param bucket path string true "Bucket name of file"
+// @Param jsonQuery body form.DeleteFileTagsForm true "File to delete tags"
+// @Success 200 {object} form.Success "Ok"
+// @Success 201 {object} form.TaskSchema "Task of reindexed file"
+// @Failure	400 {object} form.BadRequestError "Bad Request error"
+// @Failure	500 {object} form.InternalServerError "Internal server error"
+// @Failure	503 {object} form.ServerUnavailableError "Server does not available"
+// @Router /api/v1/cloud/{bucket}/file/tags [delete]
+func (s *Server) DeleteFileTags(eCtx *fiber.Ctx) error {
+	ctx := eCtx.UserContext()
+
+	span := trace.SpanFromContext(ctx)
+
+	bucket, err := ExtractBucketParameter(eCtx)
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		span.RecordError(err)
+		return eCtx.Status(fiber.StatusBadRequest).SendString(err.Error())
+	}
+
+	span.SetAttributes(attribute.String("bucket", bucket))
+
+	var jsonForm form.DeleteFileTagsForm
+	err = json.Unmarshal(eCtx.Body(), &jsonForm)
+	if err == nil && jsonForm.FilePath == "" {
+		err = fmt.Errorf("file_path is required")
+	}
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		span.RecordError(err)
+		return eCtx.Status(fiber.StatusBadRequest).SendString(err.Error())
+	}
+
+	objectStorage := s.state.GetObjectStorage()
+	if err = objectStorage.DeleteObjectTags(ctx, bucket, jsonForm.FilePath); err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		span.RecordError(err)
+		return eCtx.Status(fiber.StatusInternalServerError).SendString(err.Error())
+	}
+
+	return s.reindexFile(eCtx, bucket, jsonForm.FilePath, jsonForm.Reindex)
+}
+
+// ReplaceFileMetadata
+// @Summary Replace file metadata
+// @Description Replace user metadata of file in place, content type and tags of file are kept.
+// @Description S3 file is copied to itself, so its modification time and etag are changed.
+// @Description Pass reindex to create processing task of file.
+// @ID replace-file-metadata
+// @Tags files
+// @Accept  json
+// @Produce json
+// @Param bucket path string true "Bucket name of file"
+// @Param jsonQuery body form.ReplaceFileMetadataForm true "File metadata"
+// @Success 200 {object} form.Success "Ok"
+// @Success 201 {object} form.TaskSchema "Task of reindexed file"
+// @Failure	400 {object} form.BadRequestError "Bad Request error"
+// @Failure	500 {object} form.InternalServerError "Internal server error"
+// @Failure	503 {object} form.ServerUnavailableError "Server does not available"
+// @Router /api/v1/cloud/{bucket}/file/metadata [put]
+func (s *Server) ReplaceFileMetadata(eCtx *fiber.Ctx) error {
+	ctx := eCtx.UserContext()
+
+	span := trace.SpanFromContext(ctx)
+
+	bucket, err := ExtractBucketParameter(eCtx)
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		span.RecordError(err)
+		return eCtx.Status(fiber.StatusBadRequest).SendString(err.Error())
+	}
+
+	span.SetAttributes(attribute.String("bucket", bucket))
+
+	var jsonForm form.ReplaceFileMetadataForm
+	err = json.Unmarshal(eCtx.Body(), &jsonForm)
+	if err == nil && jsonForm.FilePath == "" {
+		err = fmt.Errorf("file_path is required")
+	}
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		span.RecordError(err)
+		return eCtx.Status(fiber.StatusBadRequest).SendString(err.Error())
+	}
+
+	err = s.state.ReplaceObjectMetadata(ctx, bucket, jsonForm.FilePath, jsonForm.Metadata)
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		span.RecordError(err)
+		return eCtx.Status(fiber.StatusInternalServerError).SendString(err.Error())
+	}
+
+	return s.reindexFile(eCtx, bucket, jsonForm.FilePath, jsonForm.Reindex)
+}
+
+// reindexFile creates processing task of file which document must be
+// updated in index, otherwise responds Ok.
+func (s *Server) reindexFile(eCtx *fiber.Ctx, bucket, filePath string, reindex bool) error {
+	if !reindex {
+		return eCtx.Status(fiber.StatusOK).SendString("Ok")
+	}
+
+	ctx := eCtx.UserContext()
+	span := trace.SpanFromContext(ctx)
+
+	task, err := s.state.CreateTask(ctx, bucket, path.Clean(filePath))
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		span.RecordError(err)
+		return eCtx.Status(fiber.StatusInternalServerError).SendString(err.Error())
+	}
+
+	return eCtx.Status(fiber.StatusCreated).JSON(form.TaskFromDomain(*task))
+}
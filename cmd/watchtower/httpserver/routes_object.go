@@ -1,13 +1,14 @@
 package httpserver
 
 import (
+	"bufio"
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log/slog"
 	"net/http"
 	"path"
-	"slices"
 	"time"
 
 	"github.com/gofiber/fiber/v2"
@@ -17,12 +18,22 @@ import (
 
 	"watchtower/cmd/watchtower/httpserver/form"
 	"watchtower/internal/core/cloud/domain"
+	"watchtower/internal/process"
+	"watchtower/internal/support/task/application/service/upload"
+
+	cloudApp "watchtower/internal/core/cloud/application"
+	taskApp "watchtower/internal/support/task/application"
 )
 
-const FolderFileKeeper = ".keeper"
+const (
+	FolderFileKeeper = ".keeper"
+
+	MIMEApplicationNDJSON = "application/x-ndjson"
+)
 
 func (s *Server) CreateStorageObjectsGroup(group fiber.Router) {
 	group.Post("/cloud/:bucket/files", s.GetFiles)
+	group.Post("/cloud/:bucket/search", s.SearchFiles)
 	group.Patch("/cloud/:bucket/file", s.CopyFile)
 	group.Put("/cloud/:bucket/file/upload", s.UploadFile)
 	group.Post("/cloud/:bucket/file/download", s.DownloadFile)
@@ -32,6 +43,15 @@ func (s *Server) CreateStorageObjectsGroup(group fiber.Router) {
 	group.Delete("/cloud/:bucket/file/remove", s.RemoveFile)
 	group.Post("/cloud/:bucket/file/attributes", s.GetFileInfo)
 	group.Post("/cloud/:bucket/file/share", s.ShareFile)
+	group.Post("/cloud/:bucket/file/tags", s.GetFileTags)
+	group.Put("/cloud/:bucket/file/tags", s.SetFileTags)
+	group.Delete("/cloud/:bucket/file/tags", s.DeleteFileTags)
+	group.Put("/cloud/:bucket/file/metadata", s.ReplaceFileMetadata)
+	group.Post("/cloud/:bucket/file/versions", s.GetFileVersions)
+	group.Post("/cloud/:bucket/file/version/download", s.DownloadFileVersion)
+	group.Post("/cloud/:bucket/file/version/restore", s.RestoreFileVersion)
+	group.Post("/cloud/:bucket/file/upload-url", s.CreateUploadURL)
+	group.Post("/cloud/:bucket/file/upload-complete", s.CompleteUploadURL)
 }
 
 // CreateFolder
@@ -167,13 +187,14 @@ func (s *Server) DeleteFolder(eCtx *fiber.Ctx) error {
 
 // UploadFile
 // @Summary Upload files to cloud
-// @Description Upload files to cloud
+// @Description Upload files to cloud. User metadata may be passed by X-Meta-* headers or x-meta-* form fields.
 // @ID upload-files
 // @Tags files
 // @Accept  multipart/form
 // @Produce  json
 // @Param bucket path string true "Bucket name to upload files"
 // @Param prefix formData string false "Prefix to load files"
+// @Param content_type formData string false "Content type of uploaded files"
 // @Param files formData file true "Files multipart form"
 // @Param expired query string false "File datetime expired like 2025-01-01T12:01:01Z"
 // @Success 200 {object} form.Success "Ok"
@@ -226,6 +247,8 @@ func (s *Server) UploadFile(eCtx *fiber.Ctx) error {
 		return eCtx.Status(fiber.StatusBadRequest).SendString(err.Error())
 	}
 
+	userMetadata := ExtractUserMetadata(eCtx, multipartForm)
+
 	var fileData bytes.Buffer
 	uploadedFiles := make([]form.TaskSchema, len(multipartForm.File["files"]))
 	for index, fileForm := range multipartForm.File["files"] {
@@ -273,9 +296,11 @@ func (s *Server) UploadFile(eCtx *fiber.Ctx) error {
 		}
 
 		params := &domain.UploadObjectParams{
-			FilePath: filePath,
-			FileData: &fileData,
-			Expired:  expiredDatetime,
+			FilePath:    filePath,
+			FileData:    &fileData,
+			ContentType: ExtractContentTypeParameter(eCtx, fileForm),
+			Expired:     expiredDatetime,
+			Metadata:    userMetadata,
 		}
 
 		task, err := s.state.UploadFile(ctx, bucket, params)
@@ -440,15 +465,20 @@ func (s *Server) RemoveFile2(eCtx *fiber.Ctx) error {
 }
 
 // CopyFile
-// @Summary Copy file to another location into bucket
-// @Description Copy file to another location into bucket
+// @Summary Copy file to another location
+// @Description Copy or move file to another location into bucket or into dst_bucket.
+// @Description Source path ending with slash copies all files under prefix into dst_path prefix,
+// @Description dst_path may be omitted to keep paths of files in another bucket. Task is created
+// @Description per copied file to index it into destination bucket, documents of files moved into
+// @Description dst_bucket are removed from index of source bucket. Documents of files moved within
+// @Description bucket are moved in index, response is Ok for files copied within bucket.
 // @ID copy-file
 // @Tags files
 // @Accept  json
 // @Produce json
 // @Param bucket path string true "Bucket name of src file"
 // @Param jsonQuery body form.CopyFileForm true "Params to copy file"
-// @Success 200 {object} form.Success "Ok"
+// @Success 200 {object} []form.TaskSchema "Tasks of files copied into dst_bucket"
 // @Failure	400 {object} form.BadRequestError "Bad Request error"
 // @Failure	404 {object} form.NotFoundError "Bucket or file not found"
 // @Failure	500 {object} form.InternalServerError "Internal server error"
@@ -483,47 +513,80 @@ func (s *Server) CopyFile(eCtx *fiber.Ctx) error {
 
 	var jsonForm form.CopyFileForm
 	err = json.Unmarshal(eCtx.Body(), &jsonForm)
+	if err == nil && jsonForm.SrcPath == "" {
+		err = fmt.Errorf("src_path is required")
+	}
+	if err == nil && jsonForm.DstPath == "" && (jsonForm.DstBucket == "" || jsonForm.DstBucket == bucket) {
+		err = fmt.Errorf("dst_path is required to copy file into the same bucket")
+	}
 	if err != nil {
 		span.SetStatus(codes.Error, err.Error())
 		span.RecordError(err)
 		return eCtx.Status(fiber.StatusBadRequest).SendString(err.Error())
 	}
 
+	if jsonForm.DstBucket != "" && jsonForm.DstBucket != bucket {
+		span.SetAttributes(attribute.String("dst-bucket", jsonForm.DstBucket))
+
+		exist, err = objectStorage.IsBucketExists(ctx, jsonForm.DstBucket)
+		if err != nil {
+			span.SetStatus(codes.Error, err.Error())
+			span.RecordError(err)
+			return eCtx.Status(fiber.StatusInternalServerError).SendString(err.Error())
+		}
+
+		if !exist {
+			err = fmt.Errorf("specified bucket %s does not exist", jsonForm.DstBucket)
+			span.SetStatus(codes.Error, err.Error())
+			span.RecordError(err)
+			return eCtx.Status(fiber.StatusNotFound).SendString(err.Error())
+		}
+	}
+
+	dstPath := jsonForm.DstPath
+	if dstPath == "" {
+		dstPath = jsonForm.SrcPath
+	}
+
 	params := &domain.CopyObjectParams{
-		SourcePath:      jsonForm.SrcPath,
-		DestinationPath: jsonForm.DstPath,
+		SourcePath:        jsonForm.SrcPath,
+		DestinationPath:   dstPath,
+		DestinationBucket: jsonForm.DstBucket,
+		WithRemoving:      jsonForm.WithRemove,
 	}
 
-	err = objectStorage.CopyObject(ctx, bucket, params)
+	tasks, err := s.state.CopyObjects(ctx, bucket, params)
 	if err != nil {
 		span.SetStatus(codes.Error, err.Error())
 		span.RecordError(err)
 		return eCtx.Status(fiber.StatusInternalServerError).SendString(err.Error())
 	}
 
-	if jsonForm.WithRemove {
-		err = objectStorage.DeleteObject(ctx, bucket, params.SourcePath)
-		if err != nil {
-			err = fmt.Errorf("failed to delete object: %w", err)
-			span.SetStatus(codes.Error, err.Error())
-			span.RecordError(err)
-			return eCtx.Status(fiber.StatusInternalServerError).SendString(err.Error())
-		}
+	if jsonForm.DstBucket == "" || jsonForm.DstBucket == bucket {
+		return eCtx.Status(fiber.StatusOK).SendString("Ok")
 	}
 
-	return eCtx.Status(fiber.StatusOK).SendString("Ok")
+	tasksDto := make([]form.TaskSchema, len(tasks))
+	for index, task := range tasks {
+		tasksDto[index] = form.TaskFromDomain(*task)
+	}
+
+	return eCtx.Status(fiber.StatusOK).JSON(tasksDto)
 }
 
 // GetFiles
 // @Summary Get files list into bucket
-// @Description Get files list into bucket
+// @Description Get page of files list into bucket. Pass next_token of response as continuation_token
+// @Description to get next page, next_token is empty for the last page. Token is opaque and is valid only
+// @Description for the same directory and sorting, offset shifts only the first page. Files may be sorted
+// @Description by name, size or modified time, sorting by size or modified time lists whole directory.
 // @ID get-list-files
 // @Tags files
 // @Accept  json
 // @Produce json
 // @Param bucket path string true "Bucket name to get list files"
 // @Param jsonQuery body form.GetFilesForm true "Parameters to get list files"
-// @Success 200 {object} form.Success "Ok"
+// @Success 200 {object} form.ObjectsPageSchema "Page of files"
 // @Failure	400 {object} form.BadRequestError "Bad Request error"
 // @Failure	404 {object} form.NotFoundError "Bucket not found"
 // @Failure	500 {object} form.InternalServerError "Internal server error"
@@ -551,31 +614,155 @@ func (s *Server) GetFiles(eCtx *fiber.Ctx) error {
 		return eCtx.Status(fiber.StatusBadRequest).SendString(err.Error())
 	}
 
+	switch jsonForm.SortBy {
+	case "", domain.SortByName, domain.SortBySize, domain.SortByModified:
+	default:
+		err = fmt.Errorf("unsupported sort_by value: %s", jsonForm.SortBy)
+		span.SetStatus(codes.Error, err.Error())
+		span.RecordError(err)
+		return eCtx.Status(fiber.StatusBadRequest).SendString(err.Error())
+	}
+
+	if jsonForm.Limit < 0 || jsonForm.Offset < 0 {
+		err = fmt.Errorf("limit and offset must not be negative")
+		span.SetStatus(codes.Error, err.Error())
+		span.RecordError(err)
+		return eCtx.Status(fiber.StatusBadRequest).SendString(err.Error())
+	}
+
 	params := &domain.GetObjectsParams{
-		PrefixPath: jsonForm.DirectoryName,
-		Limit:      jsonForm.Limit,
-		Offset:     jsonForm.Offset,
+		PrefixPath:        jsonForm.DirectoryName,
+		Limit:             jsonForm.Limit,
+		Offset:            jsonForm.Offset,
+		ContinuationToken: jsonForm.ContinuationToken,
+		SortBy:            jsonForm.SortBy,
+		SortDesc:          jsonForm.SortDesc,
+		SkipNames:         []string{FolderFileKeeper},
 	}
 
 	objectStorage := s.state.GetObjectStorage()
-	listObjects, err := objectStorage.LoadBucketObjects(ctx, bucket, params)
+	page, err := objectStorage.LoadBucketObjectsPage(ctx, bucket, params)
 	if err != nil {
 		span.SetStatus(codes.Error, err.Error())
 		span.RecordError(err)
+		if errors.Is(err, domain.ErrInvalidContinuation) {
+			return eCtx.Status(fiber.StatusBadRequest).SendString(err.Error())
+		}
 		return eCtx.Status(fiber.StatusInternalServerError).SendString(err.Error())
 	}
 
-	// Remove all .keeper meta files from target list objects
-	listObjects = slices.DeleteFunc(listObjects, func(object domain.Object) bool {
-		return object.Name == FolderFileKeeper
+	pageDto := form.ObjectsPageSchema{
+		Objects:   make([]form.ObjectSchema, len(page.Objects)),
+		NextToken: page.NextToken,
+	}
+	for index, object := range page.Objects {
+		pageDto.Objects[index] = form.ObjectFromDomain(object)
+	}
+
+	return eCtx.Status(fiber.StatusOK).JSON(pageDto)
+}
+
+// SearchFiles
+// @Summary Search files into bucket
+// @Description Walk files under prefix recursively and stream files matching all passed filters
+// @Description as newline delimited JSON, one file per line. Glob matches file name, or file path if
+// @Description pattern contains slash, regex matches file path, content type supports wildcards like
+// @Description image/*. Search failed after streaming has been started is reported by last line with error.
+// @ID search-files
+// @Tags files
+// @Accept  json
+// @Produce application/x-ndjson
+// @Param bucket path string true "Bucket name to search files"
+// @Param jsonQuery body form.SearchFilesForm true "Filters of searched files"
+// @Success 200 {object} form.ObjectSchema "Stream of found files"
+// @Failure	400 {object} form.BadRequestError "Bad Request error"
+// @Failure	404 {object} form.NotFoundError "Bucket not found"
+// @Failure	500 {object} form.InternalServerError "Internal server error"
+// @Failure	503 {object} form.ServerUnavailableError "Server does not available"
+// @Router /api/v1/cloud/{bucket}/search [post]
+func (s *Server) SearchFiles(eCtx *fiber.Ctx) error {
+	ctx := eCtx.UserContext()
+
+	span := trace.SpanFromContext(ctx)
+
+	bucket, err := ExtractBucketParameter(eCtx)
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		span.RecordError(err)
+		return eCtx.Status(fiber.StatusBadRequest).SendString(err.Error())
+	}
+
+	span.SetAttributes(attribute.String("bucket", bucket))
+
+	var jsonForm form.SearchFilesForm
+	err = json.Unmarshal(eCtx.Body(), &jsonForm)
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		span.RecordError(err)
+		return eCtx.Status(fiber.StatusBadRequest).SendString(err.Error())
+	}
+
+	filter, err := cloudApp.NewObjectFilter(&domain.SearchObjectsParams{
+		PrefixPath:     jsonForm.Prefix,
+		Glob:           jsonForm.Glob,
+		Regex:          jsonForm.Regex,
+		MinSize:        jsonForm.MinSize,
+		MaxSize:        jsonForm.MaxSize,
+		ModifiedAfter:  jsonForm.ModifiedAfter,
+		ModifiedBefore: jsonForm.ModifiedBefore,
+		ContentType:    jsonForm.ContentType,
+		Metadata:       jsonForm.Metadata,
+		Limit:          jsonForm.Limit,
 	})
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		span.RecordError(err)
+		return eCtx.Status(fiber.StatusBadRequest).SendString(err.Error())
+	}
 
-	objectsDto := make([]form.ObjectSchema, len(listObjects))
-	for index, object := range listObjects {
-		objectsDto[index] = form.ObjectFromDomain(object)
+	objectStorage := s.state.GetObjectStorage()
+	exist, err := objectStorage.IsBucketExists(ctx, bucket)
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		span.RecordError(err)
+		return eCtx.Status(http.StatusBadRequest).SendString(err.Error())
 	}
 
-	return eCtx.Status(fiber.StatusOK).JSON(objectsDto)
+	if !exist {
+		err = fmt.Errorf("specified bucket %s does not exist", bucket)
+		span.SetStatus(codes.Error, err.Error())
+		span.RecordError(err)
+		return eCtx.Status(http.StatusNotFound).SendString(err.Error())
+	}
+
+	// Found files are flushed one by one, so failed flush means
+	// disconnected client and stops walking of bucket.
+	eCtx.Set(fiber.HeaderContentType, MIMEApplicationNDJSON)
+	eCtx.Status(fiber.StatusOK).Context().SetBodyStreamWriter(func(writer *bufio.Writer) {
+		encoder := json.NewEncoder(writer)
+		err := objectStorage.SearchObjects(ctx, bucket, filter, func(object domain.Object) error {
+			if object.Name == FolderFileKeeper {
+				return nil
+			}
+
+			if err := encoder.Encode(form.ObjectFromDomain(object)); err != nil {
+				return err
+			}
+			return writer.Flush()
+		})
+
+		if err != nil {
+			slog.Warn("search",
+				slog.String("msg", "failed to search files"),
+				slog.String("bucket", bucket),
+				slog.String("err", err.Error()),
+			)
+			_ = encoder.Encode(form.SearchErrorSchema{Error: err.Error()})
+			_ = writer.Flush()
+		}
+	})
+
+	return nil
 }
 
 // GetFileInfo
@@ -677,3 +864,169 @@ func (s *Server) ShareFile(eCtx *fiber.Ctx) error {
 
 	return eCtx.Status(fiber.StatusOK).JSON(form.SuccessResponse(url))
 }
+
+// CreateUploadURL
+// @Summary Get presigned URL to upload file directly into cloud
+// @Description Get presigned PUT URL or POST form to upload file directly into cloud storage.
+// @Description Size and optional md5 or sha256 hex checksum of file are verified on completion.
+// @ID create-upload-url
+// @Tags files
+// @Accept  json
+// @Produce json
+// @Param bucket path string true "Bucket name to upload file"
+// @Param jsonQuery body form.CreateUploadURLForm true "Parameters of uploaded file"
+// @Success 200 {object} form.PresignedUploadSchema "Presigned upload request"
+// @Failure	400 {object} form.BadRequestError "Bad Request error"
+// @Failure	404 {object} form.NotFoundError "Bucket not found or presigned uploads are disabled"
+// @Failure	413 {object} form.BadRequestError "File is too large"
+// @Failure	500 {object} form.InternalServerError "Internal server error"
+// @Failure	501 {object} form.BadRequestError "Presigned uploads are not supported by storage"
+// @Failure	503 {object} form.ServerUnavailableError "Server does not available"
+// @Router /api/v1/cloud/{bucket}/file/upload-url [post]
+func (s *Server) CreateUploadURL(eCtx *fiber.Ctx) error {
+	ctx := eCtx.UserContext()
+
+	span := trace.SpanFromContext(ctx)
+
+	bucket, err := ExtractBucketParameter(eCtx)
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		span.RecordError(err)
+		return eCtx.Status(fiber.StatusBadRequest).SendString(err.Error())
+	}
+
+	span.SetAttributes(attribute.String("bucket", bucket))
+
+	var jsonForm form.CreateUploadURLForm
+	err = json.Unmarshal(eCtx.Body(), &jsonForm)
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		span.RecordError(err)
+		return eCtx.Status(fiber.StatusBadRequest).SendString(err.Error())
+	}
+
+	switch jsonForm.Method {
+	case "", domain.PresignPutMethod, domain.PresignPostMethod:
+	default:
+		err = fmt.Errorf("unsupported upload method: %s", jsonForm.Method)
+		span.SetStatus(codes.Error, err.Error())
+		span.RecordError(err)
+		return eCtx.Status(fiber.StatusBadRequest).SendString(err.Error())
+	}
+
+	if jsonForm.FilePath == "" {
+		err = fmt.Errorf("file_path is required")
+		span.SetStatus(codes.Error, err.Error())
+		span.RecordError(err)
+		return eCtx.Status(fiber.StatusBadRequest).SendString(err.Error())
+	}
+
+	objectStorage := s.state.GetObjectStorage()
+	exist, err := objectStorage.IsBucketExists(ctx, bucket)
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		span.RecordError(err)
+		return eCtx.Status(fiber.StatusInternalServerError).SendString(err.Error())
+	}
+
+	if !exist {
+		err = fmt.Errorf("specified bucket %s does not exist", bucket)
+		span.SetStatus(codes.Error, err.Error())
+		span.RecordError(err)
+		return eCtx.Status(fiber.StatusNotFound).SendString(err.Error())
+	}
+
+	params := &process.CreateUploadURLParams{
+		FilePath:    jsonForm.FilePath,
+		Method:      jsonForm.Method,
+		ContentType: jsonForm.ContentType,
+		Size:        jsonForm.Size,
+		Checksum:    jsonForm.Checksum,
+		Metadata:    jsonForm.Metadata,
+	}
+
+	presigned, err := s.state.CreateUploadURL(ctx, bucket, params)
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		span.RecordError(err)
+		return eCtx.Status(presignErrorStatus(err)).SendString(err.Error())
+	}
+
+	return eCtx.Status(fiber.StatusOK).JSON(form.PresignedUploadFromDomain(*presigned))
+}
+
+// CompleteUploadURL
+// @Summary Complete upload of file by presigned URL
+// @Description Verify size and checksum of file uploaded by presigned URL and create processing task.
+// @Description Storage event of uploaded file completes upload as well.
+// @ID complete-upload-url
+// @Tags files
+// @Accept  json
+// @Produce json
+// @Param bucket path string true "Bucket name of uploaded file"
+// @Param jsonQuery body form.CompleteUploadForm true "Path of uploaded file"
+// @Success 201 {object} form.TaskSchema "Created task of uploaded file"
+// @Failure	400 {object} form.BadRequestError "Bad Request error"
+// @Failure	404 {object} form.NotFoundError "Upload not found, already completed or expired"
+// @Failure	409 {object} form.BadRequestError "File has not been uploaded yet"
+// @Failure	422 {object} form.BadRequestError "File does not match expected size or checksum"
+// @Failure	500 {object} form.InternalServerError "Internal server error"
+// @Failure	503 {object} form.ServerUnavailableError "Server does not available"
+// @Router /api/v1/cloud/{bucket}/file/upload-complete [post]
+func (s *Server) CompleteUploadURL(eCtx *fiber.Ctx) error {
+	ctx := eCtx.UserContext()
+
+	span := trace.SpanFromContext(ctx)
+
+	bucket, err := ExtractBucketParameter(eCtx)
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		span.RecordError(err)
+		return eCtx.Status(fiber.StatusBadRequest).SendString(err.Error())
+	}
+
+	span.SetAttributes(attribute.String("bucket", bucket))
+
+	var jsonForm form.CompleteUploadForm
+	err = json.Unmarshal(eCtx.Body(), &jsonForm)
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		span.RecordError(err)
+		return eCtx.Status(fiber.StatusBadRequest).SendString(err.Error())
+	}
+
+	if jsonForm.FilePath == "" {
+		err = fmt.Errorf("file_path is required")
+		span.SetStatus(codes.Error, err.Error())
+		span.RecordError(err)
+		return eCtx.Status(fiber.StatusBadRequest).SendString(err.Error())
+	}
+
+	task, err := s.state.CompleteUploadURL(ctx, bucket, jsonForm.FilePath)
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		span.RecordError(err)
+		return eCtx.Status(presignErrorStatus(err)).SendString(err.Error())
+	}
+
+	return eCtx.Status(fiber.StatusCreated).JSON(form.TaskFromDomain(*task))
+}
+
+func presignErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, upload.ErrTicketNotFound), errors.Is(err, taskApp.ErrUploadTicketsDisabled):
+		return fiber.StatusNotFound
+	case errors.Is(err, process.ErrInvalidChecksum):
+		return fiber.StatusBadRequest
+	case errors.Is(err, process.ErrUploadTooLarge):
+		return fiber.StatusRequestEntityTooLarge
+	case errors.Is(err, process.ErrUploadNotReceived):
+		return fiber.StatusConflict
+	case errors.Is(err, process.ErrUploadVerification):
+		return fiber.StatusUnprocessableEntity
+	case errors.Is(err, domain.ErrPresignNotSupported):
+		return fiber.StatusNotImplemented
+	default:
+		return fiber.StatusInternalServerError
+	}
+}
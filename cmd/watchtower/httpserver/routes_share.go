@@ -0,0 +1,100 @@
+package httpserver
+
+import (
+	"errors"
+	"net/url"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"watchtower/internal/core/cloud/domain"
+)
+
+func (s *Server) CreateShareGroup(group fiber.Router) {
+	group.Get("/share/:bucket/*", s.DownloadSharedFile)
+}
+
+// DownloadSharedFile
+// @Summary Download file by share URL
+// @Description Download file by signed share URL of storage which is served by watchtower, like local filesystem
+// @ID download-shared-file
+// @Tags share
+// @Produce octet-stream
+// @Param bucket path string true "Bucket name of shared file"
+// @Param file_path path string true "Path of shared file"
+// @Param expires query int true "Unix time when share URL expires"
+// @Param signature query string true "Signature of share URL"
+// @Success 200 {file} io.Writer "Returned file bytes"
+// @Failure	400 {object} form.BadRequestError "Bad Request error"
+// @Failure	403 {object} form.BadRequestError "Invalid or expired signature"
+// @Failure	404 {object} form.NotFoundError "Share URLs are not served for storage"
+// @Failure	500 {object} form.InternalServerError "Internal server error"
+// @Failure	503 {object} form.ServerUnavailableError "Server does not available"
+// @Router /api/v1/share/{bucket}/{file_path} [get]
+func (s *Server) DownloadSharedFile(eCtx *fiber.Ctx) error {
+	ctx := eCtx.UserContext()
+
+	span := trace.SpanFromContext(ctx)
+
+	bucket, err := ExtractBucketParameter(eCtx)
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		span.RecordError(err)
+		return eCtx.Status(fiber.StatusBadRequest).SendString(err.Error())
+	}
+
+	filePath, err := url.PathUnescape(eCtx.Params("*"))
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		span.RecordError(err)
+		return eCtx.Status(fiber.StatusBadRequest).SendString(err.Error())
+	}
+
+	expires, err := strconv.ParseInt(eCtx.Query("expires"), 10, 64)
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		span.RecordError(err)
+		return eCtx.Status(fiber.StatusBadRequest).SendString(err.Error())
+	}
+
+	span.SetAttributes(
+		attribute.String("bucket", bucket),
+		attribute.String("file-path", filePath),
+	)
+
+	objectStorage := s.state.GetObjectStorage()
+	err = objectStorage.VerifyShareURL(ctx, bucket, filePath, expires, eCtx.Query("signature"))
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		span.RecordError(err)
+		if errors.Is(err, domain.ErrInvalidShareSignature) {
+			return eCtx.Status(fiber.StatusForbidden).SendString(err.Error())
+		}
+		return eCtx.Status(fiber.StatusNotFound).SendString(err.Error())
+	}
+
+	objInfo, err := objectStorage.GetObjectInfo(ctx, bucket, filePath)
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		span.RecordError(err)
+		return eCtx.Status(fiber.StatusNotFound).SendString(err.Error())
+	}
+
+	fileData, err := objectStorage.GetObjectData(ctx, bucket, filePath)
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		span.RecordError(err)
+		return eCtx.Status(fiber.StatusInternalServerError).SendString(err.Error())
+	}
+	defer fileData.Reset()
+
+	if objInfo.ContentType != "" {
+		eCtx.Set(fiber.HeaderContentType, objInfo.ContentType)
+	}
+	eCtx.Attachment(objInfo.Name)
+
+	return eCtx.Send(fileData.Bytes())
+}
@@ -0,0 +1,367 @@
+package httpserver
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"path"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"watchtower/internal/process"
+	"watchtower/internal/support/task/application/service/upload"
+
+	taskApp "watchtower/internal/support/task/application"
+)
+
+const (
+	TusVersion    = "1.0.0"
+	TusExtensions = "creation,termination,expiration"
+
+	HeaderTusResumable   = "Tus-Resumable"
+	HeaderTusVersion     = "Tus-Version"
+	HeaderTusExtension   = "Tus-Extension"
+	HeaderTusMaxSize     = "Tus-Max-Size"
+	HeaderUploadLength   = "Upload-Length"
+	HeaderUploadOffset   = "Upload-Offset"
+	HeaderUploadMetadata = "Upload-Metadata"
+	HeaderUploadExpires  = "Upload-Expires"
+
+	MIMEOffsetOctetStream = "application/offset+octet-stream"
+)
+
+func (s *Server) CreateUploadsGroup(group fiber.Router) {
+	uploadsGroup := group.Group("/uploads", s.checkTusResumable)
+	uploadsGroup.Options("/:bucket", s.GetUploadsOptions)
+	uploadsGroup.Options("/:bucket/:upload_id", s.GetUploadsOptions)
+	uploadsGroup.Post("/:bucket", s.CreateUpload)
+	uploadsGroup.Head("/:bucket/:upload_id", s.GetUploadOffset)
+	uploadsGroup.Patch("/:bucket/:upload_id", s.WriteUploadChunk)
+	uploadsGroup.Delete("/:bucket/:upload_id", s.TerminateUpload)
+}
+
+// checkTusResumable sets tus version to responses and rejects requests
+// of unsupported protocol version. OPTIONS requests are not versioned.
+func (s *Server) checkTusResumable(eCtx *fiber.Ctx) error {
+	eCtx.Set(HeaderTusResumable, TusVersion)
+	if eCtx.Method() == fiber.MethodOptions {
+		return eCtx.Next()
+	}
+
+	if eCtx.Get(HeaderTusResumable) != TusVersion {
+		eCtx.Set(HeaderTusVersion, TusVersion)
+		err := fmt.Errorf("unsupported tus version: %s", eCtx.Get(HeaderTusResumable))
+		return eCtx.Status(fiber.StatusPreconditionFailed).SendString(err.Error())
+	}
+
+	return eCtx.Next()
+}
+
+// GetUploadsOptions
+// @Summary Get resumable uploads capabilities
+// @Description Get tus protocol version, extensions and max upload size
+// @ID get-uploads-options
+// @Tags uploads
+// @Param bucket path string true "Bucket name to upload file"
+// @Success 204 "No Content"
+// @Router /api/v1/uploads/{bucket} [options]
+func (s *Server) GetUploadsOptions(eCtx *fiber.Ctx) error {
+	eCtx.Set(HeaderTusVersion, TusVersion)
+	eCtx.Set(HeaderTusExtension, TusExtensions)
+	if maxSize := s.state.UploadsMaxSize(); maxSize > 0 {
+		eCtx.Set(HeaderTusMaxSize, strconv.FormatInt(maxSize, 10))
+	}
+
+	return eCtx.SendStatus(fiber.StatusNoContent)
+}
+
+// CreateUpload
+// @Summary Create resumable upload
+// @Description Create resumable upload by tus protocol. Upload-Metadata may contain
+// @Description filename, filetype, prefix and user metadata of file as base64 encoded values.
+// @ID create-upload
+// @Tags uploads
+// @Param bucket path string true "Bucket name to upload file"
+// @Param Tus-Resumable header string true "Tus protocol version" default(1.0.0)
+// @Param Upload-Length header int true "Size of uploaded file in bytes"
+// @Param Upload-Metadata header string true "Metadata of uploaded file"
+// @Success 201 "Created, Location header contains upload URL"
+// @Failure	400 {object} form.BadRequestError "Bad Request error"
+// @Failure	404 {object} form.NotFoundError "Bucket not found"
+// @Failure	412 {object} form.BadRequestError "Unsupported tus version"
+// @Failure	413 {object} form.BadRequestError "Upload is too large"
+// @Failure	500 {object} form.InternalServerError "Internal server error"
+// @Failure	503 {object} form.ServerUnavailableError "Server does not available"
+// @Router /api/v1/uploads/{bucket} [post]
+func (s *Server) CreateUpload(eCtx *fiber.Ctx) error {
+	ctx := eCtx.UserContext()
+
+	span := trace.SpanFromContext(ctx)
+
+	bucket, err := ExtractBucketParameter(eCtx)
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		span.RecordError(err)
+		return eCtx.Status(fiber.StatusBadRequest).SendString(err.Error())
+	}
+
+	span.SetAttributes(attribute.String("bucket", bucket))
+
+	length, err := strconv.ParseInt(eCtx.Get(HeaderUploadLength), 10, 64)
+	if err != nil {
+		err = fmt.Errorf("invalid %s header: %w", HeaderUploadLength, err)
+		span.SetStatus(codes.Error, err.Error())
+		span.RecordError(err)
+		return eCtx.Status(fiber.StatusBadRequest).SendString(err.Error())
+	}
+
+	metadata, err := ParseUploadMetadata(eCtx.Get(HeaderUploadMetadata))
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		span.RecordError(err)
+		return eCtx.Status(fiber.StatusBadRequest).SendString(err.Error())
+	}
+
+	fileName := path.Base(metadata["filename"])
+	if fileName == "." || fileName == "/" {
+		err = fmt.Errorf("filename of upload metadata is required")
+		span.SetStatus(codes.Error, err.Error())
+		span.RecordError(err)
+		return eCtx.Status(fiber.StatusBadRequest).SendString(err.Error())
+	}
+
+	objectStorage := s.state.GetObjectStorage()
+	exist, err := objectStorage.IsBucketExists(ctx, bucket)
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		span.RecordError(err)
+		return eCtx.Status(fiber.StatusInternalServerError).SendString(err.Error())
+	}
+
+	if !exist {
+		err = fmt.Errorf("specified bucket %s does not exist", bucket)
+		span.SetStatus(codes.Error, err.Error())
+		span.RecordError(err)
+		return eCtx.Status(fiber.StatusNotFound).SendString(err.Error())
+	}
+
+	params := &process.CreateUploadParams{
+		FilePath:    path.Join(metadata["prefix"], fileName),
+		Length:      length,
+		ContentType: metadata["filetype"],
+		Metadata:    make(map[string]string),
+	}
+
+	for key, value := range metadata {
+		switch key {
+		case "filename", "filetype", "prefix":
+		default:
+			params.Metadata[key] = value
+		}
+	}
+
+	session, err := s.state.CreateUpload(ctx, bucket, params)
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		span.RecordError(err)
+		return eCtx.Status(uploadErrorStatus(err)).SendString(err.Error())
+	}
+
+	eCtx.Set(fiber.HeaderLocation, fmt.Sprintf("/api/v1/uploads/%s/%s", bucket, session.ID.String()))
+	eCtx.Set(HeaderUploadExpires, session.ExpiresAt.UTC().Format(http.TimeFormat))
+	eCtx.Set(HeaderUploadOffset, strconv.FormatInt(session.Offset, 10))
+	return eCtx.SendStatus(fiber.StatusCreated)
+}
+
+// GetUploadOffset
+// @Summary Get offset of resumable upload
+// @Description Get count of received bytes of resumable upload to resume it
+// @ID get-upload-offset
+// @Tags uploads
+// @Param bucket path string true "Bucket name of upload"
+// @Param upload_id path string true "Upload ID"
+// @Param Tus-Resumable header string true "Tus protocol version" default(1.0.0)
+// @Success 200 "Upload-Offset and Upload-Length headers"
+// @Failure	400 {object} form.BadRequestError "Bad Request error"
+// @Failure	404 {object} form.NotFoundError "Upload not found"
+// @Failure	412 {object} form.BadRequestError "Unsupported tus version"
+// @Failure	500 {object} form.InternalServerError "Internal server error"
+// @Router /api/v1/uploads/{bucket}/{upload_id} [head]
+func (s *Server) GetUploadOffset(eCtx *fiber.Ctx) error {
+	ctx := eCtx.UserContext()
+
+	span := trace.SpanFromContext(ctx)
+
+	bucket, err := ExtractBucketParameter(eCtx)
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		span.RecordError(err)
+		return eCtx.SendStatus(fiber.StatusBadRequest)
+	}
+
+	uploadID, err := ExtractUploadIDParameter(eCtx)
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		span.RecordError(err)
+		return eCtx.SendStatus(fiber.StatusBadRequest)
+	}
+
+	span.SetAttributes(
+		attribute.String("bucket", bucket),
+		attribute.String("upload-id", uploadID.String()),
+	)
+
+	session, err := s.state.GetUpload(ctx, bucket, uploadID)
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		span.RecordError(err)
+		return eCtx.SendStatus(uploadErrorStatus(err))
+	}
+
+	eCtx.Set(fiber.HeaderCacheControl, "no-store")
+	eCtx.Set(HeaderUploadOffset, strconv.FormatInt(session.Offset, 10))
+	eCtx.Set(HeaderUploadLength, strconv.FormatInt(session.Length, 10))
+	eCtx.Set(HeaderUploadExpires, session.ExpiresAt.UTC().Format(http.TimeFormat))
+	return eCtx.SendStatus(fiber.StatusOK)
+}
+
+// WriteUploadChunk
+// @Summary Upload chunk of resumable upload
+// @Description Append chunk to resumable upload at offset of received bytes. Task
+// @Description of uploaded file is created when all bytes have been received.
+// @ID write-upload-chunk
+// @Tags uploads
+// @Accept  application/offset+octet-stream
+// @Param bucket path string true "Bucket name of upload"
+// @Param upload_id path string true "Upload ID"
+// @Param Tus-Resumable header string true "Tus protocol version" default(1.0.0)
+// @Param Upload-Offset header int true "Offset of chunk"
+// @Success 204 "Upload-Offset header contains count of received bytes"
+// @Failure	400 {object} form.BadRequestError "Bad Request error"
+// @Failure	404 {object} form.NotFoundError "Upload not found"
+// @Failure	409 {object} form.BadRequestError "Offset does not match received bytes"
+// @Failure	412 {object} form.BadRequestError "Unsupported tus version"
+// @Failure	413 {object} form.BadRequestError "Chunk exceeds upload length"
+// @Failure	415 {object} form.BadRequestError "Unsupported content type"
+// @Failure	423 {object} form.BadRequestError "Upload is written by another request"
+// @Failure	500 {object} form.InternalServerError "Internal server error"
+// @Router /api/v1/uploads/{bucket}/{upload_id} [patch]
+func (s *Server) WriteUploadChunk(eCtx *fiber.Ctx) error {
+	ctx := eCtx.UserContext()
+
+	span := trace.SpanFromContext(ctx)
+
+	if eCtx.Get(fiber.HeaderContentType) != MIMEOffsetOctetStream {
+		err := fmt.Errorf("content type must be %s", MIMEOffsetOctetStream)
+		span.SetStatus(codes.Error, err.Error())
+		span.RecordError(err)
+		return eCtx.Status(fiber.StatusUnsupportedMediaType).SendString(err.Error())
+	}
+
+	bucket, err := ExtractBucketParameter(eCtx)
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		span.RecordError(err)
+		return eCtx.Status(fiber.StatusBadRequest).SendString(err.Error())
+	}
+
+	uploadID, err := ExtractUploadIDParameter(eCtx)
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		span.RecordError(err)
+		return eCtx.Status(fiber.StatusBadRequest).SendString(err.Error())
+	}
+
+	offset, err := strconv.ParseInt(eCtx.Get(HeaderUploadOffset), 10, 64)
+	if err != nil {
+		err = fmt.Errorf("invalid %s header: %w", HeaderUploadOffset, err)
+		span.SetStatus(codes.Error, err.Error())
+		span.RecordError(err)
+		return eCtx.Status(fiber.StatusBadRequest).SendString(err.Error())
+	}
+
+	span.SetAttributes(
+		attribute.String("bucket", bucket),
+		attribute.String("upload-id", uploadID.String()),
+	)
+
+	session, err := s.state.WriteUpload(ctx, bucket, uploadID, offset, eCtx.Body())
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		span.RecordError(err)
+		return eCtx.Status(uploadErrorStatus(err)).SendString(err.Error())
+	}
+
+	if !session.IsCompleted() {
+		eCtx.Set(HeaderUploadExpires, session.ExpiresAt.UTC().Format(http.TimeFormat))
+	}
+	eCtx.Set(HeaderUploadOffset, strconv.FormatInt(session.Offset, 10))
+	return eCtx.SendStatus(fiber.StatusNoContent)
+}
+
+// TerminateUpload
+// @Summary Terminate resumable upload
+// @Description Abort resumable upload and remove received bytes
+// @ID terminate-upload
+// @Tags uploads
+// @Param bucket path string true "Bucket name of upload"
+// @Param upload_id path string true "Upload ID"
+// @Param Tus-Resumable header string true "Tus protocol version" default(1.0.0)
+// @Success 204 "No Content"
+// @Failure	400 {object} form.BadRequestError "Bad Request error"
+// @Failure	404 {object} form.NotFoundError "Upload not found"
+// @Failure	412 {object} form.BadRequestError "Unsupported tus version"
+// @Failure	423 {object} form.BadRequestError "Upload is written by another request"
+// @Failure	500 {object} form.InternalServerError "Internal server error"
+// @Router /api/v1/uploads/{bucket}/{upload_id} [delete]
+func (s *Server) TerminateUpload(eCtx *fiber.Ctx) error {
+	ctx := eCtx.UserContext()
+
+	span := trace.SpanFromContext(ctx)
+
+	bucket, err := ExtractBucketParameter(eCtx)
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		span.RecordError(err)
+		return eCtx.Status(fiber.StatusBadRequest).SendString(err.Error())
+	}
+
+	uploadID, err := ExtractUploadIDParameter(eCtx)
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		span.RecordError(err)
+		return eCtx.Status(fiber.StatusBadRequest).SendString(err.Error())
+	}
+
+	span.SetAttributes(
+		attribute.String("bucket", bucket),
+		attribute.String("upload-id", uploadID.String()),
+	)
+
+	if err = s.state.TerminateUpload(ctx, bucket, uploadID); err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		span.RecordError(err)
+		return eCtx.Status(uploadErrorStatus(err)).SendString(err.Error())
+	}
+
+	return eCtx.SendStatus(fiber.StatusNoContent)
+}
+
+func uploadErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, upload.ErrSessionNotFound), errors.Is(err, taskApp.ErrUploadsDisabled):
+		return fiber.StatusNotFound
+	case errors.Is(err, process.ErrUploadOffsetMismatch):
+		return fiber.StatusConflict
+	case errors.Is(err, upload.ErrSessionLocked):
+		return fiber.StatusLocked
+	case errors.Is(err, process.ErrUploadTooLarge):
+		return fiber.StatusRequestEntityTooLarge
+	default:
+		return fiber.StatusInternalServerError
+	}
+}
@@ -0,0 +1,204 @@
+package httpserver
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/gofiber/fiber/v2"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"watchtower/cmd/watchtower/httpserver/form"
+	"watchtower/internal/core/cloud/domain"
+)
+
+// GetFileVersions
+// @Summary Get file versions
+// @Description Get versions of file in versioned bucket from the newest one.
+// @Description Delete markers are listed too, they have no content and can't be restored.
+// @ID get-file-versions
+// @Tags files
+// @Accept  json
+// @Produce json
+// @Param bucket path string true "Bucket name of file"
+// @Param jsonQuery body form.FileVersionsForm true "File to get versions"
+// @Success 200 {object} []form.ObjectVersionSchema "Versions of file"
+// @Failure	400 {object} form.BadRequestError "Bad Request error"
+// @Failure	404 {object} form.NotFoundError "File not found"
+// @Failure	500 {object} form.InternalServerError "Internal server error"
+// @Failure	501 {object} form.InternalServerError "Versions are not supported by storage"
+// @Failure	503 {object} form.ServerUnavailableError "Server does not available"
+// @Router /api/v1/cloud/{bucket}/file/versions [post]
+func (s *Server) GetFileVersions(eCtx *fiber.Ctx) error {
+	ctx := eCtx.UserContext()
+
+	span := trace.SpanFromContext(ctx)
+
+	bucket, err := ExtractBucketParameter(eCtx)
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		span.RecordError(err)
+		return eCtx.Status(fiber.StatusBadRequest).SendString(err.Error())
+	}
+
+	span.SetAttributes(attribute.String("bucket", bucket))
+
+	var jsonForm form.FileVersionsForm
+	err = json.Unmarshal(eCtx.Body(), &jsonForm)
+	if err == nil && jsonForm.FilePath == "" {
+		err = fmt.Errorf("file_path is required")
+	}
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		span.RecordError(err)
+		return eCtx.Status(fiber.StatusBadRequest).SendString(err.Error())
+	}
+
+	objectStorage := s.state.GetObjectStorage()
+	versions, err := objectStorage.GetObjectVersions(ctx, bucket, jsonForm.FilePath)
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		span.RecordError(err)
+		return eCtx.Status(versionErrorStatus(err)).SendString(err.Error())
+	}
+
+	if len(versions) == 0 {
+		err = fmt.Errorf("file %s does not exist", jsonForm.FilePath)
+		span.SetStatus(codes.Error, err.Error())
+		span.RecordError(err)
+		return eCtx.Status(fiber.StatusNotFound).SendString(err.Error())
+	}
+
+	versionsDto := make([]form.ObjectVersionSchema, len(versions))
+	for index, version := range versions {
+		versionsDto[index] = form.ObjectVersionFromDomain(version)
+	}
+
+	return eCtx.Status(fiber.StatusOK).JSON(versionsDto)
+}
+
+// DownloadFileVersion
+// @Summary Download file version
+// @Description Download content of specific version of file
+// @ID download-file-version
+// @Tags files
+// @Accept  json
+// @Produce json
+// @Param bucket path string true "Bucket name of file"
+// @Param jsonQuery body form.FileVersionForm true "File version to download"
+// @Success 200 {file} io.Writer "Returned file bytes"
+// @Failure	400 {object} form.BadRequestError "Bad Request error"
+// @Failure	404 {object} form.NotFoundError "Version not found"
+// @Failure	500 {object} form.InternalServerError "Internal server error"
+// @Failure	501 {object} form.InternalServerError "Versions are not supported by storage"
+// @Failure	503 {object} form.ServerUnavailableError "Server does not available"
+// @Router /api/v1/cloud/{bucket}/file/version/download [post]
+func (s *Server) DownloadFileVersion(eCtx *fiber.Ctx) error {
+	ctx := eCtx.UserContext()
+
+	span := trace.SpanFromContext(ctx)
+
+	bucket, err := ExtractBucketParameter(eCtx)
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		span.RecordError(err)
+		return eCtx.Status(fiber.StatusBadRequest).SendString(err.Error())
+	}
+
+	span.SetAttributes(attribute.String("bucket", bucket))
+
+	jsonForm, err := parseFileVersionForm(eCtx)
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		span.RecordError(err)
+		return eCtx.Status(fiber.StatusBadRequest).SendString(err.Error())
+	}
+
+	objectStorage := s.state.GetObjectStorage()
+	fileData, err := objectStorage.GetObjectVersionData(ctx, bucket, jsonForm.FilePath, jsonForm.VersionID)
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		span.RecordError(err)
+		return eCtx.Status(versionErrorStatus(err)).SendString(err.Error())
+	}
+	defer fileData.Reset()
+
+	return eCtx.Send(fileData.Bytes())
+}
+
+// RestoreFileVersion
+// @Summary Restore file version
+// @Description Restore specific version of file as current one. Restored content is stored
+// @Description as new version of file and task is created to reindex file.
+// @ID restore-file-version
+// @Tags files
+// @Accept  json
+// @Produce json
+// @Param bucket path string true "Bucket name of file"
+// @Param jsonQuery body form.FileVersionForm true "File version to restore"
+// @Success 201 {object} form.TaskSchema "Task of restored file"
+// @Failure	400 {object} form.BadRequestError "Bad Request error"
+// @Failure	404 {object} form.NotFoundError "Version not found"
+// @Failure	500 {object} form.InternalServerError "Internal server error"
+// @Failure	501 {object} form.InternalServerError "Versions are not supported by storage"
+// @Failure	503 {object} form.ServerUnavailableError "Server does not available"
+// @Router /api/v1/cloud/{bucket}/file/version/restore [post]
+func (s *Server) RestoreFileVersion(eCtx *fiber.Ctx) error {
+	ctx := eCtx.UserContext()
+
+	span := trace.SpanFromContext(ctx)
+
+	bucket, err := ExtractBucketParameter(eCtx)
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		span.RecordError(err)
+		return eCtx.Status(fiber.StatusBadRequest).SendString(err.Error())
+	}
+
+	span.SetAttributes(attribute.String("bucket", bucket))
+
+	jsonForm, err := parseFileVersionForm(eCtx)
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		span.RecordError(err)
+		return eCtx.Status(fiber.StatusBadRequest).SendString(err.Error())
+	}
+
+	task, err := s.state.RestoreObjectVersion(ctx, bucket, jsonForm.FilePath, jsonForm.VersionID)
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		span.RecordError(err)
+		return eCtx.Status(versionErrorStatus(err)).SendString(err.Error())
+	}
+
+	return eCtx.Status(fiber.StatusCreated).JSON(form.TaskFromDomain(*task))
+}
+
+func parseFileVersionForm(eCtx *fiber.Ctx) (form.FileVersionForm, error) {
+	var jsonForm form.FileVersionForm
+	if err := json.Unmarshal(eCtx.Body(), &jsonForm); err != nil {
+		return jsonForm, err
+	}
+
+	switch {
+	case jsonForm.FilePath == "":
+		return jsonForm, fmt.Errorf("file_path is required")
+	case jsonForm.VersionID == "":
+		return jsonForm, fmt.Errorf("version_id is required")
+	default:
+		return jsonForm, nil
+	}
+}
+
+func versionErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, domain.ErrVersionNotFound):
+		return fiber.StatusNotFound
+	case errors.Is(err, domain.ErrVersioningNotSupported):
+		return fiber.StatusNotImplemented
+	default:
+		return fiber.StatusInternalServerError
+	}
+}
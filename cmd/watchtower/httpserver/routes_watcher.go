@@ -0,0 +1,263 @@
+package httpserver
+
+import (
+	"encoding/json"
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"watchtower/cmd/watchtower/httpserver/form"
+
+	task "watchtower/internal/support/task/domain"
+)
+
+func (s *Server) CreateWatchersGroup(group fiber.Router) {
+	watchersGroup := group.Group("/watchers")
+	watchersGroup.Get("/", s.LoadWatchers)
+	watchersGroup.Post("/", s.CreateWatcher)
+	watchersGroup.Get("/:watcher_id", s.LoadWatcherByID)
+	watchersGroup.Put("/:watcher_id", s.UpdateWatcher)
+	watchersGroup.Delete("/:watcher_id", s.RemoveWatcher)
+}
+
+// LoadWatchers
+// @Summary Load registered directory watchers
+// @Description Load registered watchers of bucket directories
+// @ID load-watchers
+// @Tags watchers
+// @Produce json
+// @Success 200 {object} []form.WatcherSchema "Loaded watchers"
+// @Failure	500 {object} form.InternalServerError "Internal server error"
+// @Failure	503 {object} form.ServerUnavailableError "Server does not available"
+// @Router /api/v1/watchers [get]
+func (s *Server) LoadWatchers(eCtx *fiber.Ctx) error {
+	ctx := eCtx.UserContext()
+
+	span := trace.SpanFromContext(ctx)
+
+	taskProcessor := s.state.GetTaskProcessor()
+	watchers, err := taskProcessor.GetWatchers(ctx)
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		span.RecordError(err)
+		return eCtx.Status(fiber.StatusInternalServerError).SendString(err.Error())
+	}
+
+	watchersDto := make([]form.WatcherSchema, len(watchers))
+	for index, watcher := range watchers {
+		watchersDto[index] = form.WatcherFromDomain(*watcher)
+	}
+
+	return eCtx.Status(fiber.StatusOK).JSON(watchersDto)
+}
+
+// CreateWatcher
+// @Summary Register directory watcher
+// @Description Register watcher which processes objects created or changed into bucket directory
+// @ID create-watcher
+// @Tags watchers
+// @Accept  json
+// @Produce json
+// @Param jsonQuery body form.AddDirectoryToWatcherForm true "Bucket directory to watch"
+// @Success 201 {object} form.WatcherSchema "Registered watcher"
+// @Failure	400 {object} form.BadRequestError "Bad Request error"
+// @Failure	404 {object} form.NotFoundError "Bucket not found"
+// @Failure	500 {object} form.InternalServerError "Internal server error"
+// @Failure	503 {object} form.ServerUnavailableError "Server does not available"
+// @Router /api/v1/watchers [post]
+func (s *Server) CreateWatcher(eCtx *fiber.Ctx) error {
+	ctx := eCtx.UserContext()
+
+	span := trace.SpanFromContext(ctx)
+
+	jsonForm, status, err := s.extractWatcherForm(eCtx)
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		span.RecordError(err)
+		return eCtx.Status(status).SendString(err.Error())
+	}
+
+	watcher := task.CreateNewWatcher(jsonForm.BucketName, jsonForm.Suffix)
+	span.SetAttributes(
+		attribute.String("watcher-id", watcher.ID.String()),
+		attribute.String("bucket", watcher.BucketID),
+		attribute.String("prefix", watcher.Prefix),
+	)
+
+	taskProcessor := s.state.GetTaskProcessor()
+	if err = taskProcessor.UpdateWatcher(ctx, watcher); err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		span.RecordError(err)
+		return eCtx.Status(fiber.StatusInternalServerError).SendString(err.Error())
+	}
+
+	return eCtx.Status(fiber.StatusCreated).JSON(form.WatcherFromDomain(*watcher))
+}
+
+// LoadWatcherByID
+// @Summary Load directory watcher by id
+// @Description Load registered watcher of bucket directory by id
+// @ID load-watcher-by-id
+// @Tags watchers
+// @Produce json
+// @Param watcher_id path string true "Watcher ID"
+// @Success 200 {object} form.WatcherSchema "Loaded watcher"
+// @Failure	400 {object} form.BadRequestError "Bad Request error"
+// @Failure	404 {object} form.NotFoundError "Watcher not found"
+// @Failure	500 {object} form.InternalServerError "Internal server error"
+// @Failure	503 {object} form.ServerUnavailableError "Server does not available"
+// @Router /api/v1/watchers/{watcher_id} [get]
+func (s *Server) LoadWatcherByID(eCtx *fiber.Ctx) error {
+	ctx := eCtx.UserContext()
+
+	span := trace.SpanFromContext(ctx)
+
+	watcherID, err := ExtractWatcherIDParameter(eCtx)
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		span.RecordError(err)
+		return eCtx.Status(fiber.StatusBadRequest).SendString(err.Error())
+	}
+
+	span.SetAttributes(attribute.String("watcher-id", watcherID.String()))
+
+	taskProcessor := s.state.GetTaskProcessor()
+	watcher, err := taskProcessor.GetWatcher(ctx, watcherID)
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		span.RecordError(err)
+		return eCtx.Status(watcherErrorStatus(err)).SendString(err.Error())
+	}
+
+	return eCtx.Status(fiber.StatusOK).JSON(form.WatcherFromDomain(*watcher))
+}
+
+// UpdateWatcher
+// @Summary Update directory watcher
+// @Description Change watched bucket directory of registered watcher
+// @ID update-watcher
+// @Tags watchers
+// @Accept  json
+// @Produce json
+// @Param watcher_id path string true "Watcher ID"
+// @Param jsonQuery body form.AddDirectoryToWatcherForm true "Bucket directory to watch"
+// @Success 200 {object} form.WatcherSchema "Updated watcher"
+// @Failure	400 {object} form.BadRequestError "Bad Request error"
+// @Failure	404 {object} form.NotFoundError "Watcher or bucket not found"
+// @Failure	500 {object} form.InternalServerError "Internal server error"
+// @Failure	503 {object} form.ServerUnavailableError "Server does not available"
+// @Router /api/v1/watchers/{watcher_id} [put]
+func (s *Server) UpdateWatcher(eCtx *fiber.Ctx) error {
+	ctx := eCtx.UserContext()
+
+	span := trace.SpanFromContext(ctx)
+
+	watcherID, err := ExtractWatcherIDParameter(eCtx)
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		span.RecordError(err)
+		return eCtx.Status(fiber.StatusBadRequest).SendString(err.Error())
+	}
+
+	span.SetAttributes(attribute.String("watcher-id", watcherID.String()))
+
+	jsonForm, status, err := s.extractWatcherForm(eCtx)
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		span.RecordError(err)
+		return eCtx.Status(status).SendString(err.Error())
+	}
+
+	taskProcessor := s.state.GetTaskProcessor()
+	watcher, err := taskProcessor.GetWatcher(ctx, watcherID)
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		span.RecordError(err)
+		return eCtx.Status(watcherErrorStatus(err)).SendString(err.Error())
+	}
+
+	watcher.BucketID = jsonForm.BucketName
+	watcher.Prefix = task.NormalizeWatcherPrefix(jsonForm.Suffix)
+	if err = taskProcessor.UpdateWatcher(ctx, watcher); err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		span.RecordError(err)
+		return eCtx.Status(fiber.StatusInternalServerError).SendString(err.Error())
+	}
+
+	return eCtx.Status(fiber.StatusOK).JSON(form.WatcherFromDomain(*watcher))
+}
+
+// RemoveWatcher
+// @Summary Remove directory watcher
+// @Description Stop watching of bucket directory
+// @ID remove-watcher
+// @Tags watchers
+// @Produce json
+// @Param watcher_id path string true "Watcher ID"
+// @Success 200 {object} form.Success "Ok"
+// @Failure	400 {object} form.BadRequestError "Bad Request error"
+// @Failure	404 {object} form.NotFoundError "Watcher not found"
+// @Failure	500 {object} form.InternalServerError "Internal server error"
+// @Failure	503 {object} form.ServerUnavailableError "Server does not available"
+// @Router /api/v1/watchers/{watcher_id} [delete]
+func (s *Server) RemoveWatcher(eCtx *fiber.Ctx) error {
+	ctx := eCtx.UserContext()
+
+	span := trace.SpanFromContext(ctx)
+
+	watcherID, err := ExtractWatcherIDParameter(eCtx)
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		span.RecordError(err)
+		return eCtx.Status(fiber.StatusBadRequest).SendString(err.Error())
+	}
+
+	span.SetAttributes(attribute.String("watcher-id", watcherID.String()))
+
+	taskProcessor := s.state.GetTaskProcessor()
+	if err = taskProcessor.DeleteWatcher(ctx, watcherID); err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		span.RecordError(err)
+		return eCtx.Status(watcherErrorStatus(err)).SendString(err.Error())
+	}
+
+	return eCtx.Status(fiber.StatusOK).SendString("Ok")
+}
+
+// extractWatcherForm parses watcher form and checks that watched bucket
+// exists, returned status is http status code of error.
+func (s *Server) extractWatcherForm(eCtx *fiber.Ctx) (*form.AddDirectoryToWatcherForm, int, error) {
+	ctx := eCtx.UserContext()
+
+	var jsonForm form.AddDirectoryToWatcherForm
+	if err := json.Unmarshal(eCtx.Body(), &jsonForm); err != nil {
+		return nil, fiber.StatusBadRequest, err
+	}
+
+	if jsonForm.BucketName == "" {
+		return nil, fiber.StatusBadRequest, errors.New("bucket is required")
+	}
+
+	objStorage := s.state.GetObjectStorage()
+	exists, err := objStorage.IsBucketExists(ctx, jsonForm.BucketName)
+	if err != nil {
+		return nil, fiber.StatusInternalServerError, err
+	}
+
+	if !exists {
+		return nil, fiber.StatusNotFound, errors.New("bucket does not exist")
+	}
+
+	return &jsonForm, fiber.StatusOK, nil
+}
+
+func watcherErrorStatus(err error) int {
+	if errors.Is(err, task.ErrWatcherNotFound) {
+		return fiber.StatusNotFound
+	}
+
+	return fiber.StatusInternalServerError
+}
@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"fmt"
 	"log/slog"
 	"os"
 	"os/signal"
@@ -12,19 +13,36 @@ import (
 
 	"watchtower/cmd"
 	"watchtower/cmd/watchtower/httpserver"
+	"watchtower/internal/core/cloud/domain"
+	"watchtower/internal/core/cloud/infrastructure/localfs"
+	"watchtower/internal/core/cloud/infrastructure/notify"
 	"watchtower/internal/core/cloud/infrastructure/s3"
 	"watchtower/internal/process"
+	"watchtower/internal/support/task/infrastructure/clamd"
+	"watchtower/internal/support/task/infrastructure/cmdparser"
 	"watchtower/internal/support/task/infrastructure/docparser"
 	"watchtower/internal/support/task/infrastructure/docsearch"
+	"watchtower/internal/support/task/infrastructure/llm"
+	"watchtower/internal/support/task/infrastructure/neo4j"
+	"watchtower/internal/support/task/infrastructure/ner"
+	"watchtower/internal/support/task/infrastructure/ooxml"
+	"watchtower/internal/support/task/infrastructure/piiscan"
 	"watchtower/internal/support/task/infrastructure/redis"
 	"watchtower/internal/support/task/infrastructure/rmq"
+	"watchtower/internal/support/task/infrastructure/routing"
+	"watchtower/internal/support/task/infrastructure/textparser"
+	"watchtower/internal/support/task/infrastructure/tika"
 
 	cloudApp "watchtower/internal/core/cloud/application"
 	taskApp "watchtower/internal/support/task/application"
+	"watchtower/internal/support/task/application/service/recognizer"
 )
 
 const (
 	ShutdownDuration = 10 * time.Second
+
+	S3StorageBackend      = "s3"
+	LocalFSStorageBackend = "localfs"
 )
 
 func main() {
@@ -48,19 +66,101 @@ func main() {
 		os.Exit(1)
 	}
 
-	docParser := docparser.New(servConfig.Task.Processor.DocParser)
+	recognizers := map[string]recognizer.IRecognizer{
+		docparser.RecognizerName:  docparser.New(servConfig.Task.Processor.DocParser),
+		textparser.RecognizerName: textparser.New(),
+		ooxml.RecognizerName:      ooxml.New(),
+		cmdparser.RecognizerName:  cmdparser.New(servConfig.Task.Processor.Command),
+		tika.RecognizerName:       tika.New(servConfig.Task.Processor.Tika),
+	}
+	docRecognizer, err := routing.New(servConfig.Task.Processor.Recognizer, recognizers)
+	if err != nil {
+		slog.Error("invalid recognizers configuration", slog.String("err", err.Error()))
+		os.Exit(1)
+	}
+
 	docStorage := docsearch.New(servConfig.Task.Processor.DocStorage)
-	objStorage, err := s3.New(servConfig.Storage.S3)
+	objStorage, err := newObjectStorage(servConfig.Storage)
 	if err != nil {
 		slog.Error("object storage connection failed", slog.String("err", err.Error()))
 		os.Exit(1)
 	}
 
+	var taskOpts []taskApp.Option
+	if servConfig.Orchestrator.KnowledgeGraph.Enabled {
+		entityExtractor := ner.New(servConfig.Task.Processor.Entities)
+		graphStore := neo4j.New(servConfig.Task.Processor.Graph)
+		taskOpts = append(taskOpts, taskApp.WithKnowledgeGraph(entityExtractor, graphStore))
+	}
+	if servConfig.Orchestrator.Antivirus.Enabled {
+		scanner := clamd.New(servConfig.Task.Processor.Clamd)
+		taskOpts = append(taskOpts, taskApp.WithAntivirus(scanner))
+	}
+	if servConfig.Orchestrator.Pii.Enabled {
+		piiDetector, err := piiscan.New(servConfig.Task.Processor.Pii)
+		if err != nil {
+			slog.Error("invalid pii patterns configuration", slog.String("err", err.Error()))
+			os.Exit(1)
+		}
+		taskOpts = append(taskOpts, taskApp.WithPiiDetector(piiDetector))
+	}
+	if servConfig.Orchestrator.Dedup.Enabled {
+		fingerprints, err := redis.NewFingerprintStorage(servConfig.Task.TaskStorage.Redis, servConfig.Orchestrator.Dedup.MaxDistance)
+		if err != nil {
+			slog.Error("invalid near-duplicate configuration", slog.String("err", err.Error()))
+			os.Exit(1)
+		}
+		taskOpts = append(taskOpts, taskApp.WithFingerprints(fingerprints))
+	}
+	if servConfig.Orchestrator.Summary.Enabled {
+		summarizer := llm.New(servConfig.Task.Processor.Summarizer)
+		taskOpts = append(taskOpts, taskApp.WithSummarizer(summarizer))
+	}
+	if servConfig.Orchestrator.Uploads.Enabled {
+		// Local filesystem backend accepts parts of any size.
+		var minPartSize int64
+		if servConfig.Storage.Backend != LocalFSStorageBackend {
+			minPartSize = domain.MinMultipartPartSize
+		}
+		if err = servConfig.Orchestrator.Uploads.ValidatePartSize(minPartSize); err != nil {
+			slog.Error("invalid uploads configuration", slog.String("err", err.Error()))
+			os.Exit(1)
+		}
+
+		uploads := redis.NewUploadStorage(servConfig.Task.TaskStorage.Redis)
+		taskOpts = append(taskOpts, taskApp.WithUploads(uploads))
+	}
+	if servConfig.Orchestrator.PresignedUploads.Enabled {
+		tickets := redis.NewTicketStorage(servConfig.Task.TaskStorage.Redis)
+		taskOpts = append(taskOpts, taskApp.WithUploadTickets(tickets))
+	}
+
+	if servConfig.Orchestrator.Events.Enabled || servConfig.Orchestrator.Poller.Enabled {
+		storedObjects := redis.NewStoredObjectStorage(servConfig.Task.TaskStorage.Redis)
+		taskOpts = append(taskOpts, taskApp.WithStoredObjects(storedObjects))
+	}
+
 	storageUseCase := cloudApp.NewStorageUseCase(objStorage)
-	taskUseCase := taskApp.NewTaskUseCase(taskStorage, taskQueue, docParser, docStorage)
+	taskUseCase := taskApp.NewTaskUseCase(taskStorage, taskQueue, docRecognizer, docStorage, taskOpts...)
 
 	orchestrator := process.NewOrchestrator(servConfig.Orchestrator, storageUseCase, taskUseCase)
 	orchestrator.LaunchListener(cCtx)
+	if servConfig.Orchestrator.Watcher.Enabled {
+		orchestrator.LaunchWatchers(cCtx)
+	}
+	eventsConfig := servConfig.Orchestrator.Events
+	if eventsConfig.Enabled && eventsConfig.Source == process.AMQPEventSource {
+		eventSource := notify.NewAMQPSource(servConfig.Storage.Events)
+		orchestrator.LaunchEventsConsumer(cCtx, eventSource)
+	}
+	if servConfig.Orchestrator.Poller.Enabled {
+		snapshots := redis.NewSnapshotStorage(servConfig.Task.TaskStorage.Redis)
+		leaderLock := redis.NewLeaderLock(servConfig.Task.TaskStorage.Redis)
+		orchestrator.LaunchPoller(cCtx, snapshots, leaderLock)
+	}
+	if servConfig.Orchestrator.Uploads.Enabled {
+		orchestrator.LaunchUploadsCleaner(cCtx)
+	}
 
 	httpServer := httpserver.SetupServer(servConfig.Otlp, orchestrator)
 	go func() {
@@ -90,3 +190,14 @@ func main() {
 
 	slog.Info("application has been shutdown successfully")
 }
+
+func newObjectStorage(config cmd.StorageConfig) (domain.ICloudStorage, error) {
+	switch config.Backend {
+	case LocalFSStorageBackend:
+		return localfs.New(config.LocalFS)
+	case S3StorageBackend, "":
+		return s3.New(config.S3)
+	default:
+		return nil, fmt.Errorf("unknown storage backend: %s", config.Backend)
+	}
+}
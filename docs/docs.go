@@ -17,7 +17,7 @@ const docTemplate = `{
     "paths": {
         "/api/v1/cloud/bucket": {
             "put": {
-                "description": "Create new bucket into cloud",
+                "description": "Create new bucket into cloud. Optional config sets versioning, lifecycle rules,\nobject lock and quota of bucket, object lock may be enabled on bucket creation only.",
                 "consumes": [
                     "application/json"
                 ],
@@ -59,6 +59,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/form.InternalServerError"
                         }
                     },
+                    "501": {
+                        "description": "Config is not supported by storage",
+                        "schema": {
+                            "$ref": "#/definitions/form.InternalServerError"
+                        }
+                    },
                     "503": {
                         "description": "Server does not available",
                         "schema": {
@@ -158,38 +164,31 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/cloud/{bucket}/file": {
-            "delete": {
-                "description": "Remove file from cloud",
+        "/api/v1/cloud/{bucket}/config": {
+            "get": {
+                "description": "Get versioning, lifecycle rules, object lock and quota of bucket.\nSettings unsupported by storage are null.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "files"
+                    "buckets"
                 ],
-                "summary": "Remove file from cloud",
-                "operationId": "remove-file-2",
+                "summary": "Get bucket config",
+                "operationId": "get-bucket-config",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Bucket name to remove file",
+                        "description": "Bucket name",
                         "name": "bucket",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Parameters to remove file",
-                        "name": "file_name",
-                        "in": "query",
-                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Ok",
+                        "description": "Bucket config",
                         "schema": {
-                            "$ref": "#/definitions/form.Success"
+                            "$ref": "#/definitions/form.BucketConfigSchema"
                         }
                     },
                     "400": {
@@ -210,6 +209,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/form.InternalServerError"
                         }
                     },
+                    "501": {
+                        "description": "Config is not supported by storage",
+                        "schema": {
+                            "$ref": "#/definitions/form.InternalServerError"
+                        }
+                    },
                     "503": {
                         "description": "Server does not available",
                         "schema": {
@@ -218,8 +223,8 @@ const docTemplate = `{
                     }
                 }
             },
-            "patch": {
-                "description": "Copy file to another location into bucket",
+            "put": {
+                "description": "Update versioning, lifecycle rules, object lock retention and quota of bucket.\nOmitted settings are left unchanged, lifecycle replaces all rules of bucket.",
                 "consumes": [
                     "application/json"
                 ],
@@ -227,25 +232,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "files"
+                    "buckets"
                 ],
-                "summary": "Copy file to another location into bucket",
-                "operationId": "copy-file",
+                "summary": "Update bucket config",
+                "operationId": "set-bucket-config",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Bucket name of src file",
+                        "description": "Bucket name",
                         "name": "bucket",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Params to copy file",
+                        "description": "Changed bucket settings",
                         "name": "jsonQuery",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/form.CopyFileForm"
+                            "$ref": "#/definitions/form.BucketConfigForm"
                         }
                     }
                 ],
@@ -263,7 +268,7 @@ const docTemplate = `{
                         }
                     },
                     "404": {
-                        "description": "Bucket or file not found",
+                        "description": "Bucket not found",
                         "schema": {
                             "$ref": "#/definitions/form.NotFoundError"
                         }
@@ -274,6 +279,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/form.InternalServerError"
                         }
                     },
+                    "501": {
+                        "description": "Config is not supported by storage",
+                        "schema": {
+                            "$ref": "#/definitions/form.InternalServerError"
+                        }
+                    },
                     "503": {
                         "description": "Server does not available",
                         "schema": {
@@ -283,36 +294,31 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/cloud/{bucket}/file/attributes": {
-            "post": {
-                "description": "Get file attributes",
-                "consumes": [
-                    "application/json"
-                ],
+        "/api/v1/cloud/{bucket}/file": {
+            "delete": {
+                "description": "Remove file from cloud",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "files"
                 ],
-                "summary": "Get file attributes",
-                "operationId": "get-file-attrs",
+                "summary": "Remove file from cloud",
+                "operationId": "remove-file-2",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Bucket name to get list files",
+                        "description": "Bucket name to remove file",
                         "name": "bucket",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Parameters to get list files",
-                        "name": "jsonQuery",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/form.GetFileAttributesForm"
-                        }
+                        "type": "string",
+                        "description": "Parameters to remove file",
+                        "name": "file_name",
+                        "in": "query",
+                        "required": true
                     }
                 ],
                 "responses": {
@@ -329,7 +335,7 @@ const docTemplate = `{
                         }
                     },
                     "404": {
-                        "description": "Bucket or Object not found",
+                        "description": "Bucket not found",
                         "schema": {
                             "$ref": "#/definitions/form.NotFoundError"
                         }
@@ -347,11 +353,9 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/api/v1/cloud/{bucket}/file/download": {
-            "post": {
-                "description": "Download file from cloud",
+            },
+            "patch": {
+                "description": "Copy or move file to another location into bucket or into dst_bucket.\nSource path ending with slash copies all files under prefix into dst_path prefix,\ndst_path may be omitted to keep paths of files in another bucket. Task is created\nper copied file to index it into destination bucket, documents of files moved into\ndst_bucket are removed from index of source bucket. Documents of files moved within\nbucket are moved in index, response is Ok for files copied within bucket.",
                 "consumes": [
                     "application/json"
                 ],
@@ -361,31 +365,34 @@ const docTemplate = `{
                 "tags": [
                     "files"
                 ],
-                "summary": "Download file from cloud",
-                "operationId": "download-file",
+                "summary": "Copy file to another location",
+                "operationId": "copy-file",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Bucket name to download file",
+                        "description": "Bucket name of src file",
                         "name": "bucket",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Parameters to download file",
+                        "description": "Params to copy file",
                         "name": "jsonQuery",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/form.DownloadFileForm"
+                            "$ref": "#/definitions/form.CopyFileForm"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Returned file bytes",
+                        "description": "Tasks of files copied into dst_bucket",
                         "schema": {
-                            "type": "file"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/form.TaskSchema"
+                            }
                         }
                     },
                     "400": {
@@ -395,7 +402,7 @@ const docTemplate = `{
                         }
                     },
                     "404": {
-                        "description": "Bucket not found",
+                        "description": "Bucket or file not found",
                         "schema": {
                             "$ref": "#/definitions/form.NotFoundError"
                         }
@@ -415,32 +422,35 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/cloud/{bucket}/file/remove": {
-            "delete": {
-                "description": "Remove file from cloud",
+        "/api/v1/cloud/{bucket}/file/attributes": {
+            "post": {
+                "description": "Get file attributes",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "files"
                 ],
-                "summary": "Remove file from cloud",
-                "operationId": "remove-file",
+                "summary": "Get file attributes",
+                "operationId": "get-file-attrs",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Bucket name to remove file",
+                        "description": "Bucket name to get list files",
                         "name": "bucket",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Parameters to remove file",
+                        "description": "Parameters to get list files",
                         "name": "jsonQuery",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/form.RemoveFileForm"
+                            "$ref": "#/definitions/form.GetFileAttributesForm"
                         }
                     }
                 ],
@@ -458,7 +468,7 @@ const docTemplate = `{
                         }
                     },
                     "404": {
-                        "description": "Bucket not found",
+                        "description": "Bucket or Object not found",
                         "schema": {
                             "$ref": "#/definitions/form.NotFoundError"
                         }
@@ -478,9 +488,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/cloud/{bucket}/file/share": {
+        "/api/v1/cloud/{bucket}/file/download": {
             "post": {
-                "description": "Get share URL for file",
+                "description": "Download file from cloud",
                 "consumes": [
                     "application/json"
                 ],
@@ -488,33 +498,33 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "share"
+                    "files"
                 ],
-                "summary": "Get share URL for file",
-                "operationId": "share-file",
+                "summary": "Download file from cloud",
+                "operationId": "download-file",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Bucket name to share file",
+                        "description": "Bucket name to download file",
                         "name": "bucket",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Parameters to share file",
+                        "description": "Parameters to download file",
                         "name": "jsonQuery",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/form.ShareFileForm"
+                            "$ref": "#/definitions/form.DownloadFileForm"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "URL with shared file",
+                        "description": "Returned file bytes",
                         "schema": {
-                            "$ref": "#/definitions/form.Success"
+                            "type": "file"
                         }
                     },
                     "400": {
@@ -524,7 +534,7 @@ const docTemplate = `{
                         }
                     },
                     "404": {
-                        "description": "Bucket of object not found",
+                        "description": "Bucket not found",
                         "schema": {
                             "$ref": "#/definitions/form.NotFoundError"
                         }
@@ -544,11 +554,11 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/cloud/{bucket}/file/upload": {
+        "/api/v1/cloud/{bucket}/file/metadata": {
             "put": {
-                "description": "Upload files to cloud",
+                "description": "Replace user metadata of file in place, content type and tags of file are kept.\nS3 file is copied to itself, so its modification time and etag are changed.\nPass reindex to create processing task of file.",
                 "consumes": [
-                    "multipart/form"
+                    "application/json"
                 ],
                 "produces": [
                     "application/json"
@@ -556,34 +566,24 @@ const docTemplate = `{
                 "tags": [
                     "files"
                 ],
-                "summary": "Upload files to cloud",
-                "operationId": "upload-files",
+                "summary": "Replace file metadata",
+                "operationId": "replace-file-metadata",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Bucket name to upload files",
+                        "description": "Bucket name of file",
                         "name": "bucket",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "Prefix to load files",
-                        "name": "prefix",
-                        "in": "formData"
-                    },
-                    {
-                        "type": "file",
-                        "description": "Files multipart form",
-                        "name": "files",
-                        "in": "formData",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "File datetime expired like 2025-01-01T12:01:01Z",
-                        "name": "expired",
-                        "in": "query"
+                        "description": "File metadata",
+                        "name": "jsonQuery",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/form.ReplaceFileMetadataForm"
+                        }
                     }
                 ],
                 "responses": {
@@ -593,16 +593,16 @@ const docTemplate = `{
                             "$ref": "#/definitions/form.Success"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request error",
+                    "201": {
+                        "description": "Task of reindexed file",
                         "schema": {
-                            "$ref": "#/definitions/form.BadRequestError"
+                            "$ref": "#/definitions/form.TaskSchema"
                         }
                     },
-                    "404": {
-                        "description": "Bucket not found",
+                    "400": {
+                        "description": "Bad Request error",
                         "schema": {
-                            "$ref": "#/definitions/form.NotFoundError"
+                            "$ref": "#/definitions/form.BadRequestError"
                         }
                     },
                     "500": {
@@ -620,35 +620,32 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/cloud/{bucket}/files": {
-            "post": {
-                "description": "Get files list into bucket",
-                "consumes": [
-                    "application/json"
-                ],
+        "/api/v1/cloud/{bucket}/file/remove": {
+            "delete": {
+                "description": "Remove file from cloud",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "files"
                 ],
-                "summary": "Get files list into bucket",
-                "operationId": "get-list-files",
+                "summary": "Remove file from cloud",
+                "operationId": "remove-file",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Bucket name to get list files",
+                        "description": "Bucket name to remove file",
                         "name": "bucket",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Parameters to get list files",
+                        "description": "Parameters to remove file",
                         "name": "jsonQuery",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/form.GetFilesForm"
+                            "$ref": "#/definitions/form.RemoveFileForm"
                         }
                     }
                 ],
@@ -686,9 +683,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/cloud/{bucket}/folder": {
+        "/api/v1/cloud/{bucket}/file/share": {
             "post": {
-                "description": "Create empty folder into cloud storage",
+                "description": "Get share URL for file",
                 "consumes": [
                     "application/json"
                 ],
@@ -696,31 +693,31 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "files"
+                    "share"
                 ],
-                "summary": "Create empty folder into cloud storage",
-                "operationId": "create-folder",
+                "summary": "Get share URL for file",
+                "operationId": "share-file",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Bucket name to create folder",
+                        "description": "Bucket name to share file",
                         "name": "bucket",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Params to create folder",
+                        "description": "Parameters to share file",
                         "name": "jsonQuery",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/form.FolderForm"
+                            "$ref": "#/definitions/form.ShareFileForm"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Ok",
+                        "description": "URL with shared file",
                         "schema": {
                             "$ref": "#/definitions/form.Success"
                         }
@@ -732,7 +729,7 @@ const docTemplate = `{
                         }
                     },
                     "404": {
-                        "description": "Bucket not found",
+                        "description": "Bucket of object not found",
                         "schema": {
                             "$ref": "#/definitions/form.NotFoundError"
                         }
@@ -750,9 +747,11 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
-                "description": "Delete empty folder into cloud storage",
+            }
+        },
+        "/api/v1/cloud/{bucket}/file/tags": {
+            "put": {
+                "description": "Replace all tags of file. Up to 10 tags are allowed, key length is up to 128\nand value length is up to 256 characters. Indexed document keeps previous tags\nuntil file is reindexed, pass reindex to create processing task of file.",
                 "consumes": [
                     "application/json"
                 ],
@@ -762,23 +761,23 @@ const docTemplate = `{
                 "tags": [
                     "files"
                 ],
-                "summary": "Delete folder into cloud storage",
-                "operationId": "delete-folder",
+                "summary": "Set file tags",
+                "operationId": "set-file-tags",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Bucket name to delete folder",
+                        "description": "Bucket name of file",
                         "name": "bucket",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Params to delete folder",
+                        "description": "File tags",
                         "name": "jsonQuery",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/form.FolderForm"
+                            "$ref": "#/definitions/form.SetFileTagsForm"
                         }
                     }
                 ],
@@ -789,16 +788,16 @@ const docTemplate = `{
                             "$ref": "#/definitions/form.Success"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request error",
+                    "201": {
+                        "description": "Task of reindexed file",
                         "schema": {
-                            "$ref": "#/definitions/form.BadRequestError"
+                            "$ref": "#/definitions/form.TaskSchema"
                         }
                     },
-                    "404": {
-                        "description": "Bucket not found",
+                    "400": {
+                        "description": "Bad Request error",
                         "schema": {
-                            "$ref": "#/definitions/form.NotFoundError"
+                            "$ref": "#/definitions/form.BadRequestError"
                         }
                     },
                     "500": {
@@ -814,11 +813,9 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/api/v1/tasks/{bucket}": {
-            "get": {
-                "description": "Load tasks (processing/unrecognized/done) of uploaded files",
+            },
+            "post": {
+                "description": "Get tags of file",
                 "consumes": [
                     "application/json"
                 ],
@@ -826,35 +823,1516 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "tasks"
+                    "files"
                 ],
-                "summary": "Load processing tasks of uploaded files into bucket",
-                "operationId": "load-tasks",
+                "summary": "Get file tags",
+                "operationId": "get-file-tags",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Bucket id of uploaded files",
+                        "description": "Bucket name of file",
                         "name": "bucket",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "Status tasks to filter target result",
-                        "name": "status",
-                        "in": "query"
+                        "description": "File to get tags",
+                        "name": "jsonQuery",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/form.GetFileAttributesForm"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Loaded tasks",
+                        "description": "Tags of file",
+                        "schema": {
+                            "$ref": "#/definitions/form.ObjectTagsSchema"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request error",
+                        "schema": {
+                            "$ref": "#/definitions/form.BadRequestError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/form.InternalServerError"
+                        }
+                    },
+                    "503": {
+                        "description": "Server does not available",
+                        "schema": {
+                            "$ref": "#/definitions/form.ServerUnavailableError"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete all tags of file, pass reindex to create processing task of file.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "files"
+                ],
+                "summary": "Delete file tags",
+                "operationId": "delete-file-tags",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bucket name of file",
+                        "name": "bucket",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "File to delete tags",
+                        "name": "jsonQuery",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/form.DeleteFileTagsForm"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Ok",
+                        "schema": {
+                            "$ref": "#/definitions/form.Success"
+                        }
+                    },
+                    "201": {
+                        "description": "Task of reindexed file",
+                        "schema": {
+                            "$ref": "#/definitions/form.TaskSchema"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request error",
+                        "schema": {
+                            "$ref": "#/definitions/form.BadRequestError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/form.InternalServerError"
+                        }
+                    },
+                    "503": {
+                        "description": "Server does not available",
+                        "schema": {
+                            "$ref": "#/definitions/form.ServerUnavailableError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/cloud/{bucket}/file/upload": {
+            "put": {
+                "description": "Upload files to cloud. User metadata may be passed by X-Meta-* headers or x-meta-* form fields.",
+                "consumes": [
+                    "multipart/form"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "files"
+                ],
+                "summary": "Upload files to cloud",
+                "operationId": "upload-files",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bucket name to upload files",
+                        "name": "bucket",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Prefix to load files",
+                        "name": "prefix",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Content type of uploaded files",
+                        "name": "content_type",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "file",
+                        "description": "Files multipart form",
+                        "name": "files",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "File datetime expired like 2025-01-01T12:01:01Z",
+                        "name": "expired",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Ok",
+                        "schema": {
+                            "$ref": "#/definitions/form.Success"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request error",
+                        "schema": {
+                            "$ref": "#/definitions/form.BadRequestError"
+                        }
+                    },
+                    "404": {
+                        "description": "Bucket not found",
+                        "schema": {
+                            "$ref": "#/definitions/form.NotFoundError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/form.InternalServerError"
+                        }
+                    },
+                    "503": {
+                        "description": "Server does not available",
+                        "schema": {
+                            "$ref": "#/definitions/form.ServerUnavailableError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/cloud/{bucket}/file/upload-complete": {
+            "post": {
+                "description": "Verify size and checksum of file uploaded by presigned URL and create processing task.\nStorage event of uploaded file completes upload as well.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "files"
+                ],
+                "summary": "Complete upload of file by presigned URL",
+                "operationId": "complete-upload-url",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bucket name of uploaded file",
+                        "name": "bucket",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Path of uploaded file",
+                        "name": "jsonQuery",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/form.CompleteUploadForm"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created task of uploaded file",
+                        "schema": {
+                            "$ref": "#/definitions/form.TaskSchema"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request error",
+                        "schema": {
+                            "$ref": "#/definitions/form.BadRequestError"
+                        }
+                    },
+                    "404": {
+                        "description": "Upload not found, already completed or expired",
+                        "schema": {
+                            "$ref": "#/definitions/form.NotFoundError"
+                        }
+                    },
+                    "409": {
+                        "description": "File has not been uploaded yet",
+                        "schema": {
+                            "$ref": "#/definitions/form.BadRequestError"
+                        }
+                    },
+                    "422": {
+                        "description": "File does not match expected size or checksum",
+                        "schema": {
+                            "$ref": "#/definitions/form.BadRequestError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/form.InternalServerError"
+                        }
+                    },
+                    "503": {
+                        "description": "Server does not available",
+                        "schema": {
+                            "$ref": "#/definitions/form.ServerUnavailableError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/cloud/{bucket}/file/upload-url": {
+            "post": {
+                "description": "Get presigned PUT URL or POST form to upload file directly into cloud storage.\nSize and optional md5 or sha256 hex checksum of file are verified on completion.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "files"
+                ],
+                "summary": "Get presigned URL to upload file directly into cloud",
+                "operationId": "create-upload-url",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bucket name to upload file",
+                        "name": "bucket",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Parameters of uploaded file",
+                        "name": "jsonQuery",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/form.CreateUploadURLForm"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Presigned upload request",
+                        "schema": {
+                            "$ref": "#/definitions/form.PresignedUploadSchema"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request error",
+                        "schema": {
+                            "$ref": "#/definitions/form.BadRequestError"
+                        }
+                    },
+                    "404": {
+                        "description": "Bucket not found or presigned uploads are disabled",
+                        "schema": {
+                            "$ref": "#/definitions/form.NotFoundError"
+                        }
+                    },
+                    "413": {
+                        "description": "File is too large",
+                        "schema": {
+                            "$ref": "#/definitions/form.BadRequestError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/form.InternalServerError"
+                        }
+                    },
+                    "501": {
+                        "description": "Presigned uploads are not supported by storage",
+                        "schema": {
+                            "$ref": "#/definitions/form.BadRequestError"
+                        }
+                    },
+                    "503": {
+                        "description": "Server does not available",
+                        "schema": {
+                            "$ref": "#/definitions/form.ServerUnavailableError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/cloud/{bucket}/file/version/download": {
+            "post": {
+                "description": "Download content of specific version of file",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "files"
+                ],
+                "summary": "Download file version",
+                "operationId": "download-file-version",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bucket name of file",
+                        "name": "bucket",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "File version to download",
+                        "name": "jsonQuery",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/form.FileVersionForm"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Returned file bytes",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request error",
+                        "schema": {
+                            "$ref": "#/definitions/form.BadRequestError"
+                        }
+                    },
+                    "404": {
+                        "description": "Version not found",
+                        "schema": {
+                            "$ref": "#/definitions/form.NotFoundError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/form.InternalServerError"
+                        }
+                    },
+                    "501": {
+                        "description": "Versions are not supported by storage",
+                        "schema": {
+                            "$ref": "#/definitions/form.InternalServerError"
+                        }
+                    },
+                    "503": {
+                        "description": "Server does not available",
+                        "schema": {
+                            "$ref": "#/definitions/form.ServerUnavailableError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/cloud/{bucket}/file/version/restore": {
+            "post": {
+                "description": "Restore specific version of file as current one. Restored content is stored\nas new version of file and task is created to reindex file.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "files"
+                ],
+                "summary": "Restore file version",
+                "operationId": "restore-file-version",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bucket name of file",
+                        "name": "bucket",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "File version to restore",
+                        "name": "jsonQuery",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/form.FileVersionForm"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Task of restored file",
+                        "schema": {
+                            "$ref": "#/definitions/form.TaskSchema"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request error",
+                        "schema": {
+                            "$ref": "#/definitions/form.BadRequestError"
+                        }
+                    },
+                    "404": {
+                        "description": "Version not found",
+                        "schema": {
+                            "$ref": "#/definitions/form.NotFoundError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/form.InternalServerError"
+                        }
+                    },
+                    "501": {
+                        "description": "Versions are not supported by storage",
+                        "schema": {
+                            "$ref": "#/definitions/form.InternalServerError"
+                        }
+                    },
+                    "503": {
+                        "description": "Server does not available",
+                        "schema": {
+                            "$ref": "#/definitions/form.ServerUnavailableError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/cloud/{bucket}/file/versions": {
+            "post": {
+                "description": "Get versions of file in versioned bucket from the newest one.\nDelete markers are listed too, they have no content and can't be restored.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "files"
+                ],
+                "summary": "Get file versions",
+                "operationId": "get-file-versions",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bucket name of file",
+                        "name": "bucket",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "File to get versions",
+                        "name": "jsonQuery",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/form.FileVersionsForm"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Versions of file",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/form.ObjectVersionSchema"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request error",
+                        "schema": {
+                            "$ref": "#/definitions/form.BadRequestError"
+                        }
+                    },
+                    "404": {
+                        "description": "File not found",
+                        "schema": {
+                            "$ref": "#/definitions/form.NotFoundError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/form.InternalServerError"
+                        }
+                    },
+                    "501": {
+                        "description": "Versions are not supported by storage",
+                        "schema": {
+                            "$ref": "#/definitions/form.InternalServerError"
+                        }
+                    },
+                    "503": {
+                        "description": "Server does not available",
+                        "schema": {
+                            "$ref": "#/definitions/form.ServerUnavailableError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/cloud/{bucket}/files": {
+            "post": {
+                "description": "Get page of files list into bucket. Pass next_token of response as continuation_token\nto get next page, next_token is empty for the last page. Token is opaque and is valid only\nfor the same directory and sorting, offset shifts only the first page. Files may be sorted\nby name, size or modified time, sorting by size or modified time lists whole directory.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "files"
+                ],
+                "summary": "Get files list into bucket",
+                "operationId": "get-list-files",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bucket name to get list files",
+                        "name": "bucket",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Parameters to get list files",
+                        "name": "jsonQuery",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/form.GetFilesForm"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Page of files",
+                        "schema": {
+                            "$ref": "#/definitions/form.ObjectsPageSchema"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request error",
+                        "schema": {
+                            "$ref": "#/definitions/form.BadRequestError"
+                        }
+                    },
+                    "404": {
+                        "description": "Bucket not found",
+                        "schema": {
+                            "$ref": "#/definitions/form.NotFoundError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/form.InternalServerError"
+                        }
+                    },
+                    "503": {
+                        "description": "Server does not available",
+                        "schema": {
+                            "$ref": "#/definitions/form.ServerUnavailableError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/cloud/{bucket}/folder": {
+            "post": {
+                "description": "Create empty folder into cloud storage",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "files"
+                ],
+                "summary": "Create empty folder into cloud storage",
+                "operationId": "create-folder",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bucket name to create folder",
+                        "name": "bucket",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Params to create folder",
+                        "name": "jsonQuery",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/form.FolderForm"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Ok",
+                        "schema": {
+                            "$ref": "#/definitions/form.Success"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request error",
+                        "schema": {
+                            "$ref": "#/definitions/form.BadRequestError"
+                        }
+                    },
+                    "404": {
+                        "description": "Bucket not found",
+                        "schema": {
+                            "$ref": "#/definitions/form.NotFoundError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/form.InternalServerError"
+                        }
+                    },
+                    "503": {
+                        "description": "Server does not available",
+                        "schema": {
+                            "$ref": "#/definitions/form.ServerUnavailableError"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete empty folder into cloud storage",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "files"
+                ],
+                "summary": "Delete folder into cloud storage",
+                "operationId": "delete-folder",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bucket name to delete folder",
+                        "name": "bucket",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Params to delete folder",
+                        "name": "jsonQuery",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/form.FolderForm"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Ok",
+                        "schema": {
+                            "$ref": "#/definitions/form.Success"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request error",
+                        "schema": {
+                            "$ref": "#/definitions/form.BadRequestError"
+                        }
+                    },
+                    "404": {
+                        "description": "Bucket not found",
+                        "schema": {
+                            "$ref": "#/definitions/form.NotFoundError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/form.InternalServerError"
+                        }
+                    },
+                    "503": {
+                        "description": "Server does not available",
+                        "schema": {
+                            "$ref": "#/definitions/form.ServerUnavailableError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/cloud/{bucket}/search": {
+            "post": {
+                "description": "Walk files under prefix recursively and stream files matching all passed filters\nas newline delimited JSON, one file per line. Glob matches file name, or file path if\npattern contains slash, regex matches file path, content type supports wildcards like\nimage/*. Search failed after streaming has been started is reported by last line with error.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/x-ndjson"
+                ],
+                "tags": [
+                    "files"
+                ],
+                "summary": "Search files into bucket",
+                "operationId": "search-files",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bucket name to search files",
+                        "name": "bucket",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Filters of searched files",
+                        "name": "jsonQuery",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/form.SearchFilesForm"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Stream of found files",
+                        "schema": {
+                            "$ref": "#/definitions/form.ObjectSchema"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request error",
+                        "schema": {
+                            "$ref": "#/definitions/form.BadRequestError"
+                        }
+                    },
+                    "404": {
+                        "description": "Bucket not found",
+                        "schema": {
+                            "$ref": "#/definitions/form.NotFoundError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/form.InternalServerError"
+                        }
+                    },
+                    "503": {
+                        "description": "Server does not available",
+                        "schema": {
+                            "$ref": "#/definitions/form.ServerUnavailableError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/events/s3": {
+            "post": {
+                "description": "Receive s3:ObjectCreated and s3:ObjectRemoved notifications sent by MinIO webhook target",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "events"
+                ],
+                "summary": "Receive S3 event notifications",
+                "operationId": "receive-storage-events",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Webhook auth token",
+                        "name": "Authorization",
+                        "in": "header"
+                    },
+                    {
+                        "description": "S3 event notification",
+                        "name": "jsonQuery",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Ok",
+                        "schema": {
+                            "$ref": "#/definitions/form.Success"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request error",
+                        "schema": {
+                            "$ref": "#/definitions/form.BadRequestError"
+                        }
+                    },
+                    "401": {
+                        "description": "Invalid webhook token",
+                        "schema": {
+                            "$ref": "#/definitions/form.BadRequestError"
+                        }
+                    },
+                    "404": {
+                        "description": "Events webhook is disabled",
+                        "schema": {
+                            "$ref": "#/definitions/form.NotFoundError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/form.InternalServerError"
+                        }
+                    },
+                    "503": {
+                        "description": "Server does not available",
+                        "schema": {
+                            "$ref": "#/definitions/form.ServerUnavailableError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/share/{bucket}/{file_path}": {
+            "get": {
+                "description": "Download file by signed share URL of storage which is served by watchtower, like local filesystem",
+                "produces": [
+                    "application/octet-stream"
+                ],
+                "tags": [
+                    "share"
+                ],
+                "summary": "Download file by share URL",
+                "operationId": "download-shared-file",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bucket name of shared file",
+                        "name": "bucket",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Path of shared file",
+                        "name": "file_path",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Unix time when share URL expires",
+                        "name": "expires",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Signature of share URL",
+                        "name": "signature",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Returned file bytes",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request error",
+                        "schema": {
+                            "$ref": "#/definitions/form.BadRequestError"
+                        }
+                    },
+                    "403": {
+                        "description": "Invalid or expired signature",
+                        "schema": {
+                            "$ref": "#/definitions/form.BadRequestError"
+                        }
+                    },
+                    "404": {
+                        "description": "Share URLs are not served for storage",
+                        "schema": {
+                            "$ref": "#/definitions/form.NotFoundError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/form.InternalServerError"
+                        }
+                    },
+                    "503": {
+                        "description": "Server does not available",
+                        "schema": {
+                            "$ref": "#/definitions/form.ServerUnavailableError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/tasks/{bucket}": {
+            "get": {
+                "description": "Load tasks (processing/unrecognized/done) of uploaded files",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tasks"
+                ],
+                "summary": "Load processing tasks of uploaded files into bucket",
+                "operationId": "load-tasks",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bucket id of uploaded files",
+                        "name": "bucket",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Status tasks to filter target result",
+                        "name": "status",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Loaded tasks",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/form.TaskSchema"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request error",
+                        "schema": {
+                            "$ref": "#/definitions/form.BadRequestError"
+                        }
+                    },
+                    "404": {
+                        "description": "Bucket not found",
+                        "schema": {
+                            "$ref": "#/definitions/form.NotFoundError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/form.InternalServerError"
+                        }
+                    },
+                    "503": {
+                        "description": "Server does not available",
+                        "schema": {
+                            "$ref": "#/definitions/form.ServerUnavailableError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/tasks/{bucket}/{task_id}": {
+            "get": {
+                "description": "Load processing/unrecognized/done task by id of uploaded file",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tasks"
+                ],
+                "summary": "Load processing task by id",
+                "operationId": "load-task-by-id",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bucket id of processing task",
+                        "name": "bucket",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Task ID",
+                        "name": "task_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Loaded tasks",
+                        "schema": {
+                            "$ref": "#/definitions/form.TaskSchema"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request error",
+                        "schema": {
+                            "$ref": "#/definitions/form.BadRequestError"
+                        }
+                    },
+                    "404": {
+                        "description": "Task not found",
+                        "schema": {
+                            "$ref": "#/definitions/form.NotFoundError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/form.InternalServerError"
+                        }
+                    },
+                    "503": {
+                        "description": "Server does not available",
+                        "schema": {
+                            "$ref": "#/definitions/form.ServerUnavailableError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/uploads/{bucket}": {
+            "post": {
+                "description": "Create resumable upload by tus protocol. Upload-Metadata may contain\nfilename, filetype, prefix and user metadata of file as base64 encoded values.",
+                "tags": [
+                    "uploads"
+                ],
+                "summary": "Create resumable upload",
+                "operationId": "create-upload",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bucket name to upload file",
+                        "name": "bucket",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "1.0.0",
+                        "description": "Tus protocol version",
+                        "name": "Tus-Resumable",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Size of uploaded file in bytes",
+                        "name": "Upload-Length",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Metadata of uploaded file",
+                        "name": "Upload-Metadata",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created, Location header contains upload URL"
+                    },
+                    "400": {
+                        "description": "Bad Request error",
+                        "schema": {
+                            "$ref": "#/definitions/form.BadRequestError"
+                        }
+                    },
+                    "404": {
+                        "description": "Bucket not found",
+                        "schema": {
+                            "$ref": "#/definitions/form.NotFoundError"
+                        }
+                    },
+                    "412": {
+                        "description": "Unsupported tus version",
+                        "schema": {
+                            "$ref": "#/definitions/form.BadRequestError"
+                        }
+                    },
+                    "413": {
+                        "description": "Upload is too large",
+                        "schema": {
+                            "$ref": "#/definitions/form.BadRequestError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/form.InternalServerError"
+                        }
+                    },
+                    "503": {
+                        "description": "Server does not available",
+                        "schema": {
+                            "$ref": "#/definitions/form.ServerUnavailableError"
+                        }
+                    }
+                }
+            },
+            "options": {
+                "description": "Get tus protocol version, extensions and max upload size",
+                "tags": [
+                    "uploads"
+                ],
+                "summary": "Get resumable uploads capabilities",
+                "operationId": "get-uploads-options",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bucket name to upload file",
+                        "name": "bucket",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    }
+                }
+            }
+        },
+        "/api/v1/uploads/{bucket}/{upload_id}": {
+            "delete": {
+                "description": "Abort resumable upload and remove received bytes",
+                "tags": [
+                    "uploads"
+                ],
+                "summary": "Terminate resumable upload",
+                "operationId": "terminate-upload",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bucket name of upload",
+                        "name": "bucket",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Upload ID",
+                        "name": "upload_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "1.0.0",
+                        "description": "Tus protocol version",
+                        "name": "Tus-Resumable",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request error",
+                        "schema": {
+                            "$ref": "#/definitions/form.BadRequestError"
+                        }
+                    },
+                    "404": {
+                        "description": "Upload not found",
+                        "schema": {
+                            "$ref": "#/definitions/form.NotFoundError"
+                        }
+                    },
+                    "412": {
+                        "description": "Unsupported tus version",
+                        "schema": {
+                            "$ref": "#/definitions/form.BadRequestError"
+                        }
+                    },
+                    "423": {
+                        "description": "Upload is written by another request",
+                        "schema": {
+                            "$ref": "#/definitions/form.BadRequestError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/form.InternalServerError"
+                        }
+                    }
+                }
+            },
+            "head": {
+                "description": "Get count of received bytes of resumable upload to resume it",
+                "tags": [
+                    "uploads"
+                ],
+                "summary": "Get offset of resumable upload",
+                "operationId": "get-upload-offset",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bucket name of upload",
+                        "name": "bucket",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Upload ID",
+                        "name": "upload_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "1.0.0",
+                        "description": "Tus protocol version",
+                        "name": "Tus-Resumable",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Upload-Offset and Upload-Length headers"
+                    },
+                    "400": {
+                        "description": "Bad Request error",
+                        "schema": {
+                            "$ref": "#/definitions/form.BadRequestError"
+                        }
+                    },
+                    "404": {
+                        "description": "Upload not found",
+                        "schema": {
+                            "$ref": "#/definitions/form.NotFoundError"
+                        }
+                    },
+                    "412": {
+                        "description": "Unsupported tus version",
+                        "schema": {
+                            "$ref": "#/definitions/form.BadRequestError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/form.InternalServerError"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "Append chunk to resumable upload at offset of received bytes. Task\nof uploaded file is created when all bytes have been received.",
+                "consumes": [
+                    "application/offset+octet-stream"
+                ],
+                "tags": [
+                    "uploads"
+                ],
+                "summary": "Upload chunk of resumable upload",
+                "operationId": "write-upload-chunk",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bucket name of upload",
+                        "name": "bucket",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Upload ID",
+                        "name": "upload_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "1.0.0",
+                        "description": "Tus protocol version",
+                        "name": "Tus-Resumable",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Offset of chunk",
+                        "name": "Upload-Offset",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Upload-Offset header contains count of received bytes"
+                    },
+                    "400": {
+                        "description": "Bad Request error",
+                        "schema": {
+                            "$ref": "#/definitions/form.BadRequestError"
+                        }
+                    },
+                    "404": {
+                        "description": "Upload not found",
+                        "schema": {
+                            "$ref": "#/definitions/form.NotFoundError"
+                        }
+                    },
+                    "409": {
+                        "description": "Offset does not match received bytes",
+                        "schema": {
+                            "$ref": "#/definitions/form.BadRequestError"
+                        }
+                    },
+                    "412": {
+                        "description": "Unsupported tus version",
+                        "schema": {
+                            "$ref": "#/definitions/form.BadRequestError"
+                        }
+                    },
+                    "413": {
+                        "description": "Chunk exceeds upload length",
+                        "schema": {
+                            "$ref": "#/definitions/form.BadRequestError"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported content type",
+                        "schema": {
+                            "$ref": "#/definitions/form.BadRequestError"
+                        }
+                    },
+                    "423": {
+                        "description": "Upload is written by another request",
+                        "schema": {
+                            "$ref": "#/definitions/form.BadRequestError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/form.InternalServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/watchers": {
+            "get": {
+                "description": "Load registered watchers of bucket directories",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "watchers"
+                ],
+                "summary": "Load registered directory watchers",
+                "operationId": "load-watchers",
+                "responses": {
+                    "200": {
+                        "description": "Loaded watchers",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/form.TaskSchema"
+                                "$ref": "#/definitions/form.WatcherSchema"
                             }
                         }
                     },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/form.InternalServerError"
+                        }
+                    },
+                    "503": {
+                        "description": "Server does not available",
+                        "schema": {
+                            "$ref": "#/definitions/form.ServerUnavailableError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Register watcher which processes objects created or changed into bucket directory",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "watchers"
+                ],
+                "summary": "Register directory watcher",
+                "operationId": "create-watcher",
+                "parameters": [
+                    {
+                        "description": "Bucket directory to watch",
+                        "name": "jsonQuery",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/form.AddDirectoryToWatcherForm"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Registered watcher",
+                        "schema": {
+                            "$ref": "#/definitions/form.WatcherSchema"
+                        }
+                    },
                     "400": {
                         "description": "Bad Request error",
                         "schema": {
@@ -882,9 +2360,61 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/tasks/{bucket}/{task_id}": {
+        "/api/v1/watchers/{watcher_id}": {
             "get": {
-                "description": "Load processing/unrecognized/done task by id of uploaded file",
+                "description": "Load registered watcher of bucket directory by id",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "watchers"
+                ],
+                "summary": "Load directory watcher by id",
+                "operationId": "load-watcher-by-id",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Watcher ID",
+                        "name": "watcher_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Loaded watcher",
+                        "schema": {
+                            "$ref": "#/definitions/form.WatcherSchema"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request error",
+                        "schema": {
+                            "$ref": "#/definitions/form.BadRequestError"
+                        }
+                    },
+                    "404": {
+                        "description": "Watcher not found",
+                        "schema": {
+                            "$ref": "#/definitions/form.NotFoundError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/form.InternalServerError"
+                        }
+                    },
+                    "503": {
+                        "description": "Server does not available",
+                        "schema": {
+                            "$ref": "#/definitions/form.ServerUnavailableError"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Change watched bucket directory of registered watcher",
                 "consumes": [
                     "application/json"
                 ],
@@ -892,31 +2422,85 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "tasks"
+                    "watchers"
                 ],
-                "summary": "Load processing task by id",
-                "operationId": "load-task-by-id",
+                "summary": "Update directory watcher",
+                "operationId": "update-watcher",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Bucket id of processing task",
-                        "name": "bucket",
+                        "description": "Watcher ID",
+                        "name": "watcher_id",
                         "in": "path",
                         "required": true
                     },
+                    {
+                        "description": "Bucket directory to watch",
+                        "name": "jsonQuery",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/form.AddDirectoryToWatcherForm"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Updated watcher",
+                        "schema": {
+                            "$ref": "#/definitions/form.WatcherSchema"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request error",
+                        "schema": {
+                            "$ref": "#/definitions/form.BadRequestError"
+                        }
+                    },
+                    "404": {
+                        "description": "Watcher or bucket not found",
+                        "schema": {
+                            "$ref": "#/definitions/form.NotFoundError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/form.InternalServerError"
+                        }
+                    },
+                    "503": {
+                        "description": "Server does not available",
+                        "schema": {
+                            "$ref": "#/definitions/form.ServerUnavailableError"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Stop watching of bucket directory",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "watchers"
+                ],
+                "summary": "Remove directory watcher",
+                "operationId": "remove-watcher",
+                "parameters": [
                     {
                         "type": "string",
-                        "description": "Task ID",
-                        "name": "task_id",
+                        "description": "Watcher ID",
+                        "name": "watcher_id",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Loaded tasks",
+                        "description": "Ok",
                         "schema": {
-                            "$ref": "#/definitions/form.TaskSchema"
+                            "$ref": "#/definitions/form.Success"
                         }
                     },
                     "400": {
@@ -926,7 +2510,7 @@ const docTemplate = `{
                         }
                     },
                     "404": {
-                        "description": "Task not found",
+                        "description": "Watcher not found",
                         "schema": {
                             "$ref": "#/definitions/form.NotFoundError"
                         }
@@ -948,6 +2532,19 @@ const docTemplate = `{
         }
     },
     "definitions": {
+        "form.AddDirectoryToWatcherForm": {
+            "type": "object",
+            "properties": {
+                "bucket": {
+                    "type": "string",
+                    "example": "test-folder"
+                },
+                "suffix": {
+                    "type": "string",
+                    "example": "./some-directory"
+                }
+            }
+        },
         "form.BadRequestError": {
             "type": "object",
             "properties": {
@@ -961,6 +2558,48 @@ const docTemplate = `{
                 }
             }
         },
+        "form.BucketConfigForm": {
+            "type": "object",
+            "properties": {
+                "lifecycle": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/form.LifecycleRuleForm"
+                    }
+                },
+                "object_lock": {
+                    "$ref": "#/definitions/form.ObjectLockForm"
+                },
+                "quota_bytes": {
+                    "type": "integer",
+                    "example": 10737418240
+                },
+                "versioning": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "form.BucketConfigSchema": {
+            "type": "object",
+            "properties": {
+                "lifecycle": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/form.LifecycleRuleForm"
+                    }
+                },
+                "object_lock": {
+                    "$ref": "#/definitions/form.ObjectLockForm"
+                },
+                "quota_bytes": {
+                    "type": "integer"
+                },
+                "versioning": {
+                    "type": "boolean"
+                }
+            }
+        },
         "form.BucketSchema": {
             "type": "object",
             "properties": {
@@ -975,9 +2614,22 @@ const docTemplate = `{
                 }
             }
         },
+        "form.CompleteUploadForm": {
+            "type": "object",
+            "properties": {
+                "file_path": {
+                    "type": "string",
+                    "example": "incoming/test-file.docx"
+                }
+            }
+        },
         "form.CopyFileForm": {
             "type": "object",
             "properties": {
+                "dst_bucket": {
+                    "type": "string",
+                    "example": "archive-bucket"
+                },
                 "dst_path": {
                     "type": "string",
                     "example": "test-document.docx"
@@ -998,6 +2650,53 @@ const docTemplate = `{
                 "bucket_name": {
                     "type": "string",
                     "example": "test-bucket"
+                },
+                "config": {
+                    "$ref": "#/definitions/form.BucketConfigForm"
+                }
+            }
+        },
+        "form.CreateUploadURLForm": {
+            "type": "object",
+            "properties": {
+                "checksum": {
+                    "type": "string",
+                    "example": "9e107d9d372bb6826bd81d3542a419d6"
+                },
+                "content_type": {
+                    "type": "string",
+                    "example": "application/msword"
+                },
+                "file_path": {
+                    "type": "string",
+                    "example": "incoming/test-file.docx"
+                },
+                "metadata": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "method": {
+                    "type": "string",
+                    "example": "PUT"
+                },
+                "size": {
+                    "type": "integer",
+                    "example": 1024
+                }
+            }
+        },
+        "form.DeleteFileTagsForm": {
+            "type": "object",
+            "properties": {
+                "file_path": {
+                    "type": "string",
+                    "example": "test-file.docx"
+                },
+                "reindex": {
+                    "type": "boolean",
+                    "example": true
                 }
             }
         },
@@ -1010,6 +2709,45 @@ const docTemplate = `{
                 }
             }
         },
+        "form.DuplicateForm": {
+            "type": "object",
+            "properties": {
+                "distance": {
+                    "type": "integer"
+                },
+                "document_id": {
+                    "type": "string"
+                },
+                "linked": {
+                    "type": "boolean"
+                },
+                "object_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "form.FileVersionForm": {
+            "type": "object",
+            "properties": {
+                "file_path": {
+                    "type": "string",
+                    "example": "test-file.docx"
+                },
+                "version_id": {
+                    "type": "string",
+                    "example": "3HL4kqtJlcpXroDTDmJ+rmSpXd3dIbrHY+MTRCxf3vjVBH40Nrjfkd"
+                }
+            }
+        },
+        "form.FileVersionsForm": {
+            "type": "object",
+            "properties": {
+                "file_path": {
+                    "type": "string",
+                    "example": "test-file.docx"
+                }
+            }
+        },
         "form.FolderForm": {
             "type": "object",
             "properties": {
@@ -1031,6 +2769,10 @@ const docTemplate = `{
         "form.GetFilesForm": {
             "type": "object",
             "properties": {
+                "continuation_token": {
+                    "type": "string",
+                    "example": ""
+                },
                 "directory": {
                     "type": "string",
                     "example": "test-folder/"
@@ -1042,6 +2784,19 @@ const docTemplate = `{
                 "offset": {
                     "type": "integer",
                     "example": 0
+                },
+                "sort_by": {
+                    "type": "string",
+                    "enum": [
+                        "name",
+                        "size",
+                        "modified"
+                    ],
+                    "example": "name"
+                },
+                "sort_desc": {
+                    "type": "boolean",
+                    "example": false
                 }
             }
         },
@@ -1058,6 +2813,27 @@ const docTemplate = `{
                 }
             }
         },
+        "form.LifecycleRuleForm": {
+            "type": "object",
+            "properties": {
+                "expiration_days": {
+                    "type": "integer",
+                    "example": 7
+                },
+                "id": {
+                    "type": "string",
+                    "example": "expire-tmp"
+                },
+                "noncurrent_expiration_days": {
+                    "type": "integer",
+                    "example": 30
+                },
+                "prefix": {
+                    "type": "string",
+                    "example": "tmp/"
+                }
+            }
+        },
         "form.NotFoundError": {
             "type": "object",
             "properties": {
@@ -1071,6 +2847,156 @@ const docTemplate = `{
                 }
             }
         },
+        "form.ObjectLockForm": {
+            "type": "object",
+            "properties": {
+                "enabled": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "mode": {
+                    "type": "string",
+                    "enum": [
+                        "GOVERNANCE",
+                        "COMPLIANCE"
+                    ],
+                    "example": "GOVERNANCE"
+                },
+                "retention_days": {
+                    "type": "integer",
+                    "example": 30
+                }
+            }
+        },
+        "form.ObjectSchema": {
+            "type": "object",
+            "properties": {
+                "checksum": {
+                    "type": "string"
+                },
+                "content_type": {
+                    "type": "string"
+                },
+                "expired": {
+                    "type": "string"
+                },
+                "is_directory": {
+                    "type": "boolean"
+                },
+                "last_modified": {
+                    "type": "string"
+                },
+                "metadata": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "name": {
+                    "type": "string"
+                },
+                "path": {
+                    "type": "string"
+                },
+                "size": {
+                    "type": "integer"
+                },
+                "tags": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "version_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "form.ObjectTagsSchema": {
+            "type": "object",
+            "properties": {
+                "file_path": {
+                    "type": "string",
+                    "example": "test-file.docx"
+                },
+                "tags": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "form.ObjectVersionSchema": {
+            "type": "object",
+            "properties": {
+                "etag": {
+                    "type": "string",
+                    "example": "9e107d9d372bb6826bd81d3542a419d6"
+                },
+                "is_delete_marker": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "is_latest": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "last_modified": {
+                    "type": "string"
+                },
+                "size": {
+                    "type": "integer",
+                    "example": 1024
+                },
+                "version_id": {
+                    "type": "string",
+                    "example": "3HL4kqtJlcpXroDTDmJ+rmSpXd3dIbrHY+MTRCxf3vjVBH40Nrjfkd"
+                }
+            }
+        },
+        "form.ObjectsPageSchema": {
+            "type": "object",
+            "properties": {
+                "next_token": {
+                    "type": "string",
+                    "example": "cGF0aDp0ZXN0LWZvbGRlci90ZXN0LWZpbGUuZG9jeA"
+                },
+                "objects": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/form.ObjectSchema"
+                    }
+                }
+            }
+        },
+        "form.PresignedUploadSchema": {
+            "type": "object",
+            "properties": {
+                "expires_at": {
+                    "type": "string"
+                },
+                "form_data": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "headers": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "method": {
+                    "type": "string",
+                    "example": "PUT"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
         "form.RemoveFileForm": {
             "type": "object",
             "properties": {
@@ -1080,6 +3006,72 @@ const docTemplate = `{
                 }
             }
         },
+        "form.ReplaceFileMetadataForm": {
+            "type": "object",
+            "properties": {
+                "file_path": {
+                    "type": "string",
+                    "example": "test-file.docx"
+                },
+                "metadata": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "reindex": {
+                    "type": "boolean",
+                    "example": false
+                }
+            }
+        },
+        "form.SearchFilesForm": {
+            "type": "object",
+            "properties": {
+                "content_type": {
+                    "type": "string",
+                    "example": "application/*"
+                },
+                "glob": {
+                    "type": "string",
+                    "example": "*.docx"
+                },
+                "limit": {
+                    "type": "integer",
+                    "example": 100
+                },
+                "max_size": {
+                    "type": "integer",
+                    "example": 10485760
+                },
+                "metadata": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "min_size": {
+                    "type": "integer",
+                    "example": 1024
+                },
+                "modified_after": {
+                    "type": "string",
+                    "example": "2025-01-01T00:00:00Z"
+                },
+                "modified_before": {
+                    "type": "string",
+                    "example": "2026-01-01T00:00:00Z"
+                },
+                "prefix": {
+                    "type": "string",
+                    "example": "test-folder/"
+                },
+                "regex": {
+                    "type": "string",
+                    "example": ""
+                }
+            }
+        },
         "form.ServerUnavailableError": {
             "type": "object",
             "properties": {
@@ -1093,6 +3085,25 @@ const docTemplate = `{
                 }
             }
         },
+        "form.SetFileTagsForm": {
+            "type": "object",
+            "properties": {
+                "file_path": {
+                    "type": "string",
+                    "example": "test-file.docx"
+                },
+                "reindex": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "tags": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
         "form.ShareFileForm": {
             "type": "object",
             "properties": {
@@ -1125,12 +3136,33 @@ const docTemplate = `{
                 "bucket_id": {
                     "type": "string"
                 },
+                "children": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
                 "created_at": {
                     "type": "string"
                 },
+                "duplicate": {
+                    "$ref": "#/definitions/form.DuplicateForm"
+                },
+                "fingerprint": {
+                    "type": "string"
+                },
                 "id": {
                     "type": "string"
                 },
+                "labels": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "location": {
+                    "type": "string"
+                },
                 "modified_at": {
                     "type": "string"
                 },
@@ -1140,11 +3172,47 @@ const docTemplate = `{
                 "object_id": {
                     "type": "string"
                 },
+                "parent_id": {
+                    "type": "string"
+                },
+                "pii_counts": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                },
                 "status": {
                     "type": "integer"
                 },
                 "status_text": {
                     "type": "string"
+                },
+                "summary": {
+                    "type": "string"
+                },
+                "version_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "form.WatcherSchema": {
+            "type": "object",
+            "properties": {
+                "bucket": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "last_scan_at": {
+                    "type": "string"
+                },
+                "prefix": {
+                    "type": "string",
+                    "example": "some-directory/"
                 }
             }
         }
@@ -0,0 +1,85 @@
+package application
+
+import (
+	"fmt"
+
+	"github.com/breadrock1/otlp-go/otlp"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+
+	"watchtower/internal/core/cloud/domain"
+	"watchtower/internal/shared/kernel"
+)
+
+// CreateBucketWithConfig validates config before creating bucket,
+// bucket without config is created like by CreateBucket.
+func (s *StorageUseCase) CreateBucketWithConfig(
+	ctx kernel.Ctx,
+	bucketID kernel.BucketID,
+	config *domain.BucketConfig,
+) error {
+	if config.IsEmpty() {
+		return s.CreateBucket(ctx, bucketID)
+	}
+
+	ctx, span := otlp_go.GlobalTracer.Start(ctx, "create-bucket-with-config")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("bucket", bucketID))
+
+	err := domain.ValidateBucketConfig(config)
+	if err == nil {
+		err = s.cloudStorage.CreateBucketWithConfig(ctx, bucketID, config)
+	}
+
+	if err != nil {
+		err = fmt.Errorf("failed to create bucket %s: %w", bucketID, err)
+		span.SetStatus(codes.Error, err.Error())
+		span.RecordError(err)
+		return err
+	}
+
+	return nil
+}
+
+func (s *StorageUseCase) GetBucketConfig(ctx kernel.Ctx, bucketID kernel.BucketID) (domain.BucketConfig, error) {
+	ctx, span := otlp_go.GlobalTracer.Start(ctx, "get-bucket-config")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("bucket", bucketID))
+
+	config, err := s.cloudStorage.GetBucketConfig(ctx, bucketID)
+	if err != nil {
+		err = fmt.Errorf("failed to get config of bucket %s: %w", bucketID, err)
+		span.SetStatus(codes.Error, err.Error())
+		span.RecordError(err)
+		return config, err
+	}
+
+	return config, nil
+}
+
+func (s *StorageUseCase) SetBucketConfig(
+	ctx kernel.Ctx,
+	bucketID kernel.BucketID,
+	config *domain.BucketConfig,
+) error {
+	ctx, span := otlp_go.GlobalTracer.Start(ctx, "set-bucket-config")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("bucket", bucketID))
+
+	err := domain.ValidateBucketConfig(config)
+	if err == nil {
+		err = s.cloudStorage.SetBucketConfig(ctx, bucketID, config)
+	}
+
+	if err != nil {
+		err = fmt.Errorf("failed to set config of bucket %s: %w", bucketID, err)
+		span.SetStatus(codes.Error, err.Error())
+		span.RecordError(err)
+		return err
+	}
+
+	return nil
+}
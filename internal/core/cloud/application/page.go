@@ -0,0 +1,49 @@
+package application
+
+import (
+	"encoding/base64"
+	"strings"
+
+	"watchtower/internal/core/cloud/domain"
+)
+
+const (
+	// pathPageToken is a kind of token of listing ordered by path,
+	// it keeps path which listing continues after.
+	pathPageToken = "path"
+
+	// offsetPageToken is a kind of token of sorted listing,
+	// it keeps offset of next page.
+	offsetPageToken = "offset"
+)
+
+// encodePageToken returns opaque continuation token keeping kind of
+// listing along with position of next page, empty for the last page.
+func encodePageToken(kind, position string) string {
+	if position == "" {
+		return ""
+	}
+
+	return base64.RawURLEncoding.EncodeToString([]byte(kind + ":" + position))
+}
+
+// decodePageToken returns position of next page kept by token of listing
+// kind, empty for empty token. Returns ErrInvalidContinuation if token has
+// been returned by listing of another kind.
+func decodePageToken(token, kind string) (string, error) {
+	if token == "" {
+		return "", nil
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return "", domain.ErrInvalidContinuation
+	}
+
+	position, ok := strings.CutPrefix(string(data), kind+":")
+	if !ok || position == "" {
+		return "", domain.ErrInvalidContinuation
+	}
+
+	return position, nil
+}
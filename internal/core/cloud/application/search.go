@@ -0,0 +1,193 @@
+package application
+
+import (
+	"fmt"
+	"log/slog"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/breadrock1/otlp-go/otlp"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+
+	"watchtower/internal/core/cloud/domain"
+	"watchtower/internal/shared/kernel"
+)
+
+// ObjectFilter matches objects by validated filters of search params.
+type ObjectFilter struct {
+	params *domain.SearchObjectsParams
+	regex  *regexp.Regexp
+}
+
+// NewObjectFilter validates patterns and ranges of search params,
+// returned errors wrap domain.ErrInvalidSearchFilter.
+func NewObjectFilter(params *domain.SearchObjectsParams) (*ObjectFilter, error) {
+	filter := &ObjectFilter{params: params}
+
+	if params.Glob != "" {
+		if _, err := path.Match(params.Glob, ""); err != nil {
+			return nil, fmt.Errorf("%w: glob: %w", domain.ErrInvalidSearchFilter, err)
+		}
+	}
+
+	if params.Regex != "" {
+		regex, err := regexp.Compile(params.Regex)
+		if err != nil {
+			return nil, fmt.Errorf("%w: regex: %w", domain.ErrInvalidSearchFilter, err)
+		}
+		filter.regex = regex
+	}
+
+	if params.ContentType != "" {
+		if _, err := path.Match(params.ContentType, ""); err != nil {
+			return nil, fmt.Errorf("%w: content type: %w", domain.ErrInvalidSearchFilter, err)
+		}
+	}
+
+	if params.MinSize != nil && params.MaxSize != nil && *params.MinSize > *params.MaxSize {
+		return nil, fmt.Errorf("%w: min size is greater than max size", domain.ErrInvalidSearchFilter)
+	}
+
+	if params.ModifiedAfter != nil && params.ModifiedBefore != nil &&
+		params.ModifiedAfter.After(*params.ModifiedBefore) {
+		return nil, fmt.Errorf("%w: modified after is later than modified before", domain.ErrInvalidSearchFilter)
+	}
+
+	if params.Limit < 0 {
+		return nil, fmt.Errorf("%w: limit must not be negative", domain.ErrInvalidSearchFilter)
+	}
+
+	return filter, nil
+}
+
+// matchListed matches filters of listed object fields: path, size and
+// modification time.
+func (f *ObjectFilter) matchListed(object domain.Object) bool {
+	params := f.params
+
+	if params.Glob != "" {
+		name := object.Name
+		if strings.Contains(params.Glob, "/") {
+			name = object.Path
+		}
+		if matched, _ := path.Match(params.Glob, name); !matched {
+			return false
+		}
+	}
+
+	if f.regex != nil && !f.regex.MatchString(object.Path) {
+		return false
+	}
+
+	if params.MinSize != nil && object.Size < *params.MinSize {
+		return false
+	}
+
+	if params.MaxSize != nil && object.Size > *params.MaxSize {
+		return false
+	}
+
+	if params.ModifiedAfter != nil && object.LastModified.Before(*params.ModifiedAfter) {
+		return false
+	}
+
+	if params.ModifiedBefore != nil && object.LastModified.After(*params.ModifiedBefore) {
+		return false
+	}
+
+	return true
+}
+
+// needsInfo returns true if listed object lacks fields required by
+// content type or metadata filters.
+func (f *ObjectFilter) needsInfo(object domain.Object) bool {
+	return (f.params.ContentType != "" && object.ContentType == "") ||
+		(len(f.params.Metadata) > 0 && object.Metadata == nil)
+}
+
+// matchInfo matches filters of content type and user metadata.
+func (f *ObjectFilter) matchInfo(object domain.Object) bool {
+	params := f.params
+
+	if params.ContentType != "" {
+		mediaType, _, _ := strings.Cut(object.ContentType, ";")
+		mediaType = strings.ToLower(strings.TrimSpace(mediaType))
+		if matched, _ := path.Match(strings.ToLower(params.ContentType), mediaType); !matched {
+			return false
+		}
+	}
+
+	for key, value := range params.Metadata {
+		if object.Metadata[strings.ToLower(key)] != value {
+			return false
+		}
+	}
+
+	return true
+}
+
+// SearchObjects walks objects under prefix of filter recursively and calls
+// foundFn for each object matching filter. Objects listed without content
+// type or metadata are requested by GetObjectInfo if filter requires them.
+func (s *StorageUseCase) SearchObjects(
+	ctx kernel.Ctx,
+	bucketID kernel.BucketID,
+	filter *ObjectFilter,
+	foundFn domain.WalkObjectFunc,
+) error {
+	ctx, span := otlp_go.GlobalTracer.Start(ctx, "search-objects")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("bucket", bucketID),
+		attribute.String("folder", filter.params.PrefixPath),
+	)
+
+	var found int
+	err := s.cloudStorage.WalkBucketObjects(ctx, bucketID, filter.params.PrefixPath, func(object domain.Object) error {
+		if !filter.matchListed(object) {
+			return nil
+		}
+
+		if filter.needsInfo(object) {
+			objInfo, err := s.cloudStorage.GetObjectInfo(ctx, bucketID, object.Path)
+			if err != nil {
+				slog.Warn("search: failed to get object info",
+					slog.String("bucket", bucketID),
+					slog.String("file-path", object.Path),
+					slog.String("err", err.Error()),
+				)
+				return nil
+			}
+			object.ContentType = objInfo.ContentType
+			object.Metadata = objInfo.Metadata
+		}
+
+		if !filter.matchInfo(object) {
+			return nil
+		}
+
+		if err := foundFn(object); err != nil {
+			return err
+		}
+
+		found++
+		if filter.params.Limit > 0 && found >= filter.params.Limit {
+			return domain.ErrStopWalk
+		}
+
+		return nil
+	})
+
+	span.SetAttributes(attribute.Int("found", found))
+	if err != nil {
+		err = fmt.Errorf("failed to search bucket files: %s/%s: %w", bucketID, filter.params.PrefixPath, err)
+		span.SetStatus(codes.Error, err.Error())
+		span.RecordError(err)
+		return err
+	}
+
+	return nil
+}
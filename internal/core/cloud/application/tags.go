@@ -0,0 +1,113 @@
+package application
+
+import (
+	"fmt"
+
+	"github.com/breadrock1/otlp-go/otlp"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+
+	"watchtower/internal/core/cloud/domain"
+	"watchtower/internal/shared/kernel"
+)
+
+func (s *StorageUseCase) GetObjectTags(
+	ctx kernel.Ctx,
+	bucketID kernel.BucketID,
+	objID kernel.ObjectID,
+) (map[string]string, error) {
+	ctx, span := otlp_go.GlobalTracer.Start(ctx, "get-object-tags")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("bucket", bucketID),
+		attribute.String("file-path", objID),
+	)
+
+	tags, err := s.cloudStorage.GetObjectTags(ctx, bucketID, objID)
+	if err != nil {
+		err = fmt.Errorf("failed to get tags of %s/%s: %w", bucketID, objID, err)
+		span.SetStatus(codes.Error, err.Error())
+		span.RecordError(err)
+		return nil, err
+	}
+
+	return tags, nil
+}
+
+// SetObjectTags validates tags by S3 limits before storing them,
+// so all storages accept the same tags.
+func (s *StorageUseCase) SetObjectTags(
+	ctx kernel.Ctx,
+	bucketID kernel.BucketID,
+	objID kernel.ObjectID,
+	tags map[string]string,
+) error {
+	ctx, span := otlp_go.GlobalTracer.Start(ctx, "set-object-tags")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("bucket", bucketID),
+		attribute.String("file-path", objID),
+		attribute.Int("tags-count", len(tags)),
+	)
+
+	err := domain.ValidateObjectTags(tags)
+	if err == nil {
+		err = s.cloudStorage.SetObjectTags(ctx, bucketID, objID, tags)
+	}
+
+	if err != nil {
+		err = fmt.Errorf("failed to set tags of %s/%s: %w", bucketID, objID, err)
+		span.SetStatus(codes.Error, err.Error())
+		span.RecordError(err)
+		return err
+	}
+
+	return nil
+}
+
+func (s *StorageUseCase) DeleteObjectTags(ctx kernel.Ctx, bucketID kernel.BucketID, objID kernel.ObjectID) error {
+	ctx, span := otlp_go.GlobalTracer.Start(ctx, "delete-object-tags")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("bucket", bucketID),
+		attribute.String("file-path", objID),
+	)
+
+	err := s.cloudStorage.DeleteObjectTags(ctx, bucketID, objID)
+	if err != nil {
+		err = fmt.Errorf("failed to delete tags of %s/%s: %w", bucketID, objID, err)
+		span.SetStatus(codes.Error, err.Error())
+		span.RecordError(err)
+		return err
+	}
+
+	return nil
+}
+
+func (s *StorageUseCase) ReplaceObjectMetadata(
+	ctx kernel.Ctx,
+	bucketID kernel.BucketID,
+	objID kernel.ObjectID,
+	metadata map[string]string,
+) error {
+	ctx, span := otlp_go.GlobalTracer.Start(ctx, "replace-object-metadata")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("bucket", bucketID),
+		attribute.String("file-path", objID),
+	)
+
+	err := s.cloudStorage.ReplaceObjectMetadata(ctx, bucketID, objID, metadata)
+	if err != nil {
+		err = fmt.Errorf("failed to replace metadata of %s/%s: %w", bucketID, objID, err)
+		span.SetStatus(codes.Error, err.Error())
+		span.RecordError(err)
+		return err
+	}
+
+	return nil
+}
@@ -1,7 +1,11 @@
 package application
 
 import (
+	"cmp"
 	"fmt"
+	"slices"
+	"strconv"
+	"strings"
 
 	"github.com/breadrock1/otlp-go/otlp"
 	"go.opentelemetry.io/otel/attribute"
@@ -116,6 +120,7 @@ func (s *StorageUseCase) CopyObject(ctx kernel.Ctx, bucketID kernel.BucketID, pa
 		attribute.String("bucket", bucketID),
 		attribute.String("src-file-path", params.SourcePath),
 		attribute.String("dst-file-path", params.DestinationPath),
+		attribute.String("dst-bucket", params.DestinationBucket),
 		attribute.Bool("with-removed", params.WithRemoving),
 	)
 
@@ -191,6 +196,151 @@ func (s *StorageUseCase) LoadBucketObjects(
 	return objects, err
 }
 
+// WalkBucketObjects walks objects under prefix recursively, directories
+// are not walked.
+func (s *StorageUseCase) WalkBucketObjects(
+	ctx kernel.Ctx,
+	bucketID kernel.BucketID,
+	prefix string,
+	walkFn domain.WalkObjectFunc,
+) error {
+	ctx, span := otlp_go.GlobalTracer.Start(ctx, "walk-bucket-objects")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("bucket", bucketID),
+		attribute.String("folder", prefix),
+	)
+
+	if err := s.cloudStorage.WalkBucketObjects(ctx, bucketID, prefix, walkFn); err != nil {
+		err = fmt.Errorf("failed to walk bucket files: %s/%s: %w", bucketID, prefix, err)
+		span.SetStatus(codes.Error, err.Error())
+		span.RecordError(err)
+		return err
+	}
+
+	return nil
+}
+
+// LoadBucketObjectsPage returns page of objects. Objects ordered by name are
+// paginated by storage, other orders require listing of the whole prefix, so
+// continuation token of them is the offset of next page. Tokens are opaque
+// for clients, token of one order is rejected by listing of another order.
+func (s *StorageUseCase) LoadBucketObjectsPage(
+	ctx kernel.Ctx,
+	bucketID kernel.BucketID,
+	params *domain.GetObjectsParams,
+) (domain.ObjectsPage, error) {
+	ctx, span := otlp_go.GlobalTracer.Start(ctx, "get-bucket-objects-page")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("bucket", bucketID),
+		attribute.String("folder", params.PrefixPath),
+		attribute.Int("limit", int(params.Limit)),
+		attribute.String("sort-by", params.SortBy),
+	)
+
+	var page domain.ObjectsPage
+	var err error
+	if (params.SortBy == "" || params.SortBy == domain.SortByName) && !params.SortDesc {
+		page, err = s.loadPathObjectsPage(ctx, bucketID, params)
+	} else {
+		page, err = s.loadSortedObjectsPage(ctx, bucketID, params)
+	}
+
+	if err != nil {
+		err = fmt.Errorf("failed to get bucket files: %s/%s: %w", bucketID, params.PrefixPath, err)
+		span.SetStatus(codes.Error, err.Error())
+		span.RecordError(err)
+		return page, err
+	}
+
+	return page, nil
+}
+
+// loadPathObjectsPage returns page of objects paginated by storage, token
+// keeps path which storage continues listing after.
+func (s *StorageUseCase) loadPathObjectsPage(
+	ctx kernel.Ctx,
+	bucketID kernel.BucketID,
+	params *domain.GetObjectsParams,
+) (domain.ObjectsPage, error) {
+	startAfter, err := decodePageToken(params.ContinuationToken, pathPageToken)
+	if err != nil {
+		return domain.ObjectsPage{}, err
+	}
+
+	pageParams := *params
+	pageParams.ContinuationToken = startAfter
+	page, err := s.cloudStorage.GetBucketObjectsPage(ctx, bucketID, &pageParams)
+	if err != nil {
+		return page, err
+	}
+
+	page.NextToken = encodePageToken(pathPageToken, page.NextToken)
+	return page, nil
+}
+
+func (s *StorageUseCase) loadSortedObjectsPage(
+	ctx kernel.Ctx,
+	bucketID kernel.BucketID,
+	params *domain.GetObjectsParams,
+) (domain.ObjectsPage, error) {
+	var page domain.ObjectsPage
+
+	offset := int(max(params.Offset, 0))
+	position, err := decodePageToken(params.ContinuationToken, offsetPageToken)
+	if err != nil {
+		return page, err
+	}
+
+	if position != "" {
+		tokenOffset, err := strconv.Atoi(position)
+		if err != nil || tokenOffset < 0 {
+			return page, domain.ErrInvalidContinuation
+		}
+		offset = tokenOffset
+	}
+
+	listParams := &domain.GetObjectsParams{PrefixPath: params.PrefixPath}
+	objects, err := s.cloudStorage.GetBucketObjects(ctx, bucketID, listParams)
+	if err != nil {
+		return page, err
+	}
+
+	objects = slices.DeleteFunc(objects, func(object domain.Object) bool {
+		return domain.IsSkippedObject(object, params)
+	})
+
+	compare := func(a, b domain.Object) int {
+		switch params.SortBy {
+		case domain.SortBySize:
+			return cmp.Compare(a.Size, b.Size)
+		case domain.SortByModified:
+			return a.LastModified.Compare(b.LastModified)
+		default:
+			return 0
+		}
+	}
+
+	slices.SortStableFunc(objects, func(a, b domain.Object) int {
+		result := cmp.Or(compare(a, b), strings.Compare(a.Path, b.Path))
+		if params.SortDesc {
+			return -result
+		}
+		return result
+	})
+
+	page.Objects = objects[min(offset, len(objects)):]
+	if params.Limit > 0 && len(page.Objects) > int(params.Limit) {
+		page.Objects = page.Objects[:params.Limit]
+		page.NextToken = encodePageToken(offsetPageToken, strconv.Itoa(offset+int(params.Limit)))
+	}
+
+	return page, nil
+}
+
 func (s *StorageUseCase) StoreObject(
 	ctx kernel.Ctx,
 	bucketID kernel.BucketID,
@@ -240,6 +390,32 @@ func (s *StorageUseCase) GenShareURL(
 	return sharedURL.RequestURI(), nil
 }
 
+func (s *StorageUseCase) GenUploadURL(
+	ctx kernel.Ctx,
+	bucketID kernel.BucketID,
+	params *domain.PresignUploadParams,
+) (*domain.PresignedUpload, error) {
+	ctx, span := otlp_go.GlobalTracer.Start(ctx, "presign-upload-object")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("bucket", bucketID),
+		attribute.String("file-path", params.FilePath),
+		attribute.String("method", params.Method),
+		attribute.String("expired", params.Expired.String()),
+	)
+
+	presigned, err := s.cloudStorage.GenUploadURL(ctx, bucketID, params)
+	if err != nil {
+		err = fmt.Errorf("failed to generate upload url for %s: %w", params.FilePath, err)
+		span.SetStatus(codes.Error, err.Error())
+		span.RecordError(err)
+		return nil, err
+	}
+
+	return presigned, nil
+}
+
 func (s *StorageUseCase) GetObjectData(
 	ctx kernel.Ctx,
 	bucketID kernel.BucketID,
@@ -262,3 +438,164 @@ func (s *StorageUseCase) GetObjectData(
 	}
 	return fileData, nil
 }
+
+func (s *StorageUseCase) CreateMultipartUpload(
+	ctx kernel.Ctx,
+	bucketID kernel.BucketID,
+	params *domain.UploadObjectParams,
+) (string, error) {
+	ctx, span := otlp_go.GlobalTracer.Start(ctx, "create-multipart-upload")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("bucket", bucketID),
+		attribute.String("file-path", params.FilePath),
+	)
+
+	uploadID, err := s.cloudStorage.CreateMultipartUpload(ctx, bucketID, params)
+	if err != nil {
+		err = fmt.Errorf("failed to create multipart upload of %s: %w", params.FilePath, err)
+		span.SetStatus(codes.Error, err.Error())
+		span.RecordError(err)
+		return "", err
+	}
+
+	return uploadID, nil
+}
+
+func (s *StorageUseCase) UploadPart(
+	ctx kernel.Ctx,
+	bucketID kernel.BucketID,
+	objID kernel.ObjectID,
+	uploadID string,
+	number int,
+	data domain.ObjectData,
+) (domain.MultipartPart, error) {
+	ctx, span := otlp_go.GlobalTracer.Start(ctx, "upload-part")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("bucket", bucketID),
+		attribute.String("file-path", objID),
+		attribute.Int("part-number", number),
+		attribute.Int("part-size", data.Len()),
+	)
+
+	part, err := s.cloudStorage.UploadPart(ctx, bucketID, objID, uploadID, number, data)
+	if err != nil {
+		err = fmt.Errorf("failed to upload part %d of %s: %w", number, objID, err)
+		span.SetStatus(codes.Error, err.Error())
+		span.RecordError(err)
+		return domain.MultipartPart{}, err
+	}
+
+	return part, nil
+}
+
+func (s *StorageUseCase) GetUploadedPart(
+	ctx kernel.Ctx,
+	bucketID kernel.BucketID,
+	objID kernel.ObjectID,
+	uploadID string,
+	number int,
+) (*domain.MultipartPart, error) {
+	ctx, span := otlp_go.GlobalTracer.Start(ctx, "get-uploaded-part")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("bucket", bucketID),
+		attribute.String("file-path", objID),
+		attribute.Int("part-number", number),
+	)
+
+	part, err := s.cloudStorage.GetUploadedPart(ctx, bucketID, objID, uploadID, number)
+	if err != nil {
+		err = fmt.Errorf("failed to get uploaded part %d of %s: %w", number, objID, err)
+		span.SetStatus(codes.Error, err.Error())
+		span.RecordError(err)
+		return nil, err
+	}
+
+	return part, nil
+}
+
+func (s *StorageUseCase) CompleteMultipartUpload(
+	ctx kernel.Ctx,
+	bucketID kernel.BucketID,
+	objID kernel.ObjectID,
+	uploadID string,
+	parts []domain.MultipartPart,
+) error {
+	ctx, span := otlp_go.GlobalTracer.Start(ctx, "complete-multipart-upload")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("bucket", bucketID),
+		attribute.String("file-path", objID),
+		attribute.Int("parts", len(parts)),
+	)
+
+	if err := s.cloudStorage.CompleteMultipartUpload(ctx, bucketID, objID, uploadID, parts); err != nil {
+		err = fmt.Errorf("failed to complete multipart upload of %s: %w", objID, err)
+		span.SetStatus(codes.Error, err.Error())
+		span.RecordError(err)
+		return err
+	}
+
+	return nil
+}
+
+func (s *StorageUseCase) AbortMultipartUpload(
+	ctx kernel.Ctx,
+	bucketID kernel.BucketID,
+	objID kernel.ObjectID,
+	uploadID string,
+) error {
+	ctx, span := otlp_go.GlobalTracer.Start(ctx, "abort-multipart-upload")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("bucket", bucketID),
+		attribute.String("file-path", objID),
+	)
+
+	if err := s.cloudStorage.AbortMultipartUpload(ctx, bucketID, objID, uploadID); err != nil {
+		err = fmt.Errorf("failed to abort multipart upload of %s: %w", objID, err)
+		span.SetStatus(codes.Error, err.Error())
+		span.RecordError(err)
+		return err
+	}
+
+	return nil
+}
+
+// VerifyShareURL checks share URL signed by storage which shares objects
+// by watchtower download URLs.
+func (s *StorageUseCase) VerifyShareURL(
+	ctx kernel.Ctx,
+	bucketID kernel.BucketID,
+	objID kernel.ObjectID,
+	expires int64,
+	signature string,
+) error {
+	_, span := otlp_go.GlobalTracer.Start(ctx, "verify-share-url")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("bucket", bucketID),
+		attribute.String("file-path", objID),
+	)
+
+	verifier, ok := s.cloudStorage.(domain.IShareVerifier)
+	if !ok {
+		return domain.ErrShareNotSupported
+	}
+
+	if err := verifier.VerifyShareSignature(bucketID, objID, expires, signature); err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		span.RecordError(err)
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,88 @@
+package application
+
+import (
+	"fmt"
+
+	"github.com/breadrock1/otlp-go/otlp"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+
+	"watchtower/internal/core/cloud/domain"
+	"watchtower/internal/shared/kernel"
+)
+
+func (s *StorageUseCase) GetObjectVersions(
+	ctx kernel.Ctx,
+	bucketID kernel.BucketID,
+	objID kernel.ObjectID,
+) ([]domain.ObjectVersion, error) {
+	ctx, span := otlp_go.GlobalTracer.Start(ctx, "get-object-versions")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("bucket", bucketID),
+		attribute.String("file-path", objID),
+	)
+
+	versions, err := s.cloudStorage.GetObjectVersions(ctx, bucketID, objID)
+	if err != nil {
+		err = fmt.Errorf("failed to get versions of %s/%s: %w", bucketID, objID, err)
+		span.SetStatus(codes.Error, err.Error())
+		span.RecordError(err)
+		return nil, err
+	}
+
+	return versions, nil
+}
+
+func (s *StorageUseCase) GetObjectVersionData(
+	ctx kernel.Ctx,
+	bucketID kernel.BucketID,
+	objID kernel.ObjectID,
+	versionID string,
+) (domain.ObjectData, error) {
+	ctx, span := otlp_go.GlobalTracer.Start(ctx, "get-object-version-data")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("bucket", bucketID),
+		attribute.String("file-path", objID),
+		attribute.String("version-id", versionID),
+	)
+
+	objData, err := s.cloudStorage.GetObjectVersionData(ctx, bucketID, objID, versionID)
+	if err != nil {
+		err = fmt.Errorf("failed to get version %s of %s/%s: %w", versionID, bucketID, objID, err)
+		span.SetStatus(codes.Error, err.Error())
+		span.RecordError(err)
+		return nil, err
+	}
+
+	return objData, nil
+}
+
+func (s *StorageUseCase) RestoreObjectVersion(
+	ctx kernel.Ctx,
+	bucketID kernel.BucketID,
+	params *domain.RestoreObjectParams,
+) (string, error) {
+	ctx, span := otlp_go.GlobalTracer.Start(ctx, "restore-object-version")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("bucket", bucketID),
+		attribute.String("file-path", params.FilePath),
+		attribute.String("version-id", params.VersionID),
+	)
+
+	versionID, err := s.cloudStorage.RestoreObjectVersion(ctx, bucketID, params)
+	if err != nil {
+		err = fmt.Errorf("failed to restore version %s of %s/%s: %w",
+			params.VersionID, bucketID, params.FilePath, err)
+		span.SetStatus(codes.Error, err.Error())
+		span.RecordError(err)
+		return "", err
+	}
+
+	return versionID, nil
+}
@@ -1,7 +1,9 @@
 package domain
 
 import (
+	"fmt"
 	"time"
+
 	"watchtower/internal/shared/kernel"
 )
 
@@ -18,3 +20,118 @@ type Bucket struct {
 	// CreatedAt indicates when the bucket was created
 	CreatedAt time.Time
 }
+
+const (
+	// RetentionGovernance allows users with special permissions
+	// to remove locked object versions.
+	RetentionGovernance = "GOVERNANCE"
+
+	// RetentionCompliance forbids removing locked object versions
+	// by any user until retention period ends.
+	RetentionCompliance = "COMPLIANCE"
+)
+
+// BucketConfig represents optional features of a bucket. Nil fields
+// are unknown or unsupported by storage, and are left unchanged by updates.
+type BucketConfig struct {
+	// Versioning keeps previous versions of overwritten and deleted objects
+	Versioning *bool
+
+	// Lifecycle replaces all expiration rules of bucket,
+	// empty non-nil slice removes rules
+	Lifecycle []LifecycleRule
+
+	// ObjectLock protects object versions from removing
+	ObjectLock *ObjectLockConfig
+
+	// QuotaBytes limits total size of bucket objects, zero removes quota
+	QuotaBytes *int64
+}
+
+// LifecycleRule expires objects of prefix after days since creation.
+type LifecycleRule struct {
+	// ID identifies rule, generated by prefix if empty
+	ID string
+
+	// Prefix of expired objects, empty prefix means whole bucket
+	// Example: "tmp/"
+	Prefix string
+
+	// ExpirationDays is the age of current object versions to expire
+	ExpirationDays int
+
+	// NoncurrentExpirationDays is the age of noncurrent versions to remove
+	// in versioned bucket
+	NoncurrentExpirationDays int
+}
+
+// ObjectLockConfig defines default retention of new object versions.
+type ObjectLockConfig struct {
+	// Enabled is true if object versions of bucket may be locked
+	Enabled bool
+
+	// Mode is the default retention mode, empty for no default retention
+	Mode string
+
+	// RetentionDays is the default retention period of new object versions
+	RetentionDays int
+}
+
+// IsEmpty returns true if config has no settings.
+func (c *BucketConfig) IsEmpty() bool {
+	return c == nil || (c.Versioning == nil && c.Lifecycle == nil && c.ObjectLock == nil && c.QuotaBytes == nil)
+}
+
+// ValidateBucketConfig checks settings of config, so they are validated
+// equally by all storages. Conflicts with bucket state are checked by storage.
+func ValidateBucketConfig(config *BucketConfig) error {
+	if config == nil {
+		return nil
+	}
+
+	ruleIDs := make(map[string]bool, len(config.Lifecycle))
+	for index := range config.Lifecycle {
+		rule := &config.Lifecycle[index]
+		if rule.ExpirationDays < 0 || rule.NoncurrentExpirationDays < 0 {
+			return fmt.Errorf("%w: lifecycle days must not be negative", ErrInvalidBucketConfig)
+		}
+
+		if rule.ExpirationDays == 0 && rule.NoncurrentExpirationDays == 0 {
+			return fmt.Errorf("%w: lifecycle rule of prefix %q expires nothing", ErrInvalidBucketConfig, rule.Prefix)
+		}
+
+		if rule.ID == "" {
+			rule.ID = "expire-" + rule.Prefix
+		}
+
+		if ruleIDs[rule.ID] {
+			return fmt.Errorf("%w: duplicated lifecycle rule %q", ErrInvalidBucketConfig, rule.ID)
+		}
+		ruleIDs[rule.ID] = true
+	}
+
+	if lock := config.ObjectLock; lock != nil {
+		switch lock.Mode {
+		case "":
+			if lock.RetentionDays != 0 {
+				return fmt.Errorf("%w: retention days require retention mode", ErrInvalidBucketConfig)
+			}
+		case RetentionGovernance, RetentionCompliance:
+			if !lock.Enabled || lock.RetentionDays <= 0 {
+				return fmt.Errorf("%w: retention requires enabled object lock and positive days", ErrInvalidBucketConfig)
+			}
+		default:
+			return fmt.Errorf("%w: unsupported retention mode %s", ErrInvalidBucketConfig, lock.Mode)
+		}
+
+		if lock.Enabled && config.Versioning != nil && !*config.Versioning {
+			return fmt.Errorf("%w: object lock requires versioning", ErrInvalidBucketConfig)
+		}
+	}
+
+	if config.QuotaBytes != nil && *config.QuotaBytes < 0 {
+		return fmt.Errorf("%w: quota must not be negative", ErrInvalidBucketConfig)
+	}
+
+	return nil
+}
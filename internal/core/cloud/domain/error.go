@@ -0,0 +1,18 @@
+package domain
+
+import "errors"
+
+var (
+	ErrShareNotSupported     = errors.New("share urls are not served by watchtower for this storage")
+	ErrInvalidShareSignature = errors.New("invalid share url signature")
+	ErrPresignNotSupported   = errors.New("presigned uploads are not supported by this storage")
+	ErrInvalidContinuation   = errors.New("invalid continuation token")
+	ErrStopWalk              = errors.New("stop walking objects")
+	ErrInvalidSearchFilter   = errors.New("invalid search filter")
+	ErrInvalidObjectTags     = errors.New("invalid object tags")
+
+	ErrBucketConfigNotSupported = errors.New("bucket configuration is not supported by this storage")
+	ErrInvalidBucketConfig      = errors.New("invalid bucket configuration")
+	ErrVersioningNotSupported   = errors.New("object versions are not supported by this storage")
+	ErrVersionNotFound          = errors.New("object version not found")
+)
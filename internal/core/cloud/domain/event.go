@@ -0,0 +1,62 @@
+package domain
+
+import (
+	"watchtower/internal/shared/kernel"
+)
+
+// EventType represents the kind of change of object in cloud storage.
+type EventType int
+
+const (
+	// ObjectCreated indicates the object has been created or overwritten,
+	// including copies and multipart uploads.
+	ObjectCreated EventType = iota
+
+	// ObjectRemoved indicates the object has been deleted.
+	ObjectRemoved
+)
+
+// ObjectEvent represents notification about object changed in cloud
+// storage by any client, not only by watchtower itself.
+type ObjectEvent struct {
+	// Type is the kind of change
+	Type EventType
+
+	// Name is the original event name of storage provider
+	// Example: "s3:ObjectCreated:Put"
+	Name string
+
+	// BucketID identifies the bucket containing the object
+	BucketID kernel.BucketID
+
+	// ObjectID is the full path to the object within the bucket
+	// Example: "incoming/report.pdf"
+	ObjectID kernel.ObjectID
+
+	// Size is the object size in bytes, zero for removed objects
+	Size int64
+
+	// ETag is the entity tag of created object
+	ETag string
+
+	// Metadata contains custom key-value pairs of created object
+	Metadata map[string]string
+}
+
+// ObjectEventHandler handles notification about changed object.
+// Returned error means that event should be delivered again.
+type ObjectEventHandler func(ctx kernel.Ctx, event *ObjectEvent) error
+
+// IObjectEventSource defines subscription to notifications about objects
+// created or removed in cloud storage.
+type IObjectEventSource interface {
+	// Consume delivers events to handler until context is canceled.
+	//
+	// Parameters:
+	//   - kernel.Ctx: Context for cancellation
+	//   - handler: Function called for each received event
+	//
+	// Returns:
+	//   - error: Connection or subscription error
+	Consume(ctx kernel.Ctx, handler ObjectEventHandler) error
+}
@@ -2,7 +2,13 @@ package domain
 
 import (
 	"bytes"
+	"fmt"
+	"net/url"
+	"slices"
+	"sort"
+	"strings"
 	"time"
+	"unicode/utf8"
 )
 
 // ObjectData represents the actual content of a stored object.
@@ -24,6 +30,9 @@ type Object struct {
 	// Usually MD5, SHA256, or provider-specific checksum
 	Checksum string
 
+	// ETag is the entity tag of the object, it changes when content is overwritten
+	ETag string
+
 	// ContentType is the MIME type of the object
 	// Example: "application/pdf", "image/jpeg"
 	ContentType string
@@ -41,4 +50,156 @@ type Object struct {
 	// IsDirectory indicates if this "object" actually represents a directory/folder
 	// Some storage systems treat folders as objects with special handling
 	IsDirectory bool
+
+	// Metadata contains custom key-value pairs attached to the object by user
+	// Example: map[string]string{"department": "legal"}
+	Metadata map[string]string
+
+	// Tags contains key-value tags of the object, unlike metadata tags
+	// may be changed without rewriting the object
+	// Example: map[string]string{"project": "alpha"}
+	Tags map[string]string
+
+	// VersionID identifies current version of the object in versioned bucket,
+	// empty if bucket is not versioned
+	VersionID string
+}
+
+// ObjectVersion represents a version of an object in versioned bucket.
+type ObjectVersion struct {
+	// VersionID identifies the version
+	// Example: "3HL4kqtJlcpXroDTDmJ+rmSpXd3dIbrHY+MTRCxf3vjVBH40Nrjfkd"
+	VersionID string
+
+	// ETag is the entity tag of the version content
+	ETag string
+
+	// LastModified is the timestamp when the version has been created
+	LastModified time.Time
+
+	// Size is the version size in bytes
+	Size int64
+
+	// IsLatest indicates that the version is the current version of object
+	IsLatest bool
+
+	// IsDeleteMarker indicates that the version marks removing of object
+	// and has no content
+	IsDeleteMarker bool
+}
+
+const (
+	// MaxObjectTags is the max count of tags of single object allowed by S3.
+	MaxObjectTags = 10
+
+	// MaxTagKeyLength and MaxTagValueLength are max lengths of tag in runes.
+	MaxTagKeyLength   = 128
+	MaxTagValueLength = 256
+)
+
+// ValidateObjectTags checks tags against S3 object tagging limits,
+// so tags are validated equally by all storages.
+func ValidateObjectTags(tags map[string]string) error {
+	if len(tags) > MaxObjectTags {
+		return fmt.Errorf("%w: max count of tags is %d", ErrInvalidObjectTags, MaxObjectTags)
+	}
+
+	for key, value := range tags {
+		if key == "" || utf8.RuneCountInString(key) > MaxTagKeyLength {
+			return fmt.Errorf("%w: key length must be 1-%d: %q", ErrInvalidObjectTags, MaxTagKeyLength, key)
+		}
+
+		if utf8.RuneCountInString(value) > MaxTagValueLength {
+			return fmt.Errorf("%w: value length must be up to %d: %q", ErrInvalidObjectTags, MaxTagValueLength, key)
+		}
+	}
+
+	return nil
+}
+
+const (
+	PresignPutMethod  = "PUT"
+	PresignPostMethod = "POST"
+)
+
+// PresignedUpload is a signed request which uploads object directly into storage.
+type PresignedUpload struct {
+	// Method is the http method of upload request
+	Method string
+
+	// URL is the pre-signed URL to send upload request to
+	URL *url.URL
+
+	// Headers must be sent with PUT request as they are signed
+	Headers map[string]string
+
+	// FormData must be sent as fields of POST multipart form before file field
+	FormData map[string]string
+
+	// ExpiresAt is the time when URL expires
+	ExpiresAt time.Time
+}
+
+// WalkObjectFunc is called for each walked object. Returning ErrStopWalk
+// stops walking without error.
+type WalkObjectFunc func(object Object) error
+
+// ObjectsPage is a page of listed objects. NextToken is passed as
+// continuation token to get next page, it is empty for the last page.
+type ObjectsPage struct {
+	Objects   []Object
+	NextToken string
+}
+
+// StartAfterObject returns path which listing ordered by path continues
+// after to skip object. Listing of directory continues after all paths
+// under its prefix, so directory is never listed twice.
+func StartAfterObject(object Object) string {
+	if strings.HasSuffix(object.Path, "/") {
+		return object.Path + string(utf8.MaxRune)
+	}
+
+	return object.Path
+}
+
+// IsSkippedObject reports whether object is excluded from listing by
+// SkipNames of params.
+func IsSkippedObject(object Object, params *GetObjectsParams) bool {
+	return slices.Contains(params.SkipNames, object.Name)
+}
+
+// PageOffset returns count of objects skipped by page, only the first page
+// is shifted by offset, next pages start right after continuation token.
+func PageOffset(params *GetObjectsParams) int {
+	if params.ContinuationToken != "" {
+		return 0
+	}
+
+	return int(max(params.Offset, 0))
+}
+
+// PaginateObjects returns page of objects ordered by path which starts
+// after continuation token and offset of params.
+func PaginateObjects(objects []Object, params *GetObjectsParams) ObjectsPage {
+	objects = slices.DeleteFunc(slices.Clone(objects), func(object Object) bool {
+		return IsSkippedObject(object, params)
+	})
+
+	start := 0
+	if params.ContinuationToken != "" {
+		start = sort.Search(len(objects), func(index int) bool {
+			return objects[index].Path > params.ContinuationToken
+		})
+	}
+
+	start = min(start+PageOffset(params), len(objects))
+	objects = objects[start:]
+
+	page := ObjectsPage{Objects: objects}
+	if params.Limit > 0 && len(objects) > int(params.Limit) {
+		page.Objects = objects[:params.Limit]
+		page.NextToken = StartAfterObject(page.Objects[len(page.Objects)-1])
+	}
+
+	return page
 }
@@ -3,10 +3,12 @@ package domain
 import (
 	"bytes"
 	"time"
+
+	"watchtower/internal/shared/kernel"
 )
 
 // CopyObjectParams defines parameters for copying an object from one location to another
-// within the same bucket or across different paths.
+// within the same bucket or into another bucket.
 type CopyObjectParams struct {
 	// SourcePath is the full path of the source object
 	// Example: "documents/original/file.pdf"
@@ -16,8 +18,16 @@ type CopyObjectParams struct {
 	// Example: "backups/file.pdf" or "documents/copy/file.pdf"
 	DestinationPath string
 
+	// DestinationBucket is the bucket where the object should be copied (optional)
+	// If empty, the object is copied inside the source bucket
+	DestinationBucket kernel.BucketID
+
 	// WithRemoving is bool flag to remove source path after copying.
 	WithRemoving bool
+
+	// Metadata replaces custom key-value pairs of the destination object (optional)
+	// If nil, the metadata of the source object is kept
+	Metadata map[string]string
 }
 
 // ShareObjectParams defines parameters for generating a shareable URL for an object.
@@ -32,6 +42,12 @@ type ShareObjectParams struct {
 	Expired time.Duration
 }
 
+const (
+	SortByName     = "name"
+	SortBySize     = "size"
+	SortByModified = "modified"
+)
+
 // GetObjectsParams defines filtering and pagination parameters for listing objects.
 type GetObjectsParams struct {
 	// PrefixPath filters objects to those with paths starting with this prefix
@@ -48,7 +64,19 @@ type GetObjectsParams struct {
 	Offset int32
 
 	// ContinuationToken for pagination through large result sets
+	// It is the NextToken of previous page, listing continues after it
 	ContinuationToken string
+
+	// SkipNames excludes objects with these names before pagination
+	// Example: []string{".keeper"} to hide files keeping empty folders
+	SkipNames []string
+
+	// SortBy orders objects by SortByName, SortBySize or SortByModified
+	// Empty value means order by name
+	SortBy string
+
+	// SortDesc reverses order of objects
+	SortDesc bool
 }
 
 // UploadObjectParams defines parameters for uploading a new object to storage.
@@ -70,3 +98,86 @@ type UploadObjectParams struct {
 	// Metadata allows attaching custom key-value pairs to the object
 	Metadata map[string]string
 }
+
+// MinMultipartPartSize is the least size of every part of S3 multipart
+// upload except the last one.
+const MinMultipartPartSize = 5 << 20
+
+// MultipartPart identifies uploaded part of multipart upload.
+type MultipartPart struct {
+	// Number is the sequence number of part starting from 1
+	Number int
+
+	// ETag is the entity tag of uploaded part returned by storage
+	ETag string
+}
+
+// PresignUploadParams defines parameters for generating URL which allows
+// client to upload object directly into storage.
+type PresignUploadParams struct {
+	// FilePath is the destination path for the uploaded object
+	// Example: "uploads/images/profile.jpg"
+	FilePath string
+
+	// Method is the http method of upload request, PresignPutMethod or PresignPostMethod
+	Method string
+
+	// ContentType restricts the MIME type of uploaded object (optional)
+	ContentType string
+
+	// Size restricts the size of uploaded object in bytes (optional, POST only)
+	Size int64
+
+	// Expired specifies how long the upload URL remains valid
+	Expired time.Duration
+
+	// Metadata is the custom key-value pairs required to be attached to the object
+	Metadata map[string]string
+}
+
+// SearchObjectsParams defines filters of recursive objects search.
+// Every filter is optional, objects matching all set filters are found.
+type SearchObjectsParams struct {
+	// PrefixPath is the prefix which objects are searched under
+	// Example: "documents/2024/"
+	PrefixPath string
+
+	// Glob matches object name, or object path if pattern contains slash
+	// Example: "*.pdf" or "documents/*/report-*.pdf"
+	Glob string
+
+	// Regex matches object path
+	// Example: "invoice-[0-9]+\\.pdf$"
+	Regex string
+
+	// MinSize and MaxSize bound size of object in bytes
+	MinSize *int64
+	MaxSize *int64
+
+	// ModifiedAfter and ModifiedBefore bound last modification time of object
+	ModifiedAfter  *time.Time
+	ModifiedBefore *time.Time
+
+	// ContentType matches MIME type of object, wildcard is supported
+	// Example: "application/pdf" or "image/*"
+	ContentType string
+
+	// Metadata matches custom key-value pairs of object exactly
+	Metadata map[string]string
+
+	// Limit limits the number of found objects, zero means no limit
+	Limit int
+}
+
+// RestoreObjectParams defines version of object restored as current.
+type RestoreObjectParams struct {
+	// FilePath is the path of restored object
+	// Example: "documents/report.pdf"
+	FilePath string
+
+	// VersionID identifies restored version
+	VersionID string
+
+	// Metadata is merged into user metadata of restored version
+	Metadata map[string]string
+}
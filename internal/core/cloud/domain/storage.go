@@ -14,6 +14,8 @@ type ICloudStorage interface {
 	IObjectManager
 	IObjectWalker
 	IShareManager
+	IMultipartUploader
+	IObjectVersioner
 }
 
 // IBucketManager defines operations for managing storage buckets/containers.
@@ -90,6 +92,60 @@ type IBucketManager interface {
 	//
 	// Note: Some providers require the bucket to be empty before deletion.
 	DeleteBucket(ctx kernel.Ctx, bucketID kernel.BucketID) error
+
+	// CreateBucketWithConfig creates a new bucket and applies its configuration.
+	// Object lock may be enabled on bucket creation only.
+	//
+	// Parameters:
+	//   - kernel.Ctx: Context for cancellation and timeout
+	//   - bucketID: ID of the bucket to create
+	//   - config: Configuration of the bucket, nil fields keep storage defaults
+	//
+	// Returns:
+	//   - error: ErrBucketConfigNotSupported if storage doesn't support any of
+	//            passed settings, then bucket is not created,
+	//            or other provider-specific errors
+	//
+	// Example:
+	//   enabled := true
+	//   err := storage.CreateBucketWithConfig(ctx, "contracts", &BucketConfig{
+	//       Versioning: &enabled,
+	//       ObjectLock: &ObjectLockConfig{Enabled: true},
+	//   })
+	CreateBucketWithConfig(ctx kernel.Ctx, bucketID kernel.BucketID, config *BucketConfig) error
+
+	// GetBucketConfig retrieves versioning, lifecycle rules, object lock
+	// and quota of a bucket. Settings unsupported by storage are nil.
+	//
+	// Parameters:
+	//   - kernel.Ctx: Context for cancellation and timeout
+	//   - bucketID: ID of the bucket
+	//
+	// Returns:
+	//   - BucketConfig: Current configuration of the bucket
+	//   - error: ErrBucketConfigNotSupported if storage has no bucket configuration,
+	//            or other provider-specific errors
+	GetBucketConfig(ctx kernel.Ctx, bucketID kernel.BucketID) (BucketConfig, error)
+
+	// SetBucketConfig updates configuration of an existing bucket.
+	// Nil fields of config are left unchanged.
+	//
+	// Parameters:
+	//   - kernel.Ctx: Context for cancellation and timeout
+	//   - bucketID: ID of the bucket
+	//   - config: Changed settings of the bucket
+	//
+	// Returns:
+	//   - error: ErrBucketConfigNotSupported if storage doesn't support any of
+	//            passed settings, then nothing is changed,
+	//            ErrInvalidBucketConfig if settings conflict with bucket state,
+	//            or other provider-specific errors
+	//
+	// Example:
+	//   err := storage.SetBucketConfig(ctx, "media", &BucketConfig{
+	//       Lifecycle: []LifecycleRule{{Prefix: "tmp/", ExpirationDays: 7}},
+	//   })
+	SetBucketConfig(ctx kernel.Ctx, bucketID kernel.BucketID, config *BucketConfig) error
 }
 
 // IObjectManager defines operations for managing individual objects/files
@@ -224,6 +280,65 @@ type IObjectManager interface {
 	//       // Handle error, but ignore "not found" as it's already gone
 	//   }
 	DeleteObjects(ctx kernel.Ctx, bucketID kernel.BucketID, prefix string) error
+
+	// GetObjectTags retrieves tags of an object.
+	//
+	// Parameters:
+	//   - kernel.Ctx: Context for cancellation and timeout
+	//   - bucketID: ID of the bucket containing the object
+	//   - objID: ID/path of the object
+	//
+	// Returns:
+	//   - map[string]string: Tags of the object, empty map if object has no tags
+	//   - error: Provider-specific error if object doesn't exist or can't be read
+	GetObjectTags(ctx kernel.Ctx, bucketID kernel.BucketID, objID kernel.ObjectID) (map[string]string, error)
+
+	// SetObjectTags replaces all tags of an object without rewriting the object.
+	//
+	// Parameters:
+	//   - kernel.Ctx: Context for cancellation and timeout
+	//   - bucketID: ID of the bucket containing the object
+	//   - objID: ID/path of the object
+	//   - tags: New tags of the object, validated by ValidateObjectTags
+	//
+	// Returns:
+	//   - error: Provider-specific error if object doesn't exist or tags are rejected
+	//
+	// Example:
+	//   err := storage.SetObjectTags(ctx, "documents", "reports/q1.pdf", map[string]string{
+	//       "project": "alpha",
+	//   })
+	SetObjectTags(ctx kernel.Ctx, bucketID kernel.BucketID, objID kernel.ObjectID, tags map[string]string) error
+
+	// DeleteObjectTags removes all tags of an object.
+	//
+	// Parameters:
+	//   - kernel.Ctx: Context for cancellation and timeout
+	//   - bucketID: ID of the bucket containing the object
+	//   - objID: ID/path of the object
+	//
+	// Returns:
+	//   - error: Provider-specific error if object doesn't exist
+	DeleteObjectTags(ctx kernel.Ctx, bucketID kernel.BucketID, objID kernel.ObjectID) error
+
+	// ReplaceObjectMetadata replaces user metadata of an object in place.
+	// Content type and tags of the object are preserved. S3 storages copy
+	// object to itself, so modification time and ETag of object change.
+	//
+	// Parameters:
+	//   - kernel.Ctx: Context for cancellation and timeout
+	//   - bucketID: ID of the bucket containing the object
+	//   - objID: ID/path of the object
+	//   - metadata: New user metadata of the object, empty map removes metadata
+	//
+	// Returns:
+	//   - error: Provider-specific error if object doesn't exist or can't be copied
+	ReplaceObjectMetadata(
+		ctx kernel.Ctx,
+		bucketID kernel.BucketID,
+		objID kernel.ObjectID,
+		metadata map[string]string,
+	) error
 }
 
 // IObjectWalker defines operations for listing and iterating through objects in a bucket.
@@ -250,6 +365,144 @@ type IObjectWalker interface {
 	//       fmt.Printf("Found: %s (%d bytes)\n", obj.Path, obj.Size)
 	//   }
 	GetBucketObjects(ctx kernel.Ctx, bucketID kernel.BucketID, params *GetObjectsParams) ([]Object, error)
+
+	// GetBucketObjectsPage retrieves a page of objects in a bucket ordered by path.
+	// Listing starts after path passed as ContinuationToken, skips Offset objects
+	// of the first page and returns Limit objects at most, zero Limit means the
+	// rest of objects. Offset is ignored when ContinuationToken is passed.
+	// Objects named by SkipNames are excluded before Offset and Limit are applied.
+	// NextToken is the path returned by StartAfterObject for the last object.
+	//
+	// Parameters:
+	//   - kernel.Ctx: Context for cancellation and timeout
+	//   - bucketID: ID of the bucket to list objects from
+	//   - params: Filtering and pagination parameters, sorting is ignored
+	//
+	// Returns:
+	//   - ObjectsPage: Objects of page and token of next page
+	//   - error: ErrBucketNotFound if bucket doesn't exist, or other provider-specific errors
+	//
+	// Example:
+	//   params := &GetObjectsParams{PrefixPath: "images/", Limit: 100}
+	//   for {
+	//       page, err := storage.GetBucketObjectsPage(ctx, "media", params)
+	//       if err != nil || page.NextToken == "" {
+	//           break
+	//       }
+	//       params.ContinuationToken = page.NextToken
+	//   }
+	GetBucketObjectsPage(ctx kernel.Ctx, bucketID kernel.BucketID, params *GetObjectsParams) (ObjectsPage, error)
+
+	// WalkBucketObjects walks objects under prefix recursively in order of paths.
+	// Directories are not passed to walkFn. Objects are passed with metadata if
+	// storage lists it, otherwise Metadata of object is nil.
+	//
+	// Parameters:
+	//   - kernel.Ctx: Context for cancellation and timeout
+	//   - bucketID: ID of the bucket to walk objects of
+	//   - prefix: Prefix of walked objects, empty prefix means whole bucket
+	//   - walkFn: Function called for each object, walking stops when it returns error
+	//
+	// Returns:
+	//   - error: Error returned by walkFn except ErrStopWalk,
+	//            or provider-specific errors
+	//
+	// Example:
+	//   err := storage.WalkBucketObjects(ctx, "media", "images/", func(obj Object) error {
+	//       fmt.Printf("Found: %s (%d bytes)\n", obj.Path, obj.Size)
+	//       return nil
+	//   })
+	WalkBucketObjects(ctx kernel.Ctx, bucketID kernel.BucketID, prefix string, walkFn WalkObjectFunc) error
+}
+
+// IMultipartUploader defines operations for uploading large objects by parts.
+// Object becomes visible only after upload is completed.
+type IMultipartUploader interface {
+	// CreateMultipartUpload starts multipart upload of object.
+	//
+	// Parameters:
+	//   - kernel.Ctx: Context for cancellation and timeout
+	//   - bucketID: ID of the bucket to upload object to
+	//   - params: Upload parameters, FileData is ignored
+	//
+	// Returns:
+	//   - string: ID of multipart upload used by other methods
+	//   - error: ErrBucketNotFound if bucket doesn't exist, or other provider-specific errors
+	CreateMultipartUpload(ctx kernel.Ctx, bucketID kernel.BucketID, params *UploadObjectParams) (string, error)
+
+	// UploadPart uploads part of object. All parts except the last one
+	// must be at least 5 MiB for s3 compatible storages.
+	//
+	// Parameters:
+	//   - kernel.Ctx: Context for cancellation and timeout
+	//   - bucketID: ID of the bucket to upload object to
+	//   - objID: Path of the uploaded object
+	//   - uploadID: ID of multipart upload
+	//   - number: Sequence number of part starting from 1
+	//   - data: Content of part
+	//
+	// Returns:
+	//   - MultipartPart: Uploaded part to pass on completion
+	//   - error: ErrUploadNotFound if upload doesn't exist, or other provider-specific errors
+	UploadPart(
+		ctx kernel.Ctx,
+		bucketID kernel.BucketID,
+		objID kernel.ObjectID,
+		uploadID string,
+		number int,
+		data ObjectData,
+	) (MultipartPart, error)
+
+	// GetUploadedPart returns part of multipart upload if it has been uploaded.
+	//
+	// Parameters:
+	//   - kernel.Ctx: Context for cancellation and timeout
+	//   - bucketID: ID of the bucket to upload object to
+	//   - objID: Path of the uploaded object
+	//   - uploadID: ID of multipart upload
+	//   - number: Sequence number of part starting from 1
+	//
+	// Returns:
+	//   - *MultipartPart: Uploaded part, nil if part has not been uploaded
+	//   - error: ErrUploadNotFound if upload doesn't exist, or other provider-specific errors
+	GetUploadedPart(
+		ctx kernel.Ctx,
+		bucketID kernel.BucketID,
+		objID kernel.ObjectID,
+		uploadID string,
+		number int,
+	) (*MultipartPart, error)
+
+	// CompleteMultipartUpload assembles object from uploaded parts.
+	//
+	// Parameters:
+	//   - kernel.Ctx: Context for cancellation and timeout
+	//   - bucketID: ID of the bucket to upload object to
+	//   - objID: Path of the uploaded object
+	//   - uploadID: ID of multipart upload
+	//   - parts: Uploaded parts in order of their numbers
+	//
+	// Returns:
+	//   - error: ErrUploadNotFound if upload doesn't exist, or other provider-specific errors
+	CompleteMultipartUpload(
+		ctx kernel.Ctx,
+		bucketID kernel.BucketID,
+		objID kernel.ObjectID,
+		uploadID string,
+		parts []MultipartPart,
+	) error
+
+	// AbortMultipartUpload cancels multipart upload and removes uploaded parts.
+	//
+	// Parameters:
+	//   - kernel.Ctx: Context for cancellation and timeout
+	//   - bucketID: ID of the bucket to upload object to
+	//   - objID: Path of the uploaded object
+	//   - uploadID: ID of multipart upload
+	//
+	// Returns:
+	//   - error: ErrUploadNotFound if upload doesn't exist, or other provider-specific errors
+	AbortMultipartUpload(ctx kernel.Ctx, bucketID kernel.BucketID, objID kernel.ObjectID, uploadID string) error
 }
 
 // IShareManager defines operations for generating temporary access URLs to objects.
@@ -279,4 +532,100 @@ type IShareManager interface {
 	//       fmt.Printf("Shareable link (valid for 24h): %s\n", shareURL.String())
 	//   }
 	GenShareURL(ctx kernel.Ctx, bucketID kernel.BucketID, params *ShareObjectParams) (*url.URL, error)
+
+	// GenUploadURL generates a time-limited request which uploads object directly
+	// into storage without passing its content through watchtower.
+	//
+	// Parameters:
+	//   - kernel.Ctx: Context for cancellation and timeout
+	//   - bucketID: ID of the bucket to upload object to
+	//   - params: Upload parameters including file path, method and expiration
+	//
+	// Returns:
+	//   - *PresignedUpload: Pre-signed upload request
+	//   - error: ErrPresignNotSupported if storage does not accept direct uploads,
+	//            or other provider-specific errors
+	//
+	// Example:
+	//   params := &PresignUploadParams{
+	//       FilePath: "incoming/report.pdf",
+	//       Method: PresignPutMethod,
+	//       Expired: 15 * time.Minute,
+	//   }
+	//   upload, err := storage.GenUploadURL(ctx, "documents", params)
+	//   if err == nil {
+	//       fmt.Printf("PUT file to %s\n", upload.URL.String())
+	//   }
+	GenUploadURL(ctx kernel.Ctx, bucketID kernel.BucketID, params *PresignUploadParams) (*PresignedUpload, error)
+}
+
+// IShareVerifier is implemented by storages which share objects by signed
+// watchtower download URLs instead of URLs served by storage provider.
+type IShareVerifier interface {
+	// VerifyShareSignature checks signature and expiration of share URL.
+	//
+	// Parameters:
+	//   - bucketID: ID of the bucket containing the object
+	//   - objID: Path of the shared object
+	//   - expires: Unix time when share URL expires
+	//   - signature: Signature of share URL
+	//
+	// Returns:
+	//   - error: ErrInvalidShareSignature if signature is invalid or expired
+	VerifyShareSignature(bucketID kernel.BucketID, objID kernel.ObjectID, expires int64, signature string) error
+}
+
+// IObjectVersioner defines operations on versions of objects in versioned buckets.
+// Storages without versioning return ErrVersioningNotSupported.
+type IObjectVersioner interface {
+	// GetObjectVersions lists versions of an object from the newest one.
+	//
+	// Parameters:
+	//   - kernel.Ctx: Context for cancellation and timeout
+	//   - bucketID: ID of the bucket containing the object
+	//   - objID: ID/path of the object
+	//
+	// Returns:
+	//   - []ObjectVersion: Versions of the object, empty if object has never existed
+	//   - error: ErrVersioningNotSupported, or provider-specific errors
+	GetObjectVersions(ctx kernel.Ctx, bucketID kernel.BucketID, objID kernel.ObjectID) ([]ObjectVersion, error)
+
+	// GetObjectVersionData retrieves content of a specific version of an object.
+	//
+	// Parameters:
+	//   - kernel.Ctx: Context for cancellation and timeout
+	//   - bucketID: ID of the bucket containing the object
+	//   - objID: ID/path of the object
+	//   - versionID: ID of the version
+	//
+	// Returns:
+	//   - ObjectData: Content of the version
+	//   - error: ErrVersionNotFound if version doesn't exist or is delete marker,
+	//            ErrVersioningNotSupported, or provider-specific errors
+	GetObjectVersionData(
+		ctx kernel.Ctx,
+		bucketID kernel.BucketID,
+		objID kernel.ObjectID,
+		versionID string,
+	) (ObjectData, error)
+
+	// RestoreObjectVersion copies a version of an object over the object,
+	// so restored content becomes the new current version.
+	//
+	// Parameters:
+	//   - kernel.Ctx: Context for cancellation and timeout
+	//   - bucketID: ID of the bucket containing the object
+	//   - params: Restored version and metadata merged into its metadata
+	//
+	// Returns:
+	//   - string: ID of the new current version
+	//   - error: ErrVersionNotFound if version doesn't exist or is delete marker,
+	//            ErrVersioningNotSupported, or provider-specific errors
+	//
+	// Example:
+	//   versionID, err := storage.RestoreObjectVersion(ctx, "documents", &RestoreObjectParams{
+	//       FilePath:  "reports/q1.pdf",
+	//       VersionID: "3HL4kqtJlcpXroDTDmJ+rmSpXd3dIbrHY+MTRCxf3vjVBH40Nrjfkd",
+	//   })
+	RestoreObjectVersion(ctx kernel.Ctx, bucketID kernel.BucketID, params *RestoreObjectParams) (string, error)
 }
@@ -0,0 +1,10 @@
+package localfs
+
+// Config of local filesystem storage. Top-level directories of RootDir
+// are buckets. PublicURL is an address of watchtower http server used by
+// share URLs which are signed by SignKey.
+type Config struct {
+	RootDir   string `mapstructure:"root_dir"`
+	PublicURL string `mapstructure:"public_url"`
+	SignKey   string `mapstructure:"sign_key"`
+}
@@ -0,0 +1,13 @@
+package localfs
+
+// ObjectMeta is stored along with object file because filesystem can not
+// keep content type, expiration and user metadata of object. ModTime is
+// file modification time when ETag has been computed.
+type ObjectMeta struct {
+	ContentType string            `json:"content_type"`
+	ETag        string            `json:"etag"`
+	ModTime     int64             `json:"mod_time"`
+	Expired     int64             `json:"expired,omitempty"`
+	Metadata    map[string]string `json:"metadata,omitempty"`
+	Tags        map[string]string `json:"tags,omitempty"`
+}
@@ -0,0 +1,683 @@
+package localfs
+
+import (
+	"bytes"
+	"cmp"
+	"crypto/md5"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"maps"
+	"mime"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"watchtower/internal/core/cloud/domain"
+	"watchtower/internal/shared/kernel"
+)
+
+const (
+	// metaDirName is a hidden top-level directory of RootDir which keeps
+	// metadata of objects, it is never listed as bucket.
+	metaDirName = ".watchtower-meta"
+
+	// uploadsDirName is a hidden top-level directory of RootDir which keeps
+	// parts of multipart uploads and temporary files of written objects.
+	uploadsDirName = ".watchtower-uploads"
+)
+
+type LocalFS struct {
+	config Config
+}
+
+func New(config Config) (domain.ICloudStorage, error) {
+	if config.SignKey == "" {
+		return nil, fmt.Errorf("localfs error: sign key of share urls is required")
+	}
+
+	for _, dirName := range []string{metaDirName, uploadsDirName} {
+		if err := os.MkdirAll(filepath.Join(config.RootDir, dirName), 0o755); err != nil {
+			return nil, fmt.Errorf("localfs error: failed to init root dir: %w", err)
+		}
+	}
+
+	slog.Info("local filesystem storage initialized", slog.String("root-dir", config.RootDir))
+
+	return &LocalFS{config: config}, nil
+}
+
+func (fs *LocalFS) GetAllBuckets(_ kernel.Ctx) ([]domain.Bucket, error) {
+	entries, err := os.ReadDir(fs.config.RootDir)
+	if err != nil {
+		return nil, fmt.Errorf("localfs error: %w", err)
+	}
+
+	buckets := make([]domain.Bucket, 0, len(entries))
+	for _, entry := range entries {
+		if !entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+
+		info, err := entry.Info()
+		if err != nil {
+			slog.Warn("localfs: failed to get bucket info", slog.String("err", err.Error()))
+			continue
+		}
+
+		buckets = append(buckets, domain.Bucket{
+			ID:        entry.Name(),
+			Path:      "",
+			CreatedAt: info.ModTime(),
+		})
+	}
+
+	return buckets, nil
+}
+
+func (fs *LocalFS) IsBucketExist(_ kernel.Ctx, bucketID kernel.BucketID) (bool, error) {
+	bucketDir, err := fs.bucketDir(bucketID)
+	if err != nil {
+		return false, err
+	}
+
+	info, err := os.Stat(bucketDir)
+	if errors.Is(err, os.ErrNotExist) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("localfs error: %w", err)
+	}
+
+	return info.IsDir(), nil
+}
+
+func (fs *LocalFS) CreateBucket(_ kernel.Ctx, bucketID kernel.BucketID) error {
+	bucketDir, err := fs.bucketDir(bucketID)
+	if err != nil {
+		return err
+	}
+
+	if err = os.Mkdir(bucketDir, 0o755); err != nil {
+		return fmt.Errorf("localfs error: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteBucket removes only empty bucket like s3 does.
+func (fs *LocalFS) DeleteBucket(_ kernel.Ctx, bucketID kernel.BucketID) error {
+	bucketDir, err := fs.bucketDir(bucketID)
+	if err != nil {
+		return err
+	}
+
+	if err = os.Remove(bucketDir); err != nil {
+		return fmt.Errorf("localfs error: %w", err)
+	}
+
+	_ = os.RemoveAll(filepath.Join(fs.config.RootDir, metaDirName, bucketID))
+	return nil
+}
+
+// CreateBucketWithConfig creates bucket without any configuration,
+// bucket features are not supported by local filesystem.
+func (fs *LocalFS) CreateBucketWithConfig(
+	ctx kernel.Ctx,
+	bucketID kernel.BucketID,
+	config *domain.BucketConfig,
+) error {
+	if !config.IsEmpty() {
+		return fmt.Errorf("%w: local filesystem", domain.ErrBucketConfigNotSupported)
+	}
+
+	return fs.CreateBucket(ctx, bucketID)
+}
+
+func (fs *LocalFS) GetBucketConfig(_ kernel.Ctx, _ kernel.BucketID) (domain.BucketConfig, error) {
+	return domain.BucketConfig{}, fmt.Errorf("%w: local filesystem", domain.ErrBucketConfigNotSupported)
+}
+
+func (fs *LocalFS) SetBucketConfig(_ kernel.Ctx, _ kernel.BucketID, _ *domain.BucketConfig) error {
+	return fmt.Errorf("%w: local filesystem", domain.ErrBucketConfigNotSupported)
+}
+
+func (fs *LocalFS) GetObjectInfo(
+	_ kernel.Ctx,
+	bucketID kernel.BucketID,
+	objID kernel.ObjectID,
+) (domain.Object, error) {
+	filePath, objID, err := fs.objectPath(bucketID, objID)
+	if err != nil {
+		return domain.Object{}, err
+	}
+
+	info, err := os.Stat(filePath)
+	if err != nil {
+		return domain.Object{}, fmt.Errorf("localfs error: %w", err)
+	}
+
+	if info.IsDir() {
+		return domain.Object{}, fmt.Errorf("localfs error: %s is a directory", objID)
+	}
+
+	return fs.convertObject(bucketID, objID, info), nil
+}
+
+func (fs *LocalFS) GetObjectData(
+	_ kernel.Ctx,
+	bucketID kernel.BucketID,
+	objID kernel.ObjectID,
+) (domain.ObjectData, error) {
+	filePath, _, err := fs.objectPath(bucketID, objID)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("localfs error: %w", err)
+	}
+
+	return bytes.NewBuffer(data), nil
+}
+
+func (fs *LocalFS) StoreObject(
+	_ kernel.Ctx,
+	bucketID kernel.BucketID,
+	params *domain.UploadObjectParams,
+) (kernel.ObjectID, error) {
+	filePath, objID, err := fs.objectPath(bucketID, params.FilePath)
+	if err != nil {
+		return "", err
+	}
+
+	meta := &ObjectMeta{
+		ContentType: params.ContentType,
+		Metadata:    params.Metadata,
+	}
+
+	if meta.ContentType == "" {
+		meta.ContentType = http.DetectContentType(params.FileData.Bytes())
+	}
+
+	if params.Expired != nil {
+		meta.Expired = params.Expired.Unix()
+	}
+
+	if err = fs.writeObject(bucketID, objID, filePath, params.FileData, meta); err != nil {
+		return "", err
+	}
+
+	return objID, nil
+}
+
+func (fs *LocalFS) CopyObject(_ kernel.Ctx, bucketID kernel.BucketID, params *domain.CopyObjectParams) error {
+	srcPath, srcID, err := fs.objectPath(bucketID, params.SourcePath)
+	if err != nil {
+		return err
+	}
+
+	dstBucketID := cmp.Or(params.DestinationBucket, bucketID)
+	dstPath, dstID, err := fs.objectPath(dstBucketID, params.DestinationPath)
+	if err != nil {
+		return err
+	}
+
+	info, err := os.Stat(srcPath)
+	if err != nil {
+		return fmt.Errorf("localfs error: %w", err)
+	}
+
+	data, err := os.ReadFile(srcPath)
+	if err != nil {
+		return fmt.Errorf("localfs error: %w", err)
+	}
+
+	srcMeta := fs.readMeta(bucketID, srcID, info)
+	dstMeta := &ObjectMeta{
+		ContentType: srcMeta.ContentType,
+		Expired:     srcMeta.Expired,
+		Metadata:    srcMeta.Metadata,
+		Tags:        srcMeta.Tags,
+	}
+
+	if params.Metadata != nil {
+		dstMeta.Metadata = maps.Clone(params.Metadata)
+	}
+
+	if err = fs.writeObject(dstBucketID, dstID, dstPath, bytes.NewReader(data), dstMeta); err != nil {
+		return err
+	}
+
+	if params.WithRemoving && (dstBucketID != bucketID || srcID != dstID) {
+		if err = fs.removeObject(bucketID, srcID, srcPath); err != nil {
+			return fmt.Errorf("localfs error: failed to remove source object: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (fs *LocalFS) DeleteObject(_ kernel.Ctx, bucketID kernel.BucketID, objID kernel.ObjectID) error {
+	filePath, objID, err := fs.objectPath(bucketID, objID)
+	if err != nil {
+		return err
+	}
+
+	if err = fs.removeObject(bucketID, objID, filePath); err != nil {
+		return fmt.Errorf("localfs error: %w", err)
+	}
+
+	return nil
+}
+
+func (fs *LocalFS) DeleteObjects(_ kernel.Ctx, bucketID kernel.BucketID, prefix string) error {
+	bucketDir, err := fs.bucketDir(bucketID)
+	if err != nil {
+		return err
+	}
+
+	prefix = normalizePrefix(prefix)
+	var objIDs []kernel.ObjectID
+	err = filepath.WalkDir(bucketDir, func(filePath string, entry os.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return err
+		}
+
+		relPath, err := filepath.Rel(bucketDir, filePath)
+		if err != nil {
+			return err
+		}
+
+		if objID := filepath.ToSlash(relPath); strings.HasPrefix(objID, prefix) {
+			objIDs = append(objIDs, objID)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("localfs error: %w", err)
+	}
+
+	for _, objID := range objIDs {
+		filePath := filepath.Join(bucketDir, filepath.FromSlash(objID))
+		if err = fs.removeObject(bucketID, objID, filePath); err != nil {
+			slog.Warn("failed to delete object",
+				slog.String("bucket", bucketID),
+				slog.String("prefix", prefix),
+				slog.String("error", objID),
+				slog.String("err", err.Error()),
+			)
+		}
+	}
+
+	return nil
+}
+
+// GetBucketObjects lists objects like non-recursive s3 listing: entries
+// of directory of prefix which names start with the rest of prefix,
+// directories are returned with trailing slash.
+func (fs *LocalFS) GetBucketObjects(
+	_ kernel.Ctx,
+	bucketID kernel.BucketID,
+	params *domain.GetObjectsParams,
+) ([]domain.Object, error) {
+	bucketDir, err := fs.bucketDir(bucketID)
+	if err != nil {
+		return nil, err
+	}
+
+	prefix := normalizePrefix(params.PrefixPath)
+	dirPrefix, namePrefix := path.Split(prefix)
+
+	listDir, err := prefixDir(bucketDir, dirPrefix)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := os.ReadDir(listDir)
+	if errors.Is(err, os.ErrNotExist) {
+		return []domain.Object{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("localfs error: %w", err)
+	}
+
+	dirObjects := make([]domain.Object, 0, len(entries))
+	for _, entry := range entries {
+		if !strings.HasPrefix(entry.Name(), namePrefix) {
+			continue
+		}
+
+		objID := dirPrefix + entry.Name()
+		if entry.IsDir() {
+			dirObjects = append(dirObjects, domain.Object{
+				Name:        entry.Name(),
+				Path:        objID + "/",
+				IsDirectory: true,
+			})
+			continue
+		}
+
+		info, err := entry.Info()
+		if err != nil {
+			slog.Warn("localfs: failed to get object",
+				slog.String("bucket", bucketID),
+				slog.String("err", err.Error()),
+			)
+			continue
+		}
+
+		dirObjects = append(dirObjects, fs.convertObject(bucketID, objID, info))
+	}
+
+	return dirObjects, nil
+}
+
+// GetBucketObjectsPage returns page of objects of directory listing
+// ordered by path like s3 listing.
+func (fs *LocalFS) GetBucketObjectsPage(
+	ctx kernel.Ctx,
+	bucketID kernel.BucketID,
+	params *domain.GetObjectsParams,
+) (domain.ObjectsPage, error) {
+	objects, err := fs.GetBucketObjects(ctx, bucketID, params)
+	if err != nil {
+		return domain.ObjectsPage{}, err
+	}
+
+	slices.SortFunc(objects, func(a, b domain.Object) int {
+		return strings.Compare(a.Path, b.Path)
+	})
+
+	return domain.PaginateObjects(objects, params), nil
+}
+
+// WalkBucketObjects walks files of bucket directory recursively, files
+// are walked in lexical order of paths like s3 recursive listing.
+func (fs *LocalFS) WalkBucketObjects(
+	_ kernel.Ctx,
+	bucketID kernel.BucketID,
+	prefix string,
+	walkFn domain.WalkObjectFunc,
+) error {
+	bucketDir, err := fs.bucketDir(bucketID)
+	if err != nil {
+		return err
+	}
+
+	prefix = normalizePrefix(prefix)
+	dirPrefix, _ := path.Split(prefix)
+	rootDir, err := prefixDir(bucketDir, dirPrefix)
+	if err != nil {
+		return err
+	}
+
+	err = filepath.WalkDir(rootDir, func(filePath string, entry os.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+
+		relPath, err := filepath.Rel(bucketDir, filePath)
+		if err != nil {
+			return err
+		}
+
+		objID := filepath.ToSlash(relPath)
+		if entry.IsDir() {
+			// Directories which can't contain objects of prefix are skipped.
+			dirID := objID + "/"
+			if filePath != rootDir && !strings.HasPrefix(dirID, prefix) && !strings.HasPrefix(prefix, dirID) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		if !strings.HasPrefix(objID, prefix) {
+			return nil
+		}
+
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+
+		return walkFn(fs.convertObject(bucketID, objID, info))
+	})
+
+	if errors.Is(err, domain.ErrStopWalk) {
+		return nil
+	}
+
+	var pathErr *os.PathError
+	if errors.As(err, &pathErr) {
+		return fmt.Errorf("localfs error: %w", err)
+	}
+
+	return err
+}
+
+// GenShareURL returns watchtower download URL signed by HMAC of object
+// path and expiration time.
+func (fs *LocalFS) GenShareURL(
+	_ kernel.Ctx,
+	bucketID kernel.BucketID,
+	params *domain.ShareObjectParams,
+) (*url.URL, error) {
+	filePath, objID, err := fs.objectPath(bucketID, params.FilePath)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err = os.Stat(filePath); err != nil {
+		return nil, fmt.Errorf("localfs error: %w", err)
+	}
+
+	expires := time.Now().Add(params.Expired).Unix()
+	return fs.signShareURL(bucketID, objID, expires)
+}
+
+// GenUploadURL is not supported because objects of local filesystem are
+// written by watchtower only, resumable uploads should be used instead.
+func (fs *LocalFS) GenUploadURL(
+	_ kernel.Ctx,
+	_ kernel.BucketID,
+	_ *domain.PresignUploadParams,
+) (*domain.PresignedUpload, error) {
+	return nil, domain.ErrPresignNotSupported
+}
+
+func (fs *LocalFS) bucketDir(bucketID kernel.BucketID) (string, error) {
+	if bucketID == "" || strings.HasPrefix(bucketID, ".") || strings.ContainsAny(bucketID, `/\`) {
+		return "", fmt.Errorf("localfs error: invalid bucket name %s", bucketID)
+	}
+
+	return filepath.Join(fs.config.RootDir, bucketID), nil
+}
+
+// objectPath returns file path of object and cleaned object path which
+// never points outside of bucket directory.
+func (fs *LocalFS) objectPath(bucketID kernel.BucketID, objID kernel.ObjectID) (string, kernel.ObjectID, error) {
+	bucketDir, err := fs.bucketDir(bucketID)
+	if err != nil {
+		return "", "", err
+	}
+
+	objID = strings.TrimPrefix(path.Clean("/"+objID), "/")
+	if objID == "" {
+		return "", "", fmt.Errorf("localfs error: empty object path")
+	}
+
+	return filepath.Join(bucketDir, filepath.FromSlash(objID)), objID, nil
+}
+
+func (fs *LocalFS) metaPath(bucketID kernel.BucketID, objID kernel.ObjectID) string {
+	return filepath.Join(fs.config.RootDir, metaDirName, bucketID, filepath.FromSlash(objID)+".json")
+}
+
+// writeObject streams data to temporary file which replaces object file
+// after data is written, so readers never see partially written object.
+func (fs *LocalFS) writeObject(
+	bucketID kernel.BucketID,
+	objID kernel.ObjectID,
+	filePath string,
+	data io.Reader,
+	meta *ObjectMeta,
+) error {
+	if err := os.MkdirAll(filepath.Dir(filePath), 0o755); err != nil {
+		return fmt.Errorf("localfs error: %w", err)
+	}
+
+	tmpFile, err := os.CreateTemp(filepath.Join(fs.config.RootDir, uploadsDirName), "object-*")
+	if err != nil {
+		return fmt.Errorf("localfs error: %w", err)
+	}
+	defer func() { _ = os.Remove(tmpFile.Name()) }()
+
+	hasher := md5.New()
+	_, err = io.Copy(io.MultiWriter(tmpFile, hasher), data)
+	if closeErr := tmpFile.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("localfs error: %w", err)
+	}
+
+	if err = os.Rename(tmpFile.Name(), filePath); err != nil {
+		return fmt.Errorf("localfs error: %w", err)
+	}
+
+	info, err := os.Stat(filePath)
+	if err != nil {
+		return fmt.Errorf("localfs error: %w", err)
+	}
+
+	meta.ETag = hex.EncodeToString(hasher.Sum(nil))
+	meta.ModTime = info.ModTime().UnixNano()
+	return fs.writeMeta(bucketID, objID, meta)
+}
+
+func (fs *LocalFS) writeMeta(bucketID kernel.BucketID, objID kernel.ObjectID, meta *ObjectMeta) error {
+	jsonData, err := json.Marshal(meta)
+	if err != nil {
+		return fmt.Errorf("serialize error: %w", err)
+	}
+
+	metaPath := fs.metaPath(bucketID, objID)
+	if err = os.MkdirAll(filepath.Dir(metaPath), 0o755); err != nil {
+		return fmt.Errorf("localfs error: %w", err)
+	}
+
+	if err = os.WriteFile(metaPath, jsonData, 0o644); err != nil {
+		return fmt.Errorf("localfs error: %w", err)
+	}
+
+	return nil
+}
+
+// readMeta returns stored metadata of object. Objects written by other
+// clients have no metadata, so their content type is guessed by extension.
+// ETag is derived from modification time and size if file has been
+// changed after metadata was stored.
+func (fs *LocalFS) readMeta(bucketID kernel.BucketID, objID kernel.ObjectID, info os.FileInfo) *ObjectMeta {
+	meta := &ObjectMeta{}
+	if data, err := os.ReadFile(fs.metaPath(bucketID, objID)); err == nil {
+		if err = json.Unmarshal(data, meta); err != nil {
+			slog.Warn("localfs: failed to unmarshal object meta", slog.String("err", err.Error()))
+		}
+	}
+
+	if meta.ContentType == "" {
+		meta.ContentType = mime.TypeByExtension(path.Ext(objID))
+	}
+
+	if meta.ETag == "" || meta.ModTime != info.ModTime().UnixNano() {
+		meta.ETag = fmt.Sprintf("%x-%x", info.ModTime().UnixNano(), info.Size())
+	}
+
+	return meta
+}
+
+func (fs *LocalFS) convertObject(bucketID kernel.BucketID, objID kernel.ObjectID, info os.FileInfo) domain.Object {
+	meta := fs.readMeta(bucketID, objID, info)
+
+	obj := domain.Object{
+		Name:         path.Base(objID),
+		Path:         objID,
+		ETag:         meta.ETag,
+		ContentType:  meta.ContentType,
+		LastModified: info.ModTime(),
+		Size:         info.Size(),
+		Metadata:     meta.Metadata,
+		Tags:         meta.Tags,
+	}
+
+	if meta.Expired != 0 {
+		obj.Expired = time.Unix(meta.Expired, 0)
+	}
+
+	if obj.Metadata == nil {
+		obj.Metadata = map[string]string{}
+	}
+
+	return obj
+}
+
+// removeObject removes object file with its metadata and parent
+// directories left empty, because s3 has no empty directories.
+func (fs *LocalFS) removeObject(bucketID kernel.BucketID, objID kernel.ObjectID, filePath string) error {
+	if err := os.Remove(filePath); err != nil {
+		return err
+	}
+
+	metaPath := fs.metaPath(bucketID, objID)
+	_ = os.Remove(metaPath)
+
+	bucketDir, _ := fs.bucketDir(bucketID)
+	removeEmptyDirs(filepath.Dir(filePath), bucketDir)
+	removeEmptyDirs(filepath.Dir(metaPath), filepath.Join(fs.config.RootDir, metaDirName, bucketID))
+	return nil
+}
+
+func removeEmptyDirs(dir, stopDir string) {
+	for dir != stopDir && strings.HasPrefix(dir, stopDir) {
+		if err := os.Remove(dir); err != nil {
+			return
+		}
+		dir = filepath.Dir(dir)
+	}
+}
+
+// normalizePrefix cleans prefix the same way as object paths, so prefix
+// never points outside of bucket directory. Trailing slash is kept.
+func normalizePrefix(prefix string) string {
+	cleaned := strings.TrimPrefix(path.Clean("/"+prefix), "/")
+	if cleaned != "" && strings.HasSuffix(prefix, "/") {
+		cleaned += "/"
+	}
+	return cleaned
+}
+
+// prefixDir returns directory of listed prefix, directories outside of
+// bucket directory are rejected.
+func prefixDir(bucketDir, dirPrefix string) (string, error) {
+	dir := filepath.Join(bucketDir, filepath.FromSlash(dirPrefix))
+	relPath, err := filepath.Rel(bucketDir, dir)
+	if err != nil || relPath == ".." || strings.HasPrefix(relPath, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("localfs error: invalid prefix %s", dirPrefix)
+	}
+
+	return dir, nil
+}
@@ -0,0 +1,180 @@
+package localfs
+
+import (
+	"crypto/md5"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/google/uuid"
+
+	"watchtower/internal/core/cloud/domain"
+	"watchtower/internal/shared/kernel"
+)
+
+// uploadMetaFileName keeps object metadata of multipart upload until
+// upload is completed.
+const uploadMetaFileName = "meta.json"
+
+func (fs *LocalFS) CreateMultipartUpload(
+	_ kernel.Ctx,
+	bucketID kernel.BucketID,
+	params *domain.UploadObjectParams,
+) (string, error) {
+	if _, _, err := fs.objectPath(bucketID, params.FilePath); err != nil {
+		return "", err
+	}
+
+	meta := &ObjectMeta{
+		ContentType: params.ContentType,
+		Metadata:    params.Metadata,
+	}
+
+	if params.Expired != nil {
+		meta.Expired = params.Expired.Unix()
+	}
+
+	jsonData, err := json.Marshal(meta)
+	if err != nil {
+		return "", fmt.Errorf("serialize error: %w", err)
+	}
+
+	uploadID := uuid.NewString()
+	uploadDir := fs.uploadDir(uploadID)
+	if err = os.MkdirAll(uploadDir, 0o755); err != nil {
+		return "", fmt.Errorf("localfs error: %w", err)
+	}
+
+	if err = os.WriteFile(filepath.Join(uploadDir, uploadMetaFileName), jsonData, 0o644); err != nil {
+		return "", fmt.Errorf("localfs error: %w", err)
+	}
+
+	return uploadID, nil
+}
+
+func (fs *LocalFS) UploadPart(
+	_ kernel.Ctx,
+	_ kernel.BucketID,
+	_ kernel.ObjectID,
+	uploadID string,
+	number int,
+	data domain.ObjectData,
+) (domain.MultipartPart, error) {
+	uploadDir, err := fs.existingUploadDir(uploadID)
+	if err != nil {
+		return domain.MultipartPart{}, err
+	}
+
+	checksum := md5.Sum(data.Bytes())
+	partPath := filepath.Join(uploadDir, strconv.Itoa(number))
+	if err = os.WriteFile(partPath, data.Bytes(), 0o644); err != nil {
+		return domain.MultipartPart{}, fmt.Errorf("localfs error: %w", err)
+	}
+
+	return domain.MultipartPart{Number: number, ETag: hex.EncodeToString(checksum[:])}, nil
+}
+
+func (fs *LocalFS) GetUploadedPart(
+	_ kernel.Ctx,
+	_ kernel.BucketID,
+	_ kernel.ObjectID,
+	uploadID string,
+	number int,
+) (*domain.MultipartPart, error) {
+	uploadDir, err := fs.existingUploadDir(uploadID)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(filepath.Join(uploadDir, strconv.Itoa(number)))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("localfs error: %w", err)
+	}
+
+	checksum := md5.Sum(data)
+	return &domain.MultipartPart{Number: number, ETag: hex.EncodeToString(checksum[:])}, nil
+}
+
+func (fs *LocalFS) CompleteMultipartUpload(
+	_ kernel.Ctx,
+	bucketID kernel.BucketID,
+	objID kernel.ObjectID,
+	uploadID string,
+	parts []domain.MultipartPart,
+) error {
+	filePath, objID, err := fs.objectPath(bucketID, objID)
+	if err != nil {
+		return err
+	}
+
+	uploadDir, err := fs.existingUploadDir(uploadID)
+	if err != nil {
+		return err
+	}
+
+	metaData, err := os.ReadFile(filepath.Join(uploadDir, uploadMetaFileName))
+	if err != nil {
+		return fmt.Errorf("localfs error: %w", err)
+	}
+
+	meta := &ObjectMeta{}
+	if err = json.Unmarshal(metaData, meta); err != nil {
+		return fmt.Errorf("deserialize error: %w", err)
+	}
+
+	readers := make([]io.Reader, 0, len(parts))
+	for _, part := range parts {
+		partFile, err := os.Open(filepath.Join(uploadDir, strconv.Itoa(part.Number)))
+		if err != nil {
+			return fmt.Errorf("localfs error: part %d: %w", part.Number, err)
+		}
+		defer func() { _ = partFile.Close() }()
+
+		readers = append(readers, partFile)
+	}
+
+	if err = fs.writeObject(bucketID, objID, filePath, io.MultiReader(readers...), meta); err != nil {
+		return err
+	}
+
+	_ = os.RemoveAll(uploadDir)
+	return nil
+}
+
+func (fs *LocalFS) AbortMultipartUpload(_ kernel.Ctx, _ kernel.BucketID, _ kernel.ObjectID, uploadID string) error {
+	uploadDir, err := fs.existingUploadDir(uploadID)
+	if err != nil {
+		return err
+	}
+
+	if err = os.RemoveAll(uploadDir); err != nil {
+		return fmt.Errorf("localfs error: %w", err)
+	}
+
+	return nil
+}
+
+func (fs *LocalFS) uploadDir(uploadID string) string {
+	return filepath.Join(fs.config.RootDir, uploadsDirName, uploadID)
+}
+
+func (fs *LocalFS) existingUploadDir(uploadID string) (string, error) {
+	if err := uuid.Validate(uploadID); err != nil {
+		return "", fmt.Errorf("localfs error: invalid upload id %s", uploadID)
+	}
+
+	uploadDir := fs.uploadDir(uploadID)
+	if _, err := os.Stat(uploadDir); err != nil {
+		return "", fmt.Errorf("localfs error: %w", err)
+	}
+
+	return uploadDir, nil
+}
@@ -0,0 +1,62 @@
+package localfs
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+
+	"watchtower/internal/core/cloud/domain"
+	"watchtower/internal/shared/kernel"
+)
+
+// SharePath is a route of watchtower http server which serves objects
+// by signed share URLs.
+const SharePath = "/api/v1/share"
+
+func (fs *LocalFS) signShareURL(bucketID kernel.BucketID, objID kernel.ObjectID, expires int64) (*url.URL, error) {
+	shareURL, err := url.Parse(fs.config.PublicURL)
+	if err != nil {
+		return nil, fmt.Errorf("localfs error: invalid public url: %w", err)
+	}
+
+	// JoinPath keeps path relative when public url has no path.
+	if shareURL.Path == "" {
+		shareURL.Path = "/"
+	}
+
+	shareURL = shareURL.JoinPath(SharePath, bucketID, objID)
+	query := url.Values{}
+	query.Set("expires", strconv.FormatInt(expires, 10))
+	query.Set("signature", fs.signature(bucketID, objID, expires))
+	shareURL.RawQuery = query.Encode()
+
+	return shareURL, nil
+}
+
+func (fs *LocalFS) VerifyShareSignature(
+	bucketID kernel.BucketID,
+	objID kernel.ObjectID,
+	expires int64,
+	signature string,
+) error {
+	if time.Now().Unix() > expires {
+		return fmt.Errorf("%w: share url has expired", domain.ErrInvalidShareSignature)
+	}
+
+	expected := fs.signature(bucketID, objID, expires)
+	if !hmac.Equal([]byte(expected), []byte(signature)) {
+		return domain.ErrInvalidShareSignature
+	}
+
+	return nil
+}
+
+func (fs *LocalFS) signature(bucketID kernel.BucketID, objID kernel.ObjectID, expires int64) string {
+	mac := hmac.New(sha256.New, []byte(fs.config.SignKey))
+	_, _ = fmt.Fprintf(mac, "%s\n%s\n%d", bucketID, objID, expires)
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,95 @@
+package localfs
+
+import (
+	"fmt"
+	"maps"
+	"os"
+
+	"watchtower/internal/core/cloud/domain"
+	"watchtower/internal/shared/kernel"
+)
+
+func (fs *LocalFS) GetObjectTags(
+	_ kernel.Ctx,
+	bucketID kernel.BucketID,
+	objID kernel.ObjectID,
+) (map[string]string, error) {
+	meta, _, err := fs.loadMeta(bucketID, objID)
+	if err != nil {
+		return nil, err
+	}
+
+	if meta.Tags == nil {
+		return map[string]string{}, nil
+	}
+
+	return meta.Tags, nil
+}
+
+func (fs *LocalFS) SetObjectTags(
+	_ kernel.Ctx,
+	bucketID kernel.BucketID,
+	objID kernel.ObjectID,
+	tags map[string]string,
+) error {
+	if err := domain.ValidateObjectTags(tags); err != nil {
+		return err
+	}
+
+	meta, objID, err := fs.loadMeta(bucketID, objID)
+	if err != nil {
+		return err
+	}
+
+	meta.Tags = maps.Clone(tags)
+	return fs.writeMeta(bucketID, objID, meta)
+}
+
+func (fs *LocalFS) DeleteObjectTags(_ kernel.Ctx, bucketID kernel.BucketID, objID kernel.ObjectID) error {
+	meta, objID, err := fs.loadMeta(bucketID, objID)
+	if err != nil {
+		return err
+	}
+
+	meta.Tags = nil
+	return fs.writeMeta(bucketID, objID, meta)
+}
+
+// ReplaceObjectMetadata rewrites metadata file only, unlike s3 storages
+// object file and its modification time are kept untouched.
+func (fs *LocalFS) ReplaceObjectMetadata(
+	_ kernel.Ctx,
+	bucketID kernel.BucketID,
+	objID kernel.ObjectID,
+	metadata map[string]string,
+) error {
+	meta, objID, err := fs.loadMeta(bucketID, objID)
+	if err != nil {
+		return err
+	}
+
+	meta.Metadata = maps.Clone(metadata)
+	return fs.writeMeta(bucketID, objID, meta)
+}
+
+// loadMeta returns metadata of existing object with cleaned object path,
+// modification time of metadata is synced with object file.
+func (fs *LocalFS) loadMeta(bucketID kernel.BucketID, objID kernel.ObjectID) (*ObjectMeta, kernel.ObjectID, error) {
+	filePath, objID, err := fs.objectPath(bucketID, objID)
+	if err != nil {
+		return nil, "", err
+	}
+
+	info, err := os.Stat(filePath)
+	if err != nil {
+		return nil, "", fmt.Errorf("localfs error: %w", err)
+	}
+
+	if info.IsDir() {
+		return nil, "", fmt.Errorf("localfs error: %s is a directory", objID)
+	}
+
+	meta := fs.readMeta(bucketID, objID, info)
+	meta.ModTime = info.ModTime().UnixNano()
+	return meta, objID, nil
+}
@@ -0,0 +1,28 @@
+package localfs
+
+import (
+	"fmt"
+
+	"watchtower/internal/core/cloud/domain"
+	"watchtower/internal/shared/kernel"
+)
+
+// Local filesystem keeps only current content of files, so versions
+// of objects are not supported.
+
+func (fs *LocalFS) GetObjectVersions(_ kernel.Ctx, _ kernel.BucketID, _ kernel.ObjectID) ([]domain.ObjectVersion, error) {
+	return nil, fmt.Errorf("%w: local filesystem", domain.ErrVersioningNotSupported)
+}
+
+func (fs *LocalFS) GetObjectVersionData(
+	_ kernel.Ctx,
+	_ kernel.BucketID,
+	_ kernel.ObjectID,
+	_ string,
+) (domain.ObjectData, error) {
+	return nil, fmt.Errorf("%w: local filesystem", domain.ErrVersioningNotSupported)
+}
+
+func (fs *LocalFS) RestoreObjectVersion(_ kernel.Ctx, _ kernel.BucketID, _ *domain.RestoreObjectParams) (string, error) {
+	return "", fmt.Errorf("%w: local filesystem", domain.ErrVersioningNotSupported)
+}
@@ -0,0 +1,97 @@
+package notify
+
+import (
+	"fmt"
+	"log/slog"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+
+	"watchtower/internal/core/cloud/domain"
+	"watchtower/internal/shared/kernel"
+)
+
+const ConsumerName = "watchtower-events-consumer"
+
+// AMQPSource consumes notifications published by MinIO AMQP target
+// to exchange which is bound to configured queue.
+type AMQPSource struct {
+	config Config
+}
+
+func NewAMQPSource(config Config) domain.IObjectEventSource {
+	return &AMQPSource{config: config}
+}
+
+func (as *AMQPSource) Consume(ctx kernel.Ctx, handler domain.ObjectEventHandler) error {
+	rmqConfig := amqp.Config{
+		Properties: amqp.NewConnectionProperties(),
+		Heartbeat:  10 * time.Second,
+	}
+	rmqConfig.Properties.SetClientConnectionName(ConsumerName)
+
+	conn, err := amqp.DialConfig(as.config.Address, rmqConfig)
+	if err != nil {
+		return fmt.Errorf("failed while connecting to rmq: %w", err)
+	}
+	defer func() { _ = conn.Close() }()
+
+	channel, err := conn.Channel()
+	if err != nil {
+		return fmt.Errorf("failed to create rmq channel: %w", err)
+	}
+
+	deliveries, err := channel.Consume(
+		as.config.Queue, // name
+		ConsumerName,    // consumerTag,
+		false,           // autoAck
+		false,           // exclusive
+		false,           // noLocal
+		false,           // noWait
+		nil,             // arguments
+	)
+	if err != nil {
+		return fmt.Errorf("rmq: consume error: %w", err)
+	}
+
+	slog.Info("launching s3 events consumer", slog.String("queue", as.config.Queue))
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+
+		case delivery, ok := <-deliveries:
+			if !ok {
+				return fmt.Errorf("rmq: deliveries channel has been closed")
+			}
+
+			as.handleDelivery(ctx, &delivery, handler)
+		}
+	}
+}
+
+// handleDelivery acknowledges notification when all its events have been
+// handled, malformed notifications are dropped and failed ones requeued.
+func (as *AMQPSource) handleDelivery(ctx kernel.Ctx, delivery *amqp.Delivery, handler domain.ObjectEventHandler) {
+	events, err := ParseEvents(delivery.Body)
+	if err != nil {
+		slog.Error("s3 events: failed while deserialize msg", slog.String("err", err.Error()))
+		_ = delivery.Nack(false, false)
+		return
+	}
+
+	for index := range events {
+		if err = handler(ctx, &events[index]); err != nil {
+			slog.Error("s3 events: failed to handle event",
+				slog.String("bucket", events[index].BucketID),
+				slog.String("file-path", events[index].ObjectID),
+				slog.String("err", err.Error()),
+			)
+			_ = delivery.Nack(false, true)
+			return
+		}
+	}
+
+	_ = delivery.Ack(false)
+}
@@ -0,0 +1,6 @@
+package notify
+
+type Config struct {
+	Address string `mapstructure:"address"`
+	Queue   string `mapstructure:"queue"`
+}
@@ -0,0 +1,32 @@
+package notify
+
+// Notification is S3 event notification message sent by MinIO
+// webhook and AMQP targets in AWS compatible format.
+type Notification struct {
+	EventName string        `json:"EventName"`
+	Key       string        `json:"Key"`
+	Records   []EventRecord `json:"Records"`
+}
+
+type EventRecord struct {
+	EventName string  `json:"eventName"`
+	EventTime string  `json:"eventTime"`
+	S3        S3Event `json:"s3"`
+}
+
+type S3Event struct {
+	Bucket S3Bucket `json:"bucket"`
+	Object S3Object `json:"object"`
+}
+
+type S3Bucket struct {
+	Name string `json:"name"`
+}
+
+type S3Object struct {
+	Key          string            `json:"key"`
+	Size         int64             `json:"size"`
+	ETag         string            `json:"eTag"`
+	ContentType  string            `json:"contentType"`
+	UserMetadata map[string]string `json:"userMetadata"`
+}
@@ -0,0 +1,77 @@
+package notify
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"watchtower/internal/core/cloud/domain"
+)
+
+const (
+	objectCreatedPrefix = "s3:ObjectCreated:"
+	objectRemovedPrefix = "s3:ObjectRemoved:"
+
+	userMetadataPrefix = "x-amz-meta-"
+)
+
+// ParseEvents converts notification message to object events. Records
+// of other event types like s3:ObjectAccessed are skipped.
+func ParseEvents(data []byte) ([]domain.ObjectEvent, error) {
+	var notification Notification
+	if err := json.Unmarshal(data, &notification); err != nil {
+		return nil, fmt.Errorf("deserialize error: %w", err)
+	}
+
+	events := make([]domain.ObjectEvent, 0, len(notification.Records))
+	for _, record := range notification.Records {
+		eventName := record.EventName
+		if !strings.HasPrefix(eventName, "s3:") {
+			// AWS sends event names without s3 prefix.
+			eventName = "s3:" + eventName
+		}
+
+		var eventType domain.EventType
+		switch {
+		case strings.HasPrefix(eventName, objectCreatedPrefix):
+			eventType = domain.ObjectCreated
+		case strings.HasPrefix(eventName, objectRemovedPrefix):
+			eventType = domain.ObjectRemoved
+		default:
+			continue
+		}
+
+		// Object keys are url-encoded within notification records.
+		objID, err := url.QueryUnescape(record.S3.Object.Key)
+		if err != nil {
+			return nil, fmt.Errorf("invalid object key %s: %w", record.S3.Object.Key, err)
+		}
+
+		events = append(events, domain.ObjectEvent{
+			Type:     eventType,
+			Name:     eventName,
+			BucketID: record.S3.Bucket.Name,
+			ObjectID: objID,
+			Size:     record.S3.Object.Size,
+			ETag:     record.S3.Object.ETag,
+			Metadata: convertUserMetadata(record.S3.Object.UserMetadata),
+		})
+	}
+
+	return events, nil
+}
+
+// convertUserMetadata returns user metadata with lower-cased keys without
+// x-amz-meta- prefix like metadata of object info, standard headers
+// like content-type are skipped.
+func convertUserMetadata(userMetadata map[string]string) map[string]string {
+	metadata := make(map[string]string, len(userMetadata))
+	for key, value := range userMetadata {
+		metaKey, found := strings.CutPrefix(strings.ToLower(key), userMetadataPrefix)
+		if found {
+			metadata[metaKey] = value
+		}
+	}
+	return metadata
+}
@@ -0,0 +1,206 @@
+package s3
+
+import (
+	"fmt"
+	"log/slog"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/lifecycle"
+
+	"watchtower/internal/core/cloud/domain"
+	"watchtower/internal/shared/kernel"
+)
+
+const (
+	noLifecycleErrCode  = "NoSuchLifecycleConfiguration"
+	noObjectLockErrCode = "ObjectLockConfigurationNotFoundError"
+
+	objectLockEnabled = "Enabled"
+	daysPerYear       = 365
+)
+
+// CreateBucketWithConfig creates bucket with object locking if it is
+// requested, because it can't be enabled later. Bucket is removed if
+// its configuration has not been applied.
+func (s *S3Client) CreateBucketWithConfig(
+	ctx kernel.Ctx,
+	bucketID kernel.BucketID,
+	config *domain.BucketConfig,
+) error {
+	if err := checkSupportedConfig(config); err != nil {
+		return err
+	}
+
+	opts := minio.MakeBucketOptions{
+		ObjectLocking: config.ObjectLock != nil && config.ObjectLock.Enabled,
+	}
+
+	if err := s.mc.MakeBucket(ctx, bucketID, opts); err != nil {
+		return fmt.Errorf("s3 error: %w", err)
+	}
+
+	if err := s.SetBucketConfig(ctx, bucketID, config); err != nil {
+		if rmErr := s.mc.RemoveBucket(ctx, bucketID); rmErr != nil {
+			slog.Warn("s3: failed to remove misconfigured bucket",
+				slog.String("bucket", bucketID),
+				slog.String("err", rmErr.Error()),
+			)
+		}
+		return err
+	}
+
+	return nil
+}
+
+// GetBucketConfig returns bucket configuration, quota is managed by
+// admin API of MinIO which is not used by watchtower, so it is nil.
+func (s *S3Client) GetBucketConfig(ctx kernel.Ctx, bucketID kernel.BucketID) (domain.BucketConfig, error) {
+	var config domain.BucketConfig
+
+	versioning, err := s.mc.GetBucketVersioning(ctx, bucketID)
+	if err != nil {
+		return config, fmt.Errorf("s3 error: %w", err)
+	}
+
+	enabled := versioning.Enabled()
+	config.Versioning = &enabled
+
+	lifecycleConfig, err := s.mc.GetBucketLifecycle(ctx, bucketID)
+	if err != nil && minio.ToErrorResponse(err).Code != noLifecycleErrCode {
+		return config, fmt.Errorf("s3 error: %w", err)
+	}
+
+	config.Lifecycle = convertLifecycleRules(lifecycleConfig)
+
+	lockStatus, mode, validity, unit, err := s.mc.GetObjectLockConfig(ctx, bucketID)
+	if err != nil && minio.ToErrorResponse(err).Code != noObjectLockErrCode {
+		return config, fmt.Errorf("s3 error: %w", err)
+	}
+
+	config.ObjectLock = &domain.ObjectLockConfig{Enabled: lockStatus == objectLockEnabled}
+	if mode != nil && validity != nil {
+		config.ObjectLock.Mode = string(*mode)
+		config.ObjectLock.RetentionDays = int(*validity)
+		if unit != nil && *unit == minio.Years {
+			config.ObjectLock.RetentionDays *= daysPerYear
+		}
+	}
+
+	return config, nil
+}
+
+// SetBucketConfig applies versioning before object lock and lifecycle,
+// because both of them depend on versioning of bucket.
+func (s *S3Client) SetBucketConfig(ctx kernel.Ctx, bucketID kernel.BucketID, config *domain.BucketConfig) error {
+	if err := checkSupportedConfig(config); err != nil {
+		return err
+	}
+
+	if config.Versioning != nil {
+		var err error
+		if *config.Versioning {
+			err = s.mc.EnableVersioning(ctx, bucketID)
+		} else {
+			err = s.mc.SuspendVersioning(ctx, bucketID)
+		}
+
+		if err != nil {
+			return fmt.Errorf("s3 error: %w", err)
+		}
+	}
+
+	if config.ObjectLock != nil {
+		if err := s.setObjectLock(ctx, bucketID, config.ObjectLock); err != nil {
+			return err
+		}
+	}
+
+	if config.Lifecycle != nil {
+		lifecycleConfig := lifecycle.NewConfiguration()
+		for _, rule := range config.Lifecycle {
+			lifecycleConfig.Rules = append(lifecycleConfig.Rules, lifecycle.Rule{
+				ID:         rule.ID,
+				Status:     "Enabled",
+				RuleFilter: lifecycle.Filter{Prefix: rule.Prefix},
+				Expiration: lifecycle.Expiration{Days: lifecycle.ExpirationDays(rule.ExpirationDays)},
+				NoncurrentVersionExpiration: lifecycle.NoncurrentVersionExpiration{
+					NoncurrentDays: lifecycle.ExpirationDays(rule.NoncurrentExpirationDays),
+				},
+			})
+		}
+
+		// Empty configuration removes lifecycle rules of bucket.
+		if err := s.mc.SetBucketLifecycle(ctx, bucketID, lifecycleConfig); err != nil {
+			return fmt.Errorf("s3 error: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (s *S3Client) setObjectLock(ctx kernel.Ctx, bucketID kernel.BucketID, lock *domain.ObjectLockConfig) error {
+	lockStatus, _, _, _, err := s.mc.GetObjectLockConfig(ctx, bucketID)
+	if err != nil && minio.ToErrorResponse(err).Code != noObjectLockErrCode {
+		return fmt.Errorf("s3 error: %w", err)
+	}
+
+	isEnabled := lockStatus == objectLockEnabled
+	switch {
+	case lock.Enabled && !isEnabled:
+		return fmt.Errorf("%w: object lock may be enabled on bucket creation only", domain.ErrInvalidBucketConfig)
+	case !lock.Enabled && isEnabled:
+		return fmt.Errorf("%w: object lock can't be disabled", domain.ErrInvalidBucketConfig)
+	case !isEnabled:
+		return nil
+	}
+
+	// All nil arguments remove default retention of bucket.
+	var mode *minio.RetentionMode
+	var validity *uint
+	var unit *minio.ValidityUnit
+	if lock.Mode != "" {
+		retentionMode := minio.RetentionMode(lock.Mode)
+		retentionDays := uint(lock.RetentionDays)
+		daysUnit := minio.Days
+		mode, validity, unit = &retentionMode, &retentionDays, &daysUnit
+	}
+
+	if err = s.mc.SetObjectLockConfig(ctx, bucketID, mode, validity, unit); err != nil {
+		return fmt.Errorf("s3 error: %w", err)
+	}
+
+	return nil
+}
+
+func checkSupportedConfig(config *domain.BucketConfig) error {
+	if config.QuotaBytes != nil {
+		return fmt.Errorf("%w: bucket quota", domain.ErrBucketConfigNotSupported)
+	}
+	return nil
+}
+
+func convertLifecycleRules(config *lifecycle.Configuration) []domain.LifecycleRule {
+	rules := make([]domain.LifecycleRule, 0)
+	if config == nil {
+		return rules
+	}
+
+	for _, rule := range config.Rules {
+		prefix := rule.RuleFilter.Prefix
+		if prefix == "" {
+			prefix = rule.RuleFilter.And.Prefix
+		}
+		if prefix == "" {
+			prefix = rule.Prefix
+		}
+
+		rules = append(rules, domain.LifecycleRule{
+			ID:                       rule.ID,
+			Prefix:                   prefix,
+			ExpirationDays:           int(rule.Expiration.Days),
+			NoncurrentExpirationDays: int(rule.NoncurrentVersionExpiration.NoncurrentDays),
+		})
+	}
+
+	return rules
+}
@@ -2,18 +2,29 @@ package s3
 
 import (
 	"bytes"
+	"cmp"
+	"context"
+	"errors"
 	"fmt"
 	"log/slog"
+	"maps"
+	"net/http"
 	"net/url"
 	"path"
+	"strings"
+	"time"
 
 	"github.com/minio/minio-go/v7"
 	"github.com/minio/minio-go/v7/pkg/credentials"
+	"github.com/minio/minio-go/v7/pkg/tags"
 
 	"watchtower/internal/core/cloud/domain"
 	"watchtower/internal/shared/kernel"
 )
 
+// maxListKeys is the max count of keys returned by single list request.
+const maxListKeys = 1000
+
 type S3Client struct {
 	mc *minio.Client
 }
@@ -102,11 +113,23 @@ func (s *S3Client) GetObjectInfo(
 		Name:         path.Base(filePath),
 		Path:         path.Clean(filePath),
 		Checksum:     stats.ChecksumSHA256,
+		ETag:         stats.ETag,
 		ContentType:  stats.ContentType,
 		Expired:      stats.Expires,
 		LastModified: stats.LastModified,
 		Size:         stats.Size,
 		IsDirectory:  len(stats.ETag) == 0,
+		Metadata:     convertUserMetadata(stats.UserMetadata),
+		VersionID:    stats.VersionID,
+	}
+
+	// Tags are not returned by stat request, so they are requested
+	// separately for tagged objects only.
+	if stats.UserTagCount > 0 {
+		objectAttrs.Tags, err = s.GetObjectTags(ctx, bucketID, filePath)
+		if err != nil {
+			return objectAttrs, err
+		}
 	}
 
 	return objectAttrs, nil
@@ -140,11 +163,19 @@ func (s *S3Client) StoreObject(
 	bucketID kernel.BucketID,
 	params *domain.UploadObjectParams,
 ) (kernel.ObjectID, error) {
-	opts := minio.PutObjectOptions{}
+	opts := minio.PutObjectOptions{
+		ContentType:  params.ContentType,
+		UserMetadata: params.Metadata,
+	}
+
 	if params.Expired != nil {
 		opts.Expires = *params.Expired
 	}
 
+	if opts.ContentType == "" {
+		opts.ContentType = http.DetectContentType(params.FileData.Bytes())
+	}
+
 	dataSize := int64(params.FileData.Len())
 	filePath := path.Clean(params.FilePath)
 	_, err := s.mc.PutObject(ctx, bucketID, filePath, params.FileData, dataSize, opts)
@@ -158,15 +189,39 @@ func (s *S3Client) StoreObject(
 func (s *S3Client) CopyObject(ctx kernel.Ctx, bucketID kernel.BucketID, params *domain.CopyObjectParams) error {
 	srcPath := path.Clean(params.SourcePath)
 	dstPath := path.Clean(params.DestinationPath)
+	dstBucketID := cmp.Or(params.DestinationBucket, bucketID)
 
 	srcOpts := minio.CopySrcOptions{Bucket: bucketID, Object: srcPath}
-	dstOpts := minio.CopyDestOptions{Bucket: bucketID, Object: dstPath}
+	dstOpts := minio.CopyDestOptions{Bucket: dstBucketID, Object: dstPath}
+	if params.Metadata != nil {
+		// Replacing metadata directive drops content type of source object,
+		// so it must be passed explicitly along with user metadata.
+		srcInfo, err := s.mc.StatObject(ctx, bucketID, srcPath, minio.StatObjectOptions{})
+		if err != nil {
+			return fmt.Errorf("s3 error: %w", err)
+		}
+
+		userMetadata := make(map[string]string, len(params.Metadata)+1)
+		maps.Copy(userMetadata, params.Metadata)
+		userMetadata["Content-Type"] = srcInfo.ContentType
+
+		dstOpts.UserMetadata = userMetadata
+		dstOpts.ReplaceMetadata = true
+	}
+
 	_, err := s.mc.CopyObject(ctx, dstOpts, srcOpts)
 	if err != nil {
 		err = fmt.Errorf("s3 error: %w", err)
 		return err
 	}
 
+	if params.WithRemoving && (dstBucketID != bucketID || dstPath != srcPath) {
+		err = s.mc.RemoveObject(ctx, bucketID, srcPath, minio.RemoveObjectOptions{})
+		if err != nil {
+			return fmt.Errorf("s3 error: failed to remove source object: %w", err)
+		}
+	}
+
 	return nil
 }
 
@@ -206,6 +261,70 @@ func (s *S3Client) DeleteObject(ctx kernel.Ctx, bucketID kernel.BucketID, objID
 	return nil
 }
 
+func (s *S3Client) GetObjectTags(
+	ctx kernel.Ctx,
+	bucketID kernel.BucketID,
+	objID kernel.ObjectID,
+) (map[string]string, error) {
+	objTags, err := s.mc.GetObjectTagging(ctx, bucketID, path.Clean(objID), minio.GetObjectTaggingOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("s3 error: %w", err)
+	}
+
+	return objTags.ToMap(), nil
+}
+
+func (s *S3Client) SetObjectTags(
+	ctx kernel.Ctx,
+	bucketID kernel.BucketID,
+	objID kernel.ObjectID,
+	tagsMap map[string]string,
+) error {
+	objTags, err := tags.NewTags(tagsMap, true)
+	if err != nil {
+		return fmt.Errorf("%w: %w", domain.ErrInvalidObjectTags, err)
+	}
+
+	opts := minio.PutObjectTaggingOptions{}
+	err = s.mc.PutObjectTagging(ctx, bucketID, path.Clean(objID), objTags, opts)
+	if err != nil {
+		return fmt.Errorf("s3 error: %w", err)
+	}
+
+	return nil
+}
+
+func (s *S3Client) DeleteObjectTags(ctx kernel.Ctx, bucketID kernel.BucketID, objID kernel.ObjectID) error {
+	opts := minio.RemoveObjectTaggingOptions{}
+	err := s.mc.RemoveObjectTagging(ctx, bucketID, path.Clean(objID), opts)
+	if err != nil {
+		return fmt.Errorf("s3 error: %w", err)
+	}
+
+	return nil
+}
+
+// ReplaceObjectMetadata copies object to itself with replacing metadata
+// directive, tags are kept by default tagging directive of copying.
+func (s *S3Client) ReplaceObjectMetadata(
+	ctx kernel.Ctx,
+	bucketID kernel.BucketID,
+	objID kernel.ObjectID,
+	metadata map[string]string,
+) error {
+	if metadata == nil {
+		metadata = map[string]string{}
+	}
+
+	params := &domain.CopyObjectParams{
+		SourcePath:      objID,
+		DestinationPath: objID,
+		Metadata:        metadata,
+	}
+
+	return s.CopyObject(ctx, bucketID, params)
+}
+
 func (s *S3Client) GetBucketObjects(
 	ctx kernel.Ctx,
 	bucketID kernel.BucketID,
@@ -232,21 +351,134 @@ func (s *S3Client) GetBucketObjects(
 			continue
 		}
 
-		dirObjects = append(dirObjects, domain.Object{
-			Name:         path.Base(obj.Key),
-			Path:         obj.Key,
-			Checksum:     obj.ChecksumSHA256,
-			ContentType:  obj.ContentType,
-			LastModified: obj.LastModified,
-			Expired:      obj.Expiration,
-			Size:         obj.Size,
-			IsDirectory:  len(obj.ETag) == 0,
-		})
+		dirObjects = append(dirObjects, convertListedObject(obj))
 	}
 
 	return dirObjects, nil
 }
 
+// GetBucketObjectsPage lists objects starting after continuation token and
+// stops listing as soon as page is filled. Skipped objects are not counted
+// by offset and limit.
+func (s *S3Client) GetBucketObjectsPage(
+	ctx kernel.Ctx,
+	bucketID kernel.BucketID,
+	params *domain.GetObjectsParams,
+) (domain.ObjectsPage, error) {
+	var page domain.ObjectsPage
+	if s.mc.IsOffline() {
+		err := fmt.Errorf("s3 connection error")
+		return page, err
+	}
+
+	offset := domain.PageOffset(params)
+	limit := int(max(params.Limit, 0))
+	opts := minio.ListObjectsOptions{
+		Prefix:     params.PrefixPath,
+		Recursive:  false,
+		UseV1:      true,
+		StartAfter: params.ContinuationToken,
+	}
+
+	if limit > 0 {
+		opts.MaxKeys = min(offset+limit+1, maxListKeys)
+	}
+
+	// Canceling context stops listing of next pages by minio client.
+	listCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	page.Objects = make([]domain.Object, 0, limit)
+	for obj := range s.mc.ListObjects(listCtx, bucketID, opts) {
+		if obj.Err != nil {
+			return page, fmt.Errorf("s3 error: %w", obj.Err)
+		}
+
+		object := convertListedObject(obj)
+		if domain.IsSkippedObject(object, params) {
+			continue
+		}
+
+		if offset > 0 {
+			offset--
+			continue
+		}
+
+		if limit > 0 && len(page.Objects) == limit {
+			page.NextToken = domain.StartAfterObject(page.Objects[limit-1])
+			break
+		}
+
+		page.Objects = append(page.Objects, object)
+	}
+
+	return page, nil
+}
+
+// WalkBucketObjects walks objects by recursive listing. Metadata is listed
+// by MinIO only, so objects listed by other providers have nil Metadata.
+func (s *S3Client) WalkBucketObjects(
+	ctx kernel.Ctx,
+	bucketID kernel.BucketID,
+	prefix string,
+	walkFn domain.WalkObjectFunc,
+) error {
+	if s.mc.IsOffline() {
+		err := fmt.Errorf("s3 connection error")
+		return err
+	}
+
+	opts := minio.ListObjectsOptions{
+		Prefix:       prefix,
+		Recursive:    true,
+		WithMetadata: true,
+	}
+
+	// Canceling context stops listing of next pages by minio client.
+	listCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	for obj := range s.mc.ListObjects(listCtx, bucketID, opts) {
+		if obj.Err != nil {
+			return fmt.Errorf("s3 error: %w", obj.Err)
+		}
+
+		// Keys with trailing slash are directory markers.
+		if strings.HasSuffix(obj.Key, "/") {
+			continue
+		}
+
+		object := convertListedObject(obj)
+		object.IsDirectory = false
+		if obj.UserMetadata != nil {
+			object.Metadata, object.ContentType = convertListedMetadata(obj.UserMetadata)
+		}
+
+		if err := walkFn(object); err != nil {
+			if errors.Is(err, domain.ErrStopWalk) {
+				return nil
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+func convertListedObject(obj minio.ObjectInfo) domain.Object {
+	return domain.Object{
+		Name:         path.Base(obj.Key),
+		Path:         obj.Key,
+		Checksum:     obj.ChecksumSHA256,
+		ETag:         obj.ETag,
+		ContentType:  obj.ContentType,
+		LastModified: obj.LastModified,
+		Expired:      obj.Expiration,
+		Size:         obj.Size,
+		IsDirectory:  len(obj.ETag) == 0,
+	}
+}
+
 func (s *S3Client) GenShareURL(
 	ctx kernel.Ctx,
 	bucketID kernel.BucketID,
@@ -261,3 +493,201 @@ func (s *S3Client) GenShareURL(
 
 	return urlPath, nil
 }
+
+// GenUploadURL returns presigned PUT URL with signed metadata headers or
+// presigned POST policy which restricts content type and size of object.
+func (s *S3Client) GenUploadURL(
+	ctx kernel.Ctx,
+	bucketID kernel.BucketID,
+	params *domain.PresignUploadParams,
+) (*domain.PresignedUpload, error) {
+	filePath := path.Clean(params.FilePath)
+	presigned := &domain.PresignedUpload{
+		Method:    params.Method,
+		Headers:   make(map[string]string),
+		FormData:  make(map[string]string),
+		ExpiresAt: time.Now().Add(params.Expired),
+	}
+
+	switch params.Method {
+	case domain.PresignPutMethod:
+		headers := http.Header{}
+		if params.ContentType != "" {
+			headers.Set("Content-Type", params.ContentType)
+		}
+		for key, value := range params.Metadata {
+			headers.Set("X-Amz-Meta-"+key, value)
+		}
+
+		urlPath, err := s.mc.PresignHeader(ctx, http.MethodPut, bucketID, filePath, params.Expired, nil, headers)
+		if err != nil {
+			return nil, fmt.Errorf("s3 error: %w", err)
+		}
+
+		for key := range headers {
+			presigned.Headers[key] = headers.Get(key)
+		}
+		presigned.URL = urlPath
+
+	case domain.PresignPostMethod:
+		policy := minio.NewPostPolicy()
+		_ = policy.SetBucket(bucketID)
+		_ = policy.SetKey(filePath)
+		if err := policy.SetExpires(presigned.ExpiresAt.UTC()); err != nil {
+			return nil, fmt.Errorf("s3 error: %w", err)
+		}
+		if params.ContentType != "" {
+			_ = policy.SetContentType(params.ContentType)
+		}
+		if params.Size > 0 {
+			_ = policy.SetContentLengthRange(params.Size, params.Size)
+		}
+		for key, value := range params.Metadata {
+			if err := policy.SetUserMetadata(key, value); err != nil {
+				return nil, fmt.Errorf("s3 error: %w", err)
+			}
+		}
+
+		urlPath, formData, err := s.mc.PresignedPostPolicy(ctx, policy)
+		if err != nil {
+			return nil, fmt.Errorf("s3 error: %w", err)
+		}
+
+		presigned.URL = urlPath
+		presigned.FormData = formData
+
+	default:
+		return nil, fmt.Errorf("%w: method %s", domain.ErrPresignNotSupported, params.Method)
+	}
+
+	return presigned, nil
+}
+
+func (s *S3Client) CreateMultipartUpload(
+	ctx kernel.Ctx,
+	bucketID kernel.BucketID,
+	params *domain.UploadObjectParams,
+) (string, error) {
+	opts := minio.PutObjectOptions{
+		ContentType:  params.ContentType,
+		UserMetadata: params.Metadata,
+	}
+
+	if params.Expired != nil {
+		opts.Expires = *params.Expired
+	}
+
+	core := minio.Core{Client: s.mc}
+	uploadID, err := core.NewMultipartUpload(ctx, bucketID, path.Clean(params.FilePath), opts)
+	if err != nil {
+		return "", fmt.Errorf("s3 error: %w", err)
+	}
+
+	return uploadID, nil
+}
+
+func (s *S3Client) UploadPart(
+	ctx kernel.Ctx,
+	bucketID kernel.BucketID,
+	objID kernel.ObjectID,
+	uploadID string,
+	number int,
+	data domain.ObjectData,
+) (domain.MultipartPart, error) {
+	core := minio.Core{Client: s.mc}
+	dataSize := int64(data.Len())
+	opts := minio.PutObjectPartOptions{}
+	part, err := core.PutObjectPart(ctx, bucketID, path.Clean(objID), uploadID, number, data, dataSize, opts)
+	if err != nil {
+		return domain.MultipartPart{}, fmt.Errorf("s3 error: %w", err)
+	}
+
+	return domain.MultipartPart{Number: part.PartNumber, ETag: part.ETag}, nil
+}
+
+func (s *S3Client) GetUploadedPart(
+	ctx kernel.Ctx,
+	bucketID kernel.BucketID,
+	objID kernel.ObjectID,
+	uploadID string,
+	number int,
+) (*domain.MultipartPart, error) {
+	core := minio.Core{Client: s.mc}
+	result, err := core.ListObjectParts(ctx, bucketID, path.Clean(objID), uploadID, number-1, 1)
+	if err != nil {
+		return nil, fmt.Errorf("s3 error: %w", err)
+	}
+
+	for _, part := range result.ObjectParts {
+		if part.PartNumber == number {
+			return &domain.MultipartPart{Number: part.PartNumber, ETag: strings.Trim(part.ETag, `"`)}, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (s *S3Client) CompleteMultipartUpload(
+	ctx kernel.Ctx,
+	bucketID kernel.BucketID,
+	objID kernel.ObjectID,
+	uploadID string,
+	parts []domain.MultipartPart,
+) error {
+	completeParts := make([]minio.CompletePart, len(parts))
+	for index, part := range parts {
+		completeParts[index] = minio.CompletePart{PartNumber: part.Number, ETag: part.ETag}
+	}
+
+	core := minio.Core{Client: s.mc}
+	opts := minio.PutObjectOptions{}
+	_, err := core.CompleteMultipartUpload(ctx, bucketID, path.Clean(objID), uploadID, completeParts, opts)
+	if err != nil {
+		return fmt.Errorf("s3 error: %w", err)
+	}
+
+	return nil
+}
+
+func (s *S3Client) AbortMultipartUpload(
+	ctx kernel.Ctx,
+	bucketID kernel.BucketID,
+	objID kernel.ObjectID,
+	uploadID string,
+) error {
+	core := minio.Core{Client: s.mc}
+	if err := core.AbortMultipartUpload(ctx, bucketID, path.Clean(objID), uploadID); err != nil {
+		return fmt.Errorf("s3 error: %w", err)
+	}
+
+	return nil
+}
+
+// convertUserMetadata returns user metadata with lower-cased keys because
+// s3 returns them canonicalized like http headers.
+func convertUserMetadata(userMetadata minio.StringMap) map[string]string {
+	metadata := make(map[string]string, len(userMetadata))
+	for key, value := range userMetadata {
+		metadata[strings.ToLower(key)] = value
+	}
+	return metadata
+}
+
+// convertListedMetadata returns user metadata and content type of listed
+// object, listed user metadata keys keep X-Amz-Meta- prefix.
+func convertListedMetadata(userMetadata minio.StringMap) (map[string]string, string) {
+	var contentType string
+	metadata := make(map[string]string, len(userMetadata))
+	for key, value := range userMetadata {
+		key = strings.ToLower(key)
+		if key == "content-type" {
+			contentType = value
+			continue
+		}
+
+		if name, ok := strings.CutPrefix(key, "x-amz-meta-"); ok {
+			metadata[name] = value
+		}
+	}
+	return metadata, contentType
+}
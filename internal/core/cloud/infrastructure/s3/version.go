@@ -0,0 +1,127 @@
+package s3
+
+import (
+	"bytes"
+	"fmt"
+	"maps"
+	"path"
+
+	"github.com/minio/minio-go/v7"
+
+	"watchtower/internal/core/cloud/domain"
+	"watchtower/internal/shared/kernel"
+)
+
+const (
+	noSuchKeyErrCode     = "NoSuchKey"
+	noSuchVersionErrCode = "NoSuchVersion"
+	// deleteMarkerErrCode is returned for requests of delete marker version.
+	deleteMarkerErrCode = "MethodNotAllowed"
+)
+
+// GetObjectVersions lists versions of object with exact key, because
+// listing by prefix also returns versions of objects with longer keys.
+func (s *S3Client) GetObjectVersions(
+	ctx kernel.Ctx,
+	bucketID kernel.BucketID,
+	objID kernel.ObjectID,
+) ([]domain.ObjectVersion, error) {
+	filePath := path.Clean(objID)
+	opts := minio.ListObjectsOptions{
+		Prefix:       filePath,
+		WithVersions: true,
+	}
+
+	versions := make([]domain.ObjectVersion, 0)
+	for obj := range s.mc.ListObjects(ctx, bucketID, opts) {
+		if obj.Err != nil {
+			return nil, fmt.Errorf("s3 error: %w", obj.Err)
+		}
+
+		if obj.Key != filePath {
+			continue
+		}
+
+		versions = append(versions, domain.ObjectVersion{
+			VersionID:      obj.VersionID,
+			ETag:           obj.ETag,
+			LastModified:   obj.LastModified,
+			Size:           obj.Size,
+			IsLatest:       obj.IsLatest,
+			IsDeleteMarker: obj.IsDeleteMarker,
+		})
+	}
+
+	return versions, nil
+}
+
+func (s *S3Client) GetObjectVersionData(
+	ctx kernel.Ctx,
+	bucketID kernel.BucketID,
+	objID kernel.ObjectID,
+	versionID string,
+) (domain.ObjectData, error) {
+	opts := minio.GetObjectOptions{VersionID: versionID}
+	obj, err := s.mc.GetObject(ctx, bucketID, path.Clean(objID), opts)
+	if err != nil {
+		return nil, convertVersionError(err)
+	}
+
+	// Object is requested lazily, so errors of missing version
+	// are returned while reading.
+	objBody := bytes.Buffer{}
+	_, err = objBody.ReadFrom(obj)
+	if err != nil {
+		return nil, convertVersionError(err)
+	}
+
+	return &objBody, nil
+}
+
+// RestoreObjectVersion copies version over the object with replacing metadata
+// directive, so passed metadata is merged into metadata of restored version.
+func (s *S3Client) RestoreObjectVersion(
+	ctx kernel.Ctx,
+	bucketID kernel.BucketID,
+	params *domain.RestoreObjectParams,
+) (string, error) {
+	filePath := path.Clean(params.FilePath)
+	statOpts := minio.StatObjectOptions{VersionID: params.VersionID}
+	srcInfo, err := s.mc.StatObject(ctx, bucketID, filePath, statOpts)
+	if err != nil {
+		return "", convertVersionError(err)
+	}
+
+	// Keys of version metadata are lowercased to be overwritten by passed ones.
+	userMetadata := convertUserMetadata(srcInfo.UserMetadata)
+	maps.Copy(userMetadata, params.Metadata)
+	userMetadata["Content-Type"] = srcInfo.ContentType
+
+	srcOpts := minio.CopySrcOptions{
+		Bucket:    bucketID,
+		Object:    filePath,
+		VersionID: params.VersionID,
+	}
+	dstOpts := minio.CopyDestOptions{
+		Bucket:          bucketID,
+		Object:          filePath,
+		UserMetadata:    userMetadata,
+		ReplaceMetadata: true,
+	}
+
+	uploadInfo, err := s.mc.CopyObject(ctx, dstOpts, srcOpts)
+	if err != nil {
+		return "", convertVersionError(err)
+	}
+
+	return uploadInfo.VersionID, nil
+}
+
+func convertVersionError(err error) error {
+	switch minio.ToErrorResponse(err).Code {
+	case noSuchKeyErrCode, noSuchVersionErrCode, deleteMarkerErrCode:
+		return fmt.Errorf("s3 error: %w: %w", domain.ErrVersionNotFound, err)
+	default:
+		return fmt.Errorf("s3 error: %w", err)
+	}
+}
@@ -0,0 +1,261 @@
+package archive
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+)
+
+// Format is a kind of archive which may be expanded.
+type Format int
+
+const (
+	// None means that data is not a supported archive.
+	None Format = iota
+	Zip
+	Tar
+	TarGzip
+)
+
+var (
+	ErrTooManyEntries  = errors.New("archive entries limit exceeded")
+	ErrTooLargeContent = errors.New("archive expanded size limit exceeded")
+	ErrUnsafePath      = errors.New("archive entry has unsafe path")
+)
+
+var (
+	zipMagic  = []byte("PK\x03\x04")
+	gzipMagic = []byte{0x1f, 0x8b}
+	tarMagic  = []byte("ustar")
+)
+
+const (
+	tarMagicOffset = 257
+
+	// headerSize is the size of tar header block which covers magic
+	// bytes of all supported formats.
+	headerSize = 512
+)
+
+// Limits restricts archive expanding. Zero value of field means no limit.
+type Limits struct {
+	MaxEntries      int
+	MaxExpandedSize int64
+}
+
+// Entry is a regular file extracted from archive.
+// Reader is valid only inside WalkFunc call.
+type Entry struct {
+	Path   string
+	Size   int64
+	Reader io.Reader
+}
+
+type WalkFunc func(entry *Entry) error
+
+// Detect returns archive format by magic bytes of data. Gzip stream is
+// treated as archive only for .tar.gz and .tgz files.
+func Detect(fileName string, data []byte) Format {
+	switch {
+	case bytes.HasPrefix(data, zipMagic):
+		return Zip
+	case len(data) > tarMagicOffset+len(tarMagic) &&
+		bytes.Equal(data[tarMagicOffset:tarMagicOffset+len(tarMagic)], tarMagic):
+		return Tar
+	case bytes.HasPrefix(data, gzipMagic) && isTarGzipName(fileName):
+		return TarGzip
+	default:
+		return None
+	}
+}
+
+// DetectReader returns archive format by magic bytes read from
+// the beginning of reader.
+func DetectReader(fileName string, reader io.ReaderAt) Format {
+	header := make([]byte, headerSize)
+	n, _ := reader.ReadAt(header, 0)
+	return Detect(fileName, header[:n])
+}
+
+// HasExtension reports whether file name has extension of supported
+// archive, so objects are checked by name before their data is loaded.
+func HasExtension(fileName string) bool {
+	lowerName := strings.ToLower(fileName)
+	return strings.HasSuffix(lowerName, ".zip") || strings.HasSuffix(lowerName, ".tar") || isTarGzipName(lowerName)
+}
+
+// TrimExtension removes archive extension from file name.
+func TrimExtension(fileName string) string {
+	lowerName := strings.ToLower(fileName)
+	for _, ext := range []string{".tar.gz", ".tgz", ".tar", ".zip"} {
+		if strings.HasSuffix(lowerName, ext) {
+			return fileName[:len(fileName)-len(ext)]
+		}
+	}
+
+	return strings.TrimSuffix(fileName, path.Ext(fileName))
+}
+
+// Walk iterates over regular files of archive of size bytes read from
+// reader one by one and stops on first error returned by fn or on exceeded
+// limits. Archive is read by entries, so it may be backed by file.
+func Walk(format Format, reader io.ReaderAt, size int64, limits Limits, fn WalkFunc) error {
+	switch format {
+	case Zip:
+		return walkZip(reader, size, limits, fn)
+	case Tar:
+		return walkTar(io.NewSectionReader(reader, 0, size), limits, fn)
+	case TarGzip:
+		gzipReader, err := gzip.NewReader(io.NewSectionReader(reader, 0, size))
+		if err != nil {
+			return fmt.Errorf("failed to open gzip stream: %w", err)
+		}
+		defer func() { _ = gzipReader.Close() }()
+		return walkTar(gzipReader, limits, fn)
+	default:
+		return fmt.Errorf("unsupported archive format: %d", format)
+	}
+}
+
+func walkZip(reader io.ReaderAt, size int64, limits Limits, fn WalkFunc) error {
+	zipReader, err := zip.NewReader(reader, size)
+	if err != nil {
+		return fmt.Errorf("failed to open zip archive: %w", err)
+	}
+
+	if limits.MaxEntries > 0 && len(zipReader.File) > limits.MaxEntries {
+		return ErrTooManyEntries
+	}
+
+	counter := newSizeCounter(limits.MaxExpandedSize)
+	for _, file := range zipReader.File {
+		if !file.Mode().IsRegular() || isHiddenEntry(file.Name) {
+			continue
+		}
+
+		entryPath, err := sanitizePath(file.Name)
+		if err != nil {
+			return err
+		}
+
+		fileReader, err := file.Open()
+		if err != nil {
+			return fmt.Errorf("failed to open zip entry %s: %w", file.Name, err)
+		}
+
+		entry := &Entry{
+			Path:   entryPath,
+			Size:   int64(file.UncompressedSize64),
+			Reader: counter.wrap(fileReader),
+		}
+
+		err = fn(entry)
+		_ = fileReader.Close()
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func walkTar(reader io.Reader, limits Limits, fn WalkFunc) error {
+	tarReader := tar.NewReader(reader)
+	counter := newSizeCounter(limits.MaxExpandedSize)
+	for entriesCount := 1; ; entriesCount++ {
+		header, err := tarReader.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read tar archive: %w", err)
+		}
+
+		if limits.MaxEntries > 0 && entriesCount > limits.MaxEntries {
+			return ErrTooManyEntries
+		}
+
+		if header.Typeflag != tar.TypeReg || isHiddenEntry(header.Name) {
+			continue
+		}
+
+		entryPath, err := sanitizePath(header.Name)
+		if err != nil {
+			return err
+		}
+
+		entry := &Entry{
+			Path:   entryPath,
+			Size:   header.Size,
+			Reader: counter.wrap(tarReader),
+		}
+
+		if err = fn(entry); err != nil {
+			return err
+		}
+	}
+}
+
+// sanitizePath rejects absolute paths and paths escaping target prefix.
+func sanitizePath(name string) (string, error) {
+	name = strings.ReplaceAll(name, "\\", "/")
+	if path.IsAbs(name) || strings.Contains(name, ":") {
+		return "", fmt.Errorf("%w: %s", ErrUnsafePath, name)
+	}
+
+	cleaned := path.Clean(name)
+	if cleaned == "." || cleaned == ".." || strings.HasPrefix(cleaned, "../") {
+		return "", fmt.Errorf("%w: %s", ErrUnsafePath, name)
+	}
+
+	return cleaned, nil
+}
+
+// isHiddenEntry reports about service entries created by archivers on macOS.
+func isHiddenEntry(name string) bool {
+	return strings.HasPrefix(name, "__MACOSX/") || strings.HasPrefix(path.Base(name), "._")
+}
+
+func isTarGzipName(fileName string) bool {
+	lowerName := strings.ToLower(fileName)
+	return strings.HasSuffix(lowerName, ".tar.gz") || strings.HasSuffix(lowerName, ".tgz")
+}
+
+// sizeCounter tracks total expanded size over all archive entries.
+type sizeCounter struct {
+	limit     int64
+	remaining int64
+}
+
+func newSizeCounter(limit int64) *sizeCounter {
+	return &sizeCounter{limit: limit, remaining: limit}
+}
+
+func (sc *sizeCounter) wrap(reader io.Reader) io.Reader {
+	if sc.limit <= 0 {
+		return reader
+	}
+
+	return &countingReader{reader: reader, counter: sc}
+}
+
+type countingReader struct {
+	reader  io.Reader
+	counter *sizeCounter
+}
+
+func (cr *countingReader) Read(p []byte) (int, error) {
+	n, err := cr.reader.Read(p)
+	cr.counter.remaining -= int64(n)
+	if cr.counter.remaining < 0 {
+		return n, ErrTooLargeContent
+	}
+
+	return n, err
+}
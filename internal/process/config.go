@@ -1,5 +1,194 @@
 package process
 
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"watchtower/internal/shared/kernel"
+	"watchtower/internal/support/task/application/service/fingerprint"
+	"watchtower/internal/support/task/application/service/pii"
+)
+
+// Config of orchestrator. LowConfidenceThreshold is OCR confidence below
+// which processed task is marked for review, zero disables the check.
 type Config struct {
-	SemaphoreSize int64 `mapstructure:"semaphore_size"`
+	SemaphoreSize          int64                  `mapstructure:"semaphore_size"`
+	LowConfidenceThreshold float64                `mapstructure:"low_confidence_threshold"`
+	KnowledgeGraph         StageConfig            `mapstructure:"knowledge_graph"`
+	Archive                ArchiveConfig          `mapstructure:"archive"`
+	Summary                SummaryConfig          `mapstructure:"summary"`
+	Pii                    PiiConfig              `mapstructure:"pii"`
+	Antivirus              AntivirusConfig        `mapstructure:"antivirus"`
+	Dedup                  DedupConfig            `mapstructure:"dedup"`
+	PostProcess            PostProcessConfig      `mapstructure:"post_process"`
+	Watcher                WatcherConfig          `mapstructure:"watcher"`
+	Events                 EventsConfig           `mapstructure:"events"`
+	Poller                 PollerConfig           `mapstructure:"poller"`
+	Uploads                UploadsConfig          `mapstructure:"uploads"`
+	PresignedUploads       PresignedUploadsConfig `mapstructure:"presigned_uploads"`
+}
+
+// ArchiveConfig controls expanding of zip and tar archives uploaded through
+// API, tus uploads or storage events. Stored objects are checked by extension.
+// Extracted files are stored under <archive dir>/<TargetPrefix>/<archive name>.
+type ArchiveConfig struct {
+	StageConfig     `mapstructure:",squash"`
+	TargetPrefix    string `mapstructure:"target_prefix"`
+	MaxEntries      int    `mapstructure:"max_entries"`
+	MaxExpandedSize int64  `mapstructure:"max_expanded_size"`
+	KeepOriginal    bool   `mapstructure:"keep_original"`
+}
+
+// SummaryConfig controls summarization stage. Taxonomy lists labels
+// which documents are classified by, BucketTaxonomy overrides it per bucket.
+type SummaryConfig struct {
+	StageConfig    `mapstructure:",squash"`
+	Taxonomy       []string                     `mapstructure:"taxonomy"`
+	BucketTaxonomy map[kernel.BucketID][]string `mapstructure:"bucket_taxonomy"`
+}
+
+func (sc SummaryConfig) TaxonomyFor(bucketID kernel.BucketID) []string {
+	if taxonomy, ok := sc.BucketTaxonomy[bucketID]; ok {
+		return taxonomy
+	}
+
+	return sc.Taxonomy
+}
+
+// PiiConfig controls personal data detection stage. Action is one of
+// mask, drop or tag, BucketActions overrides it per bucket.
+type PiiConfig struct {
+	StageConfig   `mapstructure:",squash"`
+	Action        pii.Action                     `mapstructure:"action"`
+	BucketActions map[kernel.BucketID]pii.Action `mapstructure:"bucket_actions"`
+}
+
+func (pc PiiConfig) ActionFor(bucketID kernel.BucketID) pii.Action {
+	if action, ok := pc.BucketActions[bucketID]; ok {
+		return action
+	}
+
+	return pc.Action
+}
+
+// AntivirusConfig controls scanning of objects before recognizing.
+// ScanOnUpload additionally scans files before storing them, infected
+// files are moved or stored under QuarantinePrefix of the bucket.
+type AntivirusConfig struct {
+	StageConfig      `mapstructure:",squash"`
+	ScanOnUpload     bool   `mapstructure:"scan_on_upload"`
+	QuarantinePrefix string `mapstructure:"quarantine_prefix"`
+}
+
+// DedupConfig controls near-duplicate detection stage. Documents which
+// SimHash fingerprints differ by MaxDistance bits at most are considered
+// near-duplicates, Action is one of report, skip or link. MaxDistance is
+// in range [0, 63], it is validated at startup.
+type DedupConfig struct {
+	StageConfig `mapstructure:",squash"`
+	MaxDistance int                `mapstructure:"max_distance"`
+	Action      fingerprint.Action `mapstructure:"action"`
+}
+
+// PostProcessConfig controls moving of objects after processing. Objects
+// of successful tasks are moved under ProcessedPrefix, objects of failed
+// tasks are moved under UnrecognizedPrefix with error text stored to
+// ErrorMetadataKey of object metadata.
+type PostProcessConfig struct {
+	StageConfig        `mapstructure:",squash"`
+	ProcessedPrefix    string `mapstructure:"processed_prefix"`
+	UnrecognizedPrefix string `mapstructure:"unrecognized_prefix"`
+	ErrorMetadataKey   string `mapstructure:"error_metadata_key"`
+}
+
+// WatcherConfig controls scanning of directories registered by watchers
+// API. Interval is a period of scans in seconds.
+type WatcherConfig struct {
+	Enabled  bool `mapstructure:"enabled"`
+	Interval int  `mapstructure:"interval"`
+}
+
+// EventsConfig controls ingestion of storage event notifications. Source
+// is webhook or amqp, Prefixes and Suffixes filter object keys of events.
+// WebhookToken authorizes webhook requests when it is not empty.
+type EventsConfig struct {
+	StageConfig  `mapstructure:",squash"`
+	Source       string   `mapstructure:"source"`
+	Prefixes     []string `mapstructure:"prefixes"`
+	Suffixes     []string `mapstructure:"suffixes"`
+	WebhookToken string   `mapstructure:"webhook_token"`
+}
+
+// IsMatched reports whether object key matches any of prefixes and any
+// of suffixes, empty list matches all keys.
+func (ec EventsConfig) IsMatched(objID kernel.ObjectID) bool {
+	hasPrefix := func(prefix string) bool { return strings.HasPrefix(objID, prefix) }
+	hasSuffix := func(suffix string) bool { return strings.HasSuffix(objID, suffix) }
+	return (len(ec.Prefixes) == 0 || slices.ContainsFunc(ec.Prefixes, hasPrefix)) &&
+		(len(ec.Suffixes) == 0 || slices.ContainsFunc(ec.Suffixes, hasSuffix))
+}
+
+// PollerConfig controls detecting of changed objects by listing buckets
+// which can not send event notifications. Empty Buckets list means all
+// buckets, empty Prefixes list means whole bucket. Interval is a period
+// of polls and LockTTL is an expiration of leader lock in seconds. The
+// first poll of bucket only stores its snapshot, objects existing before
+// it are processed only if ProcessExisting is set.
+type PollerConfig struct {
+	StageConfig     `mapstructure:",squash"`
+	Prefixes        []string `mapstructure:"prefixes"`
+	Interval        int      `mapstructure:"interval"`
+	LockTTL         int      `mapstructure:"lock_ttl"`
+	ProcessExisting bool     `mapstructure:"process_existing"`
+}
+
+// UploadsConfig controls resumable uploads by tus protocol. MaxSize limits
+// length of upload in bytes, received bytes are sent to storage by parts of
+// PartSize bytes at least. Uploads without new chunks for Expiration seconds
+// are aborted by cleanup running every CleanupInterval seconds.
+type UploadsConfig struct {
+	Enabled         bool  `mapstructure:"enabled"`
+	MaxSize         int64 `mapstructure:"max_size"`
+	PartSize        int64 `mapstructure:"part_size"`
+	Expiration      int   `mapstructure:"expiration"`
+	CleanupInterval int   `mapstructure:"cleanup_interval"`
+}
+
+// ValidatePartSize fails if parts are smaller than minPartSize required by
+// storage, otherwise uploads would fail on completion after all bytes have
+// been received.
+func (c UploadsConfig) ValidatePartSize(minPartSize int64) error {
+	minPartSize = max(minPartSize, 1)
+	if c.PartSize < minPartSize {
+		return fmt.Errorf("upload part size must be %d bytes at least, got %d", minPartSize, c.PartSize)
+	}
+
+	return nil
+}
+
+// PresignedUploadsConfig controls direct uploads into storage by presigned
+// URLs. Expiration is a lifetime of URL and CompletionTimeout is a time in
+// seconds after URL expiration while uploaded object may still be completed.
+// MaxSize limits size of uploaded object in bytes.
+type PresignedUploadsConfig struct {
+	Enabled           bool  `mapstructure:"enabled"`
+	MaxSize           int64 `mapstructure:"max_size"`
+	Expiration        int   `mapstructure:"expiration"`
+	CompletionTimeout int   `mapstructure:"completion_timeout"`
+}
+
+// StageConfig toggles optional processing stage per bucket.
+// Empty Buckets list means that stage is enabled for all buckets.
+type StageConfig struct {
+	Enabled bool              `mapstructure:"enabled"`
+	Buckets []kernel.BucketID `mapstructure:"buckets"`
+}
+
+func (sc StageConfig) IsEnabledFor(bucketID kernel.BucketID) bool {
+	if !sc.Enabled {
+		return false
+	}
+
+	return len(sc.Buckets) == 0 || slices.Contains(sc.Buckets, bucketID)
 }
@@ -0,0 +1,162 @@
+package process
+
+import (
+	"cmp"
+	"fmt"
+	"log/slog"
+	"path"
+	"strings"
+
+	"github.com/breadrock1/otlp-go/otlp"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+
+	"watchtower/internal/core/cloud/domain"
+	"watchtower/internal/shared/kernel"
+
+	cloudApp "watchtower/internal/core/cloud/application"
+	taskDomain "watchtower/internal/support/task/domain"
+)
+
+// CopyObjects copies object, or all objects under prefix if source path ends
+// with slash. Copied objects get task per object, so documents are indexed
+// into index of destination bucket, and documents of objects moved into
+// another bucket are removed from index of source bucket. Objects moved
+// within bucket keep their documents with changed path and get no task.
+func (o *Orchestrator) CopyObjects(
+	ctx kernel.Ctx,
+	bucketID kernel.BucketID,
+	params *domain.CopyObjectParams,
+) ([]*taskDomain.Task, error) {
+	ctx, span := otlp_go.GlobalTracer.Start(ctx, "copy-objects")
+	defer span.End()
+
+	dstBucketID := cmp.Or(params.DestinationBucket, bucketID)
+	span.SetAttributes(
+		attribute.String("bucket", bucketID),
+		attribute.String("src-file-path", params.SourcePath),
+		attribute.String("dst-bucket", dstBucketID),
+		attribute.String("dst-file-path", params.DestinationPath),
+		attribute.Bool("with-removed", params.WithRemoving),
+	)
+
+	copies, err := o.collectCopies(ctx, bucketID, params)
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		span.RecordError(err)
+		return nil, err
+	}
+
+	tasks := make([]*taskDomain.Task, 0, len(copies))
+	for _, copyParams := range copies {
+		task, err := o.copyIndexedObject(ctx, bucketID, dstBucketID, copyParams)
+		if err != nil {
+			span.SetStatus(codes.Error, err.Error())
+			span.RecordError(err)
+			return tasks, err
+		}
+
+		if task != nil {
+			tasks = append(tasks, task)
+		}
+	}
+
+	return tasks, nil
+}
+
+// copyIndexedObject copies object marked as stored by watchtower, so its
+// storage event does not create second task. Documents of object moved
+// within bucket are moved in index, otherwise copy is indexed by task.
+func (o *Orchestrator) copyIndexedObject(
+	ctx kernel.Ctx,
+	bucketID kernel.BucketID,
+	dstBucketID kernel.BucketID,
+	params *domain.CopyObjectParams,
+) (*taskDomain.Task, error) {
+	srcObjID := path.Clean(params.SourcePath)
+	dstObjID := path.Clean(params.DestinationPath)
+
+	o.markStoredObject(ctx, dstBucketID, dstObjID)
+	if err := o.storageUC.CopyObject(ctx, bucketID, params); err != nil {
+		o.unmarkStoredObject(ctx, dstBucketID, dstObjID)
+		return nil, err
+	}
+
+	if dstBucketID == bucketID && params.WithRemoving {
+		err := o.taskUC.MoveDocuments(ctx, bucketID, srcObjID, dstObjID)
+		if err == nil {
+			return nil, nil
+		}
+
+		slog.Warn("processing",
+			slog.String("msg", "failed to move documents, moved object is indexed again"),
+			slog.String("bucket", bucketID),
+			slog.String("file-path", dstObjID),
+			slog.String("err", err.Error()),
+		)
+	}
+
+	if params.WithRemoving {
+		if err := o.taskUC.DeleteDocuments(ctx, bucketID, srcObjID); err != nil {
+			slog.Warn("processing",
+				slog.String("msg", "failed to cleanup index of moved object"),
+				slog.String("bucket", bucketID),
+				slog.String("file-path", srcObjID),
+				slog.String("err", err.Error()),
+			)
+		}
+	}
+
+	task, err := o.CreateTask(ctx, dstBucketID, dstObjID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create task of copied object: %w", err)
+	}
+
+	return task, nil
+}
+
+// collectCopies returns parameters to copy each object before any object
+// is copied, so objects copied under source prefix are never copied twice.
+// Metadata is not passed, so copies keep metadata of source objects.
+func (o *Orchestrator) collectCopies(
+	ctx kernel.Ctx,
+	bucketID kernel.BucketID,
+	params *domain.CopyObjectParams,
+) ([]*domain.CopyObjectParams, error) {
+	newCopy := func(srcPath, dstPath string) *domain.CopyObjectParams {
+		return &domain.CopyObjectParams{
+			SourcePath:        srcPath,
+			DestinationPath:   dstPath,
+			DestinationBucket: params.DestinationBucket,
+			WithRemoving:      params.WithRemoving,
+		}
+	}
+
+	if !strings.HasSuffix(params.SourcePath, "/") {
+		return []*domain.CopyObjectParams{newCopy(params.SourcePath, params.DestinationPath)}, nil
+	}
+
+	// Listed paths never start with slash or dot, so prefix is cleaned
+	// the same way, empty prefix means all objects of bucket.
+	prefix := strings.TrimPrefix(path.Clean("/"+params.SourcePath), "/")
+	if prefix != "" {
+		prefix += "/"
+	}
+
+	filter, err := cloudApp.NewObjectFilter(&domain.SearchObjectsParams{PrefixPath: prefix})
+	if err != nil {
+		return nil, err
+	}
+
+	var copies []*domain.CopyObjectParams
+	err = o.storageUC.SearchObjects(ctx, bucketID, filter, func(object domain.Object) error {
+		relPath := strings.TrimPrefix(object.Path, prefix)
+		copies = append(copies, newCopy(object.Path, path.Join(params.DestinationPath, relPath)))
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list objects under prefix: %w", err)
+	}
+
+	return copies, nil
+}
@@ -0,0 +1,282 @@
+package process
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"maps"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/breadrock1/otlp-go/otlp"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+
+	"watchtower/internal/core/cloud/domain"
+	"watchtower/internal/shared/kernel"
+	"watchtower/internal/shared/metrics"
+	"watchtower/internal/support/task/application/service/upload"
+
+	taskApp "watchtower/internal/support/task/application"
+)
+
+const (
+	WebhookEventSource = "webhook"
+	AMQPEventSource    = "amqp"
+
+	// UploadedByMetadataKey is user metadata which objects stored by former
+	// versions of watchtower are marked by. Clients copy it along with objects,
+	// so it is never trusted and is stripped from metadata of tasks.
+	UploadedByMetadataKey = "uploaded-by"
+
+	// eventsReconnectDelay is a pause before resubscribing to events
+	// source after connection failure.
+	eventsReconnectDelay = 5 * time.Second
+)
+
+var (
+	ErrEventsDisabled    = errors.New("events webhook is disabled")
+	ErrEventUnauthorized = errors.New("invalid events webhook token")
+)
+
+// withoutUploadedMark returns copy of metadata without watchtower mark.
+func withoutUploadedMark(metadata map[string]string) map[string]string {
+	if _, ok := metadata[UploadedByMetadataKey]; !ok {
+		return metadata
+	}
+
+	stripped := maps.Clone(metadata)
+	delete(stripped, UploadedByMetadataKey)
+	return stripped
+}
+
+// markStoredObject marks object which is about to be stored by watchtower,
+// so its storage event does not create second task. Failure is only logged
+// because object is processed at most twice then.
+func (o *Orchestrator) markStoredObject(ctx kernel.Ctx, bucketID kernel.BucketID, objID kernel.ObjectID) {
+	objID = path.Clean(objID)
+	if err := o.taskUC.MarkStoredObject(ctx, bucketID, objID); err != nil {
+		slog.Warn("events",
+			slog.String("msg", "failed to mark stored object"),
+			slog.String("bucket", bucketID),
+			slog.String("file-path", objID),
+			slog.String("err", err.Error()),
+		)
+	}
+}
+
+// unmarkStoredObject removes mark of object which has not been stored.
+func (o *Orchestrator) unmarkStoredObject(ctx kernel.Ctx, bucketID kernel.BucketID, objID kernel.ObjectID) {
+	objID = path.Clean(objID)
+	if _, err := o.taskUC.TakeStoredObject(ctx, bucketID, objID); err != nil {
+		slog.Warn("events",
+			slog.String("msg", "failed to unmark stored object"),
+			slog.String("bucket", bucketID),
+			slog.String("file-path", objID),
+			slog.String("err", err.Error()),
+		)
+	}
+}
+
+// AuthorizeEventsWebhook checks that events are received by webhook
+// and token of request matches configured one.
+func (o *Orchestrator) AuthorizeEventsWebhook(token string) error {
+	eventsConfig := o.config.Events
+	if !eventsConfig.Enabled || eventsConfig.Source != WebhookEventSource {
+		return ErrEventsDisabled
+	}
+
+	token = strings.TrimPrefix(token, "Bearer ")
+	if eventsConfig.WebhookToken != "" && token != eventsConfig.WebhookToken {
+		return ErrEventUnauthorized
+	}
+
+	return nil
+}
+
+// LaunchEventsConsumer subscribes to events source and resubscribes
+// after connection failures until context is canceled.
+func (o *Orchestrator) LaunchEventsConsumer(ctx kernel.Ctx, source domain.IObjectEventSource) {
+	slog.Info("starting storage events consumer")
+	go func() {
+		for {
+			err := source.Consume(ctx, o.HandleObjectEvent)
+			if err != nil {
+				slog.Error("events",
+					slog.String("msg", "storage events consumer failed"),
+					slog.String("err", err.Error()),
+				)
+			}
+
+			select {
+			case <-time.After(eventsReconnectDelay):
+			case <-ctx.Done():
+				slog.Info("terminating storage events consumer")
+				return
+			}
+		}
+	}()
+}
+
+// HandleObjectEvent creates processing task for created object and removes
+// indexed documents of removed object. Events of objects stored or moved
+// by watchtower itself and events filtered by configuration are skipped.
+// Removal of object moved under managed prefix is never filtered because
+// its document is indexed by the moved path.
+func (o *Orchestrator) HandleObjectEvent(ctx kernel.Ctx, event *domain.ObjectEvent) error {
+	ctx, span := otlp_go.GlobalTracer.Start(ctx, "handle-object-event")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("event", event.Name),
+		attribute.String("bucket", event.BucketID),
+		attribute.String("file-path", event.ObjectID),
+	)
+
+	var err error
+	action := "skipped"
+	eventsConfig := o.config.Events
+	isMatched := eventsConfig.IsMatched(event.ObjectID) ||
+		(event.Type == domain.ObjectRemoved && isUnderPrefixes(event.ObjectID, o.managedPrefixes(event.BucketID)))
+	if eventsConfig.IsEnabledFor(event.BucketID) && isMatched {
+		action, err = o.dispatchObjectEvent(ctx, event)
+	}
+
+	if err != nil {
+		action = "failed"
+		span.SetStatus(codes.Error, err.Error())
+		span.RecordError(err)
+	}
+
+	recordObjectEvent(event, action)
+	return err
+}
+
+// recordObjectEvent counts handled event by action taken on it.
+func recordObjectEvent(event *domain.ObjectEvent, action string) {
+	eventLabel := "created"
+	if event.Type == domain.ObjectRemoved {
+		eventLabel = "removed"
+	}
+
+	metrics.ObjectEventsCounter.
+		WithLabelValues(kernel.AppName, eventLabel, action).
+		Inc()
+
+	slog.Debug("events",
+		slog.String("event", event.Name),
+		slog.String("file-path", event.ObjectID),
+		slog.String("action", action),
+	)
+}
+
+// dispatchObjectEvent creates task or cleans up index for event which
+// passed configured filters, returns action taken on event.
+func (o *Orchestrator) dispatchObjectEvent(ctx kernel.Ctx, event *domain.ObjectEvent) (string, error) {
+	if strings.HasSuffix(event.ObjectID, "/") {
+		return "skipped", nil
+	}
+
+	// Objects under prefixes managed by watchtower like quarantine or
+	// processed are never processed again, but documents of removed ones
+	// are indexed by their final location and must be cleaned up.
+	managedPrefixes := o.managedPrefixes(event.BucketID)
+	isManaged := isUnderPrefixes(event.ObjectID, managedPrefixes)
+
+	switch event.Type {
+	case domain.ObjectCreated:
+		if isManaged {
+			return "skipped", nil
+		}
+
+		return o.dispatchCreatedObject(ctx, event)
+
+	case domain.ObjectRemoved:
+		if !isManaged && o.isMovedObject(ctx, event.BucketID, event.ObjectID, managedPrefixes) {
+			return "skipped", nil
+		}
+
+		if err := o.taskUC.DeleteDocuments(ctx, event.BucketID, event.ObjectID); err != nil {
+			return "", fmt.Errorf("failed to cleanup index: %w", err)
+		}
+
+		return "cleanup", nil
+
+	default:
+		return "skipped", nil
+	}
+}
+
+// dispatchCreatedObject skips object stored by watchtower itself, completes
+// ticket of object uploaded by presigned URL or creates task of object.
+func (o *Orchestrator) dispatchCreatedObject(ctx kernel.Ctx, event *domain.ObjectEvent) (string, error) {
+	stored, err := o.taskUC.TakeStoredObject(ctx, event.BucketID, event.ObjectID)
+	if err != nil {
+		return "", fmt.Errorf("failed to check stored object: %w", err)
+	}
+
+	if stored {
+		return "skipped", nil
+	}
+
+	if o.config.PresignedUploads.Enabled {
+		ticket, err := o.taskUC.TakeUploadTicket(ctx, event.BucketID, event.ObjectID)
+		if err == nil {
+			return o.dispatchUploadedObject(ctx, event, ticket)
+		}
+
+		if !errors.Is(err, upload.ErrTicketNotFound) && !errors.Is(err, taskApp.ErrUploadTicketsDisabled) {
+			return "", err
+		}
+	}
+
+	if _, err = o.createObjectTask(ctx, event.BucketID, event.ObjectID); err != nil {
+		return "", fmt.Errorf("failed to create task: %w", err)
+	}
+
+	return "task", nil
+}
+
+// managedPrefixes returns prefixes which objects are moved to by
+// enabled stages of bucket.
+func (o *Orchestrator) managedPrefixes(bucketID kernel.BucketID) []string {
+	var prefixes []string
+	if o.config.Antivirus.IsEnabledFor(bucketID) {
+		prefixes = append(prefixes, o.config.Antivirus.QuarantinePrefix)
+	}
+
+	if o.config.PostProcess.IsEnabledFor(bucketID) {
+		prefixes = append(prefixes, o.config.PostProcess.ProcessedPrefix, o.config.PostProcess.UnrecognizedPrefix)
+	}
+
+	return prefixes
+}
+
+// isMovedObject reports whether removed object has been moved under
+// one of managed prefixes by watchtower.
+func (o *Orchestrator) isMovedObject(
+	ctx kernel.Ctx,
+	bucketID kernel.BucketID,
+	objID kernel.ObjectID,
+	managedPrefixes []string,
+) bool {
+	for _, prefix := range managedPrefixes {
+		movedObjID := path.Join(prefix, objID)
+		if _, err := o.storageUC.GetObjectInfo(ctx, bucketID, movedObjID); err == nil {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isUnderPrefixes(objID kernel.ObjectID, prefixes []string) bool {
+	for _, prefix := range prefixes {
+		if prefix != "" && strings.HasPrefix(objID, strings.TrimSuffix(prefix, "/")+"/") {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,41 @@
+package process
+
+import (
+	"path"
+
+	"github.com/breadrock1/otlp-go/otlp"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+
+	"watchtower/internal/shared/kernel"
+)
+
+// ReplaceObjectMetadata replaces user metadata of object in place. Object
+// is marked as stored by watchtower, so storage event of object copied to
+// itself does not create task, and object is reindexed on demand only.
+func (o *Orchestrator) ReplaceObjectMetadata(
+	ctx kernel.Ctx,
+	bucketID kernel.BucketID,
+	filePath string,
+	metadata map[string]string,
+) error {
+	ctx, span := otlp_go.GlobalTracer.Start(ctx, "replace-object-metadata")
+	defer span.End()
+
+	objID := path.Clean(filePath)
+	span.SetAttributes(
+		attribute.String("bucket", bucketID),
+		attribute.String("file-path", objID),
+	)
+
+	o.markStoredObject(ctx, bucketID, objID)
+	err := o.storageUC.ReplaceObjectMetadata(ctx, bucketID, objID, metadata)
+	if err != nil {
+		o.unmarkStoredObject(ctx, bucketID, objID)
+		span.SetStatus(codes.Error, err.Error())
+		span.RecordError(err)
+		return err
+	}
+
+	return nil
+}
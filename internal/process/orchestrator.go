@@ -1,8 +1,13 @@
 package process
 
 import (
+	"bytes"
 	"fmt"
+	"io"
 	"log/slog"
+	"maps"
+	"os"
+	"path"
 	"strconv"
 	"time"
 
@@ -12,8 +17,12 @@ import (
 	"golang.org/x/sync/semaphore"
 
 	"watchtower/internal/core/cloud/domain"
+	"watchtower/internal/process/archive"
 	"watchtower/internal/shared/kernel"
 	"watchtower/internal/shared/metrics"
+	"watchtower/internal/support/task/application/service/antivirus"
+	"watchtower/internal/support/task/application/service/fingerprint"
+	"watchtower/internal/support/task/application/service/pii"
 
 	cloudApp "watchtower/internal/core/cloud/application"
 	taskUC "watchtower/internal/support/task/application"
@@ -98,6 +107,42 @@ func (o *Orchestrator) UploadFile(
 		attribute.String("file-path", params.FilePath),
 	)
 
+	antivirusConfig := o.config.Antivirus
+	if antivirusConfig.ScanOnUpload && antivirusConfig.IsEnabledFor(bucketID) {
+		verdict, err := o.taskUC.ScanObject(ctx, bucketID, params.FilePath, params.FileData)
+		if err != nil {
+			err = fmt.Errorf("failed to scan file %s: %w", params.FilePath, err)
+			span.SetStatus(codes.Error, err.Error())
+			span.RecordError(err)
+			return nil, err
+		}
+
+		if verdict.Infected {
+			return o.quarantineUpload(ctx, bucketID, params, verdict)
+		}
+	}
+
+	archiveFormat := archive.None
+	if o.config.Archive.IsEnabledFor(bucketID) {
+		archiveFormat = archive.Detect(params.FilePath, params.FileData.Bytes())
+	}
+
+	// Archive is spooled to file before storing drains upload buffer.
+	var archiveFile *os.File
+	var archiveSize int64
+	if archiveFormat != archive.None {
+		var err error
+		archiveFile, archiveSize, err = spoolArchive(bytes.NewReader(params.FileData.Bytes()))
+		if err != nil {
+			err = fmt.Errorf("failed to upload file %s: %w", params.FilePath, err)
+			span.SetStatus(codes.Error, err.Error())
+			span.RecordError(err)
+			return nil, err
+		}
+		defer removeSpooledArchive(archiveFile)
+	}
+
+	o.markStoredObject(ctx, bucketID, params.FilePath)
 	objID, err := o.storageUC.StoreObject(ctx, bucketID, params)
 
 	metrics.UploadedFilesCounter.
@@ -105,12 +150,17 @@ func (o *Orchestrator) UploadFile(
 		Inc()
 
 	if err != nil {
+		o.unmarkStoredObject(ctx, bucketID, params.FilePath)
 		err = fmt.Errorf("failed to upload file %s: %w", params.FilePath, err)
 		span.SetStatus(codes.Error, err.Error())
 		span.RecordError(err)
 		return nil, err
 	}
 
+	if archiveFile != nil {
+		return o.expandArchive(ctx, bucketID, objID, archiveFormat, archiveFile, archiveSize)
+	}
+
 	task, err := o.CreateTask(ctx, bucketID, objID)
 
 	metrics.CreatedProcessingTasksCounter.
@@ -133,8 +183,48 @@ func (o *Orchestrator) CreateTask(
 	objID kernel.ObjectID,
 ) (*taskDomain.Task, error) {
 	task := taskDomain.CreateNewTask(bucketID, objID)
+	if err := o.publishTask(ctx, task); err != nil {
+		return nil, err
+	}
+
+	return task, nil
+}
+
+// createObjectTask creates processing task of object stored by client
+// bypassing upload API. Archives are expanded like uploaded ones when
+// archive stage is enabled for bucket.
+func (o *Orchestrator) createObjectTask(
+	ctx kernel.Ctx,
+	bucketID kernel.BucketID,
+	objID kernel.ObjectID,
+) (*taskDomain.Task, error) {
+	if !o.config.Archive.IsEnabledFor(bucketID) || !archive.HasExtension(objID) {
+		return o.CreateTask(ctx, bucketID, objID)
+	}
+
+	objData, err := o.storageUC.GetObjectData(ctx, bucketID, objID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load archive %s: %w", objID, err)
+	}
+
+	archiveFile, archiveSize, err := spoolArchive(objData)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load archive %s: %w", objID, err)
+	}
+	defer removeSpooledArchive(archiveFile)
 
+	archiveFormat := archive.DetectReader(objID, archiveFile)
+	if archiveFormat == archive.None {
+		return o.CreateTask(ctx, bucketID, objID)
+	}
+
+	return o.expandArchive(ctx, bucketID, objID, archiveFormat, archiveFile, archiveSize)
+}
+
+func (o *Orchestrator) publishTask(ctx kernel.Ctx, task *taskDomain.Task) error {
 	taskID := task.ID.String()
+	bucketID := task.BucketID
+	objID := task.ObjectID
 	slog.Info("processing",
 		slog.String("msg", "creating new task"),
 		slog.String("task-id", taskID),
@@ -157,7 +247,7 @@ func (o *Orchestrator) CreateTask(
 		err = fmt.Errorf("failed to publish task: %w", err)
 		span.SetStatus(codes.Error, err.Error())
 		span.RecordError(err)
-		return nil, err
+		return err
 	}
 
 	o.taskUC.UpdateTaskStatus(ctx, task)
@@ -168,9 +258,207 @@ func (o *Orchestrator) CreateTask(
 	//	 continue
 	// }
 
+	return nil
+}
+
+// expandArchive stores archive entries under prefix named by archive and
+// creates processing task per each extracted file. Returned parent task
+// is not published to queue and only tracks the children tasks. Failed
+// expanding is reported by status of parent task, so stored archive is
+// always tracked by task.
+func (o *Orchestrator) expandArchive(
+	ctx kernel.Ctx,
+	bucketID kernel.BucketID,
+	objID kernel.ObjectID,
+	format archive.Format,
+	archiveFile io.ReaderAt,
+	archiveSize int64,
+) (*taskDomain.Task, error) {
+	ctx, span := otlp_go.GlobalTracer.Start(ctx, "expand-archive")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("bucket", bucketID),
+		attribute.String("file-path", objID),
+	)
+
+	parent := taskDomain.CreateNewTask(bucketID, objID)
+	parent.SetObjectDataSize(int(archiveSize))
+	parent.SetStatusAndText(taskDomain.Processing, taskDomain.ExpandingStatusText)
+	o.taskUC.UpdateTaskStatus(ctx, parent)
+
+	archiveConfig := o.config.Archive
+	limits := archive.Limits{
+		MaxEntries:      archiveConfig.MaxEntries,
+		MaxExpandedSize: archiveConfig.MaxExpandedSize,
+	}
+
+	archiveName := archive.TrimExtension(path.Base(objID))
+	targetPrefix := path.Join(path.Dir(objID), archiveConfig.TargetPrefix, archiveName)
+
+	var entryData bytes.Buffer
+	err := archive.Walk(format, archiveFile, archiveSize, limits, func(entry *archive.Entry) error {
+		entryData.Reset()
+		if _, err := entryData.ReadFrom(entry.Reader); err != nil {
+			return fmt.Errorf("failed to read entry %s: %w", entry.Path, err)
+		}
+
+		params := &domain.UploadObjectParams{
+			FilePath: path.Join(targetPrefix, entry.Path),
+			FileData: &entryData,
+		}
+
+		o.markStoredObject(ctx, bucketID, params.FilePath)
+		childObjID, err := o.storageUC.StoreObject(ctx, bucketID, params)
+
+		metrics.UploadedFilesCounter.
+			WithLabelValues(kernel.AppName, strconv.FormatBool(err != nil)).
+			Inc()
+
+		if err != nil {
+			o.unmarkStoredObject(ctx, bucketID, params.FilePath)
+			return fmt.Errorf("failed to upload entry %s: %w", entry.Path, err)
+		}
+
+		child := taskDomain.CreateNewTask(bucketID, childObjID)
+		child.SetParentID(parent.ID)
+		err = o.publishTask(ctx, child)
+
+		metrics.CreatedProcessingTasksCounter.
+			WithLabelValues(kernel.AppName, strconv.FormatBool(err != nil)).
+			Inc()
+
+		if err != nil {
+			return fmt.Errorf("failed to create task for entry %s: %w", entry.Path, err)
+		}
+
+		parent.AddChild(child.ID)
+		return nil
+	})
+
+	metrics.ExpandedArchivesCounter.
+		WithLabelValues(kernel.AppName, strconv.FormatBool(err != nil)).
+		Inc()
+
+	if err != nil {
+		err = fmt.Errorf("failed to expand archive %s: %w", objID, err)
+		parent.SetStatusAndText(taskDomain.Failed, err.Error())
+		o.taskUC.UpdateTaskStatus(ctx, parent)
+		span.SetStatus(codes.Error, err.Error())
+		span.RecordError(err)
+		return parent, nil
+	}
+
+	if !archiveConfig.KeepOriginal {
+		if err = o.storageUC.DeleteObject(ctx, bucketID, objID); err != nil {
+			slog.Warn("processing",
+				slog.String("msg", "failed to remove expanded archive"),
+				slog.String("file-path", objID),
+				slog.String("err", err.Error()),
+			)
+		}
+	}
+
+	msg := fmt.Sprintf("archive has been expanded into %d files", len(parent.Children))
+	parent.SetStatusAndText(taskDomain.Successful, msg)
+	o.taskUC.UpdateTaskStatus(ctx, parent)
+
+	return parent, nil
+}
+
+// spoolArchive writes archive into temporary file, so archive is expanded
+// entry by entry without keeping whole archive in memory.
+func spoolArchive(data io.Reader) (*os.File, int64, error) {
+	archiveFile, err := os.CreateTemp("", "watchtower-archive-*")
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to create archive file: %w", err)
+	}
+
+	archiveSize, err := io.Copy(archiveFile, data)
+	if err != nil {
+		removeSpooledArchive(archiveFile)
+		return nil, 0, fmt.Errorf("failed to write archive file: %w", err)
+	}
+
+	return archiveFile, archiveSize, nil
+}
+
+func removeSpooledArchive(archiveFile *os.File) {
+	_ = archiveFile.Close()
+	_ = os.Remove(archiveFile.Name())
+}
+
+// quarantineUpload stores infected uploaded file under quarantine prefix
+// and returns quarantined task which is not published to queue.
+func (o *Orchestrator) quarantineUpload(
+	ctx kernel.Ctx,
+	bucketID kernel.BucketID,
+	params *domain.UploadObjectParams,
+	verdict *antivirus.Verdict,
+) (*taskDomain.Task, error) {
+	ctx, span := otlp_go.GlobalTracer.Start(ctx, "quarantine-uploaded-file")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("bucket", bucketID),
+		attribute.String("file-path", params.FilePath),
+		attribute.String("signature", verdict.Signature),
+	)
+
+	params.FilePath = path.Join(o.config.Antivirus.QuarantinePrefix, params.FilePath)
+	objID, err := o.storageUC.StoreObject(ctx, bucketID, params)
+
+	metrics.UploadedFilesCounter.
+		WithLabelValues(kernel.AppName, strconv.FormatBool(err != nil)).
+		Inc()
+
+	if err != nil {
+		err = fmt.Errorf("failed to quarantine file %s: %w", params.FilePath, err)
+		span.SetStatus(codes.Error, err.Error())
+		span.RecordError(err)
+		return nil, err
+	}
+
+	task := taskDomain.CreateNewTask(bucketID, objID)
+	task.SetStatusAndText(taskDomain.Quarantined, quarantinedStatusText(verdict, objID))
+	o.taskUC.UpdateTaskStatus(ctx, task)
+
+	slog.Warn("processing",
+		slog.String("msg", "infected file has been quarantined"),
+		slog.String("task-id", task.ID.String()),
+		slog.String("file-path", objID),
+		slog.String("signature", verdict.Signature),
+	)
+
 	return task, nil
 }
 
+// quarantineTask moves infected object of task under quarantine prefix.
+func (o *Orchestrator) quarantineTask(
+	ctx kernel.Ctx,
+	task *taskDomain.Task,
+	verdict *antivirus.Verdict,
+) (string, error) {
+	quarantinePath := path.Join(o.config.Antivirus.QuarantinePrefix, task.ObjectID)
+	params := &domain.CopyObjectParams{
+		SourcePath:      task.ObjectID,
+		DestinationPath: quarantinePath,
+		WithRemoving:    true,
+	}
+
+	if err := o.storageUC.CopyObject(ctx, task.BucketID, params); err != nil {
+		return "", fmt.Errorf("failed to quarantine object: %w", err)
+	}
+
+	msg := quarantinedStatusText(verdict, quarantinePath)
+	task.SetStatusAndText(taskDomain.Quarantined, msg)
+	return msg, nil
+}
+
+func quarantinedStatusText(verdict *antivirus.Verdict, objID kernel.ObjectID) string {
+	return fmt.Sprintf("file is infected by %s and moved to %s", verdict.Signature, objID)
+}
+
 func (o *Orchestrator) handleTask(ctx kernel.Ctx, task *taskDomain.Task) {
 	slog.Info("processing",
 		slog.String("msg", "caught new task"),
@@ -189,7 +477,7 @@ func (o *Orchestrator) handleTask(ctx kernel.Ctx, task *taskDomain.Task) {
 	task.SetStatusAndText(taskDomain.Processing, taskDomain.ProcessingStatusText)
 	o.taskUC.UpdateTaskStatus(ctx, task)
 
-	err := o.processTask(ctx, task)
+	msg, err := o.processTask(ctx, task)
 	if err != nil {
 		err = fmt.Errorf("processing failed: %w", err)
 		span.SetStatus(codes.Error, err.Error())
@@ -198,10 +486,31 @@ func (o *Orchestrator) handleTask(ctx kernel.Ctx, task *taskDomain.Task) {
 			slog.String("task-id", task.ID.String()),
 			slog.String("err", err.Error()),
 		)
+
+		postProcess := o.config.PostProcess
+		if postProcess.IsEnabledFor(task.BucketID) {
+			// Object moved under processed prefix may have been indexed
+			// before failed stage, so its document is removed.
+			if task.Location != "" {
+				o.cleanupMovedDocuments(ctx, task)
+			}
+
+			metadata := make(map[string]string, len(task.Metadata)+1)
+			maps.Copy(metadata, task.Metadata)
+			metadata[postProcess.ErrorMetadataKey] = task.StatusText
+			o.moveTaskObject(ctx, task, postProcess.UnrecognizedPrefix, metadata)
+		}
+		return
+	}
+
+	if task.Status == taskDomain.Quarantined {
+		slog.Warn("processing",
+			slog.String("msg", msg),
+			slog.String("task-id", task.ID.String()),
+		)
 		return
 	}
 
-	msg := "task has been processed successful"
 	task.SetStatusAndText(taskDomain.Successful, msg)
 	slog.Info("processing",
 		slog.String("msg", msg),
@@ -209,7 +518,60 @@ func (o *Orchestrator) handleTask(ctx kernel.Ctx, task *taskDomain.Task) {
 	)
 }
 
-func (o *Orchestrator) processTask(ctx kernel.Ctx, task *taskDomain.Task) error {
+// moveProcessedObject moves object of processed task under processed
+// prefix before its document is indexed, so document, fingerprint and
+// graph of document refer to the final location of object.
+func (o *Orchestrator) moveProcessedObject(ctx kernel.Ctx, task *taskDomain.Task) {
+	if o.config.PostProcess.IsEnabledFor(task.BucketID) {
+		o.moveTaskObject(ctx, task, o.config.PostProcess.ProcessedPrefix, nil)
+	}
+}
+
+// cleanupMovedDocuments removes indexed documents of object moved under
+// processed prefix. Failures are only logged like failures of moving.
+func (o *Orchestrator) cleanupMovedDocuments(ctx kernel.Ctx, task *taskDomain.Task) {
+	if err := o.taskUC.DeleteDocuments(ctx, task.BucketID, task.Location); err != nil {
+		slog.Warn("processing",
+			slog.String("msg", "failed to cleanup index of failed task"),
+			slog.String("task-id", task.ID.String()),
+			slog.String("location", task.Location),
+			slog.String("err", err.Error()),
+		)
+	}
+}
+
+// moveTaskObject moves object of finished task from its current location
+// under prefix and keeps final location in task. Failures are only logged
+// because task itself has already been processed.
+func (o *Orchestrator) moveTaskObject(
+	ctx kernel.Ctx,
+	task *taskDomain.Task,
+	prefix string,
+	metadata map[string]string,
+) {
+	location := path.Join(prefix, task.ObjectID)
+	params := &domain.CopyObjectParams{
+		SourcePath:      task.CurrentObjectID(),
+		DestinationPath: location,
+		WithRemoving:    true,
+		Metadata:        metadata,
+	}
+
+	if err := o.storageUC.CopyObject(ctx, task.BucketID, params); err != nil {
+		slog.Warn("processing",
+			slog.String("msg", "failed to move processed object"),
+			slog.String("task-id", task.ID.String()),
+			slog.String("location", location),
+			slog.String("err", err.Error()),
+		)
+		return
+	}
+
+	task.SetLocation(location)
+}
+
+// processTask returns status text of successfully processed task.
+func (o *Orchestrator) processTask(ctx kernel.Ctx, task *taskDomain.Task) (string, error) {
 	ctx, span := otlp_go.GlobalTracer.Start(ctx, "task-processing")
 	defer span.End()
 
@@ -219,33 +581,151 @@ func (o *Orchestrator) processTask(ctx kernel.Ctx, task *taskDomain.Task) error
 		attribute.String("file-path", task.ObjectID),
 	)
 
-	fileData, err := o.storageUC.GetObjectData(ctx, task.BucketID, task.ObjectID)
+	objInfo, err := o.storageUC.GetObjectInfo(ctx, task.BucketID, task.ObjectID)
 	if err != nil {
 		err = fmt.Errorf("load object error: %w", err)
 		task.SetStatusAndText(taskDomain.Failed, err.Error())
 		span.SetStatus(codes.Error, err.Error())
 		span.RecordError(err)
-		return err
+		return "", err
+	}
+
+	task.SetObjectAttributes(objInfo.ContentType, withoutUploadedMark(objInfo.Metadata))
+	task.SetObjectTags(objInfo.Tags)
+	task.SetObjectVersion(objInfo.VersionID)
+	fileData, err := o.loadObjectData(ctx, task.BucketID, task.ObjectID, objInfo.VersionID)
+	if err != nil {
+		err = fmt.Errorf("load object error: %w", err)
+		task.SetStatusAndText(taskDomain.Failed, err.Error())
+		span.SetStatus(codes.Error, err.Error())
+		span.RecordError(err)
+		return "", err
 	}
 
 	task.SetObjectDataSize(fileData.Len())
+	if o.config.Antivirus.IsEnabledFor(task.BucketID) {
+		verdict, err := o.taskUC.ScanObject(ctx, task.BucketID, task.ObjectID, fileData)
+		if err != nil {
+			task.SetStatusAndText(taskDomain.Failed, "failed to scan object data")
+			err = fmt.Errorf("task processing failed: %w", err)
+			span.SetStatus(codes.Error, err.Error())
+			span.RecordError(err)
+			return "", err
+		}
+
+		if verdict.Infected {
+			msg, err := o.quarantineTask(ctx, task, verdict)
+			if err != nil {
+				task.SetStatusAndText(taskDomain.Failed, err.Error())
+				span.SetStatus(codes.Error, err.Error())
+				span.RecordError(err)
+				return "", err
+			}
+			return msg, nil
+		}
+	}
+
 	recData, err := o.taskUC.Recognize(ctx, task, fileData)
 	if err != nil {
 		task.SetStatusAndText(taskDomain.Failed, "failed to recognize object data")
 		err = fmt.Errorf("task processing failed: %w", err)
 		span.SetStatus(codes.Error, err.Error())
 		span.RecordError(err)
-		return err
+		return "", err
 	}
 
-	_, err = o.taskUC.StoreDocument(ctx, task, recData)
+	if o.config.Pii.IsEnabledFor(task.BucketID) {
+		action := o.config.Pii.ActionFor(task.BucketID)
+		if err = o.taskUC.DetectPii(ctx, task, recData, action); err != nil {
+			task.SetStatusAndText(taskDomain.Failed, "failed to detect personal data")
+			err = fmt.Errorf("task processing failed: %w", err)
+			span.SetStatus(codes.Error, err.Error())
+			span.RecordError(err)
+			return "", err
+		}
+
+		if action == pii.DropAction && task.HasPii() {
+			slog.Warn("processing",
+				slog.String("msg", "document with personal data has been dropped"),
+				slog.String("task-id", task.ID.String()),
+			)
+			o.moveProcessedObject(ctx, task)
+			return taskDomain.DroppedStatusText, nil
+		}
+	}
+
+	dedupConfig := o.config.Dedup
+	if dedupConfig.IsEnabledFor(task.BucketID) {
+		err = o.taskUC.FindNearDuplicate(ctx, task, recData, dedupConfig.MaxDistance, dedupConfig.Action)
+		if err != nil {
+			task.SetStatusAndText(taskDomain.Failed, "failed to find near-duplicate")
+			err = fmt.Errorf("task processing failed: %w", err)
+			span.SetStatus(codes.Error, err.Error())
+			span.RecordError(err)
+			return "", err
+		}
+
+		if task.Duplicate != nil && dedupConfig.Action == fingerprint.SkipAction {
+			msg := fmt.Sprintf("document has not been indexed: near-duplicate of %s", task.Duplicate.ObjectID)
+			slog.Info("processing",
+				slog.String("msg", msg),
+				slog.String("task-id", task.ID.String()),
+			)
+			o.moveProcessedObject(ctx, task)
+			return msg, nil
+		}
+	}
+
+	if o.config.Summary.IsEnabledFor(task.BucketID) {
+		taxonomy := o.config.Summary.TaxonomyFor(task.BucketID)
+		if err = o.taskUC.Summarize(ctx, task, recData, taxonomy); err != nil {
+			task.SetStatusAndText(taskDomain.Failed, "failed to summarize document")
+			err = fmt.Errorf("task processing failed: %w", err)
+			span.SetStatus(codes.Error, err.Error())
+			span.RecordError(err)
+			return "", err
+		}
+	}
+
+	o.moveProcessedObject(ctx, task)
+	docID, err := o.taskUC.StoreDocument(ctx, task, recData)
 	if err != nil {
 		task.SetStatusAndText(taskDomain.Failed, "failed to store document")
 		err = fmt.Errorf("task processing failed: %w", err)
 		span.SetStatus(codes.Error, err.Error())
 		span.RecordError(err)
-		return err
+		return "", err
 	}
 
-	return nil
+	if dedupConfig.IsEnabledFor(task.BucketID) && task.Duplicate == nil {
+		if err = o.taskUC.StoreFingerprint(ctx, task, docID); err != nil {
+			task.SetStatusAndText(taskDomain.Failed, "failed to store fingerprint")
+			err = fmt.Errorf("task processing failed: %w", err)
+			span.SetStatus(codes.Error, err.Error())
+			span.RecordError(err)
+			return "", err
+		}
+	}
+
+	if o.config.KnowledgeGraph.IsEnabledFor(task.BucketID) {
+		err = o.taskUC.BuildKnowledgeGraph(ctx, task, docID, recData)
+		if err != nil {
+			task.SetStatusAndText(taskDomain.Failed, "failed to build knowledge graph")
+			err = fmt.Errorf("task processing failed: %w", err)
+			span.SetStatus(codes.Error, err.Error())
+			span.RecordError(err)
+			return "", err
+		}
+	}
+
+	if recData.IsLowConfidence(o.config.LowConfidenceThreshold) {
+		slog.Warn("processing",
+			slog.String("msg", "low recognition confidence"),
+			slog.String("task-id", task.ID.String()),
+			slog.Float64("confidence", recData.Confidence),
+		)
+		return taskDomain.LowConfidenceStatusText, nil
+	}
+
+	return taskDomain.SuccessfulStatusText, nil
 }
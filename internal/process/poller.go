@@ -0,0 +1,288 @@
+package process
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/breadrock1/otlp-go/otlp"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+
+	"watchtower/internal/core/cloud/domain"
+	"watchtower/internal/shared/kernel"
+	"watchtower/internal/support/task/application/service/snapshot"
+)
+
+const (
+	PolledObjectCreated = "poller:ObjectCreated"
+	PolledObjectRemoved = "poller:ObjectRemoved"
+)
+
+// LaunchPoller periodically lists configured buckets and handles objects
+// created, changed or removed since previous poll. Only replica holding
+// leader lock polls buckets.
+func (o *Orchestrator) LaunchPoller(ctx kernel.Ctx, snapshots snapshot.ISnapshotStorage, lock snapshot.ILeaderLock) {
+	slog.Info("starting storage poller")
+	go func() {
+		ticker := time.NewTicker(time.Duration(o.config.Poller.Interval) * time.Second)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				o.pollBuckets(ctx, snapshots, lock)
+
+			case <-ctx.Done():
+				slog.Info("terminating storage poller")
+				if err := lock.Release(context.WithoutCancel(ctx)); err != nil {
+					slog.Warn("polling",
+						slog.String("msg", "failed to release leader lock"),
+						slog.String("err", err.Error()),
+					)
+				}
+				return
+			}
+		}
+	}()
+}
+
+func (o *Orchestrator) pollBuckets(ctx kernel.Ctx, snapshots snapshot.ISnapshotStorage, lock snapshot.ILeaderLock) {
+	lockTTL := time.Duration(o.config.Poller.LockTTL) * time.Second
+	isLeader, err := lock.Acquire(ctx, lockTTL)
+	if err != nil {
+		slog.Error("polling",
+			slog.String("msg", "failed to acquire leader lock"),
+			slog.String("err", err.Error()),
+		)
+		return
+	}
+
+	if !isLeader {
+		slog.Debug("polling", slog.String("msg", "buckets are polled by another replica"))
+		return
+	}
+
+	bucketIDs, err := o.polledBuckets(ctx)
+	if err != nil {
+		slog.Error("polling",
+			slog.String("msg", "failed to load buckets"),
+			slog.String("err", err.Error()),
+		)
+		return
+	}
+
+	for _, bucketID := range bucketIDs {
+		if err = o.PollBucket(ctx, snapshots, bucketID); err != nil {
+			slog.Warn("polling",
+				slog.String("bucket", bucketID),
+				slog.String("err", err.Error()),
+			)
+		}
+	}
+}
+
+func (o *Orchestrator) polledBuckets(ctx kernel.Ctx) ([]kernel.BucketID, error) {
+	if len(o.config.Poller.Buckets) > 0 {
+		return o.config.Poller.Buckets, nil
+	}
+
+	buckets, err := o.storageUC.GetAllBuckets(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	bucketIDs := make([]kernel.BucketID, len(buckets))
+	for index, bucket := range buckets {
+		bucketIDs[index] = bucket.ID
+	}
+
+	return bucketIDs, nil
+}
+
+// PollBucket compares objects of bucket with snapshot stored by previous
+// poll. Tasks are created for new and changed objects, documents of removed
+// objects are deleted from index. Objects which already have task created
+// after their modification, like uploads through the API, are skipped.
+// Failed objects keep previous state in snapshot and are retried by next poll.
+// The first poll of bucket stores snapshot without creating tasks, unless
+// existing objects are configured to be processed.
+func (o *Orchestrator) PollBucket(
+	ctx kernel.Ctx,
+	snapshots snapshot.ISnapshotStorage,
+	bucketID kernel.BucketID,
+) error {
+	ctx, span := otlp_go.GlobalTracer.Start(ctx, "poll-bucket")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("bucket", bucketID))
+
+	objects, err := o.walkBucketObjects(ctx, bucketID)
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		span.RecordError(err)
+		return err
+	}
+
+	prevSnapshot, err := snapshots.LoadSnapshot(ctx, bucketID)
+	if err != nil {
+		err = fmt.Errorf("failed to load snapshot: %w", err)
+		span.SetStatus(codes.Error, err.Error())
+		span.RecordError(err)
+		return err
+	}
+
+	if prevSnapshot == nil && !o.config.Poller.ProcessExisting {
+		if err = o.seedSnapshot(ctx, snapshots, bucketID, objects); err != nil {
+			span.SetStatus(codes.Error, err.Error())
+			span.RecordError(err)
+			return err
+		}
+
+		return nil
+	}
+
+	lastTaskCreatedAt, err := o.lastTaskCreatedAt(ctx, bucketID)
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		span.RecordError(err)
+		return err
+	}
+
+	var errs []error
+	curSnapshot := make(snapshot.Snapshot, len(objects))
+	for _, obj := range objects {
+		entry := snapshot.Entry{ETag: obj.ETag, LastModified: obj.LastModified}
+		prevEntry, found := prevSnapshot[obj.Path]
+		curSnapshot[obj.Path] = entry
+		if found && !prevEntry.IsChanged(entry) {
+			continue
+		}
+
+		if lastTaskCreatedAt[obj.Path] >= obj.LastModified.Unix() {
+			continue
+		}
+
+		event := &domain.ObjectEvent{
+			Type:     domain.ObjectCreated,
+			Name:     PolledObjectCreated,
+			BucketID: bucketID,
+			ObjectID: obj.Path,
+			Size:     obj.Size,
+			ETag:     obj.ETag,
+		}
+
+		if err = o.handlePolledEvent(ctx, event); err != nil {
+			errs = append(errs, err)
+			if found {
+				curSnapshot[obj.Path] = prevEntry
+			} else {
+				delete(curSnapshot, obj.Path)
+			}
+		}
+	}
+
+	listed := make(map[kernel.ObjectID]bool, len(objects))
+	for _, obj := range objects {
+		listed[obj.Path] = true
+	}
+
+	for objID, prevEntry := range prevSnapshot {
+		if listed[objID] {
+			continue
+		}
+
+		event := &domain.ObjectEvent{
+			Type:     domain.ObjectRemoved,
+			Name:     PolledObjectRemoved,
+			BucketID: bucketID,
+			ObjectID: objID,
+		}
+
+		if err = o.handlePolledEvent(ctx, event); err != nil {
+			errs = append(errs, err)
+			curSnapshot[objID] = prevEntry
+		}
+	}
+
+	if err = snapshots.StoreSnapshot(ctx, bucketID, curSnapshot); err != nil {
+		errs = append(errs, fmt.Errorf("failed to store snapshot: %w", err))
+	}
+
+	if err = errors.Join(errs...); err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		span.RecordError(err)
+		return err
+	}
+
+	return nil
+}
+
+// seedSnapshot stores state of objects existing before the first poll,
+// so only objects changed after it are processed.
+func (o *Orchestrator) seedSnapshot(
+	ctx kernel.Ctx,
+	snapshots snapshot.ISnapshotStorage,
+	bucketID kernel.BucketID,
+	objects []domain.Object,
+) error {
+	curSnapshot := make(snapshot.Snapshot, len(objects))
+	for _, obj := range objects {
+		curSnapshot[obj.Path] = snapshot.Entry{ETag: obj.ETag, LastModified: obj.LastModified}
+	}
+
+	if err := snapshots.StoreSnapshot(ctx, bucketID, curSnapshot); err != nil {
+		return fmt.Errorf("failed to store snapshot: %w", err)
+	}
+
+	slog.Info("polling",
+		slog.String("msg", "stored first snapshot of bucket"),
+		slog.String("bucket", bucketID),
+		slog.Int("objects", len(objects)),
+	)
+
+	return nil
+}
+
+func (o *Orchestrator) handlePolledEvent(ctx kernel.Ctx, event *domain.ObjectEvent) error {
+	action, err := o.dispatchObjectEvent(ctx, event)
+	if err != nil {
+		action = "failed"
+	}
+
+	recordObjectEvent(event, action)
+	return err
+}
+
+// walkBucketObjects walks objects under configured prefixes recursively,
+// objects under directories managed by watchtower like quarantine or
+// processed are skipped. Objects of overlapping prefixes are listed once.
+func (o *Orchestrator) walkBucketObjects(ctx kernel.Ctx, bucketID kernel.BucketID) ([]domain.Object, error) {
+	prefixes := o.config.Poller.Prefixes
+	if len(prefixes) == 0 {
+		prefixes = []string{""}
+	}
+
+	managedPrefixes := o.managedPrefixes(bucketID)
+	seen := make(map[kernel.ObjectID]bool)
+
+	var objects []domain.Object
+	for _, prefix := range prefixes {
+		err := o.storageUC.WalkBucketObjects(ctx, bucketID, prefix, func(obj domain.Object) error {
+			if obj.IsDirectory || seen[obj.Path] || isUnderPrefixes(obj.Path, managedPrefixes) {
+				return nil
+			}
+
+			seen[obj.Path] = true
+			objects = append(objects, obj)
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list polled objects: %w", err)
+		}
+	}
+
+	return objects, nil
+}
@@ -0,0 +1,252 @@
+package process
+
+import (
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"log/slog"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/breadrock1/otlp-go/otlp"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+
+	"watchtower/internal/core/cloud/domain"
+	"watchtower/internal/shared/kernel"
+	"watchtower/internal/support/task/application/service/upload"
+
+	taskDomain "watchtower/internal/support/task/domain"
+)
+
+var (
+	ErrInvalidChecksum    = errors.New("checksum must be hex encoded md5 or sha256")
+	ErrUploadNotReceived  = errors.New("uploaded object has not been received by storage")
+	ErrUploadVerification = errors.New("uploaded object does not match expected one")
+)
+
+// CreateUploadURLParams defines object uploaded directly into storage.
+// Size is required, Checksum is hex encoded MD5 or SHA-256 of content.
+type CreateUploadURLParams struct {
+	FilePath    string
+	Method      string
+	ContentType string
+	Size        int64
+	Checksum    string
+	Metadata    map[string]string
+}
+
+// CreateUploadURL stores ticket of expected object and returns presigned
+// request which uploads object into storage. Task of object is created by
+// CompleteUploadURL or by storage event of uploaded object.
+func (o *Orchestrator) CreateUploadURL(
+	ctx kernel.Ctx,
+	bucketID kernel.BucketID,
+	params *CreateUploadURLParams,
+) (*domain.PresignedUpload, error) {
+	ctx, span := otlp_go.GlobalTracer.Start(ctx, "create-upload-url")
+	defer span.End()
+
+	objID := path.Clean(params.FilePath)
+	span.SetAttributes(
+		attribute.String("bucket", bucketID),
+		attribute.String("file-path", objID),
+		attribute.Int64("size", params.Size),
+	)
+
+	config := o.config.PresignedUploads
+	if params.Size < 0 || (config.MaxSize > 0 && params.Size > config.MaxSize) {
+		err := fmt.Errorf("%w: max upload size is %d bytes", ErrUploadTooLarge, config.MaxSize)
+		span.SetStatus(codes.Error, err.Error())
+		span.RecordError(err)
+		return nil, err
+	}
+
+	checksum := strings.ToLower(params.Checksum)
+	if checksum != "" && newChecksumHash(checksum) == nil {
+		span.SetStatus(codes.Error, ErrInvalidChecksum.Error())
+		span.RecordError(ErrInvalidChecksum)
+		return nil, ErrInvalidChecksum
+	}
+
+	expiration := time.Duration(config.Expiration) * time.Second
+	ticket := upload.CreateNewTicket(bucketID, objID, params.Size, checksum)
+	ticket.ExpiresAt = ticket.CreatedAt.
+		Add(expiration).
+		Add(time.Duration(config.CompletionTimeout) * time.Second)
+
+	method := params.Method
+	if method == "" {
+		method = domain.PresignPutMethod
+	}
+
+	// Storage event of uploaded object completes ticket instead of
+	// creating task at once.
+	presignParams := &domain.PresignUploadParams{
+		FilePath:    objID,
+		Method:      method,
+		ContentType: params.ContentType,
+		Size:        params.Size,
+		Expired:     expiration,
+		Metadata:    params.Metadata,
+	}
+
+	presigned, err := o.storageUC.GenUploadURL(ctx, bucketID, presignParams)
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		span.RecordError(err)
+		return nil, err
+	}
+
+	if err = o.taskUC.StoreUploadTicket(ctx, ticket); err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		span.RecordError(err)
+		return nil, err
+	}
+
+	return presigned, nil
+}
+
+// CompleteUploadURL verifies object uploaded by presigned URL and creates
+// task of it. Ticket is kept when object has not been received or does
+// not match, so upload may be retried until ticket expires.
+func (o *Orchestrator) CompleteUploadURL(
+	ctx kernel.Ctx,
+	bucketID kernel.BucketID,
+	filePath string,
+) (*taskDomain.Task, error) {
+	ctx, span := otlp_go.GlobalTracer.Start(ctx, "complete-upload-url")
+	defer span.End()
+
+	objID := path.Clean(filePath)
+	span.SetAttributes(
+		attribute.String("bucket", bucketID),
+		attribute.String("file-path", objID),
+	)
+
+	ticket, err := o.taskUC.TakeUploadTicket(ctx, bucketID, objID)
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		span.RecordError(err)
+		return nil, err
+	}
+
+	// Storage event of object completed by request must not create second
+	// task, mark is taken back if upload may be retried.
+	o.markStoredObject(ctx, bucketID, objID)
+	task, err := o.completeUploadTicket(ctx, ticket)
+	if err != nil {
+		o.unmarkStoredObject(ctx, bucketID, objID)
+		span.SetStatus(codes.Error, err.Error())
+		span.RecordError(err)
+		return nil, err
+	}
+
+	return task, nil
+}
+
+// dispatchUploadedObject completes ticket of object uploaded by presigned URL.
+func (o *Orchestrator) dispatchUploadedObject(
+	ctx kernel.Ctx,
+	event *domain.ObjectEvent,
+	ticket *upload.Ticket,
+) (string, error) {
+	if _, err := o.completeUploadTicket(ctx, ticket); err != nil {
+		if errors.Is(err, ErrUploadVerification) {
+			slog.Warn("events",
+				slog.String("msg", "uploaded object has been rejected"),
+				slog.String("bucket", event.BucketID),
+				slog.String("file-path", event.ObjectID),
+				slog.String("err", err.Error()),
+			)
+			return "rejected", nil
+		}
+
+		return "", fmt.Errorf("failed to complete upload: %w", err)
+	}
+
+	return "task", nil
+}
+
+func (o *Orchestrator) completeUploadTicket(ctx kernel.Ctx, ticket *upload.Ticket) (*taskDomain.Task, error) {
+	err := o.verifyUploadedObject(ctx, ticket)
+	if err == nil {
+		return o.createObjectTask(ctx, ticket.BucketID, ticket.ObjectID)
+	}
+
+	if storeErr := o.taskUC.StoreUploadTicket(ctx, ticket); storeErr != nil {
+		slog.Warn("uploading",
+			slog.String("msg", "failed to restore upload ticket"),
+			slog.String("file-path", ticket.ObjectID),
+			slog.String("err", storeErr.Error()),
+		)
+	}
+
+	return nil, err
+}
+
+// verifyUploadedObject compares size and checksum of uploaded object with
+// ticket. MD5 checksum is compared with ETag of object uploaded by single
+// request, otherwise object content is downloaded to compute checksum.
+func (o *Orchestrator) verifyUploadedObject(ctx kernel.Ctx, ticket *upload.Ticket) error {
+	objInfo, err := o.storageUC.GetObjectInfo(ctx, ticket.BucketID, ticket.ObjectID)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrUploadNotReceived, err)
+	}
+
+	if objInfo.Size != ticket.Size {
+		return fmt.Errorf("%w: size is %d, expected %d", ErrUploadVerification, objInfo.Size, ticket.Size)
+	}
+
+	if ticket.Checksum == "" {
+		return nil
+	}
+
+	etag := strings.ToLower(strings.Trim(objInfo.ETag, `"`))
+	if len(ticket.Checksum) == md5.Size*2 && isHexChecksum(etag, md5.Size) {
+		if etag != ticket.Checksum {
+			return fmt.Errorf("%w: md5 checksum mismatch", ErrUploadVerification)
+		}
+		return nil
+	}
+
+	fileData, err := o.storageUC.GetObjectData(ctx, ticket.BucketID, ticket.ObjectID)
+	if err != nil {
+		return err
+	}
+	defer fileData.Reset()
+
+	hasher := newChecksumHash(ticket.Checksum)
+	_, _ = hasher.Write(fileData.Bytes())
+	if hex.EncodeToString(hasher.Sum(nil)) != ticket.Checksum {
+		return fmt.Errorf("%w: checksum mismatch", ErrUploadVerification)
+	}
+
+	return nil
+}
+
+// newChecksumHash returns hash of checksum by its length,
+// nil if checksum is neither md5 nor sha256.
+func newChecksumHash(checksum string) hash.Hash {
+	switch {
+	case isHexChecksum(checksum, md5.Size):
+		return md5.New()
+	case isHexChecksum(checksum, sha256.Size):
+		return sha256.New()
+	default:
+		return nil
+	}
+}
+
+func isHexChecksum(checksum string, size int) bool {
+	if len(checksum) != size*2 {
+		return false
+	}
+
+	_, err := hex.DecodeString(checksum)
+	return err == nil
+}
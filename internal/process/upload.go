@@ -0,0 +1,404 @@
+package process
+
+import (
+	"bytes"
+	"crypto/md5"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log/slog"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/breadrock1/otlp-go/otlp"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+
+	"watchtower/internal/core/cloud/domain"
+	"watchtower/internal/shared/kernel"
+	"watchtower/internal/support/task/application/service/upload"
+)
+
+// uploadLockTTL bounds lock of upload held by request which has been
+// interrupted before releasing it.
+const uploadLockTTL = 5 * time.Minute
+
+var (
+	ErrUploadOffsetMismatch = errors.New("upload offset does not match received bytes")
+	ErrUploadTooLarge       = errors.New("upload exceeds its length")
+)
+
+// CreateUploadParams defines resumable upload of object.
+type CreateUploadParams struct {
+	FilePath    string
+	Length      int64
+	ContentType string
+	Metadata    map[string]string
+}
+
+func (o *Orchestrator) UploadsMaxSize() int64 {
+	return o.config.Uploads.MaxSize
+}
+
+// CreateUpload starts multipart upload of object which chunks are sent
+// by WriteUpload. Empty object is stored at once.
+func (o *Orchestrator) CreateUpload(
+	ctx kernel.Ctx,
+	bucketID kernel.BucketID,
+	params *CreateUploadParams,
+) (*upload.Session, error) {
+	ctx, span := otlp_go.GlobalTracer.Start(ctx, "create-upload")
+	defer span.End()
+
+	objID := path.Clean(params.FilePath)
+	span.SetAttributes(
+		attribute.String("bucket", bucketID),
+		attribute.String("file-path", objID),
+		attribute.Int64("length", params.Length),
+	)
+
+	maxSize := o.config.Uploads.MaxSize
+	if params.Length < 0 || (maxSize > 0 && params.Length > maxSize) {
+		err := fmt.Errorf("%w: max upload size is %d bytes", ErrUploadTooLarge, maxSize)
+		span.SetStatus(codes.Error, err.Error())
+		span.RecordError(err)
+		return nil, err
+	}
+
+	session := upload.CreateNewSession(bucketID, objID, params.Length)
+	session.Metadata = params.Metadata
+	session.Prolong(o.uploadsExpiration())
+
+	storeParams := &domain.UploadObjectParams{
+		FilePath:    objID,
+		FileData:    bytes.NewBuffer(nil),
+		ContentType: params.ContentType,
+		Metadata:    params.Metadata,
+	}
+
+	if session.IsCompleted() {
+		o.markStoredObject(ctx, bucketID, objID)
+		if _, err := o.storageUC.StoreObject(ctx, bucketID, storeParams); err != nil {
+			o.unmarkStoredObject(ctx, bucketID, objID)
+			span.SetStatus(codes.Error, err.Error())
+			span.RecordError(err)
+			return nil, err
+		}
+
+		if _, err := o.CreateTask(ctx, bucketID, objID); err != nil {
+			span.SetStatus(codes.Error, err.Error())
+			span.RecordError(err)
+			return nil, err
+		}
+
+		return session, nil
+	}
+
+	multipartID, err := o.storageUC.CreateMultipartUpload(ctx, bucketID, storeParams)
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		span.RecordError(err)
+		return nil, err
+	}
+
+	session.MultipartID = multipartID
+	if err = o.taskUC.StoreUploadSession(ctx, session, nil); err != nil {
+		_ = o.storageUC.AbortMultipartUpload(ctx, bucketID, objID, multipartID)
+		span.SetStatus(codes.Error, err.Error())
+		span.RecordError(err)
+		return nil, err
+	}
+
+	return session, nil
+}
+
+// GetUpload returns session of upload into bucket.
+func (o *Orchestrator) GetUpload(
+	ctx kernel.Ctx,
+	bucketID kernel.BucketID,
+	uploadID kernel.UploadID,
+) (*upload.Session, error) {
+	session, err := o.taskUC.GetUploadSession(ctx, uploadID)
+	if err != nil {
+		return nil, err
+	}
+
+	if session.BucketID != bucketID {
+		return nil, upload.ErrSessionNotFound
+	}
+
+	return session, nil
+}
+
+// WriteUpload appends chunk received at offset to upload. Received bytes
+// are sent to storage as part when they fill a whole part, the rest is kept
+// pending until next chunk. Upload is completed and task is created when
+// all bytes have been received.
+func (o *Orchestrator) WriteUpload(
+	ctx kernel.Ctx,
+	bucketID kernel.BucketID,
+	uploadID kernel.UploadID,
+	offset int64,
+	data []byte,
+) (*upload.Session, error) {
+	ctx, span := otlp_go.GlobalTracer.Start(ctx, "write-upload")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("bucket", bucketID),
+		attribute.String("upload-id", uploadID.String()),
+		attribute.Int64("offset", offset),
+		attribute.Int("chunk-size", len(data)),
+	)
+
+	// Offset is checked and advanced under lock, so concurrent chunks
+	// of the same upload are never appended both.
+	token, err := o.taskUC.LockUploadSession(ctx, uploadID, uploadLockTTL)
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		span.RecordError(err)
+		return nil, err
+	}
+	defer o.unlockUpload(ctx, uploadID, token)
+
+	session, err := o.GetUpload(ctx, bucketID, uploadID)
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		span.RecordError(err)
+		return nil, err
+	}
+
+	if offset != session.Offset {
+		err = fmt.Errorf("%w: expected offset %d", ErrUploadOffsetMismatch, session.Offset)
+		span.SetStatus(codes.Error, err.Error())
+		span.RecordError(err)
+		return nil, err
+	}
+
+	if offset+int64(len(data)) > session.Length {
+		err = fmt.Errorf("%w: upload length is %d bytes", ErrUploadTooLarge, session.Length)
+		span.SetStatus(codes.Error, err.Error())
+		span.RecordError(err)
+		return nil, err
+	}
+
+	pending, err := o.taskUC.LoadUploadPending(ctx, uploadID)
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		span.RecordError(err)
+		return nil, err
+	}
+
+	pending = append(pending, data...)
+	session.Offset += int64(len(data))
+
+	isPartFilled := int64(len(pending)) >= o.config.Uploads.PartSize
+	if isPartFilled || (session.IsCompleted() && len(pending) > 0) {
+		part, err := o.uploadSessionPart(ctx, session, pending)
+		if err != nil {
+			span.SetStatus(codes.Error, err.Error())
+			span.RecordError(err)
+			return nil, err
+		}
+
+		session.AddPart(upload.Part{Number: part.Number, ETag: part.ETag})
+		pending = nil
+	}
+
+	if session.IsCompleted() {
+		if err = o.completeUpload(ctx, session); err != nil {
+			span.SetStatus(codes.Error, err.Error())
+			span.RecordError(err)
+			return nil, err
+		}
+
+		return session, nil
+	}
+
+	session.Prolong(o.uploadsExpiration())
+	if err = o.taskUC.StoreUploadSession(ctx, session, pending); err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		span.RecordError(err)
+		return nil, err
+	}
+
+	return session, nil
+}
+
+// uploadSessionPart uploads pending bytes as next part of session. Part
+// uploaded by request which failed to store session is not uploaded again
+// when the same bytes are sent by retried request.
+func (o *Orchestrator) uploadSessionPart(
+	ctx kernel.Ctx,
+	session *upload.Session,
+	pending []byte,
+) (domain.MultipartPart, error) {
+	number := session.NextPartNumber()
+	uploaded, err := o.storageUC.GetUploadedPart(ctx, session.BucketID, session.ObjectID, session.MultipartID, number)
+	if err != nil {
+		return domain.MultipartPart{}, err
+	}
+
+	checksum := md5.Sum(pending)
+	if uploaded != nil && strings.Trim(uploaded.ETag, `"`) == hex.EncodeToString(checksum[:]) {
+		return *uploaded, nil
+	}
+
+	return o.storageUC.UploadPart(ctx, session.BucketID, session.ObjectID, session.MultipartID, number, bytes.NewBuffer(pending))
+}
+
+func (o *Orchestrator) unlockUpload(ctx kernel.Ctx, uploadID kernel.UploadID, token string) {
+	if err := o.taskUC.UnlockUploadSession(ctx, uploadID, token); err != nil {
+		slog.Warn("uploading",
+			slog.String("msg", "failed to unlock upload"),
+			slog.String("upload-id", uploadID.String()),
+			slog.String("err", err.Error()),
+		)
+	}
+}
+
+func (o *Orchestrator) completeUpload(ctx kernel.Ctx, session *upload.Session) error {
+	parts := make([]domain.MultipartPart, len(session.Parts))
+	for index, part := range session.Parts {
+		parts[index] = domain.MultipartPart{Number: part.Number, ETag: part.ETag}
+	}
+
+	o.markStoredObject(ctx, session.BucketID, session.ObjectID)
+	err := o.storageUC.CompleteMultipartUpload(ctx, session.BucketID, session.ObjectID, session.MultipartID, parts)
+	if err != nil {
+		o.unmarkStoredObject(ctx, session.BucketID, session.ObjectID)
+		return err
+	}
+
+	if err = o.taskUC.DeleteUploadSession(ctx, session.ID); err != nil {
+		slog.Warn("uploading",
+			slog.String("msg", "failed to delete completed upload"),
+			slog.String("upload-id", session.ID.String()),
+			slog.String("err", err.Error()),
+		)
+	}
+
+	_, err = o.createObjectTask(ctx, session.BucketID, session.ObjectID)
+	return err
+}
+
+// TerminateUpload aborts upload and removes uploaded parts. Upload is
+// loaded under lock, so upload being written by another request is never
+// aborted, ErrSessionLocked is returned instead.
+func (o *Orchestrator) TerminateUpload(ctx kernel.Ctx, bucketID kernel.BucketID, uploadID kernel.UploadID) error {
+	token, err := o.taskUC.LockUploadSession(ctx, uploadID, uploadLockTTL)
+	if err != nil {
+		return err
+	}
+	defer o.unlockUpload(ctx, uploadID, token)
+
+	session, err := o.GetUpload(ctx, bucketID, uploadID)
+	if err != nil {
+		return err
+	}
+
+	return o.abortUpload(ctx, session)
+}
+
+// abortExpiredUpload aborts upload if it is still expired when it is loaded
+// under lock, so upload completed or prolonged meanwhile is kept. Returns
+// whether upload has been aborted.
+func (o *Orchestrator) abortExpiredUpload(ctx kernel.Ctx, uploadID kernel.UploadID) (bool, error) {
+	token, err := o.taskUC.LockUploadSession(ctx, uploadID, uploadLockTTL)
+	if err != nil {
+		return false, err
+	}
+	defer o.unlockUpload(ctx, uploadID, token)
+
+	session, err := o.taskUC.GetUploadSession(ctx, uploadID)
+	if errors.Is(err, upload.ErrSessionNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	if !session.IsExpired() {
+		return false, nil
+	}
+
+	return true, o.abortUpload(ctx, session)
+}
+
+func (o *Orchestrator) abortUpload(ctx kernel.Ctx, session *upload.Session) error {
+	err := o.storageUC.AbortMultipartUpload(ctx, session.BucketID, session.ObjectID, session.MultipartID)
+	if err != nil {
+		slog.Warn("uploading",
+			slog.String("msg", "failed to abort multipart upload"),
+			slog.String("upload-id", session.ID.String()),
+			slog.String("err", err.Error()),
+		)
+	}
+
+	return o.taskUC.DeleteUploadSession(ctx, session.ID)
+}
+
+// LaunchUploadsCleaner periodically aborts expired uploads.
+func (o *Orchestrator) LaunchUploadsCleaner(ctx kernel.Ctx) {
+	slog.Info("starting expired uploads cleaner")
+	go func() {
+		ticker := time.NewTicker(time.Duration(o.config.Uploads.CleanupInterval) * time.Second)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				if _, err := o.CleanupUploads(ctx); err != nil {
+					slog.Error("uploading",
+						slog.String("msg", "failed to cleanup expired uploads"),
+						slog.String("err", err.Error()),
+					)
+				}
+
+			case <-ctx.Done():
+				slog.Info("terminating expired uploads cleaner")
+				return
+			}
+		}
+	}()
+}
+
+// CleanupUploads aborts uploads which have not received chunks for
+// configured expiration, returns count of aborted uploads. Uploads being
+// written are skipped.
+func (o *Orchestrator) CleanupUploads(ctx kernel.Ctx) (int, error) {
+	sessions, err := o.taskUC.GetUploadSessions(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	aborted := 0
+	for _, session := range sessions {
+		if !session.IsExpired() {
+			continue
+		}
+
+		isAborted, err := o.abortExpiredUpload(ctx, session.ID)
+		if errors.Is(err, upload.ErrSessionLocked) {
+			continue
+		}
+		if err != nil {
+			slog.Warn("uploading",
+				slog.String("upload-id", session.ID.String()),
+				slog.String("err", err.Error()),
+			)
+			continue
+		}
+
+		if isAborted {
+			aborted++
+		}
+	}
+
+	return aborted, nil
+}
+
+func (o *Orchestrator) uploadsExpiration() time.Duration {
+	return time.Duration(o.config.Uploads.Expiration) * time.Second
+}
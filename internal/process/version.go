@@ -0,0 +1,64 @@
+package process
+
+import (
+	"path"
+
+	"github.com/breadrock1/otlp-go/otlp"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+
+	"watchtower/internal/core/cloud/domain"
+	"watchtower/internal/shared/kernel"
+
+	taskDomain "watchtower/internal/support/task/domain"
+)
+
+// RestoreObjectVersion restores version of object as current one and creates
+// task, so index reflects restored content. Restored object is marked as
+// stored by watchtower, so its storage event does not create second task.
+func (o *Orchestrator) RestoreObjectVersion(
+	ctx kernel.Ctx,
+	bucketID kernel.BucketID,
+	filePath string,
+	versionID string,
+) (*taskDomain.Task, error) {
+	ctx, span := otlp_go.GlobalTracer.Start(ctx, "restore-object-version")
+	defer span.End()
+
+	objID := path.Clean(filePath)
+	span.SetAttributes(
+		attribute.String("bucket", bucketID),
+		attribute.String("file-path", objID),
+		attribute.String("version-id", versionID),
+	)
+
+	params := &domain.RestoreObjectParams{
+		FilePath:  objID,
+		VersionID: versionID,
+	}
+
+	o.markStoredObject(ctx, bucketID, objID)
+	if _, err := o.storageUC.RestoreObjectVersion(ctx, bucketID, params); err != nil {
+		o.unmarkStoredObject(ctx, bucketID, objID)
+		span.SetStatus(codes.Error, err.Error())
+		span.RecordError(err)
+		return nil, err
+	}
+
+	return o.CreateTask(ctx, bucketID, objID)
+}
+
+// loadObjectData loads content of processed version of object, so content
+// and attributes of task are not mixed up by concurrent object updates.
+func (o *Orchestrator) loadObjectData(
+	ctx kernel.Ctx,
+	bucketID kernel.BucketID,
+	objID kernel.ObjectID,
+	versionID string,
+) (domain.ObjectData, error) {
+	if versionID == "" {
+		return o.storageUC.GetObjectData(ctx, bucketID, objID)
+	}
+
+	return o.storageUC.GetObjectVersionData(ctx, bucketID, objID, versionID)
+}
@@ -0,0 +1,143 @@
+package process
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/breadrock1/otlp-go/otlp"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+
+	"watchtower/internal/core/cloud/domain"
+	"watchtower/internal/shared/kernel"
+
+	taskDomain "watchtower/internal/support/task/domain"
+)
+
+// LaunchWatchers periodically scans directories of registered watchers
+// and creates tasks for objects created or changed since previous scan.
+func (o *Orchestrator) LaunchWatchers(ctx kernel.Ctx) {
+	slog.Info("starting directory watchers")
+	go func() {
+		ticker := time.NewTicker(time.Duration(o.config.Watcher.Interval) * time.Second)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				o.scanWatchers(ctx)
+
+			case <-ctx.Done():
+				slog.Info("terminating directory watchers")
+				return
+			}
+		}
+	}()
+}
+
+func (o *Orchestrator) scanWatchers(ctx kernel.Ctx) {
+	watchers, err := o.taskUC.GetWatchers(ctx)
+	if err != nil {
+		slog.Error("watching",
+			slog.String("msg", "failed to load watchers"),
+			slog.String("err", err.Error()),
+		)
+		return
+	}
+
+	for _, watcher := range watchers {
+		if _, err = o.ScanWatcher(ctx, watcher); err != nil {
+			slog.Warn("watching",
+				slog.String("watcher-id", watcher.ID.String()),
+				slog.String("err", err.Error()),
+			)
+		}
+	}
+}
+
+// ScanWatcher creates tasks for objects of watched directory and its
+// subdirectories modified since its last scan. Objects which already have task created after
+// their modification, like uploads through the API, are skipped.
+func (o *Orchestrator) ScanWatcher(ctx kernel.Ctx, watcher *taskDomain.Watcher) ([]*taskDomain.Task, error) {
+	ctx, span := otlp_go.GlobalTracer.Start(ctx, "scan-watcher")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("watcher-id", watcher.ID.String()),
+		attribute.String("bucket", watcher.BucketID),
+		attribute.String("prefix", watcher.Prefix),
+	)
+
+	scanAt := time.Now()
+	lastTaskCreatedAt, err := o.lastTaskCreatedAt(ctx, watcher.BucketID)
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		span.RecordError(err)
+		return nil, err
+	}
+
+	// Objects moved by watchtower under managed prefixes have been
+	// processed already, so they are never watched.
+	managedPrefixes := o.managedPrefixes(watcher.BucketID)
+
+	var tasks []*taskDomain.Task
+	err = o.storageUC.WalkBucketObjects(ctx, watcher.BucketID, watcher.Prefix, func(obj domain.Object) error {
+		if obj.IsDirectory || !obj.LastModified.After(watcher.LastScanAt) {
+			return nil
+		}
+
+		if isUnderPrefixes(obj.Path, managedPrefixes) {
+			return nil
+		}
+
+		if lastTaskCreatedAt[obj.Path] >= obj.LastModified.Unix() {
+			return nil
+		}
+
+		task, err := o.CreateTask(ctx, watcher.BucketID, obj.Path)
+		if err != nil {
+			return fmt.Errorf("failed to create task of watched object: %w", err)
+		}
+
+		tasks = append(tasks, task)
+		return nil
+	})
+	if err != nil {
+		err = fmt.Errorf("failed to scan watched objects: %w", err)
+		span.SetStatus(codes.Error, err.Error())
+		span.RecordError(err)
+		return tasks, err
+	}
+
+	// Watcher removed during scan is not stored again.
+	watcher.SetLastScanAt(scanAt)
+	err = o.taskUC.UpdateWatcherScanTime(ctx, watcher.ID, scanAt)
+	if err != nil && !errors.Is(err, taskDomain.ErrWatcherNotFound) {
+		err = fmt.Errorf("failed to store watcher scan time: %w", err)
+		span.SetStatus(codes.Error, err.Error())
+		span.RecordError(err)
+		return tasks, err
+	}
+
+	return tasks, nil
+}
+
+// lastTaskCreatedAt returns unix time of the latest task of each object
+// of bucket. Task storage keeps timestamps with seconds precision.
+func (o *Orchestrator) lastTaskCreatedAt(ctx kernel.Ctx, bucketID kernel.BucketID) (map[kernel.ObjectID]int64, error) {
+	bucketTasks, err := o.taskUC.GetBucketTasks(ctx, bucketID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load bucket tasks: %w", err)
+	}
+
+	createdAt := make(map[kernel.ObjectID]int64, len(bucketTasks))
+	for _, task := range bucketTasks {
+		if task != nil {
+			createdAt[task.ObjectID] = max(createdAt[task.ObjectID], task.CreatedAt.Unix())
+		}
+	}
+
+	return createdAt, nil
+}
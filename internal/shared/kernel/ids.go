@@ -17,3 +17,9 @@ type MessageID = uuid.UUID
 // TaskID is a unique identifier for a task using UUID v4.
 // This ensures globally unique task identifiers across distributed systems.
 type TaskID = uuid.UUID
+
+// WatcherID is a unique identifier for a directory watcher using UUID v4.
+type WatcherID = uuid.UUID
+
+// UploadID is a unique identifier for a resumable upload using UUID v4.
+type UploadID = uuid.UUID
@@ -10,10 +10,18 @@ var (
 	UploadedFilesCounter          *prometheus.CounterVec
 	CreatedProcessingTasksCounter *prometheus.CounterVec
 	OrchestratorProcessingCounter *prometheus.CounterVec
+	ExpandedArchivesCounter       *prometheus.CounterVec
+	DetectedPiiCounter            *prometheus.CounterVec
+	InfectedFilesCounter          *prometheus.CounterVec
+	NearDuplicatesCounter         *prometheus.CounterVec
+	ObjectEventsCounter           *prometheus.CounterVec
 
 	OrchestratorProcessingDurationSeconds *prometheus.HistogramVec
 	RecognizerDurationSeconds             *prometheus.HistogramVec
 	StoreProcessedDocumentDurationSeconds *prometheus.HistogramVec
+	KnowledgeGraphDurationSeconds         *prometheus.HistogramVec
+	SummarizationDurationSeconds          *prometheus.HistogramVec
+	AntivirusScanDurationSeconds          *prometheus.HistogramVec
 )
 
 func init() {
@@ -49,6 +57,46 @@ func init() {
 		[]string{"service", "status"},
 	)
 
+	ExpandedArchivesCounter = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "watchtower_expanded_archives_total",
+			Help: "Total number of expanded uploaded archives",
+		},
+		[]string{"service", "is_failed"},
+	)
+
+	DetectedPiiCounter = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "watchtower_detected_pii_total",
+			Help: "Total number of personal data detected in documents",
+		},
+		[]string{"service", "type", "action"},
+	)
+
+	InfectedFilesCounter = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "watchtower_infected_files_total",
+			Help: "Total number of infected files moved to quarantine",
+		},
+		[]string{"service", "signature"},
+	)
+
+	NearDuplicatesCounter = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "watchtower_near_duplicates_total",
+			Help: "Total number of detected near-duplicate documents",
+		},
+		[]string{"service", "action"},
+	)
+
+	ObjectEventsCounter = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "watchtower_object_events_total",
+			Help: "Total number of received storage object events",
+		},
+		[]string{"service", "event", "action"},
+	)
+
 	OrchestratorProcessingDurationSeconds = promauto.NewHistogramVec(
 		prometheus.HistogramOpts{
 			Name: "watchtower_orchestrator_processing_duration_seconds",
@@ -72,4 +120,28 @@ func init() {
 		},
 		[]string{"service", "is_failed"},
 	)
+
+	KnowledgeGraphDurationSeconds = promauto.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name: "watchtower_knowledge_graph_duration_seconds",
+			Help: "Latency of extracting entities and storing knowledge graph",
+		},
+		[]string{"service", "is_failed"},
+	)
+
+	SummarizationDurationSeconds = promauto.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name: "watchtower_summarization_duration_seconds",
+			Help: "Latency of summarizing and classifying document",
+		},
+		[]string{"service", "is_failed"},
+	)
+
+	AntivirusScanDurationSeconds = promauto.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name: "watchtower_antivirus_scan_duration_seconds",
+			Help: "Latency of scanning file by antivirus",
+		},
+		[]string{"service", "is_failed"},
+	)
 }
@@ -29,6 +29,28 @@ func PUT(ctx kernel.Ctx, body *bytes.Buffer, url, mime string, timeout time.Dura
 	return sendRequest(ctx, client, req)
 }
 
+// PUTWithHeaders sends request with specified headers like Accept
+// or content negotiation hints of remote service.
+func PUTWithHeaders(
+	ctx kernel.Ctx,
+	body *bytes.Buffer,
+	url string,
+	headers map[string]string,
+	timeout time.Duration,
+) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, url, body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+
+	client := &http.Client{Timeout: timeout}
+	return sendRequest(ctx, client, req)
+}
+
 func POST(ctx kernel.Ctx, body *bytes.Buffer, url, mime string, timeout time.Duration) ([]byte, error) {
 	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, body)
 	if err != nil {
@@ -40,6 +62,38 @@ func POST(ctx kernel.Ctx, body *bytes.Buffer, url, mime string, timeout time.Dur
 	return sendRequest(ctx, client, req)
 }
 
+// POSTWithHeaders sends request with specified headers like
+// Authorization of remote service.
+func POSTWithHeaders(
+	ctx kernel.Ctx,
+	body *bytes.Buffer,
+	url string,
+	headers map[string]string,
+	timeout time.Duration,
+) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+
+	client := &http.Client{Timeout: timeout}
+	return sendRequest(ctx, client, req)
+}
+
+func DELETE(ctx kernel.Ctx, url string, timeout time.Duration) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	client := &http.Client{Timeout: timeout}
+	return sendRequest(ctx, client, req)
+}
+
 func sendRequest(ctx kernel.Ctx, client *http.Client, req *http.Request) ([]byte, error) {
 	ctx, span := otlp_go.GlobalTracer.Start(ctx, "http-request")
 	defer span.End()
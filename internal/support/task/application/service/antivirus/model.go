@@ -0,0 +1,7 @@
+package antivirus
+
+// Verdict of scanned file. Signature is name of detected malware.
+type Verdict struct {
+	Infected  bool
+	Signature string
+}
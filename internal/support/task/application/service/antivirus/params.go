@@ -0,0 +1,8 @@
+package antivirus
+
+import "bytes"
+
+type ScanParams struct {
+	FileName string
+	FileData *bytes.Buffer
+}
@@ -0,0 +1,9 @@
+package antivirus
+
+import (
+	"watchtower/internal/shared/kernel"
+)
+
+type IScanner interface {
+	Scan(ctx kernel.Ctx, params *ScanParams) (*Verdict, error)
+}
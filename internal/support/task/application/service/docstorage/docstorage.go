@@ -6,4 +6,10 @@ import (
 
 type IDocumentStorage interface {
 	StoreDocument(ctx kernel.Ctx, document *Document) (DocumentID, error)
+
+	// DeleteDocuments removes documents of object stored by path from index.
+	DeleteDocuments(ctx kernel.Ctx, index string, filePath string) error
+
+	// MoveDocuments changes path of documents of object moved within index.
+	MoveDocuments(ctx kernel.Ctx, index string, srcPath string, dstPath string) error
 }
@@ -5,11 +5,29 @@ import "time"
 type DocumentID = string
 
 type Document struct {
-	Index      string
-	Name       string
-	Path       string
-	Size       int
-	Content    string
-	CreatedAt  time.Time
-	ModifiedAt time.Time
+	Index       string
+	Name        string
+	Path        string
+	Size        int
+	ContentType string
+	Metadata    map[string]string
+	Tags        map[string]string
+	VersionID   string
+	Properties  map[string]string
+	Content     string
+	Pages       []Page
+	PageCount   int
+	Language    string
+	Confidence  float64
+	Summary     string
+	Labels      []string
+	PiiCounts   map[string]int
+	DuplicateOf DocumentID
+	CreatedAt   time.Time
+	ModifiedAt  time.Time
+}
+
+type Page struct {
+	Number int
+	Text   string
 }
@@ -0,0 +1,9 @@
+package entity
+
+import (
+	"watchtower/internal/shared/kernel"
+)
+
+type IEntityExtractor interface {
+	Extract(ctx kernel.Ctx, params *ExtractParams) (*Extracted, error)
+}
@@ -0,0 +1,17 @@
+package entity
+
+type Entity struct {
+	Name string
+	Type string
+}
+
+type Relation struct {
+	Source string
+	Target string
+	Type   string
+}
+
+type Extracted struct {
+	Entities  []Entity
+	Relations []Relation
+}
@@ -0,0 +1,6 @@
+package entity
+
+type ExtractParams struct {
+	FileName string
+	Text     string
+}
@@ -0,0 +1,52 @@
+package fingerprint
+
+import (
+	"fmt"
+	"math/bits"
+	"strconv"
+
+	"watchtower/internal/shared/kernel"
+)
+
+// Action defines what happens with near-duplicate document.
+type Action string
+
+const (
+	// ReportAction stores near-duplicate to index and reports it on task.
+	ReportAction Action = "report"
+
+	// SkipAction skips storing of near-duplicate to index.
+	SkipAction Action = "skip"
+
+	// LinkAction stores near-duplicate to index with reference
+	// to canonical document.
+	LinkAction Action = "link"
+)
+
+// Fingerprint is 64-bit SimHash of text.
+type Fingerprint uint64
+
+func ParseFingerprint(value string) (Fingerprint, error) {
+	parsed, err := strconv.ParseUint(value, 16, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid fingerprint %s: %w", value, err)
+	}
+
+	return Fingerprint(parsed), nil
+}
+
+func (f Fingerprint) String() string {
+	return fmt.Sprintf("%016x", uint64(f))
+}
+
+// Distance returns hamming distance between fingerprints.
+func (f Fingerprint) Distance(other Fingerprint) int {
+	return bits.OnesCount64(uint64(f ^ other))
+}
+
+// Record is fingerprint of canonical document stored to index.
+type Record struct {
+	Fingerprint Fingerprint
+	ObjectID    kernel.ObjectID
+	DocumentID  string
+}
@@ -0,0 +1,52 @@
+package fingerprint
+
+import (
+	"hash/fnv"
+	"strings"
+	"unicode"
+)
+
+// shingleSize is count of characters hashed together. Short character
+// shingles keep fingerprint stable against single OCR mistakes.
+const shingleSize = 3
+
+// SimHash computes fingerprint of text which differs by few bits for
+// similar texts like different scans of the same paper document.
+// Text is normalized to lowercase words separated by single space.
+func SimHash(text string) Fingerprint {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+
+	runes := []rune(strings.Join(words, " "))
+	if len(runes) == 0 {
+		return 0
+	}
+
+	size := min(shingleSize, len(runes))
+	hasher := fnv.New64a()
+
+	var weights [64]int
+	for index := 0; index+size <= len(runes); index++ {
+		hasher.Reset()
+		_, _ = hasher.Write([]byte(string(runes[index : index+size])))
+
+		hash := hasher.Sum64()
+		for bit := range weights {
+			if hash&(1<<bit) != 0 {
+				weights[bit]++
+			} else {
+				weights[bit]--
+			}
+		}
+	}
+
+	var fingerprint uint64
+	for bit, weight := range weights {
+		if weight > 0 {
+			fingerprint |= 1 << bit
+		}
+	}
+
+	return Fingerprint(fingerprint)
+}
@@ -0,0 +1,29 @@
+package fingerprint
+
+import (
+	"watchtower/internal/shared/kernel"
+)
+
+type IFingerprintStorage interface {
+	// FindNearest returns stored record with the least hamming distance to
+	// fingerprint not exceeding maxDistance, nil if there is no such record.
+	// Record of objID itself is skipped, so reprocessed object never matches
+	// its own previous fingerprint.
+	FindNearest(
+		ctx kernel.Ctx,
+		bucketID kernel.BucketID,
+		objID kernel.ObjectID,
+		fingerprint Fingerprint,
+		maxDistance int,
+	) (*Record, error)
+
+	// StoreRecord stores record replacing previous record of the same object.
+	StoreRecord(ctx kernel.Ctx, bucketID kernel.BucketID, record *Record) error
+
+	// DeleteRecord removes record of object, objects without record are ignored.
+	DeleteRecord(ctx kernel.Ctx, bucketID kernel.BucketID, objID kernel.ObjectID) error
+
+	// MoveRecord replaces record of dstObjID by record of moved srcObjID,
+	// objects without record are ignored.
+	MoveRecord(ctx kernel.Ctx, bucketID kernel.BucketID, srcObjID, dstObjID kernel.ObjectID) error
+}
@@ -0,0 +1,12 @@
+package graph
+
+import (
+	"watchtower/internal/shared/kernel"
+)
+
+type IGraphStore interface {
+	StoreGraph(ctx kernel.Ctx, graph *Graph) error
+
+	// MoveDocument changes path of document of object moved within index.
+	MoveDocument(ctx kernel.Ctx, index, srcPath, dstPath string) error
+}
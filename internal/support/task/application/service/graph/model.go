@@ -0,0 +1,21 @@
+package graph
+
+// Graph contains entity nodes and relation edges linked to the source document.
+type Graph struct {
+	DocumentID string
+	Index      string
+	Path       string
+	Nodes      []Node
+	Edges      []Edge
+}
+
+type Node struct {
+	Name string
+	Type string
+}
+
+type Edge struct {
+	Source string
+	Target string
+	Type   string
+}
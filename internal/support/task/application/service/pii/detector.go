@@ -0,0 +1,9 @@
+package pii
+
+import (
+	"watchtower/internal/shared/kernel"
+)
+
+type IDetector interface {
+	Detect(ctx kernel.Ctx, text string) ([]Match, error)
+}
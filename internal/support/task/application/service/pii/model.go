@@ -0,0 +1,62 @@
+package pii
+
+import (
+	"sort"
+	"strings"
+)
+
+// Action defines what happens with document containing personal data.
+type Action string
+
+const (
+	// MaskAction replaces detected personal data by type placeholder.
+	MaskAction Action = "mask"
+
+	// DropAction skips storing of document to index.
+	DropAction Action = "drop"
+
+	// TagAction stores document as is with counts of detected data.
+	TagAction Action = "tag"
+)
+
+const (
+	PassportType = "passport"
+	PhoneType    = "phone"
+	EmailType    = "email"
+	CardType     = "card"
+)
+
+// Match is personal data found in text, Start and End are byte offsets.
+type Match struct {
+	Type  string
+	Start int
+	End   int
+}
+
+func CountByType(matches []Match) map[string]int {
+	counts := make(map[string]int)
+	for _, match := range matches {
+		counts[match.Type]++
+	}
+
+	return counts
+}
+
+// Redact replaces matches in text by placeholders like [PHONE].
+// Matches must not overlap each other.
+func Redact(text string, matches []Match) string {
+	sorted := make([]Match, len(matches))
+	copy(sorted, matches)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Start < sorted[j].Start })
+
+	var builder strings.Builder
+	offset := 0
+	for _, match := range sorted {
+		builder.WriteString(text[offset:match.Start])
+		builder.WriteString("[" + strings.ToUpper(match.Type) + "]")
+		offset = match.End
+	}
+	builder.WriteString(text[offset:])
+
+	return builder.String()
+}
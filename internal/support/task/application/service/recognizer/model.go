@@ -2,4 +2,31 @@ package recognizer
 
 type Recognized struct {
 	Text string
+
+	// Pages holds text per page when recognizer splits document by pages
+	Pages []Page
+
+	// PageCount is total count of document pages, zero when it is unknown
+	PageCount int
+
+	// Language is detected language code of document text
+	Language string
+
+	// Confidence is OCR confidence in range (0, 1],
+	// zero means that recognizer does not estimate it
+	Confidence float64
+
+	// Metadata holds document properties like title, author and
+	// creation date when recognizer is able to extract them
+	Metadata map[string]string
+}
+
+type Page struct {
+	Number int
+	Text   string
+}
+
+// IsLowConfidence reports whether estimated confidence is below threshold.
+func (r *Recognized) IsLowConfidence(threshold float64) bool {
+	return r.Confidence > 0 && r.Confidence < threshold
 }
@@ -1,8 +1,79 @@
 package recognizer
 
-import "bytes"
+import (
+	"bytes"
+	"mime"
+	"path"
+	"strings"
+)
+
+const DefaultMimeType = "application/octet-stream"
+
+// extensionMimeTypes does not depend on system mime.types table.
+var extensionMimeTypes = map[string]string{
+	".txt":      "text/plain",
+	".md":       "text/markdown",
+	".markdown": "text/markdown",
+	".csv":      "text/csv",
+	".tsv":      "text/tab-separated-values",
+	".json":     "application/json",
+	".html":     "text/html",
+	".htm":      "text/html",
+	".eml":      "message/rfc822",
+	".pdf":      "application/pdf",
+	".docx":     "application/vnd.openxmlformats-officedocument.wordprocessingml.document",
+	".xlsx":     "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+	".pptx":     "application/vnd.openxmlformats-officedocument.presentationml.presentation",
+}
+
+// genericMimeTypes are detected by content sniffing and
+// carry less information than file extension.
+var genericMimeTypes = map[string]bool{
+	"":                true,
+	DefaultMimeType:   true,
+	"text/plain":      true,
+	"application/zip": true,
+}
 
 type RecognizeParams struct {
-	FileName string
-	FileData *bytes.Buffer
+	FileName    string
+	FileData    *bytes.Buffer
+	ContentType string
+}
+
+// MimeType returns media type of file without parameters. Generic
+// content type is refined by file extension when it is known.
+func (rp *RecognizeParams) MimeType() string {
+	mediaType, _, err := mime.ParseMediaType(rp.ContentType)
+	if err != nil {
+		mediaType = ""
+	}
+
+	if !genericMimeTypes[mediaType] {
+		return mediaType
+	}
+
+	ext := strings.ToLower(path.Ext(rp.FileName))
+	if extMediaType, ok := extensionMimeTypes[ext]; ok {
+		return extMediaType
+	}
+
+	if extMediaType, _, err := mime.ParseMediaType(mime.TypeByExtension(ext)); err == nil {
+		return extMediaType
+	}
+
+	if mediaType == "" {
+		return DefaultMimeType
+	}
+
+	return mediaType
+}
+
+// MatchMimeType matches mime type exactly or by "type/*" wildcard pattern.
+func MatchMimeType(pattern, mimeType string) bool {
+	if prefix, found := strings.CutSuffix(pattern, "/*"); found {
+		return strings.HasPrefix(mimeType, prefix+"/")
+	}
+
+	return pattern == mimeType
 }
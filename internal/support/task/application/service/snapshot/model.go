@@ -0,0 +1,21 @@
+package snapshot
+
+import (
+	"time"
+
+	"watchtower/internal/shared/kernel"
+)
+
+// Entry is a state of object seen by previous poll of bucket.
+type Entry struct {
+	ETag         string
+	LastModified time.Time
+}
+
+// IsChanged reports whether object has been overwritten since entry.
+func (e Entry) IsChanged(other Entry) bool {
+	return e.ETag != other.ETag || !e.LastModified.Equal(other.LastModified)
+}
+
+// Snapshot is a state of all polled objects of bucket by their paths.
+type Snapshot map[kernel.ObjectID]Entry
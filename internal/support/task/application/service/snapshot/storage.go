@@ -0,0 +1,26 @@
+package snapshot
+
+import (
+	"time"
+
+	"watchtower/internal/shared/kernel"
+)
+
+type ISnapshotStorage interface {
+	// LoadSnapshot returns snapshot stored by previous poll of bucket,
+	// nil snapshot if bucket has not been polled yet.
+	LoadSnapshot(ctx kernel.Ctx, bucketID kernel.BucketID) (Snapshot, error)
+
+	// StoreSnapshot replaces stored snapshot of bucket.
+	StoreSnapshot(ctx kernel.Ctx, bucketID kernel.BucketID, snapshot Snapshot) error
+}
+
+// ILeaderLock elects single replica which polls buckets.
+type ILeaderLock interface {
+	// Acquire takes the lock or extends it if the lock is already held by
+	// this replica. Returns false if the lock is held by another replica.
+	Acquire(ctx kernel.Ctx, ttl time.Duration) (bool, error)
+
+	// Release frees the lock if it is held by this replica.
+	Release(ctx kernel.Ctx) error
+}
@@ -0,0 +1,8 @@
+package summary
+
+// Summary of document. Labels are chosen from taxonomy passed
+// within SummarizeParams.
+type Summary struct {
+	Text   string
+	Labels []string
+}
@@ -0,0 +1,7 @@
+package summary
+
+type SummarizeParams struct {
+	FileName string
+	Text     string
+	Taxonomy []string
+}
@@ -0,0 +1,9 @@
+package summary
+
+import (
+	"watchtower/internal/shared/kernel"
+)
+
+type ISummarizer interface {
+	Summarize(ctx kernel.Ctx, params *SummarizeParams) (*Summary, error)
+}
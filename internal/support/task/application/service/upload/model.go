@@ -0,0 +1,69 @@
+package upload
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+
+	"watchtower/internal/shared/kernel"
+)
+
+var (
+	ErrSessionNotFound = errors.New("upload not found")
+	ErrSessionLocked   = errors.New("upload is being written by another request")
+)
+
+// Part is uploaded part of multipart upload of object.
+type Part struct {
+	Number int
+	ETag   string
+}
+
+// Session is a state of resumable upload. Offset is count of received
+// bytes, bytes which do not fill a whole part yet are kept as pending data
+// of session. Multipart upload of storage is aborted when session expires.
+type Session struct {
+	ID          kernel.UploadID
+	BucketID    kernel.BucketID
+	ObjectID    kernel.ObjectID
+	MultipartID string
+	Length      int64
+	Offset      int64
+	Parts       []Part
+	Metadata    map[string]string
+	CreatedAt   time.Time
+	ExpiresAt   time.Time
+}
+
+func CreateNewSession(bucketID kernel.BucketID, objID kernel.ObjectID, length int64) *Session {
+	return &Session{
+		ID:        uuid.New(),
+		BucketID:  bucketID,
+		ObjectID:  objID,
+		Length:    length,
+		Parts:     []Part{},
+		CreatedAt: time.Now(),
+	}
+}
+
+// Prolong postpones expiration of session after received chunk.
+func (s *Session) Prolong(expiration time.Duration) {
+	s.ExpiresAt = time.Now().Add(expiration)
+}
+
+func (s *Session) AddPart(part Part) {
+	s.Parts = append(s.Parts, part)
+}
+
+func (s *Session) NextPartNumber() int {
+	return len(s.Parts) + 1
+}
+
+func (s *Session) IsCompleted() bool {
+	return s.Offset >= s.Length
+}
+
+func (s *Session) IsExpired() bool {
+	return time.Now().After(s.ExpiresAt)
+}
@@ -0,0 +1,56 @@
+package upload
+
+import (
+	"time"
+
+	"watchtower/internal/shared/kernel"
+)
+
+type ISessionStorage interface {
+	// GetSession returns ErrSessionNotFound if session does not exist.
+	GetSession(ctx kernel.Ctx, uploadID kernel.UploadID) (*Session, error)
+
+	GetAllSessions(ctx kernel.Ctx) ([]*Session, error)
+
+	StoreSession(ctx kernel.Ctx, session *Session) error
+
+	// StoreSessionPending stores session along with its pending bytes in
+	// one transaction, so received bytes are never appended twice.
+	StoreSessionPending(ctx kernel.Ctx, session *Session, pending []byte) error
+
+	// DeleteSession removes session with its pending data.
+	DeleteSession(ctx kernel.Ctx, uploadID kernel.UploadID) error
+
+	// LoadPending returns received bytes of session which have not been
+	// uploaded as part yet, empty if there are no such bytes.
+	LoadPending(ctx kernel.Ctx, uploadID kernel.UploadID) ([]byte, error)
+
+	// LockSession acquires lock of session expiring after ttl, so chunks
+	// of upload are written one at a time. Returns token to release lock
+	// or ErrSessionLocked if lock is held by another request.
+	LockSession(ctx kernel.Ctx, uploadID kernel.UploadID, ttl time.Duration) (string, error)
+
+	// UnlockSession releases lock of session if it is still held by token.
+	UnlockSession(ctx kernel.Ctx, uploadID kernel.UploadID, token string) error
+}
+
+type ITicketStorage interface {
+	// StoreTicket stores ticket until its expiration.
+	StoreTicket(ctx kernel.Ctx, ticket *Ticket) error
+
+	// TakeTicket returns ticket of object and removes it, so object is
+	// completed once. Returns ErrTicketNotFound if ticket does not exist.
+	TakeTicket(ctx kernel.Ctx, bucketID kernel.BucketID, objID kernel.ObjectID) (*Ticket, error)
+}
+
+// IStoredObjectStorage keeps marks of objects stored by watchtower itself,
+// so storage events of these objects are recognized by watchtower state
+// instead of metadata which is copied along with objects by clients.
+type IStoredObjectStorage interface {
+	// MarkStored marks object which is about to be stored by watchtower.
+	MarkStored(ctx kernel.Ctx, bucketID kernel.BucketID, objID kernel.ObjectID) error
+
+	// TakeStored reports whether object has been marked and removes mark,
+	// so only the first event of stored object is recognized.
+	TakeStored(ctx kernel.Ctx, bucketID kernel.BucketID, objID kernel.ObjectID) (bool, error)
+}
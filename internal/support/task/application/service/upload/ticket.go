@@ -0,0 +1,36 @@
+package upload
+
+import (
+	"errors"
+	"time"
+
+	"watchtower/internal/shared/kernel"
+)
+
+var ErrTicketNotFound = errors.New("upload ticket not found")
+
+// Ticket is an object expected to be uploaded directly into storage by
+// presigned URL. Uploaded object is verified by ticket before task of it
+// is created. Checksum is hex encoded MD5 or SHA-256 of content, optional.
+type Ticket struct {
+	BucketID  kernel.BucketID
+	ObjectID  kernel.ObjectID
+	Size      int64
+	Checksum  string
+	CreatedAt time.Time
+	ExpiresAt time.Time
+}
+
+func CreateNewTicket(bucketID kernel.BucketID, objID kernel.ObjectID, size int64, checksum string) *Ticket {
+	return &Ticket{
+		BucketID:  bucketID,
+		ObjectID:  objID,
+		Size:      size,
+		Checksum:  checksum,
+		CreatedAt: time.Now(),
+	}
+}
+
+func (t *Ticket) IsExpired() bool {
+	return time.Now().After(t.ExpiresAt)
+}
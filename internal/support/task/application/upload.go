@@ -0,0 +1,277 @@
+package application
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/breadrock1/otlp-go/otlp"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+
+	"watchtower/internal/shared/kernel"
+	"watchtower/internal/support/task/application/service/upload"
+)
+
+var (
+	ErrUploadsDisabled       = errors.New("resumable uploads have not been configured")
+	ErrUploadTicketsDisabled = errors.New("presigned uploads have not been configured")
+)
+
+func (p *TaskUseCase) GetUploadSession(ctx kernel.Ctx, uploadID kernel.UploadID) (*upload.Session, error) {
+	ctx, span := otlp_go.GlobalTracer.Start(ctx, "get-upload-session")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("upload-id", uploadID.String()))
+
+	if p.uploads == nil {
+		return nil, ErrUploadsDisabled
+	}
+
+	session, err := p.uploads.GetSession(ctx, uploadID)
+	if err != nil {
+		err = fmt.Errorf("upload storage error: %w", err)
+		span.SetStatus(codes.Error, err.Error())
+		span.RecordError(err)
+		return nil, err
+	}
+
+	return session, nil
+}
+
+func (p *TaskUseCase) GetUploadSessions(ctx kernel.Ctx) ([]*upload.Session, error) {
+	ctx, span := otlp_go.GlobalTracer.Start(ctx, "get-upload-sessions")
+	defer span.End()
+
+	if p.uploads == nil {
+		return nil, ErrUploadsDisabled
+	}
+
+	sessions, err := p.uploads.GetAllSessions(ctx)
+	if err != nil {
+		err = fmt.Errorf("upload storage error: %w", err)
+		span.SetStatus(codes.Error, err.Error())
+		span.RecordError(err)
+		return nil, err
+	}
+
+	return sessions, nil
+}
+
+// StoreUploadSession stores session with pending bytes which have not
+// been uploaded as part yet.
+func (p *TaskUseCase) StoreUploadSession(ctx kernel.Ctx, session *upload.Session, pending []byte) error {
+	ctx, span := otlp_go.GlobalTracer.Start(ctx, "store-upload-session")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("upload-id", session.ID.String()),
+		attribute.Int64("offset", session.Offset),
+		attribute.Int("pending", len(pending)),
+	)
+
+	if p.uploads == nil {
+		return ErrUploadsDisabled
+	}
+
+	if err := p.uploads.StoreSessionPending(ctx, session, pending); err != nil {
+		err = fmt.Errorf("upload storage error: %w", err)
+		span.SetStatus(codes.Error, err.Error())
+		span.RecordError(err)
+		return err
+	}
+
+	return nil
+}
+
+func (p *TaskUseCase) LoadUploadPending(ctx kernel.Ctx, uploadID kernel.UploadID) ([]byte, error) {
+	ctx, span := otlp_go.GlobalTracer.Start(ctx, "load-upload-pending")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("upload-id", uploadID.String()))
+
+	if p.uploads == nil {
+		return nil, ErrUploadsDisabled
+	}
+
+	pending, err := p.uploads.LoadPending(ctx, uploadID)
+	if err != nil {
+		err = fmt.Errorf("upload storage error: %w", err)
+		span.SetStatus(codes.Error, err.Error())
+		span.RecordError(err)
+		return nil, err
+	}
+
+	return pending, nil
+}
+
+func (p *TaskUseCase) DeleteUploadSession(ctx kernel.Ctx, uploadID kernel.UploadID) error {
+	ctx, span := otlp_go.GlobalTracer.Start(ctx, "delete-upload-session")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("upload-id", uploadID.String()))
+
+	if p.uploads == nil {
+		return ErrUploadsDisabled
+	}
+
+	if err := p.uploads.DeleteSession(ctx, uploadID); err != nil {
+		err = fmt.Errorf("upload storage error: %w", err)
+		span.SetStatus(codes.Error, err.Error())
+		span.RecordError(err)
+		return err
+	}
+
+	return nil
+}
+
+// LockUploadSession acquires lock of session until ttl expires, returns
+// token to release lock by UnlockUploadSession.
+func (p *TaskUseCase) LockUploadSession(ctx kernel.Ctx, uploadID kernel.UploadID, ttl time.Duration) (string, error) {
+	ctx, span := otlp_go.GlobalTracer.Start(ctx, "lock-upload-session")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("upload-id", uploadID.String()))
+
+	if p.uploads == nil {
+		return "", ErrUploadsDisabled
+	}
+
+	token, err := p.uploads.LockSession(ctx, uploadID, ttl)
+	if err != nil {
+		err = fmt.Errorf("upload storage error: %w", err)
+		span.SetStatus(codes.Error, err.Error())
+		span.RecordError(err)
+		return "", err
+	}
+
+	return token, nil
+}
+
+func (p *TaskUseCase) UnlockUploadSession(ctx kernel.Ctx, uploadID kernel.UploadID, token string) error {
+	ctx, span := otlp_go.GlobalTracer.Start(ctx, "unlock-upload-session")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("upload-id", uploadID.String()))
+
+	if p.uploads == nil {
+		return ErrUploadsDisabled
+	}
+
+	if err := p.uploads.UnlockSession(ctx, uploadID, token); err != nil {
+		err = fmt.Errorf("upload storage error: %w", err)
+		span.SetStatus(codes.Error, err.Error())
+		span.RecordError(err)
+		return err
+	}
+
+	return nil
+}
+
+func (p *TaskUseCase) StoreUploadTicket(ctx kernel.Ctx, ticket *upload.Ticket) error {
+	ctx, span := otlp_go.GlobalTracer.Start(ctx, "store-upload-ticket")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("bucket", ticket.BucketID),
+		attribute.String("file-path", ticket.ObjectID),
+		attribute.Int64("size", ticket.Size),
+	)
+
+	if p.uploadTickets == nil {
+		return ErrUploadTicketsDisabled
+	}
+
+	if err := p.uploadTickets.StoreTicket(ctx, ticket); err != nil {
+		err = fmt.Errorf("upload storage error: %w", err)
+		span.SetStatus(codes.Error, err.Error())
+		span.RecordError(err)
+		return err
+	}
+
+	return nil
+}
+
+// TakeUploadTicket returns ticket of uploaded object and removes it.
+func (p *TaskUseCase) TakeUploadTicket(
+	ctx kernel.Ctx,
+	bucketID kernel.BucketID,
+	objID kernel.ObjectID,
+) (*upload.Ticket, error) {
+	ctx, span := otlp_go.GlobalTracer.Start(ctx, "take-upload-ticket")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("bucket", bucketID),
+		attribute.String("file-path", objID),
+	)
+
+	if p.uploadTickets == nil {
+		return nil, ErrUploadTicketsDisabled
+	}
+
+	ticket, err := p.uploadTickets.TakeTicket(ctx, bucketID, objID)
+	if err != nil {
+		err = fmt.Errorf("upload storage error: %w", err)
+		span.SetStatus(codes.Error, err.Error())
+		span.RecordError(err)
+		return nil, err
+	}
+
+	return ticket, nil
+}
+
+// MarkStoredObject marks object stored by watchtower, marks are not kept
+// if storage of them has not been configured.
+func (p *TaskUseCase) MarkStoredObject(ctx kernel.Ctx, bucketID kernel.BucketID, objID kernel.ObjectID) error {
+	ctx, span := otlp_go.GlobalTracer.Start(ctx, "mark-stored-object")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("bucket", bucketID),
+		attribute.String("file-path", objID),
+	)
+
+	if p.storedObjects == nil {
+		return nil
+	}
+
+	if err := p.storedObjects.MarkStored(ctx, bucketID, objID); err != nil {
+		err = fmt.Errorf("upload storage error: %w", err)
+		span.SetStatus(codes.Error, err.Error())
+		span.RecordError(err)
+		return err
+	}
+
+	return nil
+}
+
+// TakeStoredObject reports whether object has been stored by watchtower
+// and removes its mark.
+func (p *TaskUseCase) TakeStoredObject(
+	ctx kernel.Ctx,
+	bucketID kernel.BucketID,
+	objID kernel.ObjectID,
+) (bool, error) {
+	ctx, span := otlp_go.GlobalTracer.Start(ctx, "take-stored-object")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("bucket", bucketID),
+		attribute.String("file-path", objID),
+	)
+
+	if p.storedObjects == nil {
+		return false, nil
+	}
+
+	stored, err := p.storedObjects.TakeStored(ctx, bucketID, objID)
+	if err != nil {
+		err = fmt.Errorf("upload storage error: %w", err)
+		span.SetStatus(codes.Error, err.Error())
+		span.RecordError(err)
+		return false, err
+	}
+
+	return stored, nil
+}
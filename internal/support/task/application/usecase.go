@@ -15,30 +15,121 @@ import (
 	"watchtower/internal/shared/kernel"
 	"watchtower/internal/shared/metrics"
 	"watchtower/internal/support/task/application/mapping"
+	"watchtower/internal/support/task/application/service/antivirus"
 	"watchtower/internal/support/task/application/service/docstorage"
+	"watchtower/internal/support/task/application/service/entity"
+	"watchtower/internal/support/task/application/service/fingerprint"
+	"watchtower/internal/support/task/application/service/graph"
+	"watchtower/internal/support/task/application/service/pii"
 	"watchtower/internal/support/task/application/service/recognizer"
+	"watchtower/internal/support/task/application/service/summary"
+	"watchtower/internal/support/task/application/service/upload"
 	"watchtower/internal/support/task/domain"
 )
 
 type TaskUseCase struct {
-	taskStorage domain.ITaskManager
+	taskStorage domain.ITaskStorage
 	taskQueue   domain.ITaskQueue
 	recognizer  recognizer.IRecognizer
 	docStorage  docstorage.IDocumentStorage
+
+	entityExtractor entity.IEntityExtractor
+	graphStore      graph.IGraphStore
+	summarizer      summary.ISummarizer
+	piiDetector     pii.IDetector
+	scanner         antivirus.IScanner
+	fingerprints    fingerprint.IFingerprintStorage
+	uploads         upload.ISessionStorage
+	uploadTickets   upload.ITicketStorage
+	storedObjects   upload.IStoredObjectStorage
+}
+
+// Option configures optional processing stages of TaskUseCase.
+type Option func(*TaskUseCase)
+
+// WithKnowledgeGraph enables entity extraction stage which stores
+// extracted entities and relations to graph store.
+func WithKnowledgeGraph(extractor entity.IEntityExtractor, graphStore graph.IGraphStore) Option {
+	return func(p *TaskUseCase) {
+		p.entityExtractor = extractor
+		p.graphStore = graphStore
+	}
+}
+
+// WithSummarizer enables summarization stage which stores summary
+// and taxonomy labels of recognized text to task and document.
+func WithSummarizer(summarizer summary.ISummarizer) Option {
+	return func(p *TaskUseCase) {
+		p.summarizer = summarizer
+	}
+}
+
+// WithPiiDetector enables personal data detection stage which masks,
+// drops or tags recognized documents before storing them to index.
+func WithPiiDetector(detector pii.IDetector) Option {
+	return func(p *TaskUseCase) {
+		p.piiDetector = detector
+	}
+}
+
+// WithAntivirus enables scanning of object data for malware
+// before recognizing and on uploading.
+func WithAntivirus(scanner antivirus.IScanner) Option {
+	return func(p *TaskUseCase) {
+		p.scanner = scanner
+	}
+}
+
+// WithFingerprints enables near-duplicate detection stage which
+// stores SimHash fingerprints of indexed documents.
+func WithFingerprints(storage fingerprint.IFingerprintStorage) Option {
+	return func(p *TaskUseCase) {
+		p.fingerprints = storage
+	}
+}
+
+// WithUploads enables resumable uploads which sessions are kept by storage.
+func WithUploads(storage upload.ISessionStorage) Option {
+	return func(p *TaskUseCase) {
+		p.uploads = storage
+	}
+}
+
+// WithUploadTickets enables presigned uploads which expected objects
+// are kept by storage until upload is completed.
+func WithUploadTickets(storage upload.ITicketStorage) Option {
+	return func(p *TaskUseCase) {
+		p.uploadTickets = storage
+	}
+}
+
+// WithStoredObjects enables marking of objects stored by watchtower, so
+// storage events and polls skip objects which already have tasks.
+func WithStoredObjects(storage upload.IStoredObjectStorage) Option {
+	return func(p *TaskUseCase) {
+		p.storedObjects = storage
+	}
 }
 
 func NewTaskUseCase(
-	taskStorage domain.ITaskManager,
+	taskStorage domain.ITaskStorage,
 	taskQueue domain.ITaskQueue,
 	recognizer recognizer.IRecognizer,
 	docStorage docstorage.IDocumentStorage,
+	opts ...Option,
 ) *TaskUseCase {
-	return &TaskUseCase{
+	taskUseCase := &TaskUseCase{
 		taskStorage: taskStorage,
 		taskQueue:   taskQueue,
 		recognizer:  recognizer,
 		docStorage:  docStorage,
 	}
+
+	for _, opt := range opts {
+		opt(taskUseCase)
+	}
+
+	return taskUseCase
 }
 
 func (p *TaskUseCase) GetBucketTasks(ctx kernel.Ctx, bucketID kernel.BucketID) ([]*domain.Task, error) {
@@ -129,12 +220,100 @@ func (p *TaskUseCase) IsTaskAlreadyExists(ctx kernel.Ctx, task *domain.Task) boo
 	case domain.Failed:
 		fallthrough
 	case domain.Successful:
+		fallthrough
+	case domain.Quarantined:
 		return false
 	default:
 		return false
 	}
 }
 
+func (p *TaskUseCase) GetWatchers(ctx kernel.Ctx) ([]*domain.Watcher, error) {
+	ctx, span := otlp_go.GlobalTracer.Start(ctx, "get-all-watchers")
+	defer span.End()
+
+	watchers, err := p.taskStorage.GetAllWatchers(ctx)
+	if err != nil {
+		err = fmt.Errorf("watcher manager error: %w", err)
+		span.SetStatus(codes.Error, err.Error())
+		span.RecordError(err)
+		return nil, err
+	}
+
+	return watchers, nil
+}
+
+func (p *TaskUseCase) GetWatcher(ctx kernel.Ctx, watcherID kernel.WatcherID) (*domain.Watcher, error) {
+	ctx, span := otlp_go.GlobalTracer.Start(ctx, "get-watcher-by-id")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("watcher-id", watcherID.String()))
+
+	watcher, err := p.taskStorage.GetWatcher(ctx, watcherID)
+	if err != nil {
+		err = fmt.Errorf("watcher manager error: %w", err)
+		span.SetStatus(codes.Error, err.Error())
+		span.RecordError(err)
+		return nil, err
+	}
+
+	return watcher, nil
+}
+
+func (p *TaskUseCase) UpdateWatcher(ctx kernel.Ctx, watcher *domain.Watcher) error {
+	ctx, span := otlp_go.GlobalTracer.Start(ctx, "update-watcher")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("watcher-id", watcher.ID.String()),
+		attribute.String("bucket", watcher.BucketID),
+		attribute.String("prefix", watcher.Prefix),
+	)
+
+	if err := p.taskStorage.UpdateWatcher(ctx, watcher); err != nil {
+		err = fmt.Errorf("watcher manager error: %w", err)
+		span.SetStatus(codes.Error, err.Error())
+		span.RecordError(err)
+		return err
+	}
+
+	return nil
+}
+
+// UpdateWatcherScanTime stores scan time of watcher, so changes of
+// watcher made during its scan are kept.
+func (p *TaskUseCase) UpdateWatcherScanTime(ctx kernel.Ctx, watcherID kernel.WatcherID, scanAt time.Time) error {
+	ctx, span := otlp_go.GlobalTracer.Start(ctx, "update-watcher-scan-time")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("watcher-id", watcherID.String()))
+
+	if err := p.taskStorage.UpdateWatcherScanTime(ctx, watcherID, scanAt); err != nil {
+		err = fmt.Errorf("watcher manager error: %w", err)
+		span.SetStatus(codes.Error, err.Error())
+		span.RecordError(err)
+		return err
+	}
+
+	return nil
+}
+
+func (p *TaskUseCase) DeleteWatcher(ctx kernel.Ctx, watcherID kernel.WatcherID) error {
+	ctx, span := otlp_go.GlobalTracer.Start(ctx, "delete-watcher")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("watcher-id", watcherID.String()))
+
+	if err := p.taskStorage.DeleteWatcher(ctx, watcherID); err != nil {
+		err = fmt.Errorf("watcher manager error: %w", err)
+		span.SetStatus(codes.Error, err.Error())
+		span.RecordError(err)
+		return err
+	}
+
+	return nil
+}
+
 func (p *TaskUseCase) PublishTaskToQueue(ctx kernel.Ctx, task *domain.Task) error {
 	msg := mapping.MessageFromTask(task)
 	err := p.taskQueue.Publish(ctx, msg)
@@ -145,6 +324,58 @@ func (p *TaskUseCase) GetConsumerChannel() chan domain.Message {
 	return p.taskQueue.GetConsumerChannel()
 }
 
+func (p *TaskUseCase) ScanObject(
+	ctx kernel.Ctx,
+	bucketID kernel.BucketID,
+	objID kernel.ObjectID,
+	fileData *bytes.Buffer,
+) (*antivirus.Verdict, error) {
+	ctx, span := otlp_go.GlobalTracer.Start(ctx, "scan-object-data")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("bucket", bucketID),
+		attribute.String("file-path", objID),
+	)
+
+	if p.scanner == nil {
+		err := fmt.Errorf("antivirus stage has not been configured")
+		span.SetStatus(codes.Error, err.Error())
+		span.RecordError(err)
+		return nil, err
+	}
+
+	instant := time.Now()
+
+	scanParams := &antivirus.ScanParams{
+		FileName: objID,
+		FileData: fileData,
+	}
+
+	verdict, err := p.scanner.Scan(ctx, scanParams)
+
+	elapsedTime := time.Since(instant)
+	metrics.AntivirusScanDurationSeconds.
+		WithLabelValues(kernel.AppName, strconv.FormatBool(err != nil)).
+		Observe(elapsedTime.Seconds())
+
+	if err != nil {
+		err = fmt.Errorf("failed to scan file %s: %w", objID, err)
+		span.SetStatus(codes.Error, err.Error())
+		span.RecordError(err)
+		return nil, err
+	}
+
+	if verdict.Infected {
+		metrics.InfectedFilesCounter.
+			WithLabelValues(kernel.AppName, verdict.Signature).
+			Inc()
+		span.SetAttributes(attribute.String("signature", verdict.Signature))
+	}
+
+	return verdict, nil
+}
+
 func (p *TaskUseCase) Recognize(
 	ctx kernel.Ctx,
 	task *domain.Task,
@@ -160,8 +391,9 @@ func (p *TaskUseCase) Recognize(
 	)
 
 	inputFile := &recognizer.RecognizeParams{
-		FileName: task.ObjectID,
-		FileData: fileData,
+		FileName:    task.ObjectID,
+		FileData:    fileData,
+		ContentType: task.ContentType,
 	}
 
 	instant := time.Now()
@@ -200,13 +432,33 @@ func (p *TaskUseCase) StoreDocument(
 	)
 
 	doc := &docstorage.Document{
-		Index:      task.BucketID,
-		Name:       path.Base(task.ObjectID),
-		Path:       task.ObjectID,
-		Size:       task.ObjectDataSize,
-		Content:    recData.Text,
-		CreatedAt:  task.CreatedAt,
-		ModifiedAt: task.ModifiedAt,
+		Index:       task.BucketID,
+		Name:        path.Base(task.ObjectID),
+		Path:        task.CurrentObjectID(),
+		Size:        task.ObjectDataSize,
+		ContentType: task.ContentType,
+		Metadata:    task.Metadata,
+		Tags:        task.Tags,
+		VersionID:   task.VersionID,
+		Properties:  recData.Metadata,
+		Content:     recData.Text,
+		Pages:       make([]docstorage.Page, 0, len(recData.Pages)),
+		PageCount:   recData.PageCount,
+		Language:    recData.Language,
+		Confidence:  recData.Confidence,
+		Summary:     task.Summary,
+		Labels:      task.Labels,
+		PiiCounts:   task.PiiCounts,
+		CreatedAt:   task.CreatedAt,
+		ModifiedAt:  task.ModifiedAt,
+	}
+
+	if task.Duplicate != nil && task.Duplicate.Linked {
+		doc.DuplicateOf = task.Duplicate.DocumentID
+	}
+
+	for _, page := range recData.Pages {
+		doc.Pages = append(doc.Pages, docstorage.Page{Number: page.Number, Text: page.Text})
 	}
 
 	instant := time.Now()
@@ -227,3 +479,397 @@ func (p *TaskUseCase) StoreDocument(
 
 	return docID, nil
 }
+
+// DeleteDocuments removes indexed documents of removed object along
+// with its fingerprint, so removed document is never found as canonical.
+func (p *TaskUseCase) DeleteDocuments(ctx kernel.Ctx, bucketID kernel.BucketID, objID kernel.ObjectID) error {
+	ctx, span := otlp_go.GlobalTracer.Start(ctx, "delete-documents")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("bucket", bucketID),
+		attribute.String("file-path", objID),
+	)
+
+	if err := p.docStorage.DeleteDocuments(ctx, bucketID, objID); err != nil {
+		err = fmt.Errorf("failed to delete documents: %w", err)
+		span.SetStatus(codes.Error, err.Error())
+		span.RecordError(err)
+		return err
+	}
+
+	if p.fingerprints != nil {
+		if err := p.fingerprints.DeleteRecord(ctx, bucketID, objID); err != nil {
+			err = fmt.Errorf("failed to delete fingerprint: %w", err)
+			span.SetStatus(codes.Error, err.Error())
+			span.RecordError(err)
+			return err
+		}
+	}
+
+	return nil
+}
+
+// MoveDocuments changes path of indexed documents of object moved within
+// bucket, so moved object is not recognized again. Fingerprint and graph
+// document are moved too, so moved object never matches its own fingerprint.
+func (p *TaskUseCase) MoveDocuments(
+	ctx kernel.Ctx,
+	bucketID kernel.BucketID,
+	srcObjID kernel.ObjectID,
+	dstObjID kernel.ObjectID,
+) error {
+	ctx, span := otlp_go.GlobalTracer.Start(ctx, "move-documents")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("bucket", bucketID),
+		attribute.String("src-file-path", srcObjID),
+		attribute.String("dst-file-path", dstObjID),
+	)
+
+	if err := p.docStorage.MoveDocuments(ctx, bucketID, srcObjID, dstObjID); err != nil {
+		err = fmt.Errorf("failed to move documents: %w", err)
+		span.SetStatus(codes.Error, err.Error())
+		span.RecordError(err)
+		return err
+	}
+
+	if p.fingerprints != nil {
+		if err := p.fingerprints.MoveRecord(ctx, bucketID, srcObjID, dstObjID); err != nil {
+			err = fmt.Errorf("failed to move fingerprint: %w", err)
+			span.SetStatus(codes.Error, err.Error())
+			span.RecordError(err)
+			return err
+		}
+	}
+
+	if p.graphStore != nil {
+		if err := p.graphStore.MoveDocument(ctx, bucketID, srcObjID, dstObjID); err != nil {
+			err = fmt.Errorf("failed to move graph document: %w", err)
+			span.SetStatus(codes.Error, err.Error())
+			span.RecordError(err)
+			return err
+		}
+	}
+
+	return nil
+}
+
+// DetectPii stores counts of personal data found in recognized text to
+// task. Text, pages and properties are masked in place by MaskAction,
+// other actions leave recognized data untouched.
+func (p *TaskUseCase) DetectPii(
+	ctx kernel.Ctx,
+	task *domain.Task,
+	recData *recognizer.Recognized,
+	action pii.Action,
+) error {
+	ctx, span := otlp_go.GlobalTracer.Start(ctx, "detect-personal-data")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("task-id", task.ID.String()),
+		attribute.String("bucket", task.BucketID),
+		attribute.String("file-path", task.ObjectID),
+		attribute.String("action", string(action)),
+	)
+
+	if p.piiDetector == nil {
+		err := fmt.Errorf("pii detection stage has not been configured")
+		span.SetStatus(codes.Error, err.Error())
+		span.RecordError(err)
+		return err
+	}
+
+	switch action {
+	case pii.MaskAction, pii.DropAction, pii.TagAction:
+	default:
+		err := fmt.Errorf("unknown pii action: %s", action)
+		span.SetStatus(codes.Error, err.Error())
+		span.RecordError(err)
+		return err
+	}
+
+	matches, err := p.piiDetector.Detect(ctx, recData.Text)
+	if err != nil {
+		err = fmt.Errorf("failed to detect personal data: %w", err)
+		span.SetStatus(codes.Error, err.Error())
+		span.RecordError(err)
+		return err
+	}
+
+	counts := pii.CountByType(matches)
+	for piiType, count := range counts {
+		metrics.DetectedPiiCounter.
+			WithLabelValues(kernel.AppName, piiType, string(action)).
+			Add(float64(count))
+	}
+
+	span.SetAttributes(attribute.Int("matches", len(matches)))
+	task.SetPiiCounts(counts)
+
+	if action != pii.MaskAction {
+		return nil
+	}
+
+	if err = p.maskPii(ctx, recData, matches); err != nil {
+		err = fmt.Errorf("failed to mask personal data: %w", err)
+		span.SetStatus(codes.Error, err.Error())
+		span.RecordError(err)
+		return err
+	}
+
+	return nil
+}
+
+func (p *TaskUseCase) maskPii(ctx kernel.Ctx, recData *recognizer.Recognized, matches []pii.Match) error {
+	recData.Text = pii.Redact(recData.Text, matches)
+
+	for index, page := range recData.Pages {
+		pageMatches, err := p.piiDetector.Detect(ctx, page.Text)
+		if err != nil {
+			return err
+		}
+		recData.Pages[index].Text = pii.Redact(page.Text, pageMatches)
+	}
+
+	for key, value := range recData.Metadata {
+		valueMatches, err := p.piiDetector.Detect(ctx, value)
+		if err != nil {
+			return err
+		}
+		recData.Metadata[key] = pii.Redact(value, valueMatches)
+	}
+
+	return nil
+}
+
+// FindNearDuplicate stores fingerprint of recognized text and nearest
+// canonical document within maxDistance to task.
+func (p *TaskUseCase) FindNearDuplicate(
+	ctx kernel.Ctx,
+	task *domain.Task,
+	recData *recognizer.Recognized,
+	maxDistance int,
+	action fingerprint.Action,
+) error {
+	ctx, span := otlp_go.GlobalTracer.Start(ctx, "find-near-duplicate")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("task-id", task.ID.String()),
+		attribute.String("bucket", task.BucketID),
+		attribute.String("file-path", task.ObjectID),
+		attribute.String("action", string(action)),
+	)
+
+	if p.fingerprints == nil {
+		err := fmt.Errorf("near-duplicate stage has not been configured")
+		span.SetStatus(codes.Error, err.Error())
+		span.RecordError(err)
+		return err
+	}
+
+	switch action {
+	case fingerprint.ReportAction, fingerprint.SkipAction, fingerprint.LinkAction:
+	default:
+		err := fmt.Errorf("unknown near-duplicate action: %s", action)
+		span.SetStatus(codes.Error, err.Error())
+		span.RecordError(err)
+		return err
+	}
+
+	simHash := fingerprint.SimHash(recData.Text)
+	nearest, err := p.fingerprints.FindNearest(ctx, task.BucketID, task.ObjectID, simHash, maxDistance)
+	if err != nil {
+		err = fmt.Errorf("failed to find near-duplicate: %w", err)
+		span.SetStatus(codes.Error, err.Error())
+		span.RecordError(err)
+		return err
+	}
+
+	span.SetAttributes(attribute.String("fingerprint", simHash.String()))
+	if nearest == nil {
+		task.SetFingerprint(simHash.String(), nil)
+		return nil
+	}
+
+	metrics.NearDuplicatesCounter.
+		WithLabelValues(kernel.AppName, string(action)).
+		Inc()
+
+	duplicate := &domain.Duplicate{
+		ObjectID:   nearest.ObjectID,
+		DocumentID: nearest.DocumentID,
+		Distance:   simHash.Distance(nearest.Fingerprint),
+		Linked:     action == fingerprint.LinkAction,
+	}
+
+	span.SetAttributes(attribute.String("duplicate-of", duplicate.ObjectID))
+	task.SetFingerprint(simHash.String(), duplicate)
+	return nil
+}
+
+// StoreFingerprint stores fingerprint of task as canonical document.
+func (p *TaskUseCase) StoreFingerprint(ctx kernel.Ctx, task *domain.Task, docID docstorage.DocumentID) error {
+	ctx, span := otlp_go.GlobalTracer.Start(ctx, "store-fingerprint")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("task-id", task.ID.String()),
+		attribute.String("bucket", task.BucketID),
+		attribute.String("fingerprint", task.Fingerprint),
+	)
+
+	if p.fingerprints == nil {
+		err := fmt.Errorf("near-duplicate stage has not been configured")
+		span.SetStatus(codes.Error, err.Error())
+		span.RecordError(err)
+		return err
+	}
+
+	simHash, err := fingerprint.ParseFingerprint(task.Fingerprint)
+	if err == nil {
+		record := &fingerprint.Record{
+			Fingerprint: simHash,
+			ObjectID:    task.CurrentObjectID(),
+			DocumentID:  docID,
+		}
+		err = p.fingerprints.StoreRecord(ctx, task.BucketID, record)
+	}
+
+	if err != nil {
+		err = fmt.Errorf("failed to store fingerprint: %w", err)
+		span.SetStatus(codes.Error, err.Error())
+		span.RecordError(err)
+		return err
+	}
+
+	return nil
+}
+
+func (p *TaskUseCase) Summarize(
+	ctx kernel.Ctx,
+	task *domain.Task,
+	recData *recognizer.Recognized,
+	taxonomy []string,
+) error {
+	ctx, span := otlp_go.GlobalTracer.Start(ctx, "summarize-document")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("task-id", task.ID.String()),
+		attribute.String("bucket", task.BucketID),
+		attribute.String("file-path", task.ObjectID),
+	)
+
+	if p.summarizer == nil {
+		err := fmt.Errorf("summarization stage has not been configured")
+		span.SetStatus(codes.Error, err.Error())
+		span.RecordError(err)
+		return err
+	}
+
+	instant := time.Now()
+
+	summarizeParams := &summary.SummarizeParams{
+		FileName: task.ObjectID,
+		Text:     recData.Text,
+		Taxonomy: taxonomy,
+	}
+
+	summarized, err := p.summarizer.Summarize(ctx, summarizeParams)
+
+	elapsedTime := time.Since(instant)
+	metrics.SummarizationDurationSeconds.
+		WithLabelValues(kernel.AppName, strconv.FormatBool(err != nil)).
+		Observe(elapsedTime.Seconds())
+
+	if err != nil {
+		err = fmt.Errorf("failed to summarize document: %w", err)
+		span.SetStatus(codes.Error, err.Error())
+		span.RecordError(err)
+		return err
+	}
+
+	span.SetAttributes(attribute.StringSlice("labels", summarized.Labels))
+	task.SetSummary(summarized.Text, summarized.Labels)
+	return nil
+}
+
+func (p *TaskUseCase) BuildKnowledgeGraph(
+	ctx kernel.Ctx,
+	task *domain.Task,
+	docID docstorage.DocumentID,
+	recData *recognizer.Recognized,
+) error {
+	ctx, span := otlp_go.GlobalTracer.Start(ctx, "build-knowledge-graph")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("task-id", task.ID.String()),
+		attribute.String("bucket", task.BucketID),
+		attribute.String("file-path", task.ObjectID),
+		attribute.String("document-id", docID),
+	)
+
+	if p.entityExtractor == nil || p.graphStore == nil {
+		err := fmt.Errorf("knowledge graph stage has not been configured")
+		span.SetStatus(codes.Error, err.Error())
+		span.RecordError(err)
+		return err
+	}
+
+	instant := time.Now()
+
+	extractParams := &entity.ExtractParams{
+		FileName: task.ObjectID,
+		Text:     recData.Text,
+	}
+
+	extracted, err := p.entityExtractor.Extract(ctx, extractParams)
+	if err == nil {
+		docGraph := buildDocumentGraph(task, docID, extracted)
+		err = p.graphStore.StoreGraph(ctx, docGraph)
+	}
+
+	elapsedTime := time.Since(instant)
+	metrics.KnowledgeGraphDurationSeconds.
+		WithLabelValues(kernel.AppName, strconv.FormatBool(err != nil)).
+		Observe(elapsedTime.Seconds())
+
+	if err != nil {
+		err = fmt.Errorf("failed to build knowledge graph: %w", err)
+		span.SetStatus(codes.Error, err.Error())
+		span.RecordError(err)
+		return err
+	}
+
+	span.SetAttributes(
+		attribute.Int("entities", len(extracted.Entities)),
+		attribute.Int("relations", len(extracted.Relations)),
+	)
+
+	return nil
+}
+
+func buildDocumentGraph(task *domain.Task, docID docstorage.DocumentID, extracted *entity.Extracted) *graph.Graph {
+	nodes := make([]graph.Node, len(extracted.Entities))
+	for index, value := range extracted.Entities {
+		nodes[index] = graph.Node{Name: value.Name, Type: value.Type}
+	}
+
+	edges := make([]graph.Edge, len(extracted.Relations))
+	for index, value := range extracted.Relations {
+		edges[index] = graph.Edge{Source: value.Source, Target: value.Target, Type: value.Type}
+	}
+
+	return &graph.Graph{
+		DocumentID: docID,
+		Index:      task.BucketID,
+		Path:       task.CurrentObjectID(),
+		Nodes:      nodes,
+		Edges:      edges,
+	}
+}
@@ -6,4 +6,5 @@ var (
 	ErrExecution       = errors.New("execution error")
 	ErrTaskNotFound    = errors.New("task not found")
 	ErrInvalidTaskData = errors.New("invalid task data")
+	ErrWatcherNotFound = errors.New("watcher not found")
 )
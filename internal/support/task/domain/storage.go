@@ -1,6 +1,8 @@
 package domain
 
 import (
+	"time"
+
 	"watchtower/internal/shared/kernel"
 )
 
@@ -8,6 +10,7 @@ import (
 // This is used to track task state independently from the message queue.
 type ITaskStorage interface {
 	ITaskManager
+	IWatcherManager
 }
 
 // ITaskManager defines operations for managing task lifecycle in persistent storage.
@@ -80,3 +83,75 @@ type ITaskManager interface {
 	//   }
 	UpdateTask(ctx kernel.Ctx, task *Task) error
 }
+
+// IWatcherManager defines operations for managing directory watchers
+// registrations in persistent storage.
+type IWatcherManager interface {
+	// GetWatcher retrieves a watcher by its ID.
+	//
+	// Parameters:
+	//   - kernel.Ctx: Context for cancellation and timeout
+	//   - watcherID: Unique identifier of the watcher
+	//
+	// Returns:
+	//   - *Watcher: Watched bucket directory and last scan time
+	//   - error: ErrWatcherNotFound if watcher not found,
+	//            ErrExecution if returned operation error,
+	//            or other storage errors
+	GetWatcher(ctx kernel.Ctx, watcherID kernel.WatcherID) (*Watcher, error)
+
+	// GetAllWatchers retrieves all registered watchers.
+	//
+	// Parameters:
+	//   - kernel.Ctx: Context for cancellation and timeout
+	//
+	// Returns:
+	//   - []*Watcher: Slice of all registered watchers
+	//   - error: ErrExecution if returned operation error,
+	//            or other storage errors
+	//
+	// Example:
+	//   watchers, err := storage.GetAllWatchers(ctx)
+	//   for _, watcher := range watchers {
+	//       fmt.Printf("Watching %s/%s\n", watcher.BucketID, watcher.Prefix)
+	//   }
+	GetAllWatchers(ctx kernel.Ctx) ([]*Watcher, error)
+
+	// UpdateWatcher creates a new watcher or replaces an existing one.
+	//
+	// Parameters:
+	//   - kernel.Ctx: Context for cancellation and timeout
+	//   - watcher: Complete watcher object
+	//
+	// Returns:
+	//   - error: ErrExecution if returned operation error,
+	//            ErrInvalidTaskData if watcher can not be serialized,
+	//            or other storage errors
+	UpdateWatcher(ctx kernel.Ctx, watcher *Watcher) error
+
+	// UpdateWatcherScanTime stores time of the last scan of existing watcher,
+	// other fields of watcher are left untouched.
+	//
+	// Parameters:
+	//   - kernel.Ctx: Context for cancellation and timeout
+	//   - watcherID: Unique identifier of the watcher
+	//   - scanAt: Time of the last scan
+	//
+	// Returns:
+	//   - error: ErrWatcherNotFound if watcher not found,
+	//            ErrExecution if returned operation error,
+	//            or other storage errors
+	UpdateWatcherScanTime(ctx kernel.Ctx, watcherID kernel.WatcherID, scanAt time.Time) error
+
+	// DeleteWatcher removes a watcher by its ID.
+	//
+	// Parameters:
+	//   - kernel.Ctx: Context for cancellation and timeout
+	//   - watcherID: Unique identifier of the watcher
+	//
+	// Returns:
+	//   - error: ErrWatcherNotFound if watcher not found,
+	//            ErrExecution if returned operation error,
+	//            or other storage errors
+	DeleteWatcher(ctx kernel.Ctx, watcherID kernel.WatcherID) error
+}
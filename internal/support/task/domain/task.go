@@ -13,11 +13,21 @@ import (
 const (
 	PublishedStatusText  = "publisher"
 	ProcessingStatusText = "processing"
+	ExpandingStatusText  = "expanding archive"
+	SuccessfulStatusText = "task has been processed successful"
+
+	// DroppedStatusText marks successful task which document has not
+	// been stored to index because it contains personal data.
+	DroppedStatusText = "document has not been indexed: personal data detected"
+
+	// LowConfidenceStatusText marks successful task which recognized
+	// text should be reviewed by human because of low OCR confidence.
+	LowConfidenceStatusText = "review required: low recognition confidence"
 )
 
 // TaskStatus represents the current state of a task in its lifecycle.
 // The status follows a typical workflow: Received -> Pending -> Processing -> Successful,
-// with Failed as a terminal error state.
+// with Failed as a terminal error state and Quarantined as a terminal state of infected files.
 type TaskStatus int
 
 const (
@@ -38,6 +48,11 @@ const (
 	// Successful indicates the task completed successfully.
 	// This is a terminal state.
 	Successful // 3
+
+	// Quarantined indicates the input object is infected and has been moved
+	// to quarantine prefix without recognizing and indexing.
+	// This is a terminal state.
+	Quarantined // 4
 )
 
 // Task represents a unit of work to be processed asynchronously.
@@ -57,6 +72,19 @@ type Task struct {
 	// useful for progress tracking and resource estimation
 	ObjectDataSize int
 
+	// ContentType is the MIME type of the input object as stored in the bucket
+	ContentType string
+
+	// Metadata holds user-defined metadata attached to the input object
+	Metadata map[string]string
+
+	// Tags holds tags attached to the input object
+	Tags map[string]string
+
+	// VersionID identifies version of the input object which has been
+	// processed, empty if bucket is not versioned
+	VersionID string
+
 	// StatusText provides additional context about the current status,
 	// such as error messages for failed tasks or progress for processing tasks
 	StatusText string
@@ -78,6 +106,49 @@ type Task struct {
 
 	// ProcessingDuration tracks how long the task took to process (when completed)
 	ProcessingDuration time.Duration
+
+	// ParentID identifies the archive task which this task was extracted from,
+	// uuid.Nil for tasks of directly uploaded objects
+	ParentID kernel.TaskID
+
+	// Children lists tasks created for files extracted from archive object
+	Children []kernel.TaskID
+
+	// Summary is short description of document generated by summarization stage
+	Summary string
+
+	// Labels are taxonomy labels of document chosen by summarization stage
+	Labels []string
+
+	// PiiCounts holds counts of personal data detected in document per type
+	PiiCounts map[string]int
+
+	// Fingerprint is SimHash of recognized text used to find near-duplicates
+	Fingerprint string
+
+	// Duplicate references canonical document which this document is
+	// near-duplicate of, nil for unique documents
+	Duplicate *Duplicate
+
+	// Location is the final path of object after it has been moved by
+	// post-processing stage, empty if object stays at ObjectID
+	Location kernel.ObjectID
+}
+
+// Duplicate references canonical document of near-duplicate task.
+type Duplicate struct {
+	// ObjectID identifies object of canonical document
+	ObjectID kernel.ObjectID
+
+	// DocumentID identifies canonical document in index
+	DocumentID string
+
+	// Distance is hamming distance between fingerprints of documents
+	Distance int
+
+	// Linked marks that document is stored to index with reference
+	// to canonical document
+	Linked bool
 }
 
 func CreateNewTask(bucketID kernel.BucketID, objectID kernel.ObjectID) *Task {
@@ -104,6 +175,59 @@ func (t *Task) SetObjectDataSize(size int) {
 	t.ObjectDataSize = size
 }
 
+func (t *Task) SetParentID(parentID kernel.TaskID) {
+	t.ParentID = parentID
+}
+
+func (t *Task) AddChild(childID kernel.TaskID) {
+	t.Children = append(t.Children, childID)
+}
+
+func (t *Task) SetObjectAttributes(contentType string, metadata map[string]string) {
+	t.ContentType = contentType
+	t.Metadata = metadata
+}
+
+func (t *Task) SetObjectTags(tags map[string]string) {
+	t.Tags = tags
+}
+
+func (t *Task) SetObjectVersion(versionID string) {
+	t.VersionID = versionID
+}
+
+func (t *Task) SetSummary(summary string, labels []string) {
+	t.Summary = summary
+	t.Labels = labels
+}
+
+func (t *Task) SetPiiCounts(counts map[string]int) {
+	t.PiiCounts = counts
+}
+
+func (t *Task) HasPii() bool {
+	return len(t.PiiCounts) > 0
+}
+
+func (t *Task) SetFingerprint(fingerprint string, duplicate *Duplicate) {
+	t.Fingerprint = fingerprint
+	t.Duplicate = duplicate
+}
+
+func (t *Task) SetLocation(location kernel.ObjectID) {
+	t.Location = location
+}
+
+// CurrentObjectID returns path of object in bucket, the final location
+// if object has been moved by post-processing stage.
+func (t *Task) CurrentObjectID() kernel.ObjectID {
+	if t.Location != "" {
+		return t.Location
+	}
+
+	return t.ObjectID
+}
+
 func (t *Task) SetStatusAndText(status TaskStatus, msg string) {
 	t.Status = status
 	t.StatusText = msg
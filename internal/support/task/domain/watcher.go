@@ -0,0 +1,60 @@
+package domain
+
+import (
+	"path"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"watchtower/internal/shared/kernel"
+)
+
+// Watcher registers bucket directory which objects are processed
+// automatically when they are created or changed by any client,
+// not only by uploads through the API.
+type Watcher struct {
+	// ID uniquely identifies the watcher
+	ID kernel.WatcherID
+
+	// BucketID identifies the watched bucket
+	BucketID kernel.BucketID
+
+	// Prefix is the watched directory within the bucket,
+	// empty for the bucket root
+	// Example: "incoming/"
+	Prefix string
+
+	// CreatedAt is the timestamp when the watcher was registered
+	CreatedAt time.Time
+
+	// LastScanAt is the timestamp of the last scan, objects modified
+	// after it are considered new or changed
+	LastScanAt time.Time
+}
+
+func CreateNewWatcher(bucketID kernel.BucketID, prefix string) *Watcher {
+	currTime := time.Now()
+	return &Watcher{
+		ID:         uuid.New(),
+		BucketID:   bucketID,
+		Prefix:     NormalizeWatcherPrefix(prefix),
+		CreatedAt:  currTime,
+		LastScanAt: currTime,
+	}
+}
+
+func (w *Watcher) SetLastScanAt(scanAt time.Time) {
+	w.LastScanAt = scanAt
+}
+
+// NormalizeWatcherPrefix converts directory path like "./some-directory"
+// to listing prefix "some-directory/", bucket root becomes empty prefix.
+func NormalizeWatcherPrefix(prefix string) string {
+	prefix = strings.TrimPrefix(path.Clean("/"+prefix), "/")
+	if prefix == "" {
+		return ""
+	}
+
+	return prefix + "/"
+}
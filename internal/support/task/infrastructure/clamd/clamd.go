@@ -0,0 +1,138 @@
+package clamd
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"time"
+
+	"watchtower/internal/shared/kernel"
+	"watchtower/internal/support/task/application/service/antivirus"
+)
+
+const (
+	InstreamCommand = "zINSTREAM\x00"
+
+	DefaultChunkSize = 64 * 1024
+
+	streamPrefix  = "stream:"
+	foundSuffix   = "FOUND"
+	errorSuffix   = "ERROR"
+	cleanResponse = "OK"
+)
+
+// ErrScanFailed is returned when clamd replies with error, so it is told
+// apart from failed connection to clamd.
+var ErrScanFailed = errors.New("clamd returned error")
+
+// ClamdClient scans files by clamd daemon using INSTREAM command:
+// data is sent by chunks prefixed with 4-byte big endian length and
+// terminated by zero length chunk.
+type ClamdClient struct {
+	config Config
+}
+
+func New(config Config) antivirus.IScanner {
+	if config.ChunkSize <= 0 {
+		config.ChunkSize = DefaultChunkSize
+	}
+
+	return &ClamdClient{config}
+}
+
+func (cc *ClamdClient) Scan(ctx kernel.Ctx, params *antivirus.ScanParams) (*antivirus.Verdict, error) {
+	timeout := cc.config.Timeout * time.Second
+	dialer := &net.Dialer{Timeout: timeout}
+	conn, err := dialer.DialContext(ctx, cc.config.Network, cc.config.Address)
+	if err != nil {
+		return nil, fmt.Errorf("clamd: failed to connect: %w", err)
+	}
+	defer func() { _ = conn.Close() }()
+
+	stop := context.AfterFunc(ctx, func() { _ = conn.Close() })
+	defer stop()
+
+	if timeout > 0 {
+		if err = conn.SetDeadline(time.Now().Add(timeout)); err != nil {
+			return nil, fmt.Errorf("clamd: failed to set deadline: %w", err)
+		}
+	}
+
+	sendErr := cc.sendStream(conn, params.FileData.Bytes())
+
+	// clamd replies and closes connection when stream exceeds its
+	// limit, so reply is more informative than write error.
+	reply, readErr := bufio.NewReader(conn).ReadString('\x00')
+	if readErr != nil && (readErr != io.EOF || reply == "") {
+		if sendErr != nil {
+			return nil, fmt.Errorf("clamd: failed to send %s: %w", params.FileName, sendErr)
+		}
+		return nil, fmt.Errorf("clamd: failed to read reply: %w", readErr)
+	}
+
+	verdict, err := parseReply(reply)
+	if err != nil {
+		return nil, fmt.Errorf("clamd: failed to scan %s: %w", params.FileName, err)
+	}
+
+	if sendErr != nil {
+		return nil, fmt.Errorf("clamd: failed to send %s: %w", params.FileName, sendErr)
+	}
+
+	return verdict, nil
+}
+
+func (cc *ClamdClient) sendStream(conn net.Conn, data []byte) error {
+	if _, err := io.WriteString(conn, InstreamCommand); err != nil {
+		return err
+	}
+
+	header := make([]byte, 4)
+	reader := bytes.NewReader(data)
+	chunk := make([]byte, cc.config.ChunkSize)
+	for {
+		size, err := reader.Read(chunk)
+		if size > 0 {
+			binary.BigEndian.PutUint32(header, uint32(size))
+			if _, err = conn.Write(header); err != nil {
+				return err
+			}
+			if _, err = conn.Write(chunk[:size]); err != nil {
+				return err
+			}
+		}
+
+		if err == io.EOF {
+			break
+		}
+	}
+
+	binary.BigEndian.PutUint32(header, 0)
+	_, err := conn.Write(header)
+	return err
+}
+
+// parseReply parses replies like "stream: OK", "stream: <name> FOUND"
+// and "<message> ERROR".
+func parseReply(reply string) (*antivirus.Verdict, error) {
+	reply = strings.TrimSpace(strings.TrimRight(reply, "\x00"))
+	result := strings.TrimSpace(strings.TrimPrefix(reply, streamPrefix))
+
+	switch {
+	case result == cleanResponse:
+		return &antivirus.Verdict{Infected: false}, nil
+	case strings.HasSuffix(result, foundSuffix):
+		signature := strings.TrimSpace(strings.TrimSuffix(result, foundSuffix))
+		return &antivirus.Verdict{Infected: true, Signature: signature}, nil
+	case strings.HasSuffix(result, errorSuffix):
+		return nil, fmt.Errorf("%w: %s", ErrScanFailed, strings.TrimSpace(strings.TrimSuffix(result, errorSuffix)))
+	default:
+		return nil, fmt.Errorf("%w: unexpected reply: %s", ErrScanFailed, reply)
+	}
+}
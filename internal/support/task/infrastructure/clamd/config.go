@@ -0,0 +1,12 @@
+package clamd
+
+import "time"
+
+// Config of clamd daemon connection. Network is "tcp" or "unix",
+// Address is host:port or path to socket file respectively.
+type Config struct {
+	Network   string        `mapstructure:"network"`
+	Address   string        `mapstructure:"address"`
+	Timeout   time.Duration `mapstructure:"timeout"`
+	ChunkSize int           `mapstructure:"chunk_size"`
+}
@@ -0,0 +1,159 @@
+package cmdparser
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+	"slices"
+	"strings"
+	"time"
+
+	"watchtower/internal/shared/kernel"
+	"watchtower/internal/support/task/application/service/recognizer"
+)
+
+const (
+	RecognizerName = "command"
+
+	// maxStderrSize limits stderr kept for error messages.
+	maxStderrSize = 4096
+
+	// waitDelay bounds waiting for pipes after command has been killed.
+	waitDelay = time.Second
+)
+
+var ErrOutputTooLarge = errors.New("command output size limit exceeded")
+
+// CmdParser recognizes files by local commands like pdftotext or
+// tesseract for sites where docparser service is not available.
+type CmdParser struct {
+	config Config
+}
+
+func New(config Config) recognizer.IRecognizer {
+	return &CmdParser{config}
+}
+
+func (cp *CmdParser) Recognize(ctx kernel.Ctx, params *recognizer.RecognizeParams) (*recognizer.Recognized, error) {
+	mimeType := params.MimeType()
+	command, found := cp.findCommand(mimeType)
+	if !found {
+		return nil, fmt.Errorf("cmdparser: there is no command for mime type %s", mimeType)
+	}
+
+	output, err := cp.run(ctx, command, params.FileData.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("cmdparser: %s: %w", command.Path, err)
+	}
+
+	text := strings.TrimSpace(string(output))
+	if text == "" {
+		return nil, fmt.Errorf("cmdparser: %s returned empty content data", command.Path)
+	}
+
+	return &recognizer.Recognized{Text: text}, nil
+}
+
+func (cp *CmdParser) findCommand(mimeType string) (Command, bool) {
+	for _, command := range cp.config.Commands {
+		if slices.ContainsFunc(command.MimeTypes, func(pattern string) bool {
+			return recognizer.MatchMimeType(pattern, mimeType)
+		}) {
+			return command, true
+		}
+	}
+
+	return Command{}, false
+}
+
+func (cp *CmdParser) run(ctx kernel.Ctx, command Command, data []byte) ([]byte, error) {
+	timeout := cp.config.Timeout * time.Second
+	var cmdCtx context.Context
+	var cancel context.CancelFunc
+	if timeout > 0 {
+		cmdCtx, cancel = context.WithTimeout(ctx, timeout)
+	} else {
+		cmdCtx, cancel = context.WithCancel(ctx)
+	}
+	defer cancel()
+
+	cmd := exec.CommandContext(cmdCtx, command.Path, command.Args...)
+	setProcessGroup(cmd)
+	cmd.WaitDelay = waitDelay
+	cmd.Stdin = bytes.NewReader(data)
+
+	stderr := &limitedBuffer{limit: maxStderrSize}
+	cmd.Stderr = stderr
+
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, fmt.Errorf("failed to open stdout: %w", err)
+	}
+
+	if err = cmd.Start(); err != nil {
+		return nil, fmt.Errorf("failed to start: %w", err)
+	}
+
+	output, readErr := readLimited(stdout, cp.config.MaxOutputSize)
+	if readErr != nil {
+		cancel()
+	}
+
+	waitErr := cmd.Wait()
+	switch {
+	case readErr != nil:
+		return nil, readErr
+	case errors.Is(cmdCtx.Err(), context.DeadlineExceeded):
+		return nil, fmt.Errorf("timed out after %s", timeout)
+	case ctx.Err() != nil:
+		return nil, fmt.Errorf("cancelled: %w", ctx.Err())
+	}
+
+	var exitErr *exec.ExitError
+	if errors.As(waitErr, &exitErr) {
+		stderrMsg := strings.TrimSpace(stderr.String())
+		return nil, fmt.Errorf("exited with code %d: %s", exitErr.ExitCode(), stderrMsg)
+	}
+
+	if waitErr != nil {
+		return nil, fmt.Errorf("failed to wait: %w", waitErr)
+	}
+
+	return output, nil
+}
+
+// readLimited reads reader fully and fails when data exceeds limit.
+// Zero limit means no limit.
+func readLimited(reader io.Reader, limit int64) ([]byte, error) {
+	if limit <= 0 {
+		return io.ReadAll(reader)
+	}
+
+	output, err := io.ReadAll(io.LimitReader(reader, limit+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read stdout: %w", err)
+	}
+
+	if int64(len(output)) > limit {
+		return nil, ErrOutputTooLarge
+	}
+
+	return output, nil
+}
+
+// limitedBuffer keeps only first bytes written to it.
+type limitedBuffer struct {
+	bytes.Buffer
+	limit int
+}
+
+func (lb *limitedBuffer) Write(p []byte) (int, error) {
+	if remaining := lb.limit - lb.Len(); remaining > 0 {
+		lb.Buffer.Write(p[:min(len(p), remaining)])
+	}
+
+	return len(p), nil
+}
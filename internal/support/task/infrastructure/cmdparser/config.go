@@ -0,0 +1,17 @@
+package cmdparser
+
+import "time"
+
+type Config struct {
+	Timeout       time.Duration `mapstructure:"timeout"`
+	MaxOutputSize int64         `mapstructure:"max_output_size"`
+	Commands      []Command     `mapstructure:"commands"`
+}
+
+// Command is launched for files of matched mime types. File data
+// is passed to stdin and recognized text is read from stdout.
+type Command struct {
+	MimeTypes []string `mapstructure:"mime_types"`
+	Path      string   `mapstructure:"path"`
+	Args      []string `mapstructure:"args"`
+}
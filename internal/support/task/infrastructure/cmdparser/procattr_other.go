@@ -0,0 +1,8 @@
+//go:build !unix
+
+package cmdparser
+
+import "os/exec"
+
+// setProcessGroup keeps default behaviour of killing command process only.
+func setProcessGroup(_ *exec.Cmd) {}
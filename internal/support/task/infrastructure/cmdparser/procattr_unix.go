@@ -0,0 +1,17 @@
+//go:build unix
+
+package cmdparser
+
+import (
+	"os/exec"
+	"syscall"
+)
+
+// setProcessGroup launches command in own process group so that
+// cancelling kills the command with all its children.
+func setProcessGroup(cmd *exec.Cmd) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	cmd.Cancel = func() error {
+		return syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL)
+	}
+}
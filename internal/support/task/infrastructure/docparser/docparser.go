@@ -12,7 +12,10 @@ import (
 	"watchtower/internal/support/task/application/service/recognizer"
 )
 
-const RecognitionURL = "/api/v1/parser/parse/text"
+const (
+	RecognizerName = "docparser"
+	RecognitionURL = "/api/v1/parser/parse/text"
+)
 
 type DocParser struct {
 	config Config
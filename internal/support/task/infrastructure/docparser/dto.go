@@ -3,11 +3,39 @@ package docparser
 import "watchtower/internal/support/task/application/service/recognizer"
 
 type ParsedContent struct {
-	Text string `json:"parsed_text"`
+	Text       string            `json:"parsed_text"`
+	Pages      []ParsedPage      `json:"pages,omitempty"`
+	PageCount  int               `json:"page_count,omitempty"`
+	Language   string            `json:"language,omitempty"`
+	Confidence float64           `json:"confidence,omitempty"`
+	Metadata   map[string]string `json:"metadata,omitempty"`
+}
+
+type ParsedPage struct {
+	PageNumber int    `json:"page_number"`
+	Text       string `json:"text"`
 }
 
 func (pc *ParsedContent) ToRecognized() recognizer.Recognized {
+	pages := make([]recognizer.Page, 0, len(pc.Pages))
+	for _, page := range pc.Pages {
+		pages = append(pages, recognizer.Page{
+			Number: page.PageNumber,
+			Text:   page.Text,
+		})
+	}
+
+	pageCount := pc.PageCount
+	if pageCount == 0 {
+		pageCount = len(pages)
+	}
+
 	return recognizer.Recognized{
-		Text: pc.Text,
+		Text:       pc.Text,
+		Pages:      pages,
+		PageCount:  pageCount,
+		Language:   pc.Language,
+		Confidence: pc.Confidence,
+		Metadata:   pc.Metadata,
 	}
 }
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"log/slog"
+	"net/url"
 	"strings"
 	"time"
 
@@ -28,12 +29,29 @@ func New(config Config) docstorage.IDocumentStorage {
 func (ds *DocSearch) StoreDocument(ctx kernel.Ctx, doc *docstorage.Document) (docstorage.DocumentID, error) {
 	index := doc.Index
 	storeDoc := StoreDocumentForm{
-		FileName:   doc.Name,
-		FilePath:   doc.Path,
-		FileSize:   doc.Size,
-		Content:    doc.Content,
-		CreatedAt:  doc.CreatedAt.UnixMilli(),
-		ModifiedAt: doc.ModifiedAt.UnixMilli(),
+		FileName:    doc.Name,
+		FilePath:    doc.Path,
+		FileSize:    doc.Size,
+		ContentType: doc.ContentType,
+		Metadata:    doc.Metadata,
+		Tags:        doc.Tags,
+		VersionID:   doc.VersionID,
+		Properties:  doc.Properties,
+		Content:     doc.Content,
+		Pages:       make([]StoreDocumentPage, 0, len(doc.Pages)),
+		PageCount:   doc.PageCount,
+		Language:    doc.Language,
+		Confidence:  doc.Confidence,
+		Summary:     doc.Summary,
+		Labels:      doc.Labels,
+		PiiCounts:   doc.PiiCounts,
+		DuplicateOf: doc.DuplicateOf,
+		CreatedAt:   doc.CreatedAt.UnixMilli(),
+		ModifiedAt:  doc.ModifiedAt.UnixMilli(),
+	}
+
+	for _, page := range doc.Pages {
+		storeDoc.Pages = append(storeDoc.Pages, StoreDocumentPage{PageNumber: page.Number, Text: page.Text})
 	}
 
 	jsonData, err := json.Marshal(storeDoc)
@@ -70,3 +88,45 @@ func (ds *DocSearch) StoreDocument(ctx kernel.Ctx, doc *docstorage.Document) (do
 
 	return status.Message, nil
 }
+
+func (ds *DocSearch) DeleteDocuments(ctx kernel.Ctx, index string, filePath string) error {
+	query := url.Values{"file_path": {filePath}}
+	urlPath := fmt.Sprintf("/api/v1/storage/%s/delete?%s", index, query.Encode())
+	targetURL := utils.BuildTargetURL(ds.config.Address, urlPath)
+
+	slog.Debug("deleting documents from index",
+		slog.String("index", index),
+		slog.String("file-path", filePath),
+	)
+
+	timeoutReq := ds.config.Timeout * time.Second
+	if _, err := utils.DELETE(ctx, targetURL, timeoutReq); err != nil {
+		return fmt.Errorf("http-request error: %w", err)
+	}
+
+	return nil
+}
+
+func (ds *DocSearch) MoveDocuments(ctx kernel.Ctx, index string, srcPath string, dstPath string) error {
+	jsonData, err := json.Marshal(MoveDocumentsForm{FilePath: srcPath, DstPath: dstPath})
+	if err != nil {
+		return fmt.Errorf("serialize error: %w", err)
+	}
+
+	urlPath := fmt.Sprintf("/api/v1/storage/%s/move", index)
+	targetURL := utils.BuildTargetURL(ds.config.Address, urlPath)
+
+	slog.Debug("moving documents within index",
+		slog.String("index", index),
+		slog.String("src-file-path", srcPath),
+		slog.String("dst-file-path", dstPath),
+	)
+
+	reqBody := bytes.NewBuffer(jsonData)
+	timeoutReq := ds.config.Timeout * time.Second
+	if _, err = utils.POST(ctx, reqBody, targetURL, DocumentJsonMime, timeoutReq); err != nil {
+		return fmt.Errorf("http-request error: %w", err)
+	}
+
+	return nil
+}
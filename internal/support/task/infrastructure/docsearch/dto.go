@@ -1,12 +1,35 @@
 package docsearch
 
 type StoreDocumentForm struct {
-	FileName   string `json:"file_name"`
-	FilePath   string `json:"file_path"`
-	FileSize   int    `json:"file_size"`
-	Content    string `json:"content"`
-	CreatedAt  int64  `json:"created_at"`
-	ModifiedAt int64  `json:"modified_at"`
+	FileName    string              `json:"file_name"`
+	FilePath    string              `json:"file_path"`
+	FileSize    int                 `json:"file_size"`
+	ContentType string              `json:"content_type,omitempty"`
+	Metadata    map[string]string   `json:"metadata,omitempty"`
+	Tags        map[string]string   `json:"tags,omitempty"`
+	VersionID   string              `json:"version_id,omitempty"`
+	Properties  map[string]string   `json:"properties,omitempty"`
+	Content     string              `json:"content"`
+	Pages       []StoreDocumentPage `json:"pages,omitempty"`
+	PageCount   int                 `json:"page_count,omitempty"`
+	Language    string              `json:"language,omitempty"`
+	Confidence  float64             `json:"confidence,omitempty"`
+	Summary     string              `json:"summary,omitempty"`
+	Labels      []string            `json:"labels,omitempty"`
+	PiiCounts   map[string]int      `json:"pii_counts,omitempty"`
+	DuplicateOf string              `json:"duplicate_of,omitempty"`
+	CreatedAt   int64               `json:"created_at"`
+	ModifiedAt  int64               `json:"modified_at"`
+}
+
+type StoreDocumentPage struct {
+	PageNumber int    `json:"page_number"`
+	Text       string `json:"text"`
+}
+
+type MoveDocumentsForm struct {
+	FilePath string `json:"file_path"`
+	DstPath  string `json:"dst_path"`
 }
 
 type StoreDocumentResult struct {
@@ -0,0 +1,19 @@
+package llm
+
+import "time"
+
+// Config of OpenAI-compatible chat completions service. Texts longer
+// than ChunkSize characters are summarized by parts and then reduced
+// to single summary, parts after MaxChunks are truncated. Zero values
+// disable splitting and truncating.
+type Config struct {
+	Address       string        `mapstructure:"address"`
+	APIKey        string        `mapstructure:"api_key"`
+	Model         string        `mapstructure:"model"`
+	MaxTokens     int           `mapstructure:"max_tokens"`
+	Timeout       time.Duration `mapstructure:"timeout"`
+	ChunkSize     int           `mapstructure:"chunk_size"`
+	MaxChunks     int           `mapstructure:"max_chunks"`
+	ChunkPrompt   string        `mapstructure:"chunk_prompt"`
+	SummaryPrompt string        `mapstructure:"summary_prompt"`
+}
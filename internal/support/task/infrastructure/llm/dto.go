@@ -0,0 +1,54 @@
+package llm
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+const (
+	SystemRole = "system"
+	UserRole   = "user"
+)
+
+type ChatMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+type ChatCompletionForm struct {
+	Model     string        `json:"model"`
+	Messages  []ChatMessage `json:"messages"`
+	MaxTokens int           `json:"max_tokens,omitempty"`
+}
+
+type ChatCompletionChoice struct {
+	Message      ChatMessage `json:"message"`
+	FinishReason string      `json:"finish_reason"`
+}
+
+type ChatCompletion struct {
+	Choices []ChatCompletionChoice `json:"choices"`
+}
+
+type SummaryResult struct {
+	Summary string   `json:"summary"`
+	Labels  []string `json:"labels"`
+}
+
+// parseSummaryResult decodes json object from model answer which may be
+// wrapped by markdown code block. Answer without json object is
+// considered as plain summary text without labels.
+func parseSummaryResult(content string) SummaryResult {
+	content = strings.TrimSpace(content)
+	start := strings.Index(content, "{")
+	end := strings.LastIndex(content, "}")
+	if start >= 0 && end > start {
+		var result SummaryResult
+		if err := json.Unmarshal([]byte(content[start:end+1]), &result); err == nil {
+			result.Summary = strings.TrimSpace(result.Summary)
+			return result
+		}
+	}
+
+	return SummaryResult{Summary: content}
+}
@@ -0,0 +1,191 @@
+package llm
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"watchtower/internal/shared/kernel"
+	"watchtower/internal/shared/utils"
+	"watchtower/internal/support/task/application/service/summary"
+)
+
+const (
+	CompletionsURL = "/v1/chat/completions"
+	CompletionMime = "application/json"
+
+	DefaultChunkPrompt = "Summarize the following part of a document in a few sentences. " +
+		"Keep names, dates and amounts. Answer with the summary text only."
+
+	DefaultSummaryPrompt = "Summarize the following document in a few sentences and choose " +
+		"labels describing it from the given list of labels. Answer with json object only: " +
+		`{"summary": "<summary text>", "labels": ["<label>"]}.`
+)
+
+// LlmClient summarizes and classifies documents by OpenAI-compatible
+// chat completions service.
+type LlmClient struct {
+	config Config
+}
+
+func New(config Config) summary.ISummarizer {
+	if config.ChunkPrompt == "" {
+		config.ChunkPrompt = DefaultChunkPrompt
+	}
+	if config.SummaryPrompt == "" {
+		config.SummaryPrompt = DefaultSummaryPrompt
+	}
+
+	return &LlmClient{config}
+}
+
+func (lc *LlmClient) Summarize(ctx kernel.Ctx, params *summary.SummarizeParams) (*summary.Summary, error) {
+	chunks := splitText(params.Text, lc.config.ChunkSize, lc.config.MaxChunks)
+	if len(chunks) == 0 {
+		return nil, fmt.Errorf("llm: there is no text to summarize")
+	}
+
+	text := chunks[0]
+	if len(chunks) > 1 {
+		partials := make([]string, 0, len(chunks))
+		for index, chunk := range chunks {
+			partial, err := lc.complete(ctx, lc.config.ChunkPrompt, chunk)
+			if err != nil {
+				return nil, fmt.Errorf("llm: failed to summarize part %d: %w", index+1, err)
+			}
+			partials = append(partials, partial)
+		}
+		text = strings.Join(partials, "\n\n")
+	}
+
+	content, err := lc.complete(ctx, lc.config.SummaryPrompt, buildSummaryMessage(text, params.Taxonomy))
+	if err != nil {
+		return nil, fmt.Errorf("llm: failed to summarize document: %w", err)
+	}
+
+	result := parseSummaryResult(content)
+	if result.Summary == "" {
+		return nil, fmt.Errorf("llm: returned empty summary")
+	}
+
+	return &summary.Summary{
+		Text:   result.Summary,
+		Labels: filterLabels(result.Labels, params.Taxonomy),
+	}, nil
+}
+
+func (lc *LlmClient) complete(ctx kernel.Ctx, prompt, text string) (string, error) {
+	completionForm := ChatCompletionForm{
+		Model:     lc.config.Model,
+		MaxTokens: lc.config.MaxTokens,
+		Messages: []ChatMessage{
+			{Role: SystemRole, Content: prompt},
+			{Role: UserRole, Content: text},
+		},
+	}
+
+	jsonData, err := json.Marshal(completionForm)
+	if err != nil {
+		return "", fmt.Errorf("serialize error: %w", err)
+	}
+
+	headers := map[string]string{"Content-Type": CompletionMime}
+	if lc.config.APIKey != "" {
+		headers["Authorization"] = "Bearer " + lc.config.APIKey
+	}
+
+	reqBody := bytes.NewBuffer(jsonData)
+	timeoutReq := lc.config.Timeout * time.Second
+	targetURL := utils.BuildTargetURL(lc.config.Address, CompletionsURL)
+
+	respData, err := utils.POSTWithHeaders(ctx, reqBody, targetURL, headers, timeoutReq)
+	if err != nil {
+		return "", err
+	}
+
+	var completion ChatCompletion
+	if err = json.Unmarshal(respData, &completion); err != nil {
+		return "", fmt.Errorf("deserialize error: %w", err)
+	}
+
+	if len(completion.Choices) == 0 {
+		return "", fmt.Errorf("returned empty choices")
+	}
+
+	return strings.TrimSpace(completion.Choices[0].Message.Content), nil
+}
+
+func buildSummaryMessage(text string, taxonomy []string) string {
+	if len(taxonomy) == 0 {
+		return fmt.Sprintf("Labels: none\n\nDocument:\n%s", text)
+	}
+
+	return fmt.Sprintf("Labels: %s\n\nDocument:\n%s", strings.Join(taxonomy, ", "), text)
+}
+
+// filterLabels keeps only labels from taxonomy written as in taxonomy.
+func filterLabels(labels, taxonomy []string) []string {
+	filtered := make([]string, 0, len(labels))
+	for _, label := range labels {
+		for _, value := range taxonomy {
+			if strings.EqualFold(strings.TrimSpace(label), value) && !slices.Contains(filtered, value) {
+				filtered = append(filtered, value)
+				break
+			}
+		}
+	}
+
+	return filtered
+}
+
+// splitText splits text into chunks of chunkSize runes at most, breaking
+// at line or word boundary when it is possible. Chunks after maxChunks
+// are dropped.
+func splitText(text string, chunkSize, maxChunks int) []string {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return nil
+	}
+
+	runes := []rune(text)
+	if chunkSize <= 0 || len(runes) <= chunkSize {
+		return []string{text}
+	}
+
+	var chunks []string
+	for len(runes) > 0 {
+		if maxChunks > 0 && len(chunks) == maxChunks {
+			break
+		}
+
+		end := min(chunkSize, len(runes))
+		if end < len(runes) {
+			end = findBreak(runes[:end])
+		}
+
+		if chunk := strings.TrimSpace(string(runes[:end])); chunk != "" {
+			chunks = append(chunks, chunk)
+		}
+		runes = runes[end:]
+	}
+
+	return chunks
+}
+
+// findBreak returns position after last line or word break located in
+// second half of chunk, otherwise chunk is broken at its end.
+func findBreak(chunk []rune) int {
+	half := len(chunk) / 2
+	for _, separator := range []rune{'\n', ' '} {
+		for index := len(chunk) - 1; index > half; index-- {
+			if chunk[index] == separator {
+				return index + 1
+			}
+		}
+	}
+
+	return len(chunk)
+}
@@ -0,0 +1,11 @@
+package neo4j
+
+import "time"
+
+type Config struct {
+	Address  string        `mapstructure:"address"`
+	Database string        `mapstructure:"database"`
+	Username string        `mapstructure:"username"`
+	Password string        `mapstructure:"password"`
+	Timeout  time.Duration `mapstructure:"timeout"`
+}
@@ -0,0 +1,64 @@
+package neo4j
+
+import (
+	"slices"
+
+	"watchtower/internal/support/task/application/service/graph"
+)
+
+type Statement struct {
+	Statement  string         `json:"statement"`
+	Parameters map[string]any `json:"parameters"`
+}
+
+type TransactionForm struct {
+	Statements []Statement `json:"statements"`
+}
+
+type TransactionError struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+type TransactionResult struct {
+	Errors []TransactionError `json:"errors"`
+}
+
+func nodesToParams(nodes []graph.Node) []map[string]any {
+	params := make([]map[string]any, len(nodes))
+	for index, node := range nodes {
+		params[index] = map[string]any{
+			"name": node.Name,
+			"type": node.Type,
+		}
+	}
+	return params
+}
+
+// edgesToParams resolves types of relation ends by entities of document.
+// Relation is linked to each entity of the same name, relations of entities
+// which are not mentioned by document are dropped.
+func edgesToParams(edges []graph.Edge, nodes []graph.Node) []map[string]any {
+	nodeTypes := make(map[string][]string, len(nodes))
+	for _, node := range nodes {
+		if !slices.Contains(nodeTypes[node.Name], node.Type) {
+			nodeTypes[node.Name] = append(nodeTypes[node.Name], node.Type)
+		}
+	}
+
+	params := make([]map[string]any, 0, len(edges))
+	for _, edge := range edges {
+		for _, sourceType := range nodeTypes[edge.Source] {
+			for _, targetType := range nodeTypes[edge.Target] {
+				params = append(params, map[string]any{
+					"source":      edge.Source,
+					"source_type": sourceType,
+					"target":      edge.Target,
+					"target_type": targetType,
+					"type":        edge.Type,
+				})
+			}
+		}
+	}
+	return params
+}
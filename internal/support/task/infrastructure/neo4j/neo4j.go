@@ -0,0 +1,136 @@
+package neo4j
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"time"
+
+	"watchtower/internal/shared/kernel"
+	"watchtower/internal/shared/utils"
+	"watchtower/internal/support/task/application/service/graph"
+)
+
+const (
+	CommitTransactionURL = "/db/%s/tx/commit"
+	TransactionJsonMime  = "application/json"
+)
+
+const mergeDocumentNodesQuery = `
+MERGE (d:Document {id: $document_id})
+SET d.index = $index, d.path = $path
+WITH d
+UNWIND $nodes AS node
+MERGE (e:Entity {name: node.name, type: node.type})
+MERGE (d)-[:MENTIONS]->(e)`
+
+// Entities are merged by name and type, so relation ends are matched by
+// both of them among entities mentioned by the same document.
+const mergeRelationEdgesQuery = `
+UNWIND $edges AS edge
+MATCH (d:Document {id: $document_id})-[:MENTIONS]->(s:Entity {name: edge.source, type: edge.source_type})
+MATCH (d)-[:MENTIONS]->(t:Entity {name: edge.target, type: edge.target_type})
+MERGE (s)-[:RELATED {type: edge.type}]->(t)`
+
+const moveDocumentQuery = `
+MATCH (d:Document {index: $index, path: $src_path})
+SET d.path = $dst_path`
+
+type Neo4jClient struct {
+	config Config
+}
+
+func New(config Config) graph.IGraphStore {
+	return &Neo4jClient{config}
+}
+
+func (nc *Neo4jClient) StoreGraph(ctx kernel.Ctx, docGraph *graph.Graph) error {
+	txForm := TransactionForm{
+		Statements: []Statement{
+			{
+				Statement: mergeDocumentNodesQuery,
+				Parameters: map[string]any{
+					"document_id": docGraph.DocumentID,
+					"index":       docGraph.Index,
+					"path":        docGraph.Path,
+					"nodes":       nodesToParams(docGraph.Nodes),
+				},
+			},
+			{
+				Statement: mergeRelationEdgesQuery,
+				Parameters: map[string]any{
+					"document_id": docGraph.DocumentID,
+					"edges":       edgesToParams(docGraph.Edges, docGraph.Nodes),
+				},
+			},
+		},
+	}
+
+	return nc.commit(ctx, txForm)
+}
+
+func (nc *Neo4jClient) MoveDocument(ctx kernel.Ctx, index, srcPath, dstPath string) error {
+	txForm := TransactionForm{
+		Statements: []Statement{
+			{
+				Statement: moveDocumentQuery,
+				Parameters: map[string]any{
+					"index":    index,
+					"src_path": srcPath,
+					"dst_path": dstPath,
+				},
+			},
+		},
+	}
+
+	return nc.commit(ctx, txForm)
+}
+
+// commit runs statements of form by single transaction.
+func (nc *Neo4jClient) commit(ctx kernel.Ctx, txForm TransactionForm) error {
+	jsonData, err := json.Marshal(txForm)
+	if err != nil {
+		return fmt.Errorf("neo4j: serialize error: %w", err)
+	}
+
+	targetURL, err := nc.buildTargetURL()
+	if err != nil {
+		return err
+	}
+
+	reqBody := bytes.NewBuffer(jsonData)
+	timeoutReq := nc.config.Timeout * time.Second
+	respData, err := utils.POST(ctx, reqBody, targetURL, TransactionJsonMime, timeoutReq)
+	if err != nil {
+		return fmt.Errorf("neo4j: http-request error: %w", err)
+	}
+
+	txResult := &TransactionResult{}
+	if err = json.Unmarshal(respData, txResult); err != nil {
+		return fmt.Errorf("neo4j: deserialize error: %w", err)
+	}
+
+	// Neo4j returns 200 OK even if statements have been rolled back
+	if len(txResult.Errors) > 0 {
+		txErr := txResult.Errors[0]
+		return fmt.Errorf("neo4j: transaction failed %s: %s", txErr.Code, txErr.Message)
+	}
+
+	return nil
+}
+
+func (nc *Neo4jClient) buildTargetURL() (string, error) {
+	urlPath := fmt.Sprintf(CommitTransactionURL, nc.config.Database)
+	targetURL, err := url.Parse(utils.BuildTargetURL(nc.config.Address, urlPath))
+	if err != nil {
+		return "", fmt.Errorf("neo4j: invalid address: %w", err)
+	}
+
+	// http.Client sets basic auth header from url user info
+	if nc.config.Username != "" {
+		targetURL.User = url.UserPassword(nc.config.Username, nc.config.Password)
+	}
+
+	return targetURL.String(), nil
+}
@@ -0,0 +1,8 @@
+package ner
+
+import "time"
+
+type Config struct {
+	Address string        `mapstructure:"address"`
+	Timeout time.Duration `mapstructure:"timeout"`
+}
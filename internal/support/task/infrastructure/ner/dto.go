@@ -0,0 +1,48 @@
+package ner
+
+import "watchtower/internal/support/task/application/service/entity"
+
+type ExtractForm struct {
+	FileName string `json:"file_name"`
+	Text     string `json:"text"`
+}
+
+type EntityDto struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+type RelationDto struct {
+	Source string `json:"source"`
+	Target string `json:"target"`
+	Type   string `json:"type"`
+}
+
+type ExtractedContent struct {
+	Entities  []EntityDto   `json:"entities"`
+	Relations []RelationDto `json:"relations"`
+}
+
+func (ec *ExtractedContent) ToExtracted() entity.Extracted {
+	entities := make([]entity.Entity, len(ec.Entities))
+	for index, value := range ec.Entities {
+		entities[index] = entity.Entity{
+			Name: value.Name,
+			Type: value.Type,
+		}
+	}
+
+	relations := make([]entity.Relation, len(ec.Relations))
+	for index, value := range ec.Relations {
+		relations[index] = entity.Relation{
+			Source: value.Source,
+			Target: value.Target,
+			Type:   value.Type,
+		}
+	}
+
+	return entity.Extracted{
+		Entities:  entities,
+		Relations: relations,
+	}
+}
@@ -0,0 +1,56 @@
+package ner
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"watchtower/internal/shared/kernel"
+	"watchtower/internal/shared/utils"
+	"watchtower/internal/support/task/application/service/entity"
+)
+
+const (
+	ExtractionURL   = "/api/v1/ner/extract"
+	ExtractJsonMime = "application/json"
+)
+
+type NerClient struct {
+	config Config
+}
+
+func New(config Config) entity.IEntityExtractor {
+	return &NerClient{config}
+}
+
+func (nc *NerClient) Extract(ctx kernel.Ctx, params *entity.ExtractParams) (*entity.Extracted, error) {
+	extractForm := ExtractForm{
+		FileName: params.FileName,
+		Text:     params.Text,
+	}
+
+	jsonData, err := json.Marshal(extractForm)
+	if err != nil {
+		err = fmt.Errorf("ner: serialize error: %w", err)
+		return nil, err
+	}
+
+	reqBody := bytes.NewBuffer(jsonData)
+	timeoutReq := nc.config.Timeout * time.Second
+	targetURL := utils.BuildTargetURL(nc.config.Address, ExtractionURL)
+
+	respData, err := utils.POST(ctx, reqBody, targetURL, ExtractJsonMime, timeoutReq)
+	if err != nil {
+		return nil, err
+	}
+
+	var responseData ExtractedContent
+	if err = json.Unmarshal(respData, &responseData); err != nil {
+		err = fmt.Errorf("ner: deserialize error: %w", err)
+		return nil, err
+	}
+
+	extracted := responseData.ToExtracted()
+	return &extracted, nil
+}
@@ -0,0 +1,106 @@
+package ooxml
+
+import "strings"
+
+// CoreProperties is docProps/core.xml part of package.
+type CoreProperties struct {
+	Title          string `xml:"title"`
+	Subject        string `xml:"subject"`
+	Creator        string `xml:"creator"`
+	Keywords       string `xml:"keywords"`
+	LastModifiedBy string `xml:"lastModifiedBy"`
+	Created        string `xml:"created"`
+	Modified       string `xml:"modified"`
+}
+
+func (cp *CoreProperties) ToMetadata() map[string]string {
+	properties := map[string]string{
+		"title":            cp.Title,
+		"subject":          cp.Subject,
+		"author":           cp.Creator,
+		"keywords":         cp.Keywords,
+		"last_modified_by": cp.LastModifiedBy,
+		"created":          cp.Created,
+		"modified":         cp.Modified,
+	}
+
+	metadata := make(map[string]string)
+	for key, value := range properties {
+		if value != "" {
+			metadata[key] = value
+		}
+	}
+
+	return metadata
+}
+
+// Relationships is *.rels part linking package parts by id.
+type Relationships struct {
+	Items []Relationship `xml:"Relationship"`
+}
+
+type Relationship struct {
+	ID     string `xml:"Id,attr"`
+	Type   string `xml:"Type,attr"`
+	Target string `xml:"Target,attr"`
+}
+
+// Workbook is xl/workbook.xml part of spreadsheet.
+type Workbook struct {
+	Sheets []WorkbookSheet `xml:"sheets>sheet"`
+}
+
+type WorkbookSheet struct {
+	Name  string `xml:"name,attr"`
+	RelID string `xml:"http://schemas.openxmlformats.org/officeDocument/2006/relationships id,attr"`
+}
+
+// Presentation is ppt/presentation.xml part of presentation.
+type Presentation struct {
+	Slides []PresentationSlide `xml:"sldIdLst>sldId"`
+}
+
+type PresentationSlide struct {
+	RelID string `xml:"http://schemas.openxmlformats.org/officeDocument/2006/relationships id,attr"`
+}
+
+// SharedStrings is xl/sharedStrings.xml part of spreadsheet.
+type SharedStrings struct {
+	Items []SharedString `xml:"si"`
+}
+
+type SharedString struct {
+	Text string       `xml:"t"`
+	Runs []SharedText `xml:"r"`
+}
+
+type SharedText struct {
+	Text string `xml:"t"`
+}
+
+func (ss *SharedString) String() string {
+	if len(ss.Runs) == 0 {
+		return ss.Text
+	}
+
+	texts := make([]string, 0, len(ss.Runs))
+	for _, run := range ss.Runs {
+		texts = append(texts, run.Text)
+	}
+	return strings.Join(texts, "")
+}
+
+// Worksheet is xl/worksheets/sheetN.xml part of spreadsheet.
+type Worksheet struct {
+	Rows []WorksheetRow `xml:"sheetData>row"`
+}
+
+type WorksheetRow struct {
+	Cells []WorksheetCell `xml:"c"`
+}
+
+type WorksheetCell struct {
+	Type       string `xml:"t,attr"`
+	Value      string `xml:"v"`
+	InlineText string `xml:"is>t"`
+}
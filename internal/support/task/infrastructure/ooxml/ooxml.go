@@ -0,0 +1,301 @@
+package ooxml
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"path"
+	"strconv"
+	"strings"
+
+	"watchtower/internal/shared/kernel"
+	"watchtower/internal/support/task/application/service/recognizer"
+)
+
+const (
+	RecognizerName = "ooxml"
+
+	// maxPartSize protects from zip bombs disguised as office documents.
+	maxPartSize = 256 << 20
+
+	notesSlideRelType = "/notesSlide"
+)
+
+// OoxmlParser extracts text of docx, xlsx and pptx documents
+// directly from their xml parts without OCR.
+type OoxmlParser struct{}
+
+func New() recognizer.IRecognizer {
+	return &OoxmlParser{}
+}
+
+func (op *OoxmlParser) Recognize(_ kernel.Ctx, params *recognizer.RecognizeParams) (*recognizer.Recognized, error) {
+	data := params.FileData.Bytes()
+	zipReader, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, fmt.Errorf("ooxml: failed to open package: %w", err)
+	}
+
+	pkg := newPackage(zipReader)
+
+	var builder strings.Builder
+	switch {
+	case pkg.has("word/document.xml"):
+		err = pkg.extractDocument(&builder)
+	case pkg.has("xl/workbook.xml"):
+		err = pkg.extractSpreadsheet(&builder)
+	case pkg.has("ppt/presentation.xml"):
+		err = pkg.extractPresentation(&builder)
+	default:
+		return nil, fmt.Errorf("ooxml: unsupported package of file %s", params.FileName)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("ooxml: %w", err)
+	}
+
+	text := strings.TrimSpace(builder.String())
+	if text == "" {
+		return nil, fmt.Errorf("ooxml: extracted empty content data")
+	}
+
+	var coreProps CoreProperties
+	if pkg.has("docProps/core.xml") {
+		if err = pkg.decode("docProps/core.xml", &coreProps); err != nil {
+			return nil, fmt.Errorf("ooxml: %w", err)
+		}
+	}
+
+	recData := &recognizer.Recognized{
+		Text:     text,
+		Metadata: coreProps.ToMetadata(),
+	}
+
+	return recData, nil
+}
+
+type ooxmlPackage struct {
+	files map[string]*zip.File
+}
+
+func newPackage(zipReader *zip.Reader) *ooxmlPackage {
+	files := make(map[string]*zip.File, len(zipReader.File))
+	for _, file := range zipReader.File {
+		files[file.Name] = file
+	}
+
+	return &ooxmlPackage{files: files}
+}
+
+func (p *ooxmlPackage) has(partName string) bool {
+	_, ok := p.files[partName]
+	return ok
+}
+
+func (p *ooxmlPackage) open(partName string) (io.ReadCloser, error) {
+	file, ok := p.files[partName]
+	if !ok {
+		return nil, fmt.Errorf("part %s not found", partName)
+	}
+
+	if file.UncompressedSize64 > maxPartSize {
+		return nil, fmt.Errorf("part %s exceeds size limit", partName)
+	}
+
+	reader, err := file.Open()
+	if err != nil {
+		return nil, fmt.Errorf("failed to open part %s: %w", partName, err)
+	}
+
+	limitedReader := struct {
+		io.Reader
+		io.Closer
+	}{io.LimitReader(reader, maxPartSize), reader}
+
+	return limitedReader, nil
+}
+
+func (p *ooxmlPackage) decode(partName string, value any) error {
+	reader, err := p.open(partName)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = reader.Close() }()
+
+	if err = xml.NewDecoder(reader).Decode(value); err != nil {
+		return fmt.Errorf("failed to decode part %s: %w", partName, err)
+	}
+
+	return nil
+}
+
+func (p *ooxmlPackage) extract(partName string, rules textRules, builder *strings.Builder) error {
+	reader, err := p.open(partName)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = reader.Close() }()
+
+	if err = extractText(reader, rules, builder); err != nil {
+		return fmt.Errorf("failed to extract part %s: %w", partName, err)
+	}
+
+	return nil
+}
+
+// relationships returns relationships of part by id. Part without
+// relationships returns empty map.
+func (p *ooxmlPackage) relationships(partName string) (map[string]Relationship, error) {
+	relsName := path.Join(path.Dir(partName), "_rels", path.Base(partName)+".rels")
+	relations := make(map[string]Relationship)
+	if !p.has(relsName) {
+		return relations, nil
+	}
+
+	var rels Relationships
+	if err := p.decode(relsName, &rels); err != nil {
+		return nil, err
+	}
+
+	for _, rel := range rels.Items {
+		rel.Target = resolveTarget(partName, rel.Target)
+		relations[rel.ID] = rel
+	}
+
+	return relations, nil
+}
+
+func resolveTarget(partName, target string) string {
+	if absTarget, found := strings.CutPrefix(target, "/"); found {
+		return absTarget
+	}
+
+	return path.Join(path.Dir(partName), target)
+}
+
+func (p *ooxmlPackage) extractDocument(builder *strings.Builder) error {
+	for _, partName := range []string{"word/document.xml", "word/footnotes.xml", "word/endnotes.xml"} {
+		if !p.has(partName) {
+			continue
+		}
+
+		if err := p.extract(partName, wordRules, builder); err != nil {
+			return err
+		}
+		builder.WriteString("\n")
+	}
+
+	return nil
+}
+
+func (p *ooxmlPackage) extractPresentation(builder *strings.Builder) error {
+	const presentationPart = "ppt/presentation.xml"
+
+	var presentation Presentation
+	if err := p.decode(presentationPart, &presentation); err != nil {
+		return err
+	}
+
+	presentationRels, err := p.relationships(presentationPart)
+	if err != nil {
+		return err
+	}
+
+	for index, slide := range presentation.Slides {
+		slidePart := presentationRels[slide.RelID].Target
+		if !p.has(slidePart) {
+			continue
+		}
+
+		builder.WriteString(fmt.Sprintf("Slide %d\n", index+1))
+		if err = p.extract(slidePart, slideRules, builder); err != nil {
+			return err
+		}
+
+		slideRels, err := p.relationships(slidePart)
+		if err != nil {
+			return err
+		}
+
+		for _, rel := range slideRels {
+			if !strings.HasSuffix(rel.Type, notesSlideRelType) || !p.has(rel.Target) {
+				continue
+			}
+
+			builder.WriteString("Notes:\n")
+			if err = p.extract(rel.Target, slideRules, builder); err != nil {
+				return err
+			}
+		}
+		builder.WriteString("\n")
+	}
+
+	return nil
+}
+
+func (p *ooxmlPackage) extractSpreadsheet(builder *strings.Builder) error {
+	const workbookPart = "xl/workbook.xml"
+
+	var sharedStrings SharedStrings
+	if p.has("xl/sharedStrings.xml") {
+		if err := p.decode("xl/sharedStrings.xml", &sharedStrings); err != nil {
+			return err
+		}
+	}
+
+	var workbook Workbook
+	if err := p.decode(workbookPart, &workbook); err != nil {
+		return err
+	}
+
+	workbookRels, err := p.relationships(workbookPart)
+	if err != nil {
+		return err
+	}
+
+	for _, sheet := range workbook.Sheets {
+		sheetPart := workbookRels[sheet.RelID].Target
+		if !p.has(sheetPart) {
+			continue
+		}
+
+		var worksheet Worksheet
+		if err = p.decode(sheetPart, &worksheet); err != nil {
+			return err
+		}
+
+		builder.WriteString(fmt.Sprintf("Sheet: %s\n", sheet.Name))
+		for _, row := range worksheet.Rows {
+			values := make([]string, 0, len(row.Cells))
+			for _, cell := range row.Cells {
+				values = append(values, cellValue(cell, sharedStrings))
+			}
+
+			line := strings.TrimRight(strings.Join(values, "\t"), "\t")
+			if line != "" {
+				builder.WriteString(line)
+				builder.WriteString("\n")
+			}
+		}
+		builder.WriteString("\n")
+	}
+
+	return nil
+}
+
+func cellValue(cell WorksheetCell, sharedStrings SharedStrings) string {
+	switch cell.Type {
+	case "s":
+		index, err := strconv.Atoi(cell.Value)
+		if err != nil || index < 0 || index >= len(sharedStrings.Items) {
+			return ""
+		}
+		return sharedStrings.Items[index].String()
+	case "inlineStr":
+		return cell.InlineText
+	default:
+		return cell.Value
+	}
+}
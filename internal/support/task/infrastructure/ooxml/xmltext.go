@@ -0,0 +1,102 @@
+package ooxml
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// textRules describes how to turn elements of OOXML part into text.
+// Elements are matched by local name without namespace. Table cells
+// are written as single line separated by tab.
+type textRules struct {
+	textElements  map[string]bool
+	startElements map[string]string
+	endElements   map[string]string
+	rowElement    string
+	cellElement   string
+}
+
+var wordRules = textRules{
+	textElements:  map[string]bool{"t": true},
+	startElements: map[string]string{"tab": "\t", "br": "\n", "cr": "\n"},
+	endElements:   map[string]string{"p": "\n"},
+	rowElement:    "tr",
+	cellElement:   "tc",
+}
+
+var slideRules = textRules{
+	textElements:  map[string]bool{"t": true},
+	startElements: map[string]string{"br": "\n"},
+	endElements:   map[string]string{"p": "\n"},
+	rowElement:    "tr",
+	cellElement:   "tc",
+}
+
+// extractText streams xml part and writes its text by rules.
+func extractText(reader io.Reader, rules textRules, builder *strings.Builder) error {
+	inText := 0
+	var cells []*strings.Builder
+	var rowCells []int
+
+	current := func() *strings.Builder {
+		if len(cells) > 0 {
+			return cells[len(cells)-1]
+		}
+		return builder
+	}
+
+	decoder := xml.NewDecoder(reader)
+	for {
+		token, err := decoder.Token()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to parse xml: %w", err)
+		}
+
+		switch element := token.(type) {
+		case xml.StartElement:
+			name := element.Name.Local
+			switch {
+			case rules.textElements[name]:
+				inText++
+			case name == rules.rowElement:
+				rowCells = append(rowCells, 0)
+			case name == rules.cellElement:
+				cells = append(cells, &strings.Builder{})
+			}
+			current().WriteString(rules.startElements[name])
+
+		case xml.EndElement:
+			name := element.Name.Local
+			switch {
+			case rules.textElements[name] && inText > 0:
+				inText--
+			case name == rules.cellElement && len(cells) > 0:
+				cell := cells[len(cells)-1]
+				cells = cells[:len(cells)-1]
+				if len(rowCells) > 0 {
+					if rowCells[len(rowCells)-1] > 0 {
+						current().WriteString("\t")
+					}
+					rowCells[len(rowCells)-1]++
+				}
+				current().WriteString(strings.Join(strings.Fields(cell.String()), " "))
+			case name == rules.rowElement && len(rowCells) > 0:
+				rowCells = rowCells[:len(rowCells)-1]
+				current().WriteString("\n")
+			default:
+				current().WriteString(rules.endElements[name])
+			}
+
+		case xml.CharData:
+			if inText > 0 {
+				current().Write(element)
+			}
+		}
+	}
+}
@@ -0,0 +1,29 @@
+package piiscan
+
+const LuhnChecksum = "luhn"
+
+// isLuhnValid validates digits of value by Luhn algorithm,
+// non digit characters like spaces and dashes are ignored.
+func isLuhnValid(value string) bool {
+	sum := 0
+	digits := 0
+	for index := len(value) - 1; index >= 0; index-- {
+		char := value[index]
+		if char < '0' || char > '9' {
+			continue
+		}
+
+		digit := int(char - '0')
+		if digits%2 == 1 {
+			digit *= 2
+			if digit > 9 {
+				digit -= 9
+			}
+		}
+
+		sum += digit
+		digits++
+	}
+
+	return digits > 0 && sum%10 == 0
+}
@@ -0,0 +1,16 @@
+package piiscan
+
+// Config of personal data patterns. Patterns are applied in order and
+// matches overlapping with previous ones are skipped. Empty list means
+// DefaultPatterns.
+type Config struct {
+	Patterns []Pattern `mapstructure:"patterns"`
+}
+
+// Pattern of personal data type. Checksum is optional validation of
+// matched digits, only "luhn" is supported.
+type Pattern struct {
+	Type     string `mapstructure:"type"`
+	Regex    string `mapstructure:"regex"`
+	Checksum string `mapstructure:"checksum"`
+}
@@ -0,0 +1,83 @@
+package piiscan
+
+import (
+	"fmt"
+	"regexp"
+
+	"watchtower/internal/shared/kernel"
+	"watchtower/internal/support/task/application/service/pii"
+)
+
+var DefaultPatterns = []Pattern{
+	{Type: pii.CardType, Regex: `\b(?:\d[ -]?){12,18}\d\b`, Checksum: LuhnChecksum},
+	{Type: pii.EmailType, Regex: `[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}`},
+	{Type: pii.PassportType, Regex: `\b\d{2} ?\d{2} (?:№ ?)?\d{6}\b`},
+	{Type: pii.PhoneType, Regex: `(?:\+\d{1,3}[ -]?|\b8[ -]?)?\(?\b\d{3}\)?[ -]\d{3}[ -]?\d{2}[ -]?\d{2}\b`},
+}
+
+type compiledPattern struct {
+	Type     string
+	Regex    *regexp.Regexp
+	Checksum string
+}
+
+// PiiScanner detects personal data in text by regular expressions
+// validated by checksum where it is possible.
+type PiiScanner struct {
+	patterns []compiledPattern
+}
+
+func New(config Config) (pii.IDetector, error) {
+	patterns := config.Patterns
+	if len(patterns) == 0 {
+		patterns = DefaultPatterns
+	}
+
+	compiled := make([]compiledPattern, 0, len(patterns))
+	for _, pattern := range patterns {
+		if pattern.Checksum != "" && pattern.Checksum != LuhnChecksum {
+			return nil, fmt.Errorf("piiscan: unknown checksum %s of %s", pattern.Checksum, pattern.Type)
+		}
+
+		regex, err := regexp.Compile(pattern.Regex)
+		if err != nil {
+			return nil, fmt.Errorf("piiscan: invalid regex of %s: %w", pattern.Type, err)
+		}
+
+		compiled = append(compiled, compiledPattern{
+			Type:     pattern.Type,
+			Regex:    regex,
+			Checksum: pattern.Checksum,
+		})
+	}
+
+	return &PiiScanner{patterns: compiled}, nil
+}
+
+func (ps *PiiScanner) Detect(_ kernel.Ctx, text string) ([]pii.Match, error) {
+	var matches []pii.Match
+	for _, pattern := range ps.patterns {
+		for _, loc := range pattern.Regex.FindAllStringIndex(text, -1) {
+			if pattern.Checksum == LuhnChecksum && !isLuhnValid(text[loc[0]:loc[1]]) {
+				continue
+			}
+
+			match := pii.Match{Type: pattern.Type, Start: loc[0], End: loc[1]}
+			if !isOverlapped(matches, match) {
+				matches = append(matches, match)
+			}
+		}
+	}
+
+	return matches, nil
+}
+
+func isOverlapped(matches []pii.Match, match pii.Match) bool {
+	for _, value := range matches {
+		if match.Start < value.End && value.Start < match.End {
+			return true
+		}
+	}
+
+	return false
+}
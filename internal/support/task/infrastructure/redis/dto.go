@@ -9,15 +9,27 @@ import (
 )
 
 type RedisValue struct {
-	ID         string `json:"id"`
-	Bucket     string `json:"bucket"`
-	FilePath   string `json:"file_path"`
-	FileSize   int64  `json:"file_size"`
-	CreatedAt  int64  `json:"created_at"`
-	ModifiedAt int64  `json:"modified_at"`
-	Status     int    `json:"status"`
-	StatusText string `json:"status_text"`
-	EventType  int    `json:"event_type"`
+	ID                  string         `json:"id"`
+	Bucket              string         `json:"bucket"`
+	FilePath            string         `json:"file_path"`
+	FileSize            int64          `json:"file_size"`
+	CreatedAt           int64          `json:"created_at"`
+	ModifiedAt          int64          `json:"modified_at"`
+	Status              int            `json:"status"`
+	StatusText          string         `json:"status_text"`
+	EventType           int            `json:"event_type"`
+	ParentID            string         `json:"parent_id,omitempty"`
+	Children            []string       `json:"children,omitempty"`
+	Summary             string         `json:"summary,omitempty"`
+	Labels              []string       `json:"labels,omitempty"`
+	PiiCounts           map[string]int `json:"pii_counts,omitempty"`
+	Fingerprint         string         `json:"fingerprint,omitempty"`
+	DuplicateOf         string         `json:"duplicate_of,omitempty"`
+	DuplicateDocumentID string         `json:"duplicate_document_id,omitempty"`
+	DuplicateDistance   int            `json:"duplicate_distance,omitempty"`
+	DuplicateLinked     bool           `json:"duplicate_linked,omitempty"`
+	Location            string         `json:"location,omitempty"`
+	VersionID           string         `json:"version_id,omitempty"`
 }
 
 func (rv *RedisValue) ConvertToTask() (*domain.Task, error) {
@@ -26,30 +38,128 @@ func (rv *RedisValue) ConvertToTask() (*domain.Task, error) {
 		return nil, fmt.Errorf("invalid task id: %w", err)
 	}
 
+	var parentID uuid.UUID
+	if rv.ParentID != "" {
+		parentID, err = uuid.Parse(rv.ParentID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid parent task id: %w", err)
+		}
+	}
+
+	children := make([]uuid.UUID, 0, len(rv.Children))
+	for _, childID := range rv.Children {
+		child, err := uuid.Parse(childID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid child task id: %w", err)
+		}
+		children = append(children, child)
+	}
+
+	var duplicate *domain.Duplicate
+	if rv.DuplicateOf != "" {
+		duplicate = &domain.Duplicate{
+			ObjectID:   rv.DuplicateOf,
+			DocumentID: rv.DuplicateDocumentID,
+			Distance:   rv.DuplicateDistance,
+			Linked:     rv.DuplicateLinked,
+		}
+	}
+
 	modifiedAt := time.Unix(rv.ModifiedAt, 0)
 	createdAt := time.Unix(rv.CreatedAt, 0)
 
 	event := &domain.Task{
-		ID:         taskID,
-		CreatedAt:  createdAt,
-		ModifiedAt: modifiedAt,
-		BucketID:   rv.Bucket,
-		ObjectID:   rv.FilePath,
-		StatusText: rv.StatusText,
-		Status:     domain.TaskStatus(rv.Status),
+		ID:          taskID,
+		CreatedAt:   createdAt,
+		ModifiedAt:  modifiedAt,
+		BucketID:    rv.Bucket,
+		ObjectID:    rv.FilePath,
+		StatusText:  rv.StatusText,
+		Status:      domain.TaskStatus(rv.Status),
+		ParentID:    parentID,
+		Children:    children,
+		Summary:     rv.Summary,
+		Labels:      rv.Labels,
+		PiiCounts:   rv.PiiCounts,
+		Fingerprint: rv.Fingerprint,
+		Duplicate:   duplicate,
+		Location:    rv.Location,
+		VersionID:   rv.VersionID,
 	}
 
 	return event, nil
 }
 
 func ConvertFromTaskEvent(task *domain.Task) *RedisValue {
-	return &RedisValue{
-		ID:         task.ID.String(),
-		Bucket:     task.BucketID,
-		FilePath:   task.ObjectID,
-		CreatedAt:  task.CreatedAt.Unix(),
-		ModifiedAt: task.ModifiedAt.Unix(),
-		StatusText: task.StatusText,
-		Status:     int(task.Status),
+	var parentID string
+	if task.ParentID != uuid.Nil {
+		parentID = task.ParentID.String()
+	}
+
+	children := make([]string, 0, len(task.Children))
+	for _, childID := range task.Children {
+		children = append(children, childID.String())
+	}
+
+	value := &RedisValue{
+		ID:          task.ID.String(),
+		Bucket:      task.BucketID,
+		FilePath:    task.ObjectID,
+		CreatedAt:   task.CreatedAt.Unix(),
+		ModifiedAt:  task.ModifiedAt.Unix(),
+		StatusText:  task.StatusText,
+		Status:      int(task.Status),
+		ParentID:    parentID,
+		Children:    children,
+		Summary:     task.Summary,
+		Labels:      task.Labels,
+		PiiCounts:   task.PiiCounts,
+		Fingerprint: task.Fingerprint,
+		Location:    task.Location,
+		VersionID:   task.VersionID,
+	}
+
+	if task.Duplicate != nil {
+		value.DuplicateOf = task.Duplicate.ObjectID
+		value.DuplicateDocumentID = task.Duplicate.DocumentID
+		value.DuplicateDistance = task.Duplicate.Distance
+		value.DuplicateLinked = task.Duplicate.Linked
+	}
+
+	return value
+}
+
+type WatcherValue struct {
+	ID         string `json:"id"`
+	Bucket     string `json:"bucket"`
+	Prefix     string `json:"prefix"`
+	CreatedAt  int64  `json:"created_at"`
+	LastScanAt int64  `json:"last_scan_at"`
+}
+
+func (wv *WatcherValue) ConvertToWatcher() (*domain.Watcher, error) {
+	watcherID, err := uuid.Parse(wv.ID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid watcher id: %w", err)
+	}
+
+	watcher := &domain.Watcher{
+		ID:         watcherID,
+		BucketID:   wv.Bucket,
+		Prefix:     wv.Prefix,
+		CreatedAt:  time.Unix(wv.CreatedAt, 0),
+		LastScanAt: time.Unix(wv.LastScanAt, 0),
+	}
+
+	return watcher, nil
+}
+
+func ConvertFromWatcher(watcher *domain.Watcher) *WatcherValue {
+	return &WatcherValue{
+		ID:         watcher.ID.String(),
+		Bucket:     watcher.BucketID,
+		Prefix:     watcher.Prefix,
+		CreatedAt:  watcher.CreatedAt.Unix(),
+		LastScanAt: watcher.LastScanAt.Unix(),
 	}
 }
@@ -0,0 +1,261 @@
+package redis
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/redis/go-redis/v9"
+
+	"watchtower/internal/shared/kernel"
+	"watchtower/internal/support/task/application/service/fingerprint"
+)
+
+const (
+	fingerprintKeyPrefix = "simhash"
+	fingerprintBits      = 64
+)
+
+type FingerprintValue struct {
+	Fingerprint string `json:"fingerprint"`
+	ObjectID    string `json:"object_id"`
+	DocumentID  string `json:"document_id"`
+}
+
+// FingerprintStorage stores SimHash fingerprints of documents per
+// bucket. Fingerprint is split into bands, each band value indexes
+// records by redis set. Records within hamming distance less than
+// bands count share at least one band, so they are found by union
+// of band sets. Current record of each object is kept by hash, so
+// it is replaced or removed from band sets.
+type FingerprintStorage struct {
+	rsConn   *redis.Client
+	bands    int
+	bandBits int
+}
+
+// NewFingerprintStorage splits fingerprint into maxDistance+1 bands, so
+// near-duplicates within maxDistance are never missed. Larger distance
+// makes narrower bands, which sets keep more records to compare.
+func NewFingerprintStorage(config Config, maxDistance int) (fingerprint.IFingerprintStorage, error) {
+	if maxDistance < 0 || maxDistance >= fingerprintBits {
+		return nil, fmt.Errorf("max distance must be in range [0, %d], got %d", fingerprintBits-1, maxDistance)
+	}
+
+	redisOpts := &redis.Options{Addr: config.Address}
+	conn := redis.NewClient(redisOpts)
+
+	bands := maxDistance + 1
+	storage := &FingerprintStorage{
+		rsConn:   conn,
+		bands:    bands,
+		bandBits: fingerprintBits / bands,
+	}
+
+	return storage, nil
+}
+
+func (fs *FingerprintStorage) FindNearest(
+	ctx kernel.Ctx,
+	bucketID kernel.BucketID,
+	objID kernel.ObjectID,
+	value fingerprint.Fingerprint,
+	maxDistance int,
+) (*fingerprint.Record, error) {
+	members, err := fs.rsConn.SUnion(ctx, fs.bandKeys(bucketID, value)...).Result()
+	if err != nil {
+		return nil, fmt.Errorf("redis error: %w", err)
+	}
+
+	var nearest *fingerprint.Record
+	nearestDistance := maxDistance + 1
+	for _, member := range members {
+		record, err := decodeFingerprintValue(member)
+		if err != nil {
+			slog.Warn("failed to unmarshal fingerprint", slog.String("err", err.Error()))
+			continue
+		}
+
+		if record.ObjectID == objID {
+			continue
+		}
+
+		if distance := value.Distance(record.Fingerprint); distance < nearestDistance {
+			nearest = record
+			nearestDistance = distance
+		}
+	}
+
+	return nearest, nil
+}
+
+func (fs *FingerprintStorage) StoreRecord(ctx kernel.Ctx, bucketID kernel.BucketID, record *fingerprint.Record) error {
+	value := FingerprintValue{
+		Fingerprint: record.Fingerprint.String(),
+		ObjectID:    record.ObjectID,
+		DocumentID:  record.DocumentID,
+	}
+
+	jsonData, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("serialize error: %w", err)
+	}
+
+	prevMember, err := fs.loadMember(ctx, bucketID, record.ObjectID)
+	if err != nil {
+		return err
+	}
+
+	pipe := fs.rsConn.TxPipeline()
+	fs.removeMember(ctx, pipe, bucketID, prevMember)
+	for _, key := range fs.bandKeys(bucketID, record.Fingerprint) {
+		pipe.SAdd(ctx, key, jsonData)
+	}
+	pipe.HSet(ctx, fs.objectsKey(bucketID), record.ObjectID, jsonData)
+
+	if _, err = pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("redis error: %w", err)
+	}
+
+	return nil
+}
+
+func (fs *FingerprintStorage) DeleteRecord(ctx kernel.Ctx, bucketID kernel.BucketID, objID kernel.ObjectID) error {
+	member, err := fs.loadMember(ctx, bucketID, objID)
+	if err != nil || member == "" {
+		return err
+	}
+
+	pipe := fs.rsConn.TxPipeline()
+	fs.removeMember(ctx, pipe, bucketID, member)
+	pipe.HDel(ctx, fs.objectsKey(bucketID), objID)
+
+	if _, err = pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("redis error: %w", err)
+	}
+
+	return nil
+}
+
+func (fs *FingerprintStorage) MoveRecord(
+	ctx kernel.Ctx,
+	bucketID kernel.BucketID,
+	srcObjID kernel.ObjectID,
+	dstObjID kernel.ObjectID,
+) error {
+	member, err := fs.loadMember(ctx, bucketID, srcObjID)
+	if err != nil || member == "" {
+		return err
+	}
+
+	record, err := decodeFingerprintValue(member)
+	if err != nil {
+		return fmt.Errorf("deserialize error: %w", err)
+	}
+
+	prevMember, err := fs.loadMember(ctx, bucketID, dstObjID)
+	if err != nil {
+		return err
+	}
+
+	value := FingerprintValue{
+		Fingerprint: record.Fingerprint.String(),
+		ObjectID:    dstObjID,
+		DocumentID:  record.DocumentID,
+	}
+
+	jsonData, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("serialize error: %w", err)
+	}
+
+	pipe := fs.rsConn.TxPipeline()
+	fs.removeMember(ctx, pipe, bucketID, member)
+	fs.removeMember(ctx, pipe, bucketID, prevMember)
+	for _, key := range fs.bandKeys(bucketID, record.Fingerprint) {
+		pipe.SAdd(ctx, key, jsonData)
+	}
+	pipe.HDel(ctx, fs.objectsKey(bucketID), srcObjID)
+	pipe.HSet(ctx, fs.objectsKey(bucketID), dstObjID, jsonData)
+
+	if _, err = pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("redis error: %w", err)
+	}
+
+	return nil
+}
+
+// loadMember returns current band sets member of object, empty
+// if object has no record.
+func (fs *FingerprintStorage) loadMember(ctx kernel.Ctx, bucketID kernel.BucketID, objID kernel.ObjectID) (string, error) {
+	member, err := fs.rsConn.HGet(ctx, fs.objectsKey(bucketID), objID).Result()
+	if errors.Is(err, redis.Nil) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("redis error: %w", err)
+	}
+
+	return member, nil
+}
+
+func (fs *FingerprintStorage) removeMember(ctx kernel.Ctx, pipe redis.Pipeliner, bucketID kernel.BucketID, member string) {
+	if member == "" {
+		return
+	}
+
+	record, err := decodeFingerprintValue(member)
+	if err != nil {
+		slog.Warn("failed to unmarshal fingerprint", slog.String("err", err.Error()))
+		return
+	}
+
+	for _, key := range fs.bandKeys(bucketID, record.Fingerprint) {
+		pipe.SRem(ctx, key, member)
+	}
+}
+
+// bandKeys uses own key prefix, so fingerprints are not listed
+// as tasks of bucket. The last band takes remaining bits, keys
+// contain bands count, so records stored with another max distance
+// are never mixed up with current band sets.
+func (fs *FingerprintStorage) bandKeys(bucketID kernel.BucketID, value fingerprint.Fingerprint) []string {
+	keys := make([]string, fs.bands)
+	for band := range fs.bands {
+		bandBits := fs.bandBits
+		if band == fs.bands-1 {
+			bandBits = fingerprintBits - band*fs.bandBits
+		}
+
+		bandMask := uint64(1)<<bandBits - 1
+		bandValue := (uint64(value) >> (band * fs.bandBits)) & bandMask
+		keys[band] = fmt.Sprintf("%s-%s:%s:%d-%d:%x",
+			kernel.AppName, fingerprintKeyPrefix, bucketID, fs.bands, band, bandValue)
+	}
+
+	return keys
+}
+
+// objectsKey is a hash of current band sets member of each object of bucket.
+func (fs *FingerprintStorage) objectsKey(bucketID kernel.BucketID) string {
+	return fmt.Sprintf("%s-%s:%s:objects", kernel.AppName, fingerprintKeyPrefix, bucketID)
+}
+
+func decodeFingerprintValue(member string) (*fingerprint.Record, error) {
+	var value FingerprintValue
+	if err := json.Unmarshal([]byte(member), &value); err != nil {
+		return nil, err
+	}
+
+	parsed, err := fingerprint.ParseFingerprint(value.Fingerprint)
+	if err != nil {
+		return nil, err
+	}
+
+	return &fingerprint.Record{
+		Fingerprint: parsed,
+		ObjectID:    value.ObjectID,
+		DocumentID:  value.DocumentID,
+	}, nil
+}
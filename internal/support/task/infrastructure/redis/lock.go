@@ -0,0 +1,71 @@
+package redis
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+
+	"watchtower/internal/shared/kernel"
+	"watchtower/internal/support/task/application/service/snapshot"
+)
+
+// pollerLockKey is a key of lock held by replica which polls buckets.
+const pollerLockKey = kernel.AppName + "-poller-lock"
+
+var (
+	// acquireScript sets the lock if it is free or prolongs it if it is
+	// held by the same replica.
+	acquireScript = redis.NewScript(`
+if redis.call("SET", KEYS[1], ARGV[1], "NX", "PX", ARGV[2]) then
+	return 1
+end
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	redis.call("PEXPIRE", KEYS[1], ARGV[2])
+	return 1
+end
+return 0
+`)
+
+	// releaseScript deletes the lock only if it is held by the same replica.
+	releaseScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0
+`)
+)
+
+// LeaderLock is a redis lock identified by random value of replica,
+// so the lock expired during long poll is never released by mistake.
+type LeaderLock struct {
+	rsConn  *redis.Client
+	ownerID string
+}
+
+func NewLeaderLock(config Config) snapshot.ILeaderLock {
+	redisOpts := &redis.Options{Addr: config.Address}
+	conn := redis.NewClient(redisOpts)
+
+	return &LeaderLock{rsConn: conn, ownerID: uuid.NewString()}
+}
+
+func (ll *LeaderLock) Acquire(ctx kernel.Ctx, ttl time.Duration) (bool, error) {
+	keys := []string{pollerLockKey}
+	acquired, err := acquireScript.Run(ctx, ll.rsConn, keys, ll.ownerID, ttl.Milliseconds()).Int()
+	if err != nil {
+		return false, fmt.Errorf("redis error: %w", err)
+	}
+
+	return acquired == 1, nil
+}
+
+func (ll *LeaderLock) Release(ctx kernel.Ctx) error {
+	keys := []string{pollerLockKey}
+	if err := releaseScript.Run(ctx, ll.rsConn, keys, ll.ownerID).Err(); err != nil {
+		return fmt.Errorf("redis error: %w", err)
+	}
+
+	return nil
+}
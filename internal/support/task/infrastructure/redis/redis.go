@@ -12,6 +12,9 @@ import (
 	"watchtower/internal/support/task/domain"
 )
 
+// scanBatchSize is the count of keys hinted to each SCAN call.
+const scanBatchSize = 1000
+
 type RedisClient struct {
 	config Config
 	rsConn *redis.Client
@@ -29,16 +32,27 @@ func New(config Config) domain.ITaskStorage {
 	}
 }
 
+// GetAllBucketTasks iterates SCAN cursor until all keys of bucket tasks
+// are loaded, single SCAN call returns one batch of keys only.
 func (rs *RedisClient) GetAllBucketTasks(ctx kernel.Ctx, bucketID kernel.BucketID) ([]*domain.Task, error) {
 	key := rs.generateUniqID(bucketID, "*")
-	status := rs.rsConn.Scan(ctx, 0, key, -1)
-	if status.Err() != nil {
-		return nil, fmt.Errorf("redis error: %w", status.Err())
+
+	var rKeys []string
+	seenKeys := make(map[string]struct{})
+	iter := rs.rsConn.Scan(ctx, 0, key, scanBatchSize).Iterator()
+	for iter.Next(ctx) {
+		// SCAN may return the same key more than once.
+		if _, ok := seenKeys[iter.Val()]; !ok {
+			seenKeys[iter.Val()] = struct{}{}
+			rKeys = append(rKeys, iter.Val())
+		}
+	}
+	if err := iter.Err(); err != nil {
+		return nil, fmt.Errorf("redis error: %w", err)
 	}
 
-	rKeys, _ := status.Val()
-	tasks := make([]*domain.Task, len(rKeys))
-	for index, rKey := range rKeys {
+	tasks := make([]*domain.Task, 0, len(rKeys))
+	for _, rKey := range rKeys {
 		cmd := rs.rsConn.Get(ctx, rKey)
 
 		data, err := cmd.Bytes()
@@ -59,7 +73,7 @@ func (rs *RedisClient) GetAllBucketTasks(ctx kernel.Ctx, bucketID kernel.BucketI
 			continue
 		}
 
-		tasks[index] = task
+		tasks = append(tasks, task)
 	}
 
 	return tasks, nil
@@ -0,0 +1,111 @@
+package redis
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"watchtower/internal/shared/kernel"
+	"watchtower/internal/support/task/application/service/snapshot"
+)
+
+const snapshotKeyPrefix = "snapshot"
+
+type SnapshotValue struct {
+	ETag         string `json:"etag"`
+	LastModified int64  `json:"last_modified"`
+}
+
+// SnapshotStorage stores snapshot of polled bucket as redis hash
+// of object states by their paths. Empty hash is never stored by
+// redis, so polled bucket is marked by own key.
+type SnapshotStorage struct {
+	rsConn *redis.Client
+}
+
+func NewSnapshotStorage(config Config) snapshot.ISnapshotStorage {
+	redisOpts := &redis.Options{Addr: config.Address}
+	conn := redis.NewClient(redisOpts)
+
+	return &SnapshotStorage{rsConn: conn}
+}
+
+func (ss *SnapshotStorage) LoadSnapshot(ctx kernel.Ctx, bucketID kernel.BucketID) (snapshot.Snapshot, error) {
+	pipe := ss.rsConn.TxPipeline()
+	polledCmd := pipe.Exists(ctx, ss.polledKey(bucketID))
+	valuesCmd := pipe.HGetAll(ctx, ss.snapshotKey(bucketID))
+	if _, err := pipe.Exec(ctx); err != nil {
+		return nil, fmt.Errorf("redis error: %w", err)
+	}
+
+	if polledCmd.Val() == 0 {
+		return nil, nil
+	}
+
+	values := valuesCmd.Val()
+
+	result := make(snapshot.Snapshot, len(values))
+	for objID, data := range values {
+		var value SnapshotValue
+		if err := json.Unmarshal([]byte(data), &value); err != nil {
+			slog.Warn("failed to unmarshal snapshot entry", slog.String("err", err.Error()))
+			continue
+		}
+
+		result[objID] = snapshot.Entry{
+			ETag:         value.ETag,
+			LastModified: time.Unix(0, value.LastModified),
+		}
+	}
+
+	return result, nil
+}
+
+func (ss *SnapshotStorage) StoreSnapshot(
+	ctx kernel.Ctx,
+	bucketID kernel.BucketID,
+	snap snapshot.Snapshot,
+) error {
+	values := make(map[string]any, len(snap))
+	for objID, entry := range snap {
+		value := SnapshotValue{
+			ETag:         entry.ETag,
+			LastModified: entry.LastModified.UnixNano(),
+		}
+
+		jsonData, err := json.Marshal(value)
+		if err != nil {
+			return fmt.Errorf("serialize error: %w", err)
+		}
+
+		values[objID] = jsonData
+	}
+
+	key := ss.snapshotKey(bucketID)
+	pipe := ss.rsConn.TxPipeline()
+	pipe.Del(ctx, key)
+	if len(values) > 0 {
+		pipe.HSet(ctx, key, values)
+	}
+	pipe.Set(ctx, ss.polledKey(bucketID), time.Now().Unix(), 0)
+
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("redis error: %w", err)
+	}
+
+	return nil
+}
+
+// snapshotKey uses own key prefix, so snapshots are not listed
+// as tasks of bucket.
+func (ss *SnapshotStorage) snapshotKey(bucketID kernel.BucketID) string {
+	return fmt.Sprintf("%s-%s:%s", kernel.AppName, snapshotKeyPrefix, bucketID)
+}
+
+// polledKey keeps time of the last stored snapshot of bucket.
+func (ss *SnapshotStorage) polledKey(bucketID kernel.BucketID) string {
+	return fmt.Sprintf("%s-%s:%s:polled", kernel.AppName, snapshotKeyPrefix, bucketID)
+}
@@ -0,0 +1,55 @@
+package redis
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"watchtower/internal/shared/kernel"
+	"watchtower/internal/support/task/application/service/upload"
+)
+
+const (
+	storedObjectKeyPrefix = "stored-object"
+
+	// storedObjectTTL bounds lifetime of marks which events have been
+	// lost for, events of stored objects are delivered much earlier.
+	storedObjectTTL = 24 * time.Hour
+)
+
+// StoredObjectStorage keeps mark of each stored object in key expiring
+// after storedObjectTTL.
+type StoredObjectStorage struct {
+	rsConn *redis.Client
+}
+
+func NewStoredObjectStorage(config Config) upload.IStoredObjectStorage {
+	redisOpts := &redis.Options{Addr: config.Address}
+	conn := redis.NewClient(redisOpts)
+
+	return &StoredObjectStorage{rsConn: conn}
+}
+
+func (ss *StoredObjectStorage) MarkStored(ctx kernel.Ctx, bucketID kernel.BucketID, objID kernel.ObjectID) error {
+	key := ss.storedKey(bucketID, objID)
+	if err := ss.rsConn.Set(ctx, key, time.Now().Unix(), storedObjectTTL).Err(); err != nil {
+		return fmt.Errorf("redis error: %w", err)
+	}
+
+	return nil
+}
+
+func (ss *StoredObjectStorage) TakeStored(ctx kernel.Ctx, bucketID kernel.BucketID, objID kernel.ObjectID) (bool, error) {
+	deleted, err := ss.rsConn.Del(ctx, ss.storedKey(bucketID, objID)).Result()
+	if err != nil {
+		return false, fmt.Errorf("redis error: %w", err)
+	}
+
+	return deleted > 0, nil
+}
+
+// storedKey uses own key prefix, so marks are not listed as tasks of bucket.
+func (ss *StoredObjectStorage) storedKey(bucketID kernel.BucketID, objID kernel.ObjectID) string {
+	return fmt.Sprintf("%s-%s:%s/%s", kernel.AppName, storedObjectKeyPrefix, bucketID, objID)
+}
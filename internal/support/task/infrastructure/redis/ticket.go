@@ -0,0 +1,103 @@
+package redis
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"watchtower/internal/shared/kernel"
+	"watchtower/internal/support/task/application/service/upload"
+)
+
+const uploadTicketKeyPrefix = "upload-ticket"
+
+type UploadTicketValue struct {
+	Bucket    string `json:"bucket"`
+	FilePath  string `json:"file_path"`
+	Size      int64  `json:"size"`
+	Checksum  string `json:"checksum"`
+	CreatedAt int64  `json:"created_at"`
+	ExpiresAt int64  `json:"expires_at"`
+}
+
+func (tv *UploadTicketValue) ConvertToTicket() *upload.Ticket {
+	return &upload.Ticket{
+		BucketID:  tv.Bucket,
+		ObjectID:  tv.FilePath,
+		Size:      tv.Size,
+		Checksum:  tv.Checksum,
+		CreatedAt: time.Unix(tv.CreatedAt, 0),
+		ExpiresAt: time.Unix(tv.ExpiresAt, 0),
+	}
+}
+
+func ConvertFromTicket(ticket *upload.Ticket) *UploadTicketValue {
+	return &UploadTicketValue{
+		Bucket:    ticket.BucketID,
+		FilePath:  ticket.ObjectID,
+		Size:      ticket.Size,
+		Checksum:  ticket.Checksum,
+		CreatedAt: ticket.CreatedAt.Unix(),
+		ExpiresAt: ticket.ExpiresAt.Unix(),
+	}
+}
+
+// TicketStorage stores each upload ticket into key expiring with ticket.
+type TicketStorage struct {
+	rsConn *redis.Client
+}
+
+func NewTicketStorage(config Config) upload.ITicketStorage {
+	redisOpts := &redis.Options{Addr: config.Address}
+	conn := redis.NewClient(redisOpts)
+
+	return &TicketStorage{rsConn: conn}
+}
+
+func (ts *TicketStorage) StoreTicket(ctx kernel.Ctx, ticket *upload.Ticket) error {
+	ttl := time.Until(ticket.ExpiresAt)
+	if ttl <= 0 {
+		return nil
+	}
+
+	jsonData, err := json.Marshal(ConvertFromTicket(ticket))
+	if err != nil {
+		return fmt.Errorf("serialize error: %w", err)
+	}
+
+	key := ts.ticketKey(ticket.BucketID, ticket.ObjectID)
+	if err = ts.rsConn.Set(ctx, key, jsonData, ttl).Err(); err != nil {
+		return fmt.Errorf("redis error: %w", err)
+	}
+
+	return nil
+}
+
+func (ts *TicketStorage) TakeTicket(
+	ctx kernel.Ctx,
+	bucketID kernel.BucketID,
+	objID kernel.ObjectID,
+) (*upload.Ticket, error) {
+	data, err := ts.rsConn.GetDel(ctx, ts.ticketKey(bucketID, objID)).Bytes()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, fmt.Errorf("redis error: %w", upload.ErrTicketNotFound)
+		}
+		return nil, fmt.Errorf("redis error: %w", err)
+	}
+
+	var value UploadTicketValue
+	if err = json.Unmarshal(data, &value); err != nil {
+		return nil, fmt.Errorf("deserialize error: %w", err)
+	}
+
+	return value.ConvertToTicket(), nil
+}
+
+// ticketKey uses own key prefix, so tickets are not listed as tasks of bucket.
+func (ts *TicketStorage) ticketKey(bucketID kernel.BucketID, objID kernel.ObjectID) string {
+	return fmt.Sprintf("%s-%s:%s/%s", kernel.AppName, uploadTicketKeyPrefix, bucketID, objID)
+}
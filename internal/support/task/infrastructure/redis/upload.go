@@ -0,0 +1,237 @@
+package redis
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+
+	"watchtower/internal/shared/kernel"
+	"watchtower/internal/support/task/application/service/upload"
+)
+
+const (
+	// uploadsKey is a hash of all resumable upload sessions by their ids.
+	uploadsKey = kernel.AppName + "-uploads"
+
+	uploadPendingKeyPrefix = "upload-pending"
+	uploadLockKeyPrefix    = "upload-lock"
+)
+
+type UploadPartValue struct {
+	Number int    `json:"number"`
+	ETag   string `json:"etag"`
+}
+
+type UploadValue struct {
+	ID          string            `json:"id"`
+	Bucket      string            `json:"bucket"`
+	FilePath    string            `json:"file_path"`
+	MultipartID string            `json:"multipart_id"`
+	Length      int64             `json:"length"`
+	Offset      int64             `json:"offset"`
+	Parts       []UploadPartValue `json:"parts"`
+	Metadata    map[string]string `json:"metadata"`
+	CreatedAt   int64             `json:"created_at"`
+	ExpiresAt   int64             `json:"expires_at"`
+}
+
+func (uv *UploadValue) ConvertToSession() (*upload.Session, error) {
+	uploadID, err := uuid.Parse(uv.ID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid upload id: %w", err)
+	}
+
+	parts := make([]upload.Part, len(uv.Parts))
+	for index, part := range uv.Parts {
+		parts[index] = upload.Part{Number: part.Number, ETag: part.ETag}
+	}
+
+	return &upload.Session{
+		ID:          uploadID,
+		BucketID:    uv.Bucket,
+		ObjectID:    uv.FilePath,
+		MultipartID: uv.MultipartID,
+		Length:      uv.Length,
+		Offset:      uv.Offset,
+		Parts:       parts,
+		Metadata:    uv.Metadata,
+		CreatedAt:   time.Unix(uv.CreatedAt, 0),
+		ExpiresAt:   time.Unix(uv.ExpiresAt, 0),
+	}, nil
+}
+
+func ConvertFromSession(session *upload.Session) *UploadValue {
+	parts := make([]UploadPartValue, len(session.Parts))
+	for index, part := range session.Parts {
+		parts[index] = UploadPartValue{Number: part.Number, ETag: part.ETag}
+	}
+
+	return &UploadValue{
+		ID:          session.ID.String(),
+		Bucket:      session.BucketID,
+		FilePath:    session.ObjectID,
+		MultipartID: session.MultipartID,
+		Length:      session.Length,
+		Offset:      session.Offset,
+		Parts:       parts,
+		Metadata:    session.Metadata,
+		CreatedAt:   session.CreatedAt.Unix(),
+		ExpiresAt:   session.ExpiresAt.Unix(),
+	}
+}
+
+// UploadStorage stores resumable upload sessions into redis hash and
+// pending bytes of each session into separate key.
+type UploadStorage struct {
+	rsConn *redis.Client
+}
+
+func NewUploadStorage(config Config) upload.ISessionStorage {
+	redisOpts := &redis.Options{Addr: config.Address}
+	conn := redis.NewClient(redisOpts)
+
+	return &UploadStorage{rsConn: conn}
+}
+
+func (us *UploadStorage) GetSession(ctx kernel.Ctx, uploadID kernel.UploadID) (*upload.Session, error) {
+	data, err := us.rsConn.HGet(ctx, uploadsKey, uploadID.String()).Bytes()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, fmt.Errorf("redis error: %w", upload.ErrSessionNotFound)
+		}
+		return nil, fmt.Errorf("redis error: %w", err)
+	}
+
+	var value UploadValue
+	if err = json.Unmarshal(data, &value); err != nil {
+		return nil, fmt.Errorf("deserialize error: %w", err)
+	}
+
+	return value.ConvertToSession()
+}
+
+func (us *UploadStorage) GetAllSessions(ctx kernel.Ctx) ([]*upload.Session, error) {
+	values, err := us.rsConn.HVals(ctx, uploadsKey).Result()
+	if err != nil {
+		return nil, fmt.Errorf("redis error: %w", err)
+	}
+
+	sessions := make([]*upload.Session, 0, len(values))
+	for _, data := range values {
+		var value UploadValue
+		if err = json.Unmarshal([]byte(data), &value); err != nil {
+			slog.Warn("failed to unmarshal upload", slog.String("err", err.Error()))
+			continue
+		}
+
+		session, err := value.ConvertToSession()
+		if err != nil {
+			slog.Warn("failed to unmarshal upload", slog.String("err", err.Error()))
+			continue
+		}
+
+		sessions = append(sessions, session)
+	}
+
+	return sessions, nil
+}
+
+func (us *UploadStorage) StoreSession(ctx kernel.Ctx, session *upload.Session) error {
+	jsonData, err := json.Marshal(ConvertFromSession(session))
+	if err != nil {
+		return fmt.Errorf("serialize error: %w", err)
+	}
+
+	if err = us.rsConn.HSet(ctx, uploadsKey, session.ID.String(), jsonData).Err(); err != nil {
+		return fmt.Errorf("redis error: %w", err)
+	}
+
+	return nil
+}
+
+func (us *UploadStorage) DeleteSession(ctx kernel.Ctx, uploadID kernel.UploadID) error {
+	pipe := us.rsConn.TxPipeline()
+	deleted := pipe.HDel(ctx, uploadsKey, uploadID.String())
+	pipe.Del(ctx, us.pendingKey(uploadID))
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("redis error: %w", err)
+	}
+
+	if deleted.Val() == 0 {
+		return fmt.Errorf("redis error: %w", upload.ErrSessionNotFound)
+	}
+
+	return nil
+}
+
+func (us *UploadStorage) LoadPending(ctx kernel.Ctx, uploadID kernel.UploadID) ([]byte, error) {
+	data, err := us.rsConn.Get(ctx, us.pendingKey(uploadID)).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("redis error: %w", err)
+	}
+
+	return data, nil
+}
+
+func (us *UploadStorage) StoreSessionPending(ctx kernel.Ctx, session *upload.Session, pending []byte) error {
+	jsonData, err := json.Marshal(ConvertFromSession(session))
+	if err != nil {
+		return fmt.Errorf("serialize error: %w", err)
+	}
+
+	pipe := us.rsConn.TxPipeline()
+	pipe.HSet(ctx, uploadsKey, session.ID.String(), jsonData)
+	if len(pending) == 0 {
+		pipe.Del(ctx, us.pendingKey(session.ID))
+	} else {
+		pipe.Set(ctx, us.pendingKey(session.ID), pending, 0)
+	}
+
+	if _, err = pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("redis error: %w", err)
+	}
+
+	return nil
+}
+
+func (us *UploadStorage) LockSession(ctx kernel.Ctx, uploadID kernel.UploadID, ttl time.Duration) (string, error) {
+	token := uuid.NewString()
+	acquired, err := us.rsConn.SetNX(ctx, us.lockKey(uploadID), token, ttl).Result()
+	if err != nil {
+		return "", fmt.Errorf("redis error: %w", err)
+	}
+
+	if !acquired {
+		return "", fmt.Errorf("redis error: %w", upload.ErrSessionLocked)
+	}
+
+	return token, nil
+}
+
+func (us *UploadStorage) UnlockSession(ctx kernel.Ctx, uploadID kernel.UploadID, token string) error {
+	keys := []string{us.lockKey(uploadID)}
+	if err := releaseScript.Run(ctx, us.rsConn, keys, token).Err(); err != nil {
+		return fmt.Errorf("redis error: %w", err)
+	}
+
+	return nil
+}
+
+// pendingKey uses own key prefix, so pending bytes are not listed
+// as tasks of bucket.
+func (us *UploadStorage) pendingKey(uploadID kernel.UploadID) string {
+	return fmt.Sprintf("%s-%s:%s", kernel.AppName, uploadPendingKeyPrefix, uploadID.String())
+}
+
+// lockKey uses own key prefix, so locks are not listed as tasks of bucket.
+func (us *UploadStorage) lockKey(uploadID kernel.UploadID) string {
+	return fmt.Sprintf("%s-%s:%s", kernel.AppName, uploadLockKeyPrefix, uploadID.String())
+}
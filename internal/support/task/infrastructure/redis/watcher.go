@@ -0,0 +1,121 @@
+package redis
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"watchtower/internal/shared/kernel"
+	"watchtower/internal/support/task/domain"
+)
+
+// watchersKey is a hash of all registered watchers by their ids.
+const watchersKey = kernel.AppName + "-watchers"
+
+// updateScanTimeScript sets scan time of watcher only if it exists,
+// so watcher removed during scan is not stored again.
+var updateScanTimeScript = redis.NewScript(`
+local data = redis.call("HGET", KEYS[1], ARGV[1])
+if not data then
+	return 0
+end
+local value = cjson.decode(data)
+value["last_scan_at"] = tonumber(ARGV[2])
+redis.call("HSET", KEYS[1], ARGV[1], cjson.encode(value))
+return 1
+`)
+
+func (rs *RedisClient) GetWatcher(ctx kernel.Ctx, watcherID kernel.WatcherID) (*domain.Watcher, error) {
+	cmd := rs.rsConn.HGet(ctx, watchersKey, watcherID.String())
+	data, err := cmd.Bytes()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, fmt.Errorf("redis error: %w", domain.ErrWatcherNotFound)
+		}
+		return nil, fmt.Errorf("redis error: %w: %w", domain.ErrExecution, err)
+	}
+
+	var value WatcherValue
+	if err = json.Unmarshal(data, &value); err != nil {
+		return nil, fmt.Errorf("deserialize error: %w: %w", domain.ErrInvalidTaskData, err)
+	}
+
+	watcher, err := value.ConvertToWatcher()
+	if err != nil {
+		return nil, fmt.Errorf("watcher validation error: %w: %w", domain.ErrInvalidTaskData, err)
+	}
+
+	return watcher, nil
+}
+
+func (rs *RedisClient) GetAllWatchers(ctx kernel.Ctx) ([]*domain.Watcher, error) {
+	cmd := rs.rsConn.HVals(ctx, watchersKey)
+	if cmd.Err() != nil {
+		return nil, fmt.Errorf("redis error: %w: %w", domain.ErrExecution, cmd.Err())
+	}
+
+	watchers := make([]*domain.Watcher, 0, len(cmd.Val()))
+	for _, data := range cmd.Val() {
+		var value WatcherValue
+		if err := json.Unmarshal([]byte(data), &value); err != nil {
+			slog.Warn("failed to unmarshal watcher", slog.String("err", err.Error()))
+			continue
+		}
+
+		watcher, err := value.ConvertToWatcher()
+		if err != nil {
+			slog.Warn("failed to unmarshal watcher", slog.String("err", err.Error()))
+			continue
+		}
+
+		watchers = append(watchers, watcher)
+	}
+
+	return watchers, nil
+}
+
+func (rs *RedisClient) UpdateWatcher(ctx kernel.Ctx, watcher *domain.Watcher) error {
+	value := ConvertFromWatcher(watcher)
+	jsonData, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("serialize error: %w: %w", domain.ErrInvalidTaskData, err)
+	}
+
+	status := rs.rsConn.HSet(ctx, watchersKey, value.ID, jsonData)
+	if status.Err() != nil {
+		return fmt.Errorf("redis error: %w: %w", domain.ErrExecution, status.Err())
+	}
+
+	return nil
+}
+
+func (rs *RedisClient) UpdateWatcherScanTime(ctx kernel.Ctx, watcherID kernel.WatcherID, scanAt time.Time) error {
+	keys := []string{watchersKey}
+	updated, err := updateScanTimeScript.Run(ctx, rs.rsConn, keys, watcherID.String(), scanAt.Unix()).Int()
+	if err != nil {
+		return fmt.Errorf("redis error: %w: %w", domain.ErrExecution, err)
+	}
+
+	if updated == 0 {
+		return fmt.Errorf("redis error: %w", domain.ErrWatcherNotFound)
+	}
+
+	return nil
+}
+
+func (rs *RedisClient) DeleteWatcher(ctx kernel.Ctx, watcherID kernel.WatcherID) error {
+	status := rs.rsConn.HDel(ctx, watchersKey, watcherID.String())
+	if status.Err() != nil {
+		return fmt.Errorf("redis error: %w: %w", domain.ErrExecution, status.Err())
+	}
+
+	if status.Val() == 0 {
+		return fmt.Errorf("redis error: %w", domain.ErrWatcherNotFound)
+	}
+
+	return nil
+}
@@ -0,0 +1,14 @@
+package routing
+
+// Config describes which recognizers handle files of specified mime
+// types. Route recognizers are tried in order, then Fallback ones.
+type Config struct {
+	Fallback []string `mapstructure:"fallback"`
+	Routes   []Route  `mapstructure:"routes"`
+}
+
+// Route matches mime types exactly or by "type/*" wildcard.
+type Route struct {
+	MimeTypes   []string `mapstructure:"mime_types"`
+	Recognizers []string `mapstructure:"recognizers"`
+}
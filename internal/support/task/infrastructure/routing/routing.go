@@ -0,0 +1,85 @@
+package routing
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+
+	"watchtower/internal/shared/kernel"
+	"watchtower/internal/support/task/application/service/recognizer"
+)
+
+// RoutingRecognizer picks recognizers by mime type of file and
+// falls back to the next one of chain when recognizing fails.
+type RoutingRecognizer struct {
+	config      Config
+	recognizers map[string]recognizer.IRecognizer
+}
+
+func New(config Config, recognizers map[string]recognizer.IRecognizer) (recognizer.IRecognizer, error) {
+	names := slices.Clone(config.Fallback)
+	for _, route := range config.Routes {
+		names = append(names, route.Recognizers...)
+	}
+
+	for _, name := range names {
+		if _, ok := recognizers[name]; !ok {
+			return nil, fmt.Errorf("routing: unknown recognizer %s", name)
+		}
+	}
+
+	return &RoutingRecognizer{config: config, recognizers: recognizers}, nil
+}
+
+func (rr *RoutingRecognizer) Recognize(ctx kernel.Ctx, params *recognizer.RecognizeParams) (*recognizer.Recognized, error) {
+	mimeType := params.MimeType()
+	chain := rr.buildChain(mimeType)
+	if len(chain) == 0 {
+		return nil, fmt.Errorf("routing: there is no recognizer for mime type %s", mimeType)
+	}
+
+	var errs []error
+	for _, name := range chain {
+		recData, err := rr.recognizers[name].Recognize(ctx, params)
+		if err == nil {
+			return recData, nil
+		}
+
+		slog.Warn("recognizing",
+			slog.String("msg", "recognizer failed, trying next one"),
+			slog.String("recognizer", name),
+			slog.String("mime-type", mimeType),
+			slog.String("err", err.Error()),
+		)
+		errs = append(errs, fmt.Errorf("%s: %w", name, err))
+	}
+
+	return nil, fmt.Errorf("routing: all recognizers failed: %w", errors.Join(errs...))
+}
+
+func (rr *RoutingRecognizer) buildChain(mimeType string) []string {
+	var chain []string
+	for _, route := range rr.config.Routes {
+		if !slices.ContainsFunc(route.MimeTypes, func(pattern string) bool {
+			return recognizer.MatchMimeType(pattern, mimeType)
+		}) {
+			continue
+		}
+
+		chain = appendUnique(chain, route.Recognizers...)
+		break
+	}
+
+	return appendUnique(chain, rr.config.Fallback...)
+}
+
+func appendUnique(chain []string, names ...string) []string {
+	for _, name := range names {
+		if !slices.Contains(chain, name) {
+			chain = append(chain, name)
+		}
+	}
+
+	return chain
+}
@@ -0,0 +1,85 @@
+package textparser
+
+import (
+	"fmt"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/net/html/charset"
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/charmap"
+)
+
+// cyrillicEncodings are checked when text is not valid UTF-8
+// and does not declare its charset.
+var cyrillicEncodings = []encoding.Encoding{
+	charmap.Windows1251,
+	charmap.KOI8R,
+}
+
+// decodeText converts data to UTF-8 by BOM, declared charset or
+// detected single byte cyrillic encoding.
+func decodeText(data []byte, contentType string) (string, error) {
+	enc, name, certain := charset.DetermineEncoding(data, contentType)
+
+	// Sniffed content type always declares utf-8 charset.
+	isValidUTF8 := utf8.Valid(data)
+	if name == "utf-8" && !isValidUTF8 {
+		certain = false
+	}
+
+	if !certain {
+		if isValidUTF8 {
+			return string(data), nil
+		}
+
+		if cyrillicEnc := detectCyrillicEncoding(data); cyrillicEnc != nil {
+			enc = cyrillicEnc
+		}
+	}
+
+	decoded, err := enc.NewDecoder().Bytes(data)
+	if err != nil {
+		return "", fmt.Errorf("failed to decode %s text: %w", name, err)
+	}
+
+	return string(decoded), nil
+}
+
+// detectCyrillicEncoding compares letter case distribution of decoded
+// variants. Lowercase letters of windows-1251 are uppercase in koi8-r
+// and vice versa, so the right encoding gives mostly lowercase text.
+func detectCyrillicEncoding(data []byte) encoding.Encoding {
+	var bestEnc encoding.Encoding
+	bestScore := 0
+	for _, enc := range cyrillicEncodings {
+		decoded, err := enc.NewDecoder().Bytes(data)
+		if err != nil {
+			continue
+		}
+
+		score, lettersCount := 0, 0
+		for _, r := range string(decoded) {
+			if !unicode.Is(unicode.Cyrillic, r) {
+				continue
+			}
+
+			lettersCount++
+			if unicode.IsLower(r) {
+				score++
+			} else {
+				score--
+			}
+		}
+
+		if lettersCount == 0 {
+			continue
+		}
+
+		if bestEnc == nil || score > bestScore {
+			bestEnc, bestScore = enc, score
+		}
+	}
+
+	return bestEnc
+}
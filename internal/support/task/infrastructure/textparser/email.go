@@ -0,0 +1,142 @@
+package textparser
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"strings"
+
+	"golang.org/x/net/html/charset"
+)
+
+var emailHeaders = []string{"Subject", "From", "To", "Cc", "Date"}
+
+func emailToText(data []byte) (string, error) {
+	msg, err := mail.ReadMessage(bytes.NewReader(data))
+	if err != nil {
+		return "", fmt.Errorf("failed to read email message: %w", err)
+	}
+
+	var builder strings.Builder
+	wordDecoder := &mime.WordDecoder{CharsetReader: charset.NewReaderLabel}
+	for _, key := range emailHeaders {
+		value := msg.Header.Get(key)
+		if value == "" {
+			continue
+		}
+
+		if decoded, err := wordDecoder.DecodeHeader(value); err == nil {
+			value = decoded
+		}
+		builder.WriteString(fmt.Sprintf("%s: %s\n", key, value))
+	}
+
+	contentType := msg.Header.Get("Content-Type")
+	transferEncoding := msg.Header.Get("Content-Transfer-Encoding")
+	body, _, err := readEmailPart(contentType, transferEncoding, msg.Body)
+	if err != nil {
+		return "", err
+	}
+
+	builder.WriteString("\n")
+	builder.WriteString(body)
+	return builder.String(), nil
+}
+
+// readEmailPart returns text of message part and its media type.
+// Attachments and non text parts are skipped.
+func readEmailPart(contentType, transferEncoding string, body io.Reader) (string, string, error) {
+	mediaType, params, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		mediaType = "text/plain"
+	}
+
+	reader := decodeTransferEncoding(transferEncoding, body)
+	if strings.HasPrefix(mediaType, "multipart/") {
+		text, err := readEmailMultipart(mediaType, params["boundary"], reader)
+		return text, mediaType, err
+	}
+
+	if !strings.HasPrefix(mediaType, "text/") {
+		return "", mediaType, nil
+	}
+
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return "", mediaType, fmt.Errorf("failed to read email part: %w", err)
+	}
+
+	var text string
+	if mediaType == "text/html" {
+		text, err = htmlToText(data, contentType)
+	} else {
+		text, err = decodeText(data, contentType)
+	}
+
+	return text, mediaType, err
+}
+
+func readEmailMultipart(mediaType, boundary string, reader io.Reader) (string, error) {
+	partTexts := make(map[string]string)
+	var contents []string
+
+	multipartReader := multipart.NewReader(reader, boundary)
+	for {
+		part, err := multipartReader.NextPart()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return "", fmt.Errorf("failed to read email multipart: %w", err)
+		}
+
+		if part.FileName() != "" {
+			continue
+		}
+
+		partContentType := part.Header.Get("Content-Type")
+		partTransferEncoding := part.Header.Get("Content-Transfer-Encoding")
+		text, partMediaType, err := readEmailPart(partContentType, partTransferEncoding, part)
+		if err != nil {
+			return "", err
+		}
+
+		if strings.TrimSpace(text) == "" {
+			continue
+		}
+
+		if _, ok := partTexts[partMediaType]; !ok {
+			partTexts[partMediaType] = text
+		}
+		contents = append(contents, text)
+	}
+
+	// Alternative parts contain the same content, plain text is preferred.
+	if mediaType == "multipart/alternative" {
+		if text, ok := partTexts["text/plain"]; ok {
+			return text, nil
+		}
+		if len(contents) > 0 {
+			return contents[0], nil
+		}
+	}
+
+	return strings.Join(contents, "\n\n"), nil
+}
+
+func decodeTransferEncoding(transferEncoding string, body io.Reader) io.Reader {
+	switch strings.ToLower(strings.TrimSpace(transferEncoding)) {
+	case "base64":
+		return base64.NewDecoder(base64.StdEncoding, body)
+	case "quoted-printable":
+		return quotedprintable.NewReader(body)
+	default:
+		return body
+	}
+}
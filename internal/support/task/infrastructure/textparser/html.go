@@ -0,0 +1,88 @@
+package textparser
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// skippedTags contain no readable text of document.
+var skippedTags = map[string]bool{
+	"script":   true,
+	"style":    true,
+	"noscript": true,
+	"template": true,
+	"svg":      true,
+}
+
+var blockTags = map[string]bool{
+	"title": true, "p": true, "div": true, "br": true, "hr": true,
+	"h1": true, "h2": true, "h3": true, "h4": true, "h5": true, "h6": true,
+	"ul": true, "ol": true, "li": true, "dl": true, "dt": true, "dd": true,
+	"table": true, "tr": true, "section": true, "article": true,
+	"header": true, "footer": true, "nav": true, "aside": true,
+	"blockquote": true, "pre": true, "address": true, "form": true,
+}
+
+func htmlToText(data []byte, contentType string) (string, error) {
+	decoded, err := decodeText(data, contentType)
+	if err != nil {
+		return "", err
+	}
+
+	var builder strings.Builder
+	skipDepth := 0
+	tokenizer := html.NewTokenizer(strings.NewReader(decoded))
+	for {
+		tokenType := tokenizer.Next()
+		switch tokenType {
+		case html.ErrorToken:
+			if err = tokenizer.Err(); !errors.Is(err, io.EOF) {
+				return "", fmt.Errorf("failed to parse html: %w", err)
+			}
+			return normalizeLines(builder.String()), nil
+
+		case html.StartTagToken, html.SelfClosingTagToken:
+			tagName, _ := tokenizer.TagName()
+			name := string(tagName)
+			if skippedTags[name] && tokenType == html.StartTagToken {
+				skipDepth++
+			}
+			if blockTags[name] {
+				builder.WriteByte('\n')
+			}
+
+		case html.EndTagToken:
+			tagName, _ := tokenizer.TagName()
+			name := string(tagName)
+			if skippedTags[name] && skipDepth > 0 {
+				skipDepth--
+			}
+			if blockTags[name] {
+				builder.WriteByte('\n')
+			}
+
+		case html.TextToken:
+			if skipDepth == 0 {
+				builder.Write(tokenizer.Text())
+			}
+		}
+	}
+}
+
+// normalizeLines collapses whitespaces inside lines and drops empty lines.
+func normalizeLines(text string) string {
+	lines := strings.Split(text, "\n")
+	result := make([]string, 0, len(lines))
+	for _, line := range lines {
+		line = strings.Join(strings.Fields(line), " ")
+		if line != "" {
+			result = append(result, line)
+		}
+	}
+
+	return strings.Join(result, "\n")
+}
@@ -0,0 +1,62 @@
+package textparser
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// jsonToText flattens json document into "key.path: value" lines.
+func jsonToText(data []byte, contentType string) (string, error) {
+	decoded, err := decodeText(data, contentType)
+	if err != nil {
+		return "", err
+	}
+
+	var value any
+	decoder := json.NewDecoder(strings.NewReader(decoded))
+	decoder.UseNumber()
+	if err = decoder.Decode(&value); err != nil {
+		return "", fmt.Errorf("failed to decode json: %w", err)
+	}
+
+	var builder strings.Builder
+	writeJSONValue(&builder, "", value)
+	return builder.String(), nil
+}
+
+func writeJSONValue(builder *strings.Builder, keyPath string, value any) {
+	switch typedValue := value.(type) {
+	case map[string]any:
+		keys := make([]string, 0, len(typedValue))
+		for key := range typedValue {
+			keys = append(keys, key)
+		}
+		slices.Sort(keys)
+
+		for _, key := range keys {
+			childPath := key
+			if keyPath != "" {
+				childPath = keyPath + "." + key
+			}
+			writeJSONValue(builder, childPath, typedValue[key])
+		}
+
+	case []any:
+		for _, item := range typedValue {
+			writeJSONValue(builder, keyPath, item)
+		}
+
+	case nil:
+		return
+
+	default:
+		if keyPath != "" {
+			builder.WriteString(keyPath)
+			builder.WriteString(": ")
+		}
+		builder.WriteString(fmt.Sprint(typedValue))
+		builder.WriteByte('\n')
+	}
+}
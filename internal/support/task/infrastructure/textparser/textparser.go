@@ -0,0 +1,94 @@
+package textparser
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"watchtower/internal/shared/kernel"
+	"watchtower/internal/support/task/application/service/recognizer"
+)
+
+const RecognizerName = "textparser"
+
+type textFormat int
+
+const (
+	unsupportedFormat textFormat = iota
+	plainFormat
+	htmlFormat
+	jsonFormat
+	emailFormat
+)
+
+var extensionFormats = map[string]textFormat{
+	".txt":      plainFormat,
+	".md":       plainFormat,
+	".markdown": plainFormat,
+	".csv":      plainFormat,
+	".tsv":      plainFormat,
+	".json":     jsonFormat,
+	".html":     htmlFormat,
+	".htm":      htmlFormat,
+	".eml":      emailFormat,
+}
+
+// TextParser extracts text from plain text based formats locally
+// without sending them to the remote docparser service.
+type TextParser struct{}
+
+func New() recognizer.IRecognizer {
+	return &TextParser{}
+}
+
+func (tp *TextParser) Recognize(_ kernel.Ctx, params *recognizer.RecognizeParams) (*recognizer.Recognized, error) {
+	var text string
+	var err error
+
+	data := params.FileData.Bytes()
+	switch detectFormat(params) {
+	case plainFormat:
+		text, err = decodeText(data, params.ContentType)
+	case htmlFormat:
+		text, err = htmlToText(data, params.ContentType)
+	case jsonFormat:
+		text, err = jsonToText(data, params.ContentType)
+	case emailFormat:
+		text, err = emailToText(data)
+	default:
+		return nil, fmt.Errorf("textparser: unsupported mime type %s", params.MimeType())
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("textparser: %w", err)
+	}
+
+	if strings.TrimSpace(text) == "" {
+		return nil, fmt.Errorf("textparser: extracted empty content data")
+	}
+
+	return &recognizer.Recognized{Text: text}, nil
+}
+
+// detectFormat prefers file extension because content type of
+// plain text files is often sniffed as generic text/plain.
+func detectFormat(params *recognizer.RecognizeParams) textFormat {
+	ext := strings.ToLower(path.Ext(params.FileName))
+	if format, ok := extensionFormats[ext]; ok {
+		return format
+	}
+
+	mimeType := params.MimeType()
+	switch {
+	case mimeType == "text/html" || mimeType == "application/xhtml+xml":
+		return htmlFormat
+	case mimeType == "application/json" || strings.HasSuffix(mimeType, "+json"):
+		return jsonFormat
+	case mimeType == "message/rfc822":
+		return emailFormat
+	case strings.HasPrefix(mimeType, "text/"):
+		return plainFormat
+	default:
+		return unsupportedFormat
+	}
+}
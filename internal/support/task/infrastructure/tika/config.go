@@ -0,0 +1,9 @@
+package tika
+
+import "time"
+
+type Config struct {
+	Address         string        `mapstructure:"address"`
+	Timeout         time.Duration `mapstructure:"timeout"`
+	ExtractMetadata bool          `mapstructure:"extract_metadata"`
+}
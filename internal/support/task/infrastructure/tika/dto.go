@@ -0,0 +1,63 @@
+package tika
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	"watchtower/internal/support/task/application/service/recognizer"
+)
+
+// metadataKeys maps Tika metadata keys to document properties.
+var metadataKeys = map[string]string{
+	"dc:title":         "title",
+	"dc:subject":       "subject",
+	"dc:creator":       "author",
+	"dcterms:created":  "created",
+	"dcterms:modified": "modified",
+	"meta:keyword":     "keywords",
+	"Content-Type":     "content_type",
+}
+
+const (
+	pageCountKey = "xmpTPg:NPages"
+	languageKey  = "dc:language"
+)
+
+// Metadata is response of /meta endpoint. Tika returns
+// multivalued keys as arrays of strings.
+type Metadata map[string]json.RawMessage
+
+func (m Metadata) Get(key string) string {
+	rawValue, ok := m[key]
+	if !ok {
+		return ""
+	}
+
+	var value string
+	if err := json.Unmarshal(rawValue, &value); err == nil {
+		return value
+	}
+
+	var values []string
+	if err := json.Unmarshal(rawValue, &values); err == nil {
+		return strings.Join(values, ", ")
+	}
+
+	return strings.Trim(string(rawValue), `"`)
+}
+
+func (m Metadata) FillRecognized(recData *recognizer.Recognized) {
+	properties := make(map[string]string)
+	for tikaKey, key := range metadataKeys {
+		if value := m.Get(tikaKey); value != "" {
+			properties[key] = value
+		}
+	}
+
+	recData.Metadata = properties
+	recData.Language = m.Get(languageKey)
+	if pageCount, err := strconv.Atoi(m.Get(pageCountKey)); err == nil {
+		recData.PageCount = pageCount
+	}
+}
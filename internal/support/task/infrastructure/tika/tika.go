@@ -0,0 +1,82 @@
+package tika
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"mime"
+	"path"
+	"strings"
+	"time"
+
+	"watchtower/internal/shared/kernel"
+	"watchtower/internal/shared/utils"
+	"watchtower/internal/support/task/application/service/recognizer"
+)
+
+const (
+	RecognizerName = "tika"
+	TextURL        = "/tika"
+	MetadataURL    = "/meta"
+)
+
+// TikaClient recognizes documents by Apache Tika server.
+type TikaClient struct {
+	config Config
+}
+
+func New(config Config) recognizer.IRecognizer {
+	return &TikaClient{config}
+}
+
+func (tc *TikaClient) Recognize(ctx kernel.Ctx, params *recognizer.RecognizeParams) (*recognizer.Recognized, error) {
+	textData, err := tc.put(ctx, TextURL, "text/plain", params)
+	if err != nil {
+		return nil, fmt.Errorf("tika: failed to extract text: %w", err)
+	}
+
+	text := strings.TrimSpace(string(textData))
+	if text == "" {
+		return nil, fmt.Errorf("tika: returned empty content data")
+	}
+
+	recData := &recognizer.Recognized{Text: text}
+	if !tc.config.ExtractMetadata {
+		return recData, nil
+	}
+
+	metaData, err := tc.put(ctx, MetadataURL, "application/json", params)
+	if err != nil {
+		return nil, fmt.Errorf("tika: failed to extract metadata: %w", err)
+	}
+
+	var metadata Metadata
+	if err = json.Unmarshal(metaData, &metadata); err != nil {
+		return nil, fmt.Errorf("tika: failed to decode metadata: %w", err)
+	}
+
+	metadata.FillRecognized(recData)
+	return recData, nil
+}
+
+// put sends file data with content type and file name hints, so
+// Tika does not have to detect document type by content only.
+func (tc *TikaClient) put(
+	ctx kernel.Ctx,
+	url, accept string,
+	params *recognizer.RecognizeParams,
+) ([]byte, error) {
+	headers := map[string]string{
+		"Accept":              accept,
+		"Content-Disposition": mime.FormatMediaType("attachment", map[string]string{"filename": path.Base(params.FileName)}),
+	}
+
+	if mimeType := params.MimeType(); mimeType != recognizer.DefaultMimeType {
+		headers["Content-Type"] = mimeType
+	}
+
+	body := bytes.NewBuffer(params.FileData.Bytes())
+	timeoutReq := tc.config.Timeout * time.Second
+	targetURL := utils.BuildTargetURL(tc.config.Address, url)
+	return utils.PUTWithHeaders(ctx, body, targetURL, headers, timeoutReq)
+}
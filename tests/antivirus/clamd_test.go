@@ -0,0 +1,108 @@
+package antivirus_test
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"watchtower/internal/support/task/application/service/antivirus"
+	"watchtower/internal/support/task/infrastructure/clamd"
+	"watchtower/tests/common"
+)
+
+const TestMaxStreamSize = 1024
+
+func TestClamdScanner(t *testing.T) {
+	ctx := context.Background()
+
+	var clamdTestCases = []struct {
+		Name              string
+		Network           string
+		FileData          string
+		ChunkSize         int
+		ExpectedVerdict   *antivirus.Verdict
+		ExpectedChunks    int
+		ExpectedErrorText string
+	}{
+		{
+			Name:            "Clean file over tcp",
+			Network:         "tcp",
+			FileData:        "quarterly report",
+			ExpectedVerdict: &antivirus.Verdict{Infected: false},
+			ExpectedChunks:  1,
+		},
+		{
+			Name:            "Infected file over unix socket",
+			Network:         "unix",
+			FileData:        "prefix " + common.EicarTestData,
+			ExpectedVerdict: &antivirus.Verdict{Infected: true, Signature: common.EicarTestSignature},
+			ExpectedChunks:  1,
+		},
+		{
+			Name:            "File is streamed by chunks",
+			Network:         "tcp",
+			FileData:        strings.Repeat("a", 100),
+			ChunkSize:       30,
+			ExpectedVerdict: &antivirus.Verdict{Infected: false},
+			ExpectedChunks:  4,
+		},
+		{
+			Name:              "Stream size limit exceeded",
+			Network:           "tcp",
+			FileData:          strings.Repeat("a", 8*TestMaxStreamSize),
+			ChunkSize:         TestMaxStreamSize,
+			ExpectedErrorText: "INSTREAM size limit exceeded",
+		},
+	}
+
+	for _, testCase := range clamdTestCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			address := "127.0.0.1:0"
+			if testCase.Network == "unix" {
+				address = filepath.Join(t.TempDir(), "clamd.sock")
+			}
+
+			fakeClamd, err := common.NewFakeClamd(testCase.Network, address, TestMaxStreamSize)
+			assert.NoError(t, err, "failed to launch fake clamd")
+			defer fakeClamd.Close()
+
+			scanner := clamd.New(clamd.Config{
+				Network:   testCase.Network,
+				Address:   fakeClamd.Address(),
+				Timeout:   10,
+				ChunkSize: testCase.ChunkSize,
+			})
+
+			params := &antivirus.ScanParams{
+				FileName: "upload.bin",
+				FileData: bytes.NewBufferString(testCase.FileData),
+			}
+
+			verdict, err := scanner.Scan(ctx, params)
+			if testCase.ExpectedErrorText != "" {
+				assert.ErrorContains(t, err, testCase.ExpectedErrorText)
+				assert.ErrorIs(t, err, clamd.ErrScanFailed)
+				return
+			}
+
+			assert.NoError(t, err, "failed to scan file")
+			assert.Equal(t, testCase.ExpectedVerdict, verdict)
+			assert.Equal(t, testCase.ExpectedChunks, fakeClamd.Chunks())
+			assert.Equal(t, testCase.FileData, params.FileData.String(), "file data has been drained")
+		})
+	}
+
+	t.Run("Daemon is not available", func(t *testing.T) {
+		socketPath := filepath.Join(t.TempDir(), "missing.sock")
+		scanner := clamd.New(clamd.Config{Network: "unix", Address: socketPath, Timeout: 1})
+		params := &antivirus.ScanParams{FileName: "upload.bin", FileData: bytes.NewBufferString("data")}
+
+		_, err := scanner.Scan(ctx, params)
+		assert.ErrorContains(t, err, "failed to connect")
+		assert.NotErrorIs(t, err, clamd.ErrScanFailed)
+	})
+}
@@ -0,0 +1,200 @@
+package archive_test
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"watchtower/internal/process/archive"
+)
+
+type testEntry struct {
+	Name    string
+	Content string
+}
+
+func buildZip(t *testing.T, entries []testEntry) []byte {
+	buffer := bytes.NewBuffer(nil)
+	zipWriter := zip.NewWriter(buffer)
+	for _, entry := range entries {
+		entryWriter, err := zipWriter.Create(entry.Name)
+		assert.NoError(t, err, "failed to create zip entry")
+		_, err = entryWriter.Write([]byte(entry.Content))
+		assert.NoError(t, err, "failed to write zip entry")
+	}
+
+	assert.NoError(t, zipWriter.Close(), "failed to close zip archive")
+	return buffer.Bytes()
+}
+
+func buildTar(t *testing.T, entries []testEntry) []byte {
+	buffer := bytes.NewBuffer(nil)
+	tarWriter := tar.NewWriter(buffer)
+	for _, entry := range entries {
+		header := &tar.Header{
+			Name:     entry.Name,
+			Mode:     0o600,
+			Size:     int64(len(entry.Content)),
+			Typeflag: tar.TypeReg,
+		}
+		assert.NoError(t, tarWriter.WriteHeader(header), "failed to write tar header")
+		_, err := tarWriter.Write([]byte(entry.Content))
+		assert.NoError(t, err, "failed to write tar entry")
+	}
+
+	assert.NoError(t, tarWriter.Close(), "failed to close tar archive")
+	return buffer.Bytes()
+}
+
+func buildTarGzip(t *testing.T, entries []testEntry) []byte {
+	buffer := bytes.NewBuffer(nil)
+	gzipWriter := gzip.NewWriter(buffer)
+	_, err := gzipWriter.Write(buildTar(t, entries))
+	assert.NoError(t, err, "failed to write gzip stream")
+	assert.NoError(t, gzipWriter.Close(), "failed to close gzip stream")
+	return buffer.Bytes()
+}
+
+func walkArchive(format archive.Format, data []byte, limits archive.Limits) (map[string]string, error) {
+	walked := make(map[string]string)
+	err := archive.Walk(format, bytes.NewReader(data), int64(len(data)), limits, func(entry *archive.Entry) error {
+		content, err := io.ReadAll(entry.Reader)
+		if err != nil {
+			return err
+		}
+
+		walked[entry.Path] = string(content)
+		return nil
+	})
+
+	return walked, err
+}
+
+func TestDetect(t *testing.T) {
+	entries := []testEntry{{Name: "contract.txt", Content: "contract"}}
+
+	assert.Equal(t, archive.Zip, archive.Detect("contracts.zip", buildZip(t, entries)))
+	assert.Equal(t, archive.Tar, archive.Detect("contracts.tar", buildTar(t, entries)))
+	assert.Equal(t, archive.TarGzip, archive.Detect("contracts.tar.gz", buildTarGzip(t, entries)))
+	assert.Equal(t, archive.None, archive.Detect("contract.gz", buildTarGzip(t, entries)))
+	assert.Equal(t, archive.None, archive.Detect("contract.txt", []byte("contract")))
+
+	tarData := buildTar(t, entries)
+	assert.Equal(t, archive.Tar, archive.DetectReader("contracts.tar", bytes.NewReader(tarData)))
+	assert.Equal(t, archive.None, archive.DetectReader("contracts.zip", bytes.NewReader([]byte("PK"))))
+
+	assert.True(t, archive.HasExtension("incoming/Contracts.ZIP"))
+	assert.True(t, archive.HasExtension("incoming/contracts.tgz"))
+	assert.False(t, archive.HasExtension("incoming/contract.docx"))
+}
+
+func TestWalk(t *testing.T) {
+	entries := []testEntry{
+		{Name: "contract-1.txt", Content: "first contract"},
+		{Name: "./scans/../scans/contract-2.txt", Content: "second contract"},
+		{Name: "__MACOSX/._contract-1.txt", Content: "service entry"},
+		{Name: "scans/._contract-2.txt", Content: "service entry"},
+	}
+
+	expected := map[string]string{
+		"contract-1.txt":       "first contract",
+		"scans/contract-2.txt": "second contract",
+	}
+
+	formats := map[archive.Format][]byte{
+		archive.Zip:     buildZip(t, entries),
+		archive.Tar:     buildTar(t, entries),
+		archive.TarGzip: buildTarGzip(t, entries),
+	}
+
+	for format, data := range formats {
+		walked, err := walkArchive(format, data, archive.Limits{})
+		assert.NoError(t, err, "failed to walk archive %d", format)
+		assert.Equal(t, expected, walked)
+	}
+}
+
+func TestWalkUnsafePaths(t *testing.T) {
+	unsafeNames := []string{
+		"../../etc/passwd",
+		"scans/../../passwd",
+		"/etc/passwd",
+		"..\\..\\windows\\system.ini",
+		"C:/windows/system.ini",
+		"..",
+	}
+
+	for _, name := range unsafeNames {
+		entries := []testEntry{{Name: name, Content: "unsafe entry"}}
+		for _, format := range []archive.Format{archive.Zip, archive.Tar} {
+			data := buildZip(t, entries)
+			if format == archive.Tar {
+				data = buildTar(t, entries)
+			}
+
+			walked, err := walkArchive(format, data, archive.Limits{})
+			assert.ErrorIs(t, err, archive.ErrUnsafePath, "entry %s must be rejected", name)
+			assert.Empty(t, walked)
+		}
+	}
+}
+
+func TestWalkLimits(t *testing.T) {
+	entries := []testEntry{
+		{Name: "contract-1.txt", Content: "first contract"},
+		{Name: "contract-2.txt", Content: "second contract"},
+		{Name: "contract-3.txt", Content: "third contract"},
+	}
+
+	var walkLimitsTestCases = []struct {
+		Name        string
+		Limits      archive.Limits
+		ExpectedErr error
+	}{
+		{
+			Name:   "Limits are not exceeded",
+			Limits: archive.Limits{MaxEntries: 3, MaxExpandedSize: 64},
+		},
+		{
+			Name:        "Entries limit exceeded",
+			Limits:      archive.Limits{MaxEntries: 2},
+			ExpectedErr: archive.ErrTooManyEntries,
+		},
+		{
+			Name:        "Expanded size limit exceeded",
+			Limits:      archive.Limits{MaxExpandedSize: 20},
+			ExpectedErr: archive.ErrTooLargeContent,
+		},
+	}
+
+	for _, testCase := range walkLimitsTestCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			for _, format := range []archive.Format{archive.Zip, archive.Tar, archive.TarGzip} {
+				var data []byte
+				switch format {
+				case archive.Zip:
+					data = buildZip(t, entries)
+				case archive.Tar:
+					data = buildTar(t, entries)
+				default:
+					data = buildTarGzip(t, entries)
+				}
+
+				walked, err := walkArchive(format, data, testCase.Limits)
+				if testCase.ExpectedErr == nil {
+					assert.NoError(t, err, "failed to walk archive %d", format)
+					assert.Len(t, walked, len(entries))
+					continue
+				}
+
+				assert.True(t, errors.Is(err, testCase.ExpectedErr), "unexpected error of format %d: %v", format, err)
+			}
+		})
+	}
+}
@@ -33,9 +33,12 @@ func InitTestAppEnvironment() *TestAppServerEnvironment {
 	}
 }
 
-func (e *TestAppServerEnvironment) BuildAppServer(servConfig *cmd.Config) (*httpserver.Server, error) {
+func (e *TestAppServerEnvironment) BuildAppServer(
+	servConfig *cmd.Config,
+	opts ...taskApp.Option,
+) (*httpserver.Server, error) {
 	storageUseCase := cloudApp.NewStorageUseCase(e.ObjectStorage)
-	taskUseCase := taskApp.NewTaskUseCase(e.TaskStorage, e.TaskQueue, e.Recognizer, e.DocStorage)
+	taskUseCase := taskApp.NewTaskUseCase(e.TaskStorage, e.TaskQueue, e.Recognizer, e.DocStorage, opts...)
 	orchestrator := process.NewOrchestrator(servConfig.Orchestrator, storageUseCase, taskUseCase)
 	appServer := httpserver.SetupServer(servConfig.Otlp, orchestrator)
 	return appServer, nil
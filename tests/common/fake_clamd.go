@@ -0,0 +1,103 @@
+package common
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"io"
+	"net"
+	"sync"
+)
+
+const (
+	EicarTestSignature = "Eicar-Test-Signature"
+	EicarTestData      = `X5O!P%@AP[4\PZX54(P^)7CC)7}$EICAR-STANDARD-ANTIVIRUS-TEST-FILE!$H+H*`
+)
+
+// FakeClamd serves clamd INSTREAM command and reports data
+// containing EICAR test string as infected.
+type FakeClamd struct {
+	listener  net.Listener
+	maxStream int
+
+	mu     sync.Mutex
+	chunks int
+}
+
+func NewFakeClamd(network, address string, maxStream int) (*FakeClamd, error) {
+	listener, err := net.Listen(network, address)
+	if err != nil {
+		return nil, err
+	}
+
+	fakeClamd := &FakeClamd{listener: listener, maxStream: maxStream}
+	go fakeClamd.serve()
+	return fakeClamd, nil
+}
+
+func (fc *FakeClamd) Address() string {
+	return fc.listener.Addr().String()
+}
+
+func (fc *FakeClamd) Chunks() int {
+	fc.mu.Lock()
+	defer fc.mu.Unlock()
+	return fc.chunks
+}
+
+func (fc *FakeClamd) Close() {
+	_ = fc.listener.Close()
+}
+
+func (fc *FakeClamd) serve() {
+	for {
+		conn, err := fc.listener.Accept()
+		if err != nil {
+			return
+		}
+		go fc.handle(conn)
+	}
+}
+
+func (fc *FakeClamd) handle(conn net.Conn) {
+	defer func() { _ = conn.Close() }()
+
+	reader := bufio.NewReader(conn)
+	command, err := reader.ReadString('\x00')
+	if err != nil || command != "zINSTREAM\x00" {
+		_, _ = io.WriteString(conn, "UNKNOWN COMMAND\x00")
+		return
+	}
+
+	var data bytes.Buffer
+	header := make([]byte, 4)
+	for {
+		if _, err = io.ReadFull(reader, header); err != nil {
+			return
+		}
+
+		size := int(binary.BigEndian.Uint32(header))
+		if size == 0 {
+			break
+		}
+
+		if data.Len()+size > fc.maxStream {
+			_, _ = io.WriteString(conn, "INSTREAM size limit exceeded. ERROR\x00")
+			return
+		}
+
+		if _, err = io.CopyN(&data, reader, int64(size)); err != nil {
+			return
+		}
+
+		fc.mu.Lock()
+		fc.chunks++
+		fc.mu.Unlock()
+	}
+
+	reply := "stream: OK\x00"
+	if bytes.Contains(data.Bytes(), []byte("EICAR-STANDARD-ANTIVIRUS-TEST-FILE")) {
+		reply = "stream: " + EicarTestSignature + " FOUND\x00"
+	}
+	_, _ = io.WriteString(conn, reply)
+}
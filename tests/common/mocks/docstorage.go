@@ -16,3 +16,13 @@ func (m *MockDocStorage) StoreDocument(_ kernel.Ctx, doc *docstorage.Document) (
 	args := m.Called(doc)
 	return args.Get(0).(string), args.Error(1)
 }
+
+func (m *MockDocStorage) DeleteDocuments(_ kernel.Ctx, index string, filePath string) error {
+	args := m.Called(index, filePath)
+	return args.Error(0)
+}
+
+func (m *MockDocStorage) MoveDocuments(_ kernel.Ctx, index string, srcPath string, dstPath string) error {
+	args := m.Called(index, srcPath, dstPath)
+	return args.Error(0)
+}
@@ -0,0 +1,32 @@
+package mocks
+
+import (
+	"github.com/stretchr/testify/mock"
+
+	"watchtower/internal/shared/kernel"
+	"watchtower/internal/support/task/application/service/entity"
+	"watchtower/internal/support/task/application/service/graph"
+)
+
+type MockEntityExtractor struct {
+	mock.Mock
+}
+
+func (m *MockEntityExtractor) Extract(_ kernel.Ctx, params *entity.ExtractParams) (*entity.Extracted, error) {
+	args := m.Called(params)
+	return args.Get(0).(*entity.Extracted), args.Error(1)
+}
+
+type MockGraphStore struct {
+	mock.Mock
+}
+
+func (m *MockGraphStore) StoreGraph(_ kernel.Ctx, docGraph *graph.Graph) error {
+	args := m.Called(docGraph)
+	return args.Error(0)
+}
+
+func (m *MockGraphStore) MoveDocument(_ kernel.Ctx, index, srcPath, dstPath string) error {
+	args := m.Called(index, srcPath, dstPath)
+	return args.Error(0)
+}
@@ -1,6 +1,7 @@
 package mocks
 
 import (
+	"errors"
 	"net/url"
 
 	"github.com/stretchr/testify/mock"
@@ -28,6 +29,25 @@ func (m *MockObjectStorage) CreateBucket(_ kernel.Ctx, bucketID kernel.BucketID)
 	return args.Error(0)
 }
 
+func (m *MockObjectStorage) CreateBucketWithConfig(
+	_ kernel.Ctx,
+	bucketID kernel.BucketID,
+	config *domain.BucketConfig,
+) error {
+	args := m.Called(bucketID, config)
+	return args.Error(0)
+}
+
+func (m *MockObjectStorage) GetBucketConfig(_ kernel.Ctx, bucketID kernel.BucketID) (domain.BucketConfig, error) {
+	args := m.Called(bucketID)
+	return args.Get(0).(domain.BucketConfig), args.Error(1)
+}
+
+func (m *MockObjectStorage) SetBucketConfig(_ kernel.Ctx, bucketID kernel.BucketID, config *domain.BucketConfig) error {
+	args := m.Called(bucketID, config)
+	return args.Error(0)
+}
+
 func (m *MockObjectStorage) DeleteBucket(_ kernel.Ctx, bucketID kernel.BucketID) error {
 	args := m.Called(bucketID)
 	return args.Error(0)
@@ -87,6 +107,40 @@ func (m *MockObjectStorage) DeleteObjects(
 	return args.Error(1)
 }
 
+func (m *MockObjectStorage) GetObjectTags(
+	_ kernel.Ctx,
+	bucketID kernel.BucketID,
+	objID kernel.ObjectID,
+) (map[string]string, error) {
+	args := m.Called(bucketID, objID)
+	return args.Get(0).(map[string]string), args.Error(1)
+}
+
+func (m *MockObjectStorage) SetObjectTags(
+	_ kernel.Ctx,
+	bucketID kernel.BucketID,
+	objID kernel.ObjectID,
+	tags map[string]string,
+) error {
+	args := m.Called(bucketID, objID, tags)
+	return args.Error(0)
+}
+
+func (m *MockObjectStorage) DeleteObjectTags(_ kernel.Ctx, bucketID kernel.BucketID, objID kernel.ObjectID) error {
+	args := m.Called(bucketID, objID)
+	return args.Error(0)
+}
+
+func (m *MockObjectStorage) ReplaceObjectMetadata(
+	_ kernel.Ctx,
+	bucketID kernel.BucketID,
+	objID kernel.ObjectID,
+	metadata map[string]string,
+) error {
+	args := m.Called(bucketID, objID, metadata)
+	return args.Error(0)
+}
+
 func (m *MockObjectStorage) GetBucketObjects(
 	_ kernel.Ctx,
 	bucketID kernel.BucketID,
@@ -96,6 +150,39 @@ func (m *MockObjectStorage) GetBucketObjects(
 	return args.Get(0).([]domain.Object), args.Error(1)
 }
 
+func (m *MockObjectStorage) GetBucketObjectsPage(
+	_ kernel.Ctx,
+	bucketID kernel.BucketID,
+	params *domain.GetObjectsParams,
+) (domain.ObjectsPage, error) {
+	args := m.Called(bucketID, params)
+	return args.Get(0).(domain.ObjectsPage), args.Error(1)
+}
+
+// WalkBucketObjects walks objects returned by mocked call.
+func (m *MockObjectStorage) WalkBucketObjects(
+	_ kernel.Ctx,
+	bucketID kernel.BucketID,
+	prefix string,
+	walkFn domain.WalkObjectFunc,
+) error {
+	args := m.Called(bucketID, prefix)
+	if err := args.Error(1); err != nil {
+		return err
+	}
+
+	for _, object := range args.Get(0).([]domain.Object) {
+		if err := walkFn(object); err != nil {
+			if errors.Is(err, domain.ErrStopWalk) {
+				return nil
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (m *MockObjectStorage) GenShareURL(
 	_ kernel.Ctx,
 	bucketID kernel.BucketID,
@@ -104,3 +191,94 @@ func (m *MockObjectStorage) GenShareURL(
 	args := m.Called(bucketID, params)
 	return args.Get(0).(*url.URL), args.Error(1)
 }
+
+func (m *MockObjectStorage) GenUploadURL(
+	_ kernel.Ctx,
+	bucketID kernel.BucketID,
+	params *domain.PresignUploadParams,
+) (*domain.PresignedUpload, error) {
+	args := m.Called(bucketID, params)
+	return args.Get(0).(*domain.PresignedUpload), args.Error(1)
+}
+
+func (m *MockObjectStorage) CreateMultipartUpload(
+	_ kernel.Ctx,
+	bucketID kernel.BucketID,
+	params *domain.UploadObjectParams,
+) (string, error) {
+	args := m.Called(bucketID, params)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockObjectStorage) UploadPart(
+	_ kernel.Ctx,
+	bucketID kernel.BucketID,
+	objID kernel.ObjectID,
+	uploadID string,
+	number int,
+	data domain.ObjectData,
+) (domain.MultipartPart, error) {
+	args := m.Called(bucketID, objID, uploadID, number, data.String())
+	return args.Get(0).(domain.MultipartPart), args.Error(1)
+}
+
+func (m *MockObjectStorage) GetUploadedPart(
+	_ kernel.Ctx,
+	bucketID kernel.BucketID,
+	objID kernel.ObjectID,
+	uploadID string,
+	number int,
+) (*domain.MultipartPart, error) {
+	args := m.Called(bucketID, objID, uploadID, number)
+	part, _ := args.Get(0).(*domain.MultipartPart)
+	return part, args.Error(1)
+}
+
+func (m *MockObjectStorage) CompleteMultipartUpload(
+	_ kernel.Ctx,
+	bucketID kernel.BucketID,
+	objID kernel.ObjectID,
+	uploadID string,
+	parts []domain.MultipartPart,
+) error {
+	args := m.Called(bucketID, objID, uploadID, parts)
+	return args.Error(0)
+}
+
+func (m *MockObjectStorage) AbortMultipartUpload(
+	_ kernel.Ctx,
+	bucketID kernel.BucketID,
+	objID kernel.ObjectID,
+	uploadID string,
+) error {
+	args := m.Called(bucketID, objID, uploadID)
+	return args.Error(0)
+}
+
+func (m *MockObjectStorage) GetObjectVersions(
+	_ kernel.Ctx,
+	bucketID kernel.BucketID,
+	objID kernel.ObjectID,
+) ([]domain.ObjectVersion, error) {
+	args := m.Called(bucketID, objID)
+	return args.Get(0).([]domain.ObjectVersion), args.Error(1)
+}
+
+func (m *MockObjectStorage) GetObjectVersionData(
+	_ kernel.Ctx,
+	bucketID kernel.BucketID,
+	objID kernel.ObjectID,
+	versionID string,
+) (domain.ObjectData, error) {
+	args := m.Called(bucketID, objID, versionID)
+	return args.Get(0).(domain.ObjectData), args.Error(1)
+}
+
+func (m *MockObjectStorage) RestoreObjectVersion(
+	_ kernel.Ctx,
+	bucketID kernel.BucketID,
+	params *domain.RestoreObjectParams,
+) (string, error) {
+	args := m.Called(bucketID, params)
+	return args.String(0), args.Error(1)
+}
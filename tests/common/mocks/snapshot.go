@@ -0,0 +1,38 @@
+package mocks
+
+import (
+	"time"
+
+	"github.com/stretchr/testify/mock"
+
+	"watchtower/internal/shared/kernel"
+	"watchtower/internal/support/task/application/service/snapshot"
+)
+
+type MockSnapshotStorage struct {
+	mock.Mock
+}
+
+func (m *MockSnapshotStorage) LoadSnapshot(_ kernel.Ctx, bucketID kernel.BucketID) (snapshot.Snapshot, error) {
+	args := m.Called(bucketID)
+	return args.Get(0).(snapshot.Snapshot), args.Error(1)
+}
+
+func (m *MockSnapshotStorage) StoreSnapshot(_ kernel.Ctx, bucketID kernel.BucketID, snap snapshot.Snapshot) error {
+	args := m.Called(bucketID, snap)
+	return args.Error(0)
+}
+
+type MockLeaderLock struct {
+	mock.Mock
+}
+
+func (m *MockLeaderLock) Acquire(_ kernel.Ctx, ttl time.Duration) (bool, error) {
+	args := m.Called(ttl)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockLeaderLock) Release(_ kernel.Ctx) error {
+	args := m.Called()
+	return args.Error(0)
+}
@@ -1,6 +1,8 @@
 package mocks
 
 import (
+	"time"
+
 	"github.com/stretchr/testify/mock"
 
 	"watchtower/internal/shared/kernel"
@@ -25,3 +27,28 @@ func (m *MockTaskStorage) UpdateTask(_ kernel.Ctx, task *domain.Task) error {
 	args := m.Called(task)
 	return args.Error(0)
 }
+
+func (m *MockTaskStorage) GetWatcher(_ kernel.Ctx, watcherID kernel.WatcherID) (*domain.Watcher, error) {
+	args := m.Called(watcherID)
+	return args.Get(0).(*domain.Watcher), args.Error(1)
+}
+
+func (m *MockTaskStorage) GetAllWatchers(_ kernel.Ctx) ([]*domain.Watcher, error) {
+	args := m.Called()
+	return args.Get(0).([]*domain.Watcher), args.Error(1)
+}
+
+func (m *MockTaskStorage) UpdateWatcher(_ kernel.Ctx, watcher *domain.Watcher) error {
+	args := m.Called(watcher)
+	return args.Error(0)
+}
+
+func (m *MockTaskStorage) UpdateWatcherScanTime(_ kernel.Ctx, watcherID kernel.WatcherID, scanAt time.Time) error {
+	args := m.Called(watcherID, scanAt)
+	return args.Error(0)
+}
+
+func (m *MockTaskStorage) DeleteWatcher(_ kernel.Ctx, watcherID kernel.WatcherID) error {
+	args := m.Called(watcherID)
+	return args.Error(0)
+}
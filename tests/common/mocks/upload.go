@@ -0,0 +1,189 @@
+package mocks
+
+import (
+	"sync"
+	"time"
+
+	"watchtower/internal/shared/kernel"
+	"watchtower/internal/support/task/application/service/upload"
+)
+
+// MockUploadStorage keeps upload sessions in memory to drive
+// multi-request upload flows.
+type MockUploadStorage struct {
+	mu       sync.Mutex
+	sessions map[kernel.UploadID]upload.Session
+	pending  map[kernel.UploadID][]byte
+	locked   map[kernel.UploadID]bool
+}
+
+func NewMockUploadStorage() *MockUploadStorage {
+	return &MockUploadStorage{
+		sessions: make(map[kernel.UploadID]upload.Session),
+		pending:  make(map[kernel.UploadID][]byte),
+		locked:   make(map[kernel.UploadID]bool),
+	}
+}
+
+func (m *MockUploadStorage) GetSession(_ kernel.Ctx, uploadID kernel.UploadID) (*upload.Session, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	session, ok := m.sessions[uploadID]
+	if !ok {
+		return nil, upload.ErrSessionNotFound
+	}
+
+	session.Parts = append([]upload.Part{}, session.Parts...)
+	return &session, nil
+}
+
+func (m *MockUploadStorage) GetAllSessions(_ kernel.Ctx) ([]*upload.Session, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	sessions := make([]*upload.Session, 0, len(m.sessions))
+	for _, session := range m.sessions {
+		sessions = append(sessions, &session)
+	}
+
+	return sessions, nil
+}
+
+func (m *MockUploadStorage) StoreSession(_ kernel.Ctx, session *upload.Session) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.sessions[session.ID] = *session
+	return nil
+}
+
+func (m *MockUploadStorage) DeleteSession(_ kernel.Ctx, uploadID kernel.UploadID) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.sessions, uploadID)
+	delete(m.pending, uploadID)
+	return nil
+}
+
+func (m *MockUploadStorage) LoadPending(_ kernel.Ctx, uploadID kernel.UploadID) ([]byte, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return append([]byte{}, m.pending[uploadID]...), nil
+}
+
+func (m *MockUploadStorage) StoreSessionPending(_ kernel.Ctx, session *upload.Session, pending []byte) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.sessions[session.ID] = *session
+	m.pending[session.ID] = append([]byte{}, pending...)
+	return nil
+}
+
+func (m *MockUploadStorage) LockSession(_ kernel.Ctx, uploadID kernel.UploadID, _ time.Duration) (string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.locked[uploadID] {
+		return "", upload.ErrSessionLocked
+	}
+
+	m.locked[uploadID] = true
+	return uploadID.String(), nil
+}
+
+func (m *MockUploadStorage) UnlockSession(_ kernel.Ctx, uploadID kernel.UploadID, _ string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.locked, uploadID)
+	return nil
+}
+
+func (m *MockUploadStorage) SessionsCount() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return len(m.sessions)
+}
+
+// MockTicketStorage keeps upload tickets in memory.
+type MockTicketStorage struct {
+	mu      sync.Mutex
+	tickets map[string]upload.Ticket
+}
+
+func NewMockTicketStorage() *MockTicketStorage {
+	return &MockTicketStorage{tickets: make(map[string]upload.Ticket)}
+}
+
+func (m *MockTicketStorage) StoreTicket(_ kernel.Ctx, ticket *upload.Ticket) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.tickets[ticket.BucketID+"/"+ticket.ObjectID] = *ticket
+	return nil
+}
+
+func (m *MockTicketStorage) TakeTicket(
+	_ kernel.Ctx,
+	bucketID kernel.BucketID,
+	objID kernel.ObjectID,
+) (*upload.Ticket, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	key := bucketID + "/" + objID
+	ticket, ok := m.tickets[key]
+	if !ok {
+		return nil, upload.ErrTicketNotFound
+	}
+
+	delete(m.tickets, key)
+	return &ticket, nil
+}
+
+func (m *MockTicketStorage) TicketsCount() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return len(m.tickets)
+}
+
+// MockStoredObjectStorage keeps marks of stored objects in memory.
+type MockStoredObjectStorage struct {
+	mu     sync.Mutex
+	stored map[string]bool
+}
+
+func NewMockStoredObjectStorage() *MockStoredObjectStorage {
+	return &MockStoredObjectStorage{stored: make(map[string]bool)}
+}
+
+func (m *MockStoredObjectStorage) MarkStored(_ kernel.Ctx, bucketID kernel.BucketID, objID kernel.ObjectID) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.stored[bucketID+"/"+objID] = true
+	return nil
+}
+
+func (m *MockStoredObjectStorage) TakeStored(_ kernel.Ctx, bucketID kernel.BucketID, objID kernel.ObjectID) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	key := bucketID + "/" + objID
+	stored := m.stored[key]
+	delete(m.stored, key)
+	return stored, nil
+}
+
+func (m *MockStoredObjectStorage) StoredCount() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return len(m.stored)
+}
@@ -0,0 +1,73 @@
+package events_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"watchtower/internal/core/cloud/domain"
+	"watchtower/internal/core/cloud/infrastructure/notify"
+)
+
+const TestMinioNotification = `{
+  "EventName": "s3:ObjectCreated:Put",
+  "Key": "watchtower-test-bucket/incoming/quarterly report.pdf",
+  "Records": [
+    {
+      "eventVersion": "2.0",
+      "eventSource": "minio:s3",
+      "eventTime": "2024-04-05T10:00:00.000Z",
+      "eventName": "s3:ObjectCreated:Put",
+      "s3": {
+        "bucket": {"name": "watchtower-test-bucket"},
+        "object": {
+          "key": "incoming%2Fquarterly+report.pdf",
+          "size": 1024,
+          "eTag": "d41d8cd98f00b204e9800998ecf8427e",
+          "contentType": "application/pdf",
+          "userMetadata": {"X-Amz-Meta-Department": "legal", "content-type": "application/pdf"}
+        }
+      }
+    },
+    {
+      "eventName": "s3:ObjectAccessed:Get",
+      "s3": {"bucket": {"name": "watchtower-test-bucket"}, "object": {"key": "incoming%2Fother.pdf"}}
+    },
+    {
+      "eventName": "ObjectRemoved:Delete",
+      "s3": {"bucket": {"name": "watchtower-test-bucket"}, "object": {"key": "incoming%2Fold.pdf"}}
+    }
+  ]
+}`
+
+func TestParseEvents(t *testing.T) {
+	t.Run("MinIO notification", func(t *testing.T) {
+		events, err := notify.ParseEvents([]byte(TestMinioNotification))
+		assert.NoError(t, err, "failed to parse notification")
+
+		expected := []domain.ObjectEvent{
+			{
+				Type:     domain.ObjectCreated,
+				Name:     "s3:ObjectCreated:Put",
+				BucketID: "watchtower-test-bucket",
+				ObjectID: "incoming/quarterly report.pdf",
+				Size:     1024,
+				ETag:     "d41d8cd98f00b204e9800998ecf8427e",
+				Metadata: map[string]string{"department": "legal"},
+			},
+			{
+				Type:     domain.ObjectRemoved,
+				Name:     "s3:ObjectRemoved:Delete",
+				BucketID: "watchtower-test-bucket",
+				ObjectID: "incoming/old.pdf",
+				Metadata: map[string]string{},
+			},
+		}
+		assert.Equal(t, expected, events)
+	})
+
+	t.Run("Malformed notification", func(t *testing.T) {
+		_, err := notify.ParseEvents([]byte(`{"Records": "none"}`))
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,218 @@
+package fingerprint_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"watchtower/internal/shared/kernel"
+	"watchtower/internal/support/task/application/service/fingerprint"
+	"watchtower/internal/support/task/application/service/recognizer"
+	"watchtower/internal/support/task/domain"
+	"watchtower/internal/support/task/infrastructure/redis"
+	"watchtower/tests/common/mocks"
+
+	taskApp "watchtower/internal/support/task/application"
+)
+
+const (
+	TestBucketName = "watchtower-test-bucket"
+
+	TestContractText = `SUPPLY CONTRACT No. 45/2024
+Moscow, 12 March 2024
+Acme Trading LLC, hereinafter referred to as the Supplier, represented by General Director Ivan Petrov
+acting on the basis of the Charter, on the one hand, and Globex Industries JSC, hereinafter referred to
+as the Buyer, represented by Chief Executive Officer Anna Smirnova acting on the basis of the Charter,
+on the other hand, have concluded this contract as follows.
+1. SUBJECT OF THE CONTRACT
+1.1. The Supplier undertakes to deliver and the Buyer undertakes to accept and pay for industrial
+equipment in accordance with the specification which is an integral part of this contract.
+1.2. The quantity, assortment and price of the equipment are determined by the specification.
+2. PRICE AND PAYMENT
+2.1. The total price of the contract is 1 250 000 rubles including VAT.
+2.2. The Buyer pays 30 percent of the price in advance within five banking days after signing
+of this contract and the remaining amount within ten banking days after delivery.
+3. DELIVERY
+3.1. The equipment is delivered to the warehouse of the Buyer within thirty calendar days
+after the advance payment.
+3.2. Ownership passes to the Buyer upon signing of the delivery note.`
+
+	TestInvoiceText = `INVOICE No. 1289 Date 05.04.2024 Seller Initech LLC Buyer Umbrella Corp
+Item office chairs quantity 40 price 5 400 rubles total 216 000 rubles VAT included
+payment due within 14 days bank details account 40702810900000012345`
+)
+
+func TestSimHash(t *testing.T) {
+	contractHash := fingerprint.SimHash(TestContractText)
+
+	t.Run("Rescans of the same document are near", func(t *testing.T) {
+		rescans := []string{
+			strings.NewReplacer("contract", "contrnct", "Charter", "Charier").Replace(TestContractText),
+			strings.NewReplacer("Buyer", "Buver", "within", "withln", "price", "prlce").Replace(TestContractText),
+			"Page 1 of 2\n" + TestContractText + "\nScanned 2024-03-13",
+		}
+
+		for _, rescan := range rescans {
+			assert.LessOrEqual(t, contractHash.Distance(fingerprint.SimHash(rescan)), 3)
+		}
+	})
+
+	t.Run("Different documents are far", func(t *testing.T) {
+		assert.Greater(t, contractHash.Distance(fingerprint.SimHash(TestInvoiceText)), 10)
+	})
+
+	t.Run("Fingerprint is parsed from string", func(t *testing.T) {
+		parsed, err := fingerprint.ParseFingerprint(contractHash.String())
+		assert.NoError(t, err, "failed to parse fingerprint")
+		assert.Equal(t, contractHash, parsed)
+	})
+}
+
+func TestFingerprintStorageMaxDistance(t *testing.T) {
+	config := redis.Config{Address: "localhost:6379"}
+
+	for _, maxDistance := range []int{0, 3, 10, 63} {
+		_, err := redis.NewFingerprintStorage(config, maxDistance)
+		assert.NoError(t, err, "max distance %d must be accepted", maxDistance)
+	}
+
+	for _, maxDistance := range []int{-1, 64} {
+		_, err := redis.NewFingerprintStorage(config, maxDistance)
+		assert.Error(t, err, "max distance %d must be rejected", maxDistance)
+	}
+}
+
+// memoryFingerprints keeps record of each object in memory with linear search.
+type memoryFingerprints struct {
+	records map[kernel.BucketID]map[kernel.ObjectID]fingerprint.Record
+}
+
+func (mf *memoryFingerprints) FindNearest(
+	_ kernel.Ctx,
+	bucketID kernel.BucketID,
+	objID kernel.ObjectID,
+	value fingerprint.Fingerprint,
+	maxDistance int,
+) (*fingerprint.Record, error) {
+	for _, record := range mf.records[bucketID] {
+		if record.ObjectID != objID && value.Distance(record.Fingerprint) <= maxDistance {
+			return &record, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (mf *memoryFingerprints) StoreRecord(_ kernel.Ctx, bucketID kernel.BucketID, record *fingerprint.Record) error {
+	if mf.records[bucketID] == nil {
+		mf.records[bucketID] = make(map[kernel.ObjectID]fingerprint.Record)
+	}
+	mf.records[bucketID][record.ObjectID] = *record
+	return nil
+}
+
+func (mf *memoryFingerprints) DeleteRecord(_ kernel.Ctx, bucketID kernel.BucketID, objID kernel.ObjectID) error {
+	delete(mf.records[bucketID], objID)
+	return nil
+}
+
+func (mf *memoryFingerprints) MoveRecord(_ kernel.Ctx, bucketID kernel.BucketID, srcObjID, dstObjID kernel.ObjectID) error {
+	record, ok := mf.records[bucketID][srcObjID]
+	if !ok {
+		return nil
+	}
+
+	record.ObjectID = dstObjID
+	delete(mf.records[bucketID], srcObjID)
+	mf.records[bucketID][dstObjID] = record
+	return nil
+}
+
+func TestFindNearDuplicate(t *testing.T) {
+	ctx := context.Background()
+
+	storage := &memoryFingerprints{records: make(map[kernel.BucketID]map[kernel.ObjectID]fingerprint.Record)}
+	taskUseCase := taskApp.NewTaskUseCase(nil, nil, nil, nil, taskApp.WithFingerprints(storage))
+
+	original := domain.CreateNewTask(TestBucketName, "scans/contract.pdf")
+	err := taskUseCase.FindNearDuplicate(ctx, original, &recognizer.Recognized{Text: TestContractText}, 3, fingerprint.LinkAction)
+	assert.NoError(t, err, "failed to find near-duplicate")
+	assert.Nil(t, original.Duplicate)
+	assert.NotEmpty(t, original.Fingerprint)
+
+	err = taskUseCase.StoreFingerprint(ctx, original, "contract-doc-id")
+	assert.NoError(t, err, "failed to store fingerprint")
+
+	rescanText := strings.NewReplacer("contract", "contrnct").Replace(TestContractText)
+	rescan := domain.CreateNewTask(TestBucketName, "scans/contract-rescan.pdf")
+	err = taskUseCase.FindNearDuplicate(ctx, rescan, &recognizer.Recognized{Text: rescanText}, 3, fingerprint.LinkAction)
+	assert.NoError(t, err, "failed to find near-duplicate")
+	assert.NotNil(t, rescan.Duplicate)
+	assert.Equal(t, "scans/contract.pdf", rescan.Duplicate.ObjectID)
+	assert.Equal(t, "contract-doc-id", rescan.Duplicate.DocumentID)
+	assert.True(t, rescan.Duplicate.Linked)
+
+	invoice := domain.CreateNewTask(TestBucketName, "scans/invoice.pdf")
+	err = taskUseCase.FindNearDuplicate(ctx, invoice, &recognizer.Recognized{Text: TestInvoiceText}, 3, fingerprint.ReportAction)
+	assert.NoError(t, err, "failed to find near-duplicate")
+	assert.Nil(t, invoice.Duplicate)
+
+	err = taskUseCase.FindNearDuplicate(ctx, invoice, &recognizer.Recognized{Text: TestInvoiceText}, 3, "delete")
+	assert.Error(t, err)
+
+	reprocessed := domain.CreateNewTask(TestBucketName, "scans/contract.pdf")
+	err = taskUseCase.FindNearDuplicate(ctx, reprocessed, &recognizer.Recognized{Text: TestContractText}, 3, fingerprint.SkipAction)
+	assert.NoError(t, err, "failed to find near-duplicate")
+	assert.Nil(t, reprocessed.Duplicate, "reprocessed object must not match its own fingerprint")
+}
+
+func TestDeleteDocumentsRemovesFingerprint(t *testing.T) {
+	ctx := context.Background()
+
+	storage := &memoryFingerprints{records: make(map[kernel.BucketID]map[kernel.ObjectID]fingerprint.Record)}
+	docStorage := new(mocks.MockDocStorage)
+	docStorage.On("DeleteDocuments", TestBucketName, "scans/contract.pdf").Return(nil)
+	taskUseCase := taskApp.NewTaskUseCase(nil, nil, nil, docStorage, taskApp.WithFingerprints(storage))
+
+	original := domain.CreateNewTask(TestBucketName, "scans/contract.pdf")
+	err := taskUseCase.FindNearDuplicate(ctx, original, &recognizer.Recognized{Text: TestContractText}, 3, fingerprint.SkipAction)
+	assert.NoError(t, err, "failed to find near-duplicate")
+	assert.NoError(t, taskUseCase.StoreFingerprint(ctx, original, "contract-doc-id"))
+
+	assert.NoError(t, taskUseCase.DeleteDocuments(ctx, TestBucketName, "scans/contract.pdf"))
+
+	copied := domain.CreateNewTask(TestBucketName, "scans/contract-copy.pdf")
+	err = taskUseCase.FindNearDuplicate(ctx, copied, &recognizer.Recognized{Text: TestContractText}, 3, fingerprint.SkipAction)
+	assert.NoError(t, err, "failed to find near-duplicate")
+	assert.Nil(t, copied.Duplicate, "fingerprint of removed document must be removed")
+}
+
+func TestMoveDocumentsMovesFingerprint(t *testing.T) {
+	ctx := context.Background()
+
+	storage := &memoryFingerprints{records: make(map[kernel.BucketID]map[kernel.ObjectID]fingerprint.Record)}
+	docStorage := new(mocks.MockDocStorage)
+	docStorage.On("MoveDocuments", TestBucketName, "scans/contract.pdf", "archive/contract.pdf").Return(nil)
+	graphStore := new(mocks.MockGraphStore)
+	graphStore.On("MoveDocument", TestBucketName, "scans/contract.pdf", "archive/contract.pdf").Return(nil)
+	taskUseCase := taskApp.NewTaskUseCase(nil, nil, nil, docStorage,
+		taskApp.WithFingerprints(storage),
+		taskApp.WithKnowledgeGraph(new(mocks.MockEntityExtractor), graphStore),
+	)
+
+	original := domain.CreateNewTask(TestBucketName, "scans/contract.pdf")
+	err := taskUseCase.FindNearDuplicate(ctx, original, &recognizer.Recognized{Text: TestContractText}, 3, fingerprint.SkipAction)
+	assert.NoError(t, err, "failed to find near-duplicate")
+	assert.NoError(t, taskUseCase.StoreFingerprint(ctx, original, "contract-doc-id"))
+
+	err = taskUseCase.MoveDocuments(ctx, TestBucketName, "scans/contract.pdf", "archive/contract.pdf")
+	assert.NoError(t, err, "failed to move documents")
+	graphStore.AssertExpectations(t)
+
+	reprocessed := domain.CreateNewTask(TestBucketName, "archive/contract.pdf")
+	err = taskUseCase.FindNearDuplicate(ctx, reprocessed, &recognizer.Recognized{Text: TestContractText}, 3, fingerprint.SkipAction)
+	assert.NoError(t, err, "failed to find near-duplicate")
+	assert.Nil(t, reprocessed.Duplicate, "moved object must not match its own fingerprint")
+}
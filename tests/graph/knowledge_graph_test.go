@@ -0,0 +1,172 @@
+package graph_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"watchtower/internal/support/task/application/service/entity"
+	"watchtower/internal/support/task/application/service/graph"
+	"watchtower/internal/support/task/infrastructure/neo4j"
+	"watchtower/internal/support/task/infrastructure/ner"
+)
+
+const (
+	TestDocumentID = "document-id"
+	TestIndex      = "watchtower-test-bucket"
+	TestFilePath   = "contracts/supply.txt"
+	TestText       = "Acme supplies Globex"
+)
+
+func TestNerClient(t *testing.T) {
+	ctx := context.Background()
+
+	var received ner.ExtractForm
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, ner.ExtractionURL, r.URL.Path)
+		assert.Equal(t, ner.ExtractJsonMime, r.Header.Get("Content-Type"))
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+
+		extracted := ner.ExtractedContent{
+			Entities: []ner.EntityDto{
+				{Name: "Acme", Type: "ORG"},
+				{Name: "Globex", Type: "ORG"},
+			},
+			Relations: []ner.RelationDto{
+				{Source: "Acme", Target: "Globex", Type: "SUPPLIES"},
+			},
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(extracted)
+	}))
+	defer server.Close()
+
+	extractor := ner.New(ner.Config{Address: server.URL, Timeout: 10})
+	extracted, err := extractor.Extract(ctx, &entity.ExtractParams{FileName: TestFilePath, Text: TestText})
+	assert.NoError(t, err, "failed to extract entities")
+
+	assert.Equal(t, ner.ExtractForm{FileName: TestFilePath, Text: TestText}, received)
+	assert.Equal(t, []entity.Entity{{Name: "Acme", Type: "ORG"}, {Name: "Globex", Type: "ORG"}}, extracted.Entities)
+	assert.Equal(t, []entity.Relation{{Source: "Acme", Target: "Globex", Type: "SUPPLIES"}}, extracted.Relations)
+}
+
+func TestNeo4jClient(t *testing.T) {
+	ctx := context.Background()
+
+	docGraph := &graph.Graph{
+		DocumentID: TestDocumentID,
+		Index:      TestIndex,
+		Path:       TestFilePath,
+		Nodes: []graph.Node{
+			{Name: "Acme", Type: "ORG"},
+			{Name: "Globex", Type: "ORG"},
+			{Name: "Globex", Type: "PRODUCT"},
+		},
+		Edges: []graph.Edge{
+			{Source: "Acme", Target: "Globex", Type: "SUPPLIES"},
+			{Source: "Acme", Target: "Initech", Type: "OWNS"},
+		},
+	}
+
+	newServer := func(t *testing.T, result neo4j.TransactionResult, received *neo4j.TransactionForm) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			username, password, ok := r.BasicAuth()
+			assert.True(t, ok, "basic auth must be set")
+			assert.Equal(t, "neo4j", username)
+			assert.Equal(t, "secret", password)
+			assert.Equal(t, "/db/graph/tx/commit", r.URL.Path)
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(received))
+
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(result)
+		}))
+	}
+
+	t.Run("Store document graph", func(t *testing.T) {
+		var received neo4j.TransactionForm
+		server := newServer(t, neo4j.TransactionResult{}, &received)
+		defer server.Close()
+
+		graphStore := neo4j.New(neo4j.Config{
+			Address:  server.URL,
+			Database: "graph",
+			Username: "neo4j",
+			Password: "secret",
+			Timeout:  10,
+		})
+		assert.NoError(t, graphStore.StoreGraph(ctx, docGraph), "failed to store graph")
+
+		assert.Len(t, received.Statements, 2)
+		nodesParams := received.Statements[0].Parameters
+		assert.Equal(t, TestDocumentID, nodesParams["document_id"])
+		assert.Equal(t, TestIndex, nodesParams["index"])
+		assert.Equal(t, TestFilePath, nodesParams["path"])
+		assert.Len(t, nodesParams["nodes"], 3)
+
+		// Relation is linked to entities of both types named Globex,
+		// relation of entity which is not mentioned is dropped.
+		edgesParams := received.Statements[1].Parameters
+		assert.Equal(t, TestDocumentID, edgesParams["document_id"])
+		assert.Equal(t, []any{
+			map[string]any{
+				"source": "Acme", "source_type": "ORG",
+				"target": "Globex", "target_type": "ORG",
+				"type": "SUPPLIES",
+			},
+			map[string]any{
+				"source": "Acme", "source_type": "ORG",
+				"target": "Globex", "target_type": "PRODUCT",
+				"type": "SUPPLIES",
+			},
+		}, edgesParams["edges"])
+		assert.Contains(t, received.Statements[1].Statement, "MENTIONS")
+	})
+
+	t.Run("Move document", func(t *testing.T) {
+		var received neo4j.TransactionForm
+		server := newServer(t, neo4j.TransactionResult{}, &received)
+		defer server.Close()
+
+		graphStore := neo4j.New(neo4j.Config{
+			Address:  server.URL,
+			Database: "graph",
+			Username: "neo4j",
+			Password: "secret",
+			Timeout:  10,
+		})
+		err := graphStore.MoveDocument(ctx, TestIndex, TestFilePath, "archive/supply.txt")
+		assert.NoError(t, err, "failed to move document")
+
+		assert.Len(t, received.Statements, 1)
+		assert.Equal(t, map[string]any{
+			"index":    TestIndex,
+			"src_path": TestFilePath,
+			"dst_path": "archive/supply.txt",
+		}, received.Statements[0].Parameters)
+	})
+
+	t.Run("Rolled back transaction", func(t *testing.T) {
+		var received neo4j.TransactionForm
+		result := neo4j.TransactionResult{
+			Errors: []neo4j.TransactionError{{Code: "Neo.ClientError.Statement.SyntaxError", Message: "invalid"}},
+		}
+		server := newServer(t, result, &received)
+		defer server.Close()
+
+		graphStore := neo4j.New(neo4j.Config{
+			Address:  server.URL,
+			Database: "graph",
+			Username: "neo4j",
+			Password: "secret",
+			Timeout:  10,
+		})
+		err := graphStore.StoreGraph(ctx, docGraph)
+		assert.ErrorContains(t, err, "Neo.ClientError.Statement.SyntaxError")
+	})
+}
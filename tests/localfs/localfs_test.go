@@ -0,0 +1,459 @@
+package localfs_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+
+	"watchtower/cmd"
+	"watchtower/cmd/watchtower/httpserver"
+	"watchtower/internal/core/cloud/domain"
+	"watchtower/internal/core/cloud/infrastructure/localfs"
+	"watchtower/internal/process"
+	"watchtower/tests/common/mocks"
+
+	cloudApp "watchtower/internal/core/cloud/application"
+	taskApp "watchtower/internal/support/task/application"
+)
+
+const (
+	TestBucketName = "watchtower-test-bucket"
+	TestSignKey    = "test-sign-key"
+)
+
+func initStorage(t *testing.T) domain.ICloudStorage {
+	config := localfs.Config{
+		RootDir:   t.TempDir(),
+		PublicURL: "http://localhost:2893",
+		SignKey:   TestSignKey,
+	}
+
+	storage, err := localfs.New(config)
+	assert.NoError(t, err, "failed to init local storage")
+
+	err = storage.CreateBucket(context.Background(), TestBucketName)
+	assert.NoError(t, err, "failed to create bucket")
+
+	return storage
+}
+
+func storeObject(t *testing.T, storage domain.ICloudStorage, filePath, data string) {
+	params := &domain.UploadObjectParams{
+		FilePath: filePath,
+		FileData: bytes.NewBufferString(data),
+		Metadata: map[string]string{"department": "legal"},
+	}
+
+	_, err := storage.StoreObject(context.Background(), TestBucketName, params)
+	assert.NoError(t, err, "failed to store object")
+}
+
+func listPaths(t *testing.T, storage domain.ICloudStorage, prefix string) []string {
+	params := &domain.GetObjectsParams{PrefixPath: prefix}
+	objects, err := storage.GetBucketObjects(context.Background(), TestBucketName, params)
+	assert.NoError(t, err, "failed to list objects")
+
+	paths := make([]string, 0, len(objects))
+	for _, obj := range objects {
+		paths = append(paths, obj.Path)
+	}
+	return paths
+}
+
+func TestLocalStorage(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Buckets", func(t *testing.T) {
+		storage := initStorage(t)
+
+		buckets, err := storage.GetAllBuckets(ctx)
+		assert.NoError(t, err)
+		assert.Len(t, buckets, 1, "metadata directory must not be listed as bucket")
+		assert.Equal(t, TestBucketName, buckets[0].ID)
+
+		exist, err := storage.IsBucketExist(ctx, "unknown")
+		assert.NoError(t, err)
+		assert.False(t, exist)
+
+		assert.Error(t, storage.CreateBucket(ctx, "../escape"), "bucket must not escape root dir")
+
+		storeObject(t, storage, "report.txt", "quarterly report")
+		assert.Error(t, storage.DeleteBucket(ctx, TestBucketName), "non-empty bucket must not be deleted")
+	})
+
+	t.Run("Objects", func(t *testing.T) {
+		storage := initStorage(t)
+		storeObject(t, storage, "incoming/2024/report.txt", "quarterly report")
+		storeObject(t, storage, "incoming/invoice.txt", "invoice")
+		storeObject(t, storage, "readme.txt", "readme")
+
+		assert.Equal(t, []string{"incoming/", "readme.txt"}, listPaths(t, storage, ""))
+		assert.Equal(t, []string{"incoming/2024/", "incoming/invoice.txt"}, listPaths(t, storage, "incoming/"))
+		assert.Equal(t, []string{"incoming/invoice.txt"}, listPaths(t, storage, "./incoming/inv"))
+
+		objInfo, err := storage.GetObjectInfo(ctx, TestBucketName, "incoming/invoice.txt")
+		assert.NoError(t, err)
+		assert.Equal(t, int64(len("invoice")), objInfo.Size)
+		assert.Equal(t, "text/plain; charset=utf-8", objInfo.ContentType)
+		assert.Equal(t, map[string]string{"department": "legal"}, objInfo.Metadata)
+		assert.NotEmpty(t, objInfo.ETag)
+
+		_, err = storage.GetObjectData(ctx, TestBucketName, "../../etc/passwd")
+		assert.Error(t, err, "object path must not escape bucket")
+
+		copyParams := &domain.CopyObjectParams{
+			SourcePath:      "incoming/invoice.txt",
+			DestinationPath: "processed/incoming/invoice.txt",
+			WithRemoving:    true,
+			Metadata:        map[string]string{"status": "done"},
+		}
+		assert.NoError(t, storage.CopyObject(ctx, TestBucketName, copyParams))
+
+		movedInfo, err := storage.GetObjectInfo(ctx, TestBucketName, "processed/incoming/invoice.txt")
+		assert.NoError(t, err)
+		assert.Equal(t, objInfo.ETag, movedInfo.ETag)
+		assert.Equal(t, map[string]string{"status": "done"}, movedInfo.Metadata)
+
+		_, err = storage.GetObjectInfo(ctx, TestBucketName, "incoming/invoice.txt")
+		assert.Error(t, err, "source object must be removed")
+
+		fileData, err := storage.GetObjectData(ctx, TestBucketName, "processed/incoming/invoice.txt")
+		assert.NoError(t, err)
+		assert.Equal(t, "invoice", fileData.String())
+
+		assert.NoError(t, storage.DeleteObjects(ctx, TestBucketName, "incoming/"))
+		assert.Equal(t, []string{"processed/", "readme.txt"}, listPaths(t, storage, ""))
+
+		assert.NoError(t, storage.DeleteObject(ctx, TestBucketName, "processed/incoming/invoice.txt"))
+		assert.Equal(t, []string{"readme.txt"}, listPaths(t, storage, ""))
+	})
+	t.Run("Multipart upload", func(t *testing.T) {
+		storage := initStorage(t)
+
+		params := &domain.UploadObjectParams{
+			FilePath:    "incoming/report.txt",
+			ContentType: "text/plain",
+			Metadata:    map[string]string{"department": "legal"},
+		}
+		uploadID, err := storage.CreateMultipartUpload(ctx, TestBucketName, params)
+		assert.NoError(t, err, "failed to create multipart upload")
+
+		first, err := storage.UploadPart(ctx, TestBucketName, params.FilePath, uploadID, 1, bytes.NewBufferString("hello"))
+		assert.NoError(t, err, "failed to upload part")
+		second, err := storage.UploadPart(ctx, TestBucketName, params.FilePath, uploadID, 2, bytes.NewBufferString(" world"))
+		assert.NoError(t, err, "failed to upload part")
+
+		uploaded, err := storage.GetUploadedPart(ctx, TestBucketName, params.FilePath, uploadID, 1)
+		assert.NoError(t, err, "failed to get uploaded part")
+		assert.Equal(t, &first, uploaded)
+		uploaded, err = storage.GetUploadedPart(ctx, TestBucketName, params.FilePath, uploadID, 3)
+		assert.NoError(t, err, "failed to get uploaded part")
+		assert.Nil(t, uploaded)
+
+		parts := []domain.MultipartPart{first, second}
+		assert.NoError(t, storage.CompleteMultipartUpload(ctx, TestBucketName, params.FilePath, uploadID, parts))
+
+		objInfo, err := storage.GetObjectInfo(ctx, TestBucketName, params.FilePath)
+		assert.NoError(t, err)
+		assert.Equal(t, "text/plain", objInfo.ContentType)
+		assert.Equal(t, map[string]string{"department": "legal"}, objInfo.Metadata)
+
+		fileData, err := storage.GetObjectData(ctx, TestBucketName, params.FilePath)
+		assert.NoError(t, err)
+		assert.Equal(t, "hello world", fileData.String())
+
+		abortedID, err := storage.CreateMultipartUpload(ctx, TestBucketName, params)
+		assert.NoError(t, err, "failed to create multipart upload")
+		assert.NoError(t, storage.AbortMultipartUpload(ctx, TestBucketName, params.FilePath, abortedID))
+		assert.Error(t, storage.AbortMultipartUpload(ctx, TestBucketName, params.FilePath, abortedID))
+		assert.Equal(t, []string{"incoming/"}, listPaths(t, storage, ""), "uploads must not be listed")
+	})
+	t.Run("Paginated listing", func(t *testing.T) {
+		storage := initStorage(t)
+		storeObject(t, storage, "incoming/b.txt", "b")
+		storeObject(t, storage, "incoming/a.txt", "a")
+		storeObject(t, storage, "incoming/nested/c.txt", "c")
+		storeObject(t, storage, "incoming/d.txt", "d")
+
+		params := &domain.GetObjectsParams{PrefixPath: "incoming/", Limit: 2}
+		page, err := storage.GetBucketObjectsPage(ctx, TestBucketName, params)
+		assert.NoError(t, err)
+		assert.Len(t, page.Objects, 2)
+		assert.Equal(t, "incoming/a.txt", page.Objects[0].Path)
+		assert.Equal(t, "incoming/b.txt", page.NextToken)
+
+		params.ContinuationToken = page.NextToken
+		page, err = storage.GetBucketObjectsPage(ctx, TestBucketName, params)
+		assert.NoError(t, err)
+		assert.Equal(t, "incoming/d.txt", page.Objects[0].Path)
+		assert.Equal(t, "incoming/nested/", page.Objects[1].Path)
+		assert.Empty(t, page.NextToken, "last page must not have next token")
+
+		params = &domain.GetObjectsParams{PrefixPath: "incoming/", Offset: 3, Limit: 2}
+		page, err = storage.GetBucketObjectsPage(ctx, TestBucketName, params)
+		assert.NoError(t, err)
+		assert.Len(t, page.Objects, 1)
+		assert.Empty(t, page.NextToken)
+
+		// Offset shifts only the first page.
+		params = &domain.GetObjectsParams{PrefixPath: "incoming/", Offset: 1, Limit: 1}
+		page, err = storage.GetBucketObjectsPage(ctx, TestBucketName, params)
+		assert.NoError(t, err)
+		assert.Equal(t, "incoming/b.txt", page.Objects[0].Path)
+
+		params.ContinuationToken = page.NextToken
+		page, err = storage.GetBucketObjectsPage(ctx, TestBucketName, params)
+		assert.NoError(t, err)
+		assert.Equal(t, "incoming/d.txt", page.Objects[0].Path)
+	})
+
+	t.Run("Paginated listing of directories", func(t *testing.T) {
+		storage := initStorage(t)
+		storeObject(t, storage, "incoming/.keeper", "")
+		storeObject(t, storage, "incoming/nested/a.txt", "a")
+		storeObject(t, storage, "incoming/nested/b.txt", "b")
+		storeObject(t, storage, "incoming/nested0.txt", "c")
+
+		params := &domain.GetObjectsParams{PrefixPath: "incoming/", Limit: 1, SkipNames: []string{".keeper"}}
+		page, err := storage.GetBucketObjectsPage(ctx, TestBucketName, params)
+		assert.NoError(t, err)
+		assert.Len(t, page.Objects, 1)
+		assert.Equal(t, "incoming/nested/", page.Objects[0].Path, "skipped file must not take place on page")
+		assert.Equal(t, "incoming/nested/"+string(utf8.MaxRune), page.NextToken)
+
+		params.ContinuationToken = page.NextToken
+		page, err = storage.GetBucketObjectsPage(ctx, TestBucketName, params)
+		assert.NoError(t, err)
+		assert.Len(t, page.Objects, 1)
+		assert.Equal(t, "incoming/nested0.txt", page.Objects[0].Path, "page must advance past directory")
+		assert.Empty(t, page.NextToken)
+	})
+
+	t.Run("Recursive search", func(t *testing.T) {
+		storage := initStorage(t)
+		storeObject(t, storage, "incoming/report.txt", "report")
+		storeObject(t, storage, "incoming/nested/deep/notes.txt", "nested notes")
+		storeObject(t, storage, "incoming/nested/image.png", "\x89PNG\r\n\x1a\n")
+		storeObject(t, storage, "incomplete/report.txt", "other")
+		storeObject(t, storage, "archive/report.txt", "archived")
+
+		search := func(params *domain.SearchObjectsParams) []string {
+			filter, err := cloudApp.NewObjectFilter(params)
+			assert.NoError(t, err, "failed to create filter")
+
+			paths := make([]string, 0)
+			err = cloudApp.NewStorageUseCase(storage).SearchObjects(ctx, TestBucketName, filter, func(obj domain.Object) error {
+				paths = append(paths, obj.Path)
+				return nil
+			})
+			assert.NoError(t, err, "failed to search objects")
+			return paths
+		}
+
+		paths := search(&domain.SearchObjectsParams{PrefixPath: "incom"})
+		assert.Equal(t, []string{
+			"incoming/nested/deep/notes.txt",
+			"incoming/nested/image.png",
+			"incoming/report.txt",
+			"incomplete/report.txt",
+		}, paths)
+
+		minSize := int64(7)
+		paths = search(&domain.SearchObjectsParams{PrefixPath: "incoming/", Glob: "*.txt", MinSize: &minSize})
+		assert.Equal(t, []string{"incoming/nested/deep/notes.txt"}, paths)
+
+		paths = search(&domain.SearchObjectsParams{ContentType: "image/*", Metadata: map[string]string{"Department": "legal"}})
+		assert.Equal(t, []string{"incoming/nested/image.png"}, paths)
+
+		paths = search(&domain.SearchObjectsParams{Regex: `^(archive|incomplete)/`, Limit: 1})
+		assert.Equal(t, []string{"archive/report.txt"}, paths)
+
+		_, err := cloudApp.NewObjectFilter(&domain.SearchObjectsParams{Regex: "report("})
+		assert.ErrorIs(t, err, domain.ErrInvalidSearchFilter)
+	})
+
+	t.Run("Prefix traversal", func(t *testing.T) {
+		tempDir := t.TempDir()
+		secretDir := filepath.Join(tempDir, "secret-dir")
+		assert.NoError(t, os.MkdirAll(secretDir, 0o755))
+		assert.NoError(t, os.WriteFile(filepath.Join(secretDir, "passwd"), []byte("secret"), 0o600))
+
+		storage, err := localfs.New(localfs.Config{RootDir: filepath.Join(tempDir, "root"), SignKey: TestSignKey})
+		assert.NoError(t, err, "failed to init local storage")
+		assert.NoError(t, storage.CreateBucket(ctx, TestBucketName))
+		storeObject(t, storage, "secret-dir/report.txt", "report")
+
+		for _, prefix := range []string{"../../secret-dir/", "/../../secret-dir/", "secret-dir/../../../secret-dir/"} {
+			assert.Equal(t, []string{"secret-dir/report.txt"}, listPaths(t, storage, prefix))
+
+			walked := make([]string, 0)
+			err = storage.WalkBucketObjects(ctx, TestBucketName, prefix, func(obj domain.Object) error {
+				walked = append(walked, obj.Path)
+				return nil
+			})
+			assert.NoError(t, err)
+			assert.Equal(t, []string{"secret-dir/report.txt"}, walked, "prefix must not escape bucket")
+		}
+
+		assert.Empty(t, listPaths(t, storage, "../../secret-dir/pa"))
+
+		assert.NoError(t, storage.DeleteObjects(ctx, TestBucketName, "../../secret-dir/"))
+		_, err = os.Stat(filepath.Join(secretDir, "passwd"))
+		assert.NoError(t, err, "file outside of root must not be removed")
+	})
+
+	t.Run("Move into another bucket", func(t *testing.T) {
+		storage := initStorage(t)
+		storeObject(t, storage, "reports/report.txt", "report")
+		assert.NoError(t, storage.CreateBucket(ctx, "archive"))
+
+		copyParams := &domain.CopyObjectParams{
+			SourcePath:        "reports/report.txt",
+			DestinationPath:   "reports/report.txt",
+			DestinationBucket: "archive",
+			WithRemoving:      true,
+			Metadata:          map[string]string{"department": "legal"},
+		}
+		assert.NoError(t, storage.CopyObject(ctx, TestBucketName, copyParams))
+
+		obj, err := storage.GetObjectInfo(ctx, "archive", "reports/report.txt")
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]string{"department": "legal"}, obj.Metadata)
+
+		_, err = storage.GetObjectInfo(ctx, TestBucketName, "reports/report.txt")
+		assert.Error(t, err, "source object must be removed by moving")
+	})
+
+	t.Run("Tags and metadata", func(t *testing.T) {
+		storage := initStorage(t)
+		storeObject(t, storage, "tagged/report.txt", "report")
+
+		tags := map[string]string{"project": "alpha", "stage": "draft"}
+		assert.NoError(t, storage.SetObjectTags(ctx, TestBucketName, "tagged/report.txt", tags))
+
+		objTags, err := storage.GetObjectTags(ctx, TestBucketName, "tagged/report.txt")
+		assert.NoError(t, err)
+		assert.Equal(t, tags, objTags)
+
+		tooManyTags := make(map[string]string)
+		for index := range domain.MaxObjectTags + 1 {
+			tooManyTags[fmt.Sprintf("tag-%d", index)] = "value"
+		}
+		err = storage.SetObjectTags(ctx, TestBucketName, "tagged/report.txt", tooManyTags)
+		assert.ErrorIs(t, err, domain.ErrInvalidObjectTags)
+
+		metadata := map[string]string{"department": "finance"}
+		assert.NoError(t, storage.ReplaceObjectMetadata(ctx, TestBucketName, "tagged/report.txt", metadata))
+
+		obj, err := storage.GetObjectInfo(ctx, TestBucketName, "tagged/report.txt")
+		assert.NoError(t, err)
+		assert.Equal(t, metadata, obj.Metadata)
+		assert.Equal(t, tags, obj.Tags, "tags must be kept by metadata replacing")
+		assert.Equal(t, "text/plain; charset=utf-8", obj.ContentType)
+
+		copyParams := &domain.CopyObjectParams{SourcePath: "tagged/report.txt", DestinationPath: "tagged/copy.txt"}
+		assert.NoError(t, storage.CopyObject(ctx, TestBucketName, copyParams))
+		objTags, err = storage.GetObjectTags(ctx, TestBucketName, "tagged/copy.txt")
+		assert.NoError(t, err)
+		assert.Equal(t, tags, objTags, "tags must be copied")
+
+		assert.NoError(t, storage.DeleteObjectTags(ctx, TestBucketName, "tagged/report.txt"))
+		objTags, err = storage.GetObjectTags(ctx, TestBucketName, "tagged/report.txt")
+		assert.NoError(t, err)
+		assert.Empty(t, objTags)
+
+		_, err = storage.GetObjectTags(ctx, TestBucketName, "tagged/missing.txt")
+		assert.Error(t, err)
+	})
+
+	t.Run("Bucket config is not supported", func(t *testing.T) {
+		storage := initStorage(t)
+
+		_, err := storage.GetBucketConfig(ctx, TestBucketName)
+		assert.ErrorIs(t, err, domain.ErrBucketConfigNotSupported)
+
+		versioning := true
+		config := &domain.BucketConfig{Versioning: &versioning}
+		assert.ErrorIs(t, storage.SetBucketConfig(ctx, TestBucketName, config), domain.ErrBucketConfigNotSupported)
+		assert.ErrorIs(t, storage.CreateBucketWithConfig(ctx, "versioned", config), domain.ErrBucketConfigNotSupported)
+
+		exists, err := storage.IsBucketExist(ctx, "versioned")
+		assert.NoError(t, err)
+		assert.False(t, exists, "bucket must not be created with unsupported config")
+
+		assert.NoError(t, storage.CreateBucketWithConfig(ctx, "plain", &domain.BucketConfig{}))
+	})
+
+	t.Run("Versions are not supported", func(t *testing.T) {
+		storage := initStorage(t)
+
+		_, err := storage.GetObjectVersions(ctx, TestBucketName, "report.txt")
+		assert.ErrorIs(t, err, domain.ErrVersioningNotSupported)
+
+		_, err = storage.GetObjectVersionData(ctx, TestBucketName, "report.txt", "v1")
+		assert.ErrorIs(t, err, domain.ErrVersioningNotSupported)
+
+		params := &domain.RestoreObjectParams{FilePath: "report.txt", VersionID: "v1"}
+		_, err = storage.RestoreObjectVersion(ctx, TestBucketName, params)
+		assert.ErrorIs(t, err, domain.ErrVersioningNotSupported)
+	})
+}
+
+// nolint
+func TestLocalStorageShareURL(t *testing.T) {
+	ctx := context.Background()
+
+	servConfig, err := cmd.InitConfig()
+	assert.NoError(t, err, "failed to read config file")
+
+	storage := initStorage(t)
+	storeObject(t, storage, "shared/quarterly report.txt", "quarterly report")
+
+	storageUseCase := cloudApp.NewStorageUseCase(storage)
+	taskUseCase := taskApp.NewTaskUseCase(new(mocks.MockTaskStorage), new(mocks.MockTaskQueue), nil, nil)
+	orchestrator := process.NewOrchestrator(servConfig.Orchestrator, storageUseCase, taskUseCase)
+	appServer := httpserver.SetupServer(servConfig.Otlp, orchestrator)
+
+	shareParams := &domain.ShareObjectParams{FilePath: "shared/quarterly report.txt", Expired: time.Hour}
+	sharedURL, err := storageUseCase.GenShareURL(ctx, TestBucketName, shareParams)
+	assert.NoError(t, err, "failed to generate share url")
+
+	t.Run("Valid signature", func(t *testing.T) {
+		req := httptest.NewRequestWithContext(ctx, http.MethodGet, sharedURL, nil)
+		resp, err := appServer.Server.Test(req, -1)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		body, _ := io.ReadAll(resp.Body)
+		assert.Equal(t, "quarterly report", string(body))
+	})
+
+	t.Run("Tampered signature", func(t *testing.T) {
+		parsed, _ := url.Parse(sharedURL)
+		query := parsed.Query()
+		query.Set("expires", "9999999999")
+		parsed.RawQuery = query.Encode()
+
+		req := httptest.NewRequestWithContext(ctx, http.MethodGet, parsed.String(), nil)
+		resp, err := appServer.Server.Test(req, -1)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+	})
+
+	t.Run("Expired signature", func(t *testing.T) {
+		verifier := storage.(domain.IShareVerifier)
+		err := verifier.VerifyShareSignature(TestBucketName, "shared/quarterly report.txt", time.Now().Add(-time.Minute).Unix(), "")
+		assert.ErrorIs(t, err, domain.ErrInvalidShareSignature)
+	})
+}
@@ -0,0 +1,100 @@
+package pii_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"watchtower/internal/support/task/application/service/pii"
+	"watchtower/internal/support/task/infrastructure/piiscan"
+)
+
+func TestPiiScanner(t *testing.T) {
+	ctx := context.Background()
+
+	detector, err := piiscan.New(piiscan.Config{})
+	assert.NoError(t, err, "failed to create detector")
+
+	var piiScannerTestCases = []struct {
+		Name           string
+		Text           string
+		ExpectedCounts map[string]int
+		ExpectedText   string
+	}{
+		{
+			Name:           "Valid card number",
+			Text:           "Paid by card 4111 1111 1111 1111 yesterday",
+			ExpectedCounts: map[string]int{pii.CardType: 1},
+			ExpectedText:   "Paid by card [CARD] yesterday",
+		},
+		{
+			Name:           "Card number with invalid checksum",
+			Text:           "Order 4111 1111 1111 1112 has been shipped",
+			ExpectedCounts: map[string]int{},
+			ExpectedText:   "Order 4111 1111 1111 1112 has been shipped",
+		},
+		{
+			Name:           "Email and phone",
+			Text:           "Contact ivan.petrov@example.com or +7 (999) 123-45-67",
+			ExpectedCounts: map[string]int{pii.EmailType: 1, pii.PhoneType: 1},
+			ExpectedText:   "Contact [EMAIL] or [PHONE]",
+		},
+		{
+			Name:           "Passport number",
+			Text:           "Passport 45 10 № 123456 issued in 2015",
+			ExpectedCounts: map[string]int{pii.PassportType: 1},
+			ExpectedText:   "Passport [PASSPORT] issued in 2015",
+		},
+		{
+			Name:           "Text without personal data",
+			Text:           "Total amount is 1200 for 3 items",
+			ExpectedCounts: map[string]int{},
+			ExpectedText:   "Total amount is 1200 for 3 items",
+		},
+	}
+
+	for _, testCase := range piiScannerTestCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			matches, err := detector.Detect(ctx, testCase.Text)
+			assert.NoError(t, err, "failed to detect personal data")
+			assert.Equal(t, testCase.ExpectedCounts, pii.CountByType(matches))
+			assert.Equal(t, testCase.ExpectedText, pii.Redact(testCase.Text, matches))
+		})
+	}
+}
+
+func TestPiiScannerConfig(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Custom pattern", func(t *testing.T) {
+		config := piiscan.Config{
+			Patterns: []piiscan.Pattern{{Type: "snils", Regex: `\b\d{3}-\d{3}-\d{3} \d{2}\b`}},
+		}
+
+		detector, err := piiscan.New(config)
+		assert.NoError(t, err, "failed to create detector")
+
+		matches, err := detector.Detect(ctx, "SNILS 112-233-445 95, email a@b.io")
+		assert.NoError(t, err, "failed to detect personal data")
+		assert.Equal(t, map[string]int{"snils": 1}, pii.CountByType(matches))
+	})
+
+	t.Run("Invalid regex", func(t *testing.T) {
+		config := piiscan.Config{
+			Patterns: []piiscan.Pattern{{Type: pii.PhoneType, Regex: `(\d{3}`}},
+		}
+
+		_, err := piiscan.New(config)
+		assert.Error(t, err)
+	})
+
+	t.Run("Unknown checksum", func(t *testing.T) {
+		config := piiscan.Config{
+			Patterns: []piiscan.Pattern{{Type: pii.CardType, Regex: `\d{16}`, Checksum: "crc32"}},
+		}
+
+		_, err := piiscan.New(config)
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,110 @@
+package process_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"watchtower/cmd"
+	"watchtower/internal/core/cloud/domain"
+	"watchtower/internal/process"
+	"watchtower/tests/common/mocks"
+
+	cloudApp "watchtower/internal/core/cloud/application"
+	taskApp "watchtower/internal/support/task/application"
+)
+
+// nolint
+func TestEventArchiveExpanding(t *testing.T) {
+	ctx := context.Background()
+
+	servConfig, err := cmd.InitConfig()
+	assert.NoError(t, err, "failed to read config file")
+
+	servConfig.Orchestrator.Events = process.EventsConfig{StageConfig: process.StageConfig{Enabled: true}}
+	servConfig.Orchestrator.Archive = process.ArchiveConfig{
+		StageConfig: process.StageConfig{Enabled: true},
+		MaxEntries:  10,
+	}
+
+	archiveData := bytes.NewBuffer(nil)
+	zipWriter := zip.NewWriter(archiveData)
+	for _, name := range []string{"contract-1.txt", "scans/contract-2.txt"} {
+		entryWriter, err := zipWriter.Create(name)
+		assert.NoError(t, err, "failed to create zip entry")
+		_, err = entryWriter.Write([]byte("contract"))
+		assert.NoError(t, err, "failed to write zip entry")
+	}
+	assert.NoError(t, zipWriter.Close(), "failed to close zip archive")
+
+	var eventArchiveTestCases = []struct {
+		Name              string
+		ObjectID          string
+		ObjectData        []byte
+		ExpectedStored    []string
+		ExpectedPublished int
+	}{
+		{
+			Name:              "Archive is expanded",
+			ObjectID:          "incoming/contracts.zip",
+			ObjectData:        archiveData.Bytes(),
+			ExpectedStored:    []string{"incoming/contracts/contract-1.txt", "incoming/contracts/scans/contract-2.txt"},
+			ExpectedPublished: 2,
+		},
+		{
+			Name:              "File with archive extension is processed",
+			ObjectID:          "incoming/broken.zip",
+			ObjectData:        []byte("not an archive"),
+			ExpectedPublished: 1,
+		},
+		{
+			Name:              "Other files are not loaded",
+			ObjectID:          "incoming/report.docx",
+			ExpectedPublished: 1,
+		},
+	}
+
+	for _, testCase := range eventArchiveTestCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			objectStorage := new(mocks.MockObjectStorage)
+			taskStorage := new(mocks.MockTaskStorage)
+			taskQueue := new(mocks.MockTaskQueue)
+
+			var stored []string
+			objectStorage.
+				On("GetObjectData", TestBucketName, testCase.ObjectID).
+				Return(bytes.NewBuffer(testCase.ObjectData), nil)
+			objectStorage.
+				On("StoreObject", TestBucketName, mock.Anything).
+				Run(func(args mock.Arguments) {
+					stored = append(stored, args.Get(1).(*domain.UploadObjectParams).FilePath)
+				}).
+				Return("incoming/contracts/entry", nil)
+			objectStorage.On("DeleteObject", TestBucketName, testCase.ObjectID).Return(nil)
+
+			taskStorage.On("UpdateTask", mock.Anything).Return(nil)
+			taskQueue.On("Publish", mock.Anything).Return(nil)
+
+			storageUseCase := cloudApp.NewStorageUseCase(objectStorage)
+			taskUseCase := taskApp.NewTaskUseCase(taskStorage, taskQueue, nil, nil)
+			orchestrator := process.NewOrchestrator(servConfig.Orchestrator, storageUseCase, taskUseCase)
+
+			event := &domain.ObjectEvent{
+				Type:     domain.ObjectCreated,
+				BucketID: TestBucketName,
+				ObjectID: testCase.ObjectID,
+			}
+			assert.NoError(t, orchestrator.HandleObjectEvent(ctx, event))
+
+			assert.Equal(t, testCase.ExpectedStored, stored)
+			taskQueue.AssertNumberOfCalls(t, "Publish", testCase.ExpectedPublished)
+			if testCase.ObjectData == nil {
+				objectStorage.AssertNotCalled(t, "GetObjectData", mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
@@ -0,0 +1,127 @@
+package process_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"watchtower/cmd"
+	"watchtower/internal/core/cloud/domain"
+	"watchtower/internal/process"
+	"watchtower/internal/support/task/application/mapping"
+	"watchtower/internal/support/task/application/service/entity"
+	"watchtower/internal/support/task/application/service/graph"
+	"watchtower/internal/support/task/application/service/recognizer"
+	"watchtower/tests/common/mocks"
+
+	cloudApp "watchtower/internal/core/cloud/application"
+	taskApp "watchtower/internal/support/task/application"
+	taskDomain "watchtower/internal/support/task/domain"
+)
+
+// nolint
+func TestKnowledgeGraphStage(t *testing.T) {
+	servConfig, err := cmd.InitConfig()
+	assert.NoError(t, err, "failed to read config file")
+
+	servConfig.Orchestrator.KnowledgeGraph = process.StageConfig{Enabled: true}
+
+	extracted := &entity.Extracted{
+		Entities:  []entity.Entity{{Name: "Acme", Type: "ORG"}, {Name: "Globex", Type: "ORG"}},
+		Relations: []entity.Relation{{Source: "Acme", Target: "Globex", Type: "SUPPLIES"}},
+	}
+
+	var knowledgeGraphTestCases = []struct {
+		Name           string
+		StoreGraphErr  error
+		ExpectedStatus taskDomain.TaskStatus
+	}{
+		{
+			Name:           "Document graph is stored",
+			ExpectedStatus: taskDomain.Successful,
+		},
+		{
+			Name:           "Failed graph store fails task",
+			StoreGraphErr:  fmt.Errorf("neo4j unavailable"),
+			ExpectedStatus: taskDomain.Failed,
+		},
+	}
+
+	for _, testCase := range knowledgeGraphTestCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			objectStorage := new(mocks.MockObjectStorage)
+			taskStorage := new(mocks.MockTaskStorage)
+			taskQueue := &mocks.MockTaskQueue{Ch: make(chan taskDomain.Message)}
+			recognizerMock := new(mocks.MockRecognizer)
+			docStorage := new(mocks.MockDocStorage)
+			extractor := new(mocks.MockEntityExtractor)
+			graphStore := new(mocks.MockGraphStore)
+
+			objectStorage.
+				On("GetObjectInfo", TestBucketName, TestObjectID).
+				Return(domain.Object{ContentType: "text/plain"}, nil)
+			objectStorage.
+				On("GetObjectData", TestBucketName, TestObjectID).
+				Return(bytes.NewBufferString(TestFileData), nil)
+
+			recognizerMock.
+				On("Recognize", mock.Anything).
+				Return(&recognizer.Recognized{Text: TestFileData}, nil)
+			docStorage.On("StoreDocument", mock.Anything).Return("document-id", nil)
+
+			extractor.
+				On("Extract", &entity.ExtractParams{FileName: TestObjectID, Text: TestFileData}).
+				Return(extracted, nil)
+			graphStore.
+				On("StoreGraph", &graph.Graph{
+					DocumentID: "document-id",
+					Index:      TestBucketName,
+					Path:       TestObjectID,
+					Nodes:      []graph.Node{{Name: "Acme", Type: "ORG"}, {Name: "Globex", Type: "ORG"}},
+					Edges:      []graph.Edge{{Source: "Acme", Target: "Globex", Type: "SUPPLIES"}},
+				}).
+				Return(testCase.StoreGraphErr)
+
+			finishedCh := make(chan *taskDomain.Task, 1)
+			taskStorage.
+				On("UpdateTask", mock.Anything).
+				Run(func(args mock.Arguments) {
+					task := args.Get(0).(*taskDomain.Task)
+					if task.Status == testCase.ExpectedStatus {
+						finishedCh <- task
+					}
+				}).
+				Return(nil)
+
+			storageUseCase := cloudApp.NewStorageUseCase(objectStorage)
+			taskUseCase := taskApp.NewTaskUseCase(
+				taskStorage, taskQueue, recognizerMock, docStorage,
+				taskApp.WithKnowledgeGraph(extractor, graphStore),
+			)
+			orchestrator := process.NewOrchestrator(servConfig.Orchestrator, storageUseCase, taskUseCase)
+			orchestrator.LaunchListener(ctx)
+
+			msg := mapping.MessageFromTask(taskDomain.CreateNewTask(TestBucketName, TestObjectID))
+			msg.Ctx = ctx
+			taskQueue.Ch <- msg
+
+			select {
+			case task := <-finishedCh:
+				assert.Equal(t, testCase.ExpectedStatus, task.Status)
+			case <-time.After(5 * time.Second):
+				t.Fatal("task has not been processed")
+			}
+
+			extractor.AssertExpectations(t)
+			graphStore.AssertExpectations(t)
+		})
+	}
+}
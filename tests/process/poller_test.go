@@ -0,0 +1,169 @@
+package process_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"watchtower/cmd"
+	"watchtower/internal/core/cloud/domain"
+	"watchtower/internal/process"
+	"watchtower/internal/support/task/application/service/snapshot"
+	"watchtower/tests/common/mocks"
+
+	cloudApp "watchtower/internal/core/cloud/application"
+	taskApp "watchtower/internal/support/task/application"
+	taskDomain "watchtower/internal/support/task/domain"
+)
+
+// nolint
+func TestPollBucket(t *testing.T) {
+	ctx := context.Background()
+
+	servConfig, err := cmd.InitConfig()
+	assert.NoError(t, err, "failed to read config file")
+
+	servConfig.Orchestrator.PostProcess = process.PostProcessConfig{
+		StageConfig:        process.StageConfig{Enabled: true},
+		ProcessedPrefix:    "processed",
+		UnrecognizedPrefix: "unrecognized",
+	}
+
+	modifiedAt := time.Now().Add(-time.Minute).Truncate(time.Second)
+	prevSnapshot := snapshot.Snapshot{
+		"incoming/same.pdf":    {ETag: "same", LastModified: modifiedAt},
+		"incoming/changed.pdf": {ETag: "old", LastModified: modifiedAt.Add(-time.Hour)},
+		"incoming/removed.pdf": {ETag: "removed", LastModified: modifiedAt},
+		"incoming/failed.pdf":  {ETag: "failed", LastModified: modifiedAt},
+	}
+
+	walkedObjects := []domain.Object{
+		{Path: "incoming/changed.pdf", ETag: "changed", LastModified: modifiedAt},
+		{Path: "incoming/same.pdf", ETag: "same", LastModified: modifiedAt},
+		{Path: "incoming/uploaded.pdf", ETag: "uploaded", LastModified: modifiedAt},
+		{Path: "new.pdf", ETag: "new", LastModified: modifiedAt},
+		{Path: "processed/done.pdf", ETag: "done", LastModified: modifiedAt},
+	}
+
+	uploadedTask := taskDomain.CreateNewTask(TestBucketName, "incoming/uploaded.pdf")
+	uploadedTask.CreatedAt = modifiedAt.Add(time.Second)
+
+	objectStorage := new(mocks.MockObjectStorage)
+	taskStorage := new(mocks.MockTaskStorage)
+	taskQueue := new(mocks.MockTaskQueue)
+	docStorage := new(mocks.MockDocStorage)
+	snapshots := new(mocks.MockSnapshotStorage)
+
+	objectStorage.On("WalkBucketObjects", TestBucketName, "").Return(walkedObjects, nil)
+	objectStorage.On("GetObjectInfo", TestBucketName, mock.Anything).Return(domain.Object{}, fmt.Errorf("object not found"))
+
+	taskStorage.On("GetAllBucketTasks", TestBucketName).Return([]*taskDomain.Task{uploadedTask}, nil)
+	taskStorage.On("UpdateTask", mock.Anything).Return(nil)
+	taskQueue.On("Publish", mock.Anything).Return(nil)
+
+	docStorage.On("DeleteDocuments", TestBucketName, "incoming/removed.pdf").Return(nil)
+	docStorage.On("DeleteDocuments", TestBucketName, "incoming/failed.pdf").Return(fmt.Errorf("docstorage unavailable"))
+
+	expectedSnapshot := snapshot.Snapshot{
+		"new.pdf":               {ETag: "new", LastModified: modifiedAt},
+		"incoming/same.pdf":     {ETag: "same", LastModified: modifiedAt},
+		"incoming/changed.pdf":  {ETag: "changed", LastModified: modifiedAt},
+		"incoming/uploaded.pdf": {ETag: "uploaded", LastModified: modifiedAt},
+		"incoming/failed.pdf":   {ETag: "failed", LastModified: modifiedAt},
+	}
+	snapshots.On("LoadSnapshot", TestBucketName).Return(prevSnapshot, nil)
+	snapshots.On("StoreSnapshot", TestBucketName, expectedSnapshot).Return(nil)
+
+	storageUseCase := cloudApp.NewStorageUseCase(objectStorage)
+	taskUseCase := taskApp.NewTaskUseCase(taskStorage, taskQueue, nil, docStorage)
+	orchestrator := process.NewOrchestrator(servConfig.Orchestrator, storageUseCase, taskUseCase)
+
+	err = orchestrator.PollBucket(ctx, snapshots, TestBucketName)
+	assert.Error(t, err, "failed cleanup must be reported")
+
+	taskQueue.AssertNumberOfCalls(t, "Publish", 2)
+	docStorage.AssertNumberOfCalls(t, "DeleteDocuments", 2)
+	snapshots.AssertExpectations(t)
+}
+
+func TestPollBucketFirstPoll(t *testing.T) {
+	ctx := context.Background()
+
+	servConfig, err := cmd.InitConfig()
+	assert.NoError(t, err, "failed to read config file")
+
+	servConfig.Orchestrator.Poller.ProcessExisting = true
+
+	modifiedAt := time.Now().Add(-time.Minute).Truncate(time.Second)
+	walkedObjects := []domain.Object{
+		{Path: "incoming/indexed.pdf", ETag: "indexed", LastModified: modifiedAt},
+		{Path: "incoming/new.pdf", ETag: "new", LastModified: modifiedAt},
+	}
+
+	indexedTask := taskDomain.CreateNewTask(TestBucketName, "incoming/indexed.pdf")
+	indexedTask.CreatedAt = modifiedAt.Add(time.Second)
+
+	objectStorage := new(mocks.MockObjectStorage)
+	taskStorage := new(mocks.MockTaskStorage)
+	taskQueue := new(mocks.MockTaskQueue)
+	snapshots := new(mocks.MockSnapshotStorage)
+
+	objectStorage.On("WalkBucketObjects", TestBucketName, "").Return(walkedObjects, nil)
+	taskStorage.On("GetAllBucketTasks", TestBucketName).Return([]*taskDomain.Task{indexedTask}, nil)
+	taskStorage.On("UpdateTask", mock.Anything).Return(nil)
+	taskQueue.On("Publish", mock.Anything).Return(nil)
+
+	snapshots.On("LoadSnapshot", TestBucketName).Return(snapshot.Snapshot(nil), nil)
+	snapshots.On("StoreSnapshot", TestBucketName, mock.Anything).Return(nil)
+
+	storageUseCase := cloudApp.NewStorageUseCase(objectStorage)
+	taskUseCase := taskApp.NewTaskUseCase(taskStorage, taskQueue, nil, nil)
+	orchestrator := process.NewOrchestrator(servConfig.Orchestrator, storageUseCase, taskUseCase)
+
+	err = orchestrator.PollBucket(ctx, snapshots, TestBucketName)
+	assert.NoError(t, err, "failed to poll bucket")
+
+	// Object indexed before the first poll already has a task.
+	taskQueue.AssertNumberOfCalls(t, "Publish", 1)
+	taskStorage.AssertCalled(t, "UpdateTask", mock.MatchedBy(func(task *taskDomain.Task) bool {
+		return task.ObjectID == "incoming/new.pdf"
+	}))
+}
+
+func TestPollBucketSeedsSnapshot(t *testing.T) {
+	ctx := context.Background()
+
+	servConfig, err := cmd.InitConfig()
+	assert.NoError(t, err, "failed to read config file")
+
+	modifiedAt := time.Now().Add(-time.Minute).Truncate(time.Second)
+	walkedObjects := []domain.Object{
+		{Path: "incoming/old.pdf", ETag: "old", LastModified: modifiedAt},
+	}
+
+	objectStorage := new(mocks.MockObjectStorage)
+	taskQueue := new(mocks.MockTaskQueue)
+	snapshots := new(mocks.MockSnapshotStorage)
+
+	objectStorage.On("WalkBucketObjects", TestBucketName, "").Return(walkedObjects, nil)
+
+	expectedSnapshot := snapshot.Snapshot{
+		"incoming/old.pdf": {ETag: "old", LastModified: modifiedAt},
+	}
+	snapshots.On("LoadSnapshot", TestBucketName).Return(snapshot.Snapshot(nil), nil)
+	snapshots.On("StoreSnapshot", TestBucketName, expectedSnapshot).Return(nil)
+
+	storageUseCase := cloudApp.NewStorageUseCase(objectStorage)
+	taskUseCase := taskApp.NewTaskUseCase(new(mocks.MockTaskStorage), taskQueue, nil, nil)
+	orchestrator := process.NewOrchestrator(servConfig.Orchestrator, storageUseCase, taskUseCase)
+
+	// Objects existing before the first poll are not processed again.
+	err = orchestrator.PollBucket(ctx, snapshots, TestBucketName)
+	assert.NoError(t, err, "failed to poll bucket")
+	taskQueue.AssertNotCalled(t, "Publish", mock.Anything)
+	snapshots.AssertExpectations(t)
+}
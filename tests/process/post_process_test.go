@@ -0,0 +1,166 @@
+package process_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"watchtower/cmd"
+	"watchtower/internal/core/cloud/domain"
+	"watchtower/internal/process"
+	"watchtower/internal/support/task/application/mapping"
+	"watchtower/internal/support/task/application/service/docstorage"
+	"watchtower/internal/support/task/application/service/recognizer"
+	"watchtower/tests/common/mocks"
+
+	cloudApp "watchtower/internal/core/cloud/application"
+	taskApp "watchtower/internal/support/task/application"
+	taskDomain "watchtower/internal/support/task/domain"
+)
+
+const (
+	TestBucketName = "watchtower-test-bucket"
+	TestObjectID   = "reports/report.txt"
+	TestFileData   = "quarterly report"
+)
+
+// nolint
+func TestPostProcessMoves(t *testing.T) {
+	servConfig, err := cmd.InitConfig()
+	assert.NoError(t, err, "failed to read config file")
+
+	servConfig.Orchestrator.PostProcess = process.PostProcessConfig{
+		StageConfig:        process.StageConfig{Enabled: true},
+		ProcessedPrefix:    "processed",
+		UnrecognizedPrefix: "unrecognized",
+		ErrorMetadataKey:   "processing-error",
+	}
+
+	var postProcessTestCases = []struct {
+		Name             string
+		RecognizeErr     error
+		CopyErr          error
+		ExpectedStatus   taskDomain.TaskStatus
+		ExpectedPath     string
+		ExpectedMetadata map[string]string
+		ExpectedLocation string
+		ExpectedDocPath  string
+	}{
+		{
+			Name:             "Processed file is moved",
+			ExpectedStatus:   taskDomain.Successful,
+			ExpectedPath:     "processed/reports/report.txt",
+			ExpectedLocation: "processed/reports/report.txt",
+			ExpectedDocPath:  "processed/reports/report.txt",
+		},
+		{
+			Name:           "Unrecognized file is moved with error",
+			RecognizeErr:   fmt.Errorf("service unavailable"),
+			ExpectedStatus: taskDomain.Failed,
+			ExpectedPath:   "unrecognized/reports/report.txt",
+			ExpectedMetadata: map[string]string{
+				"department":       "legal",
+				"processing-error": "failed to recognize object data",
+			},
+			ExpectedLocation: "unrecognized/reports/report.txt",
+		},
+		{
+			Name:             "Failed move keeps original location",
+			CopyErr:          fmt.Errorf("access denied"),
+			ExpectedStatus:   taskDomain.Successful,
+			ExpectedPath:     "processed/reports/report.txt",
+			ExpectedLocation: "",
+			ExpectedDocPath:  TestObjectID,
+		},
+	}
+
+	for _, testCase := range postProcessTestCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			objectStorage := new(mocks.MockObjectStorage)
+			taskStorage := new(mocks.MockTaskStorage)
+			taskQueue := &mocks.MockTaskQueue{Ch: make(chan taskDomain.Message)}
+			recognizerMock := new(mocks.MockRecognizer)
+			docStorage := new(mocks.MockDocStorage)
+
+			// Legacy watchtower mark is never copied to task metadata.
+			objMetadata := map[string]string{"department": "legal", process.UploadedByMetadataKey: "watchtower"}
+			objectStorage.
+				On("GetObjectInfo", TestBucketName, TestObjectID).
+				Return(domain.Object{ContentType: "text/plain", Metadata: objMetadata}, nil)
+
+			objectStorage.
+				On("GetObjectData", TestBucketName, TestObjectID).
+				Return(bytes.NewBufferString(TestFileData), nil)
+
+			objectStorage.
+				On("CopyObject", TestBucketName, mock.MatchedBy(func(params *domain.CopyObjectParams) bool {
+					return params.SourcePath == TestObjectID &&
+						params.DestinationPath == testCase.ExpectedPath &&
+						params.WithRemoving
+				})).
+				Return(testCase.CopyErr)
+
+			recognizerMock.
+				On("Recognize", mock.Anything).
+				Return(&recognizer.Recognized{Text: TestFileData}, testCase.RecognizeErr)
+
+			docStorage.
+				On("StoreDocument", mock.Anything).
+				Return("document-id", nil)
+
+			finishedCh := make(chan *taskDomain.Task, 1)
+			taskStorage.
+				On("UpdateTask", mock.Anything).
+				Run(func(args mock.Arguments) {
+					task := args.Get(0).(*taskDomain.Task)
+					if task.Status == testCase.ExpectedStatus {
+						finishedCh <- task
+					}
+				}).
+				Return(nil)
+
+			storageUseCase := cloudApp.NewStorageUseCase(objectStorage)
+			taskUseCase := taskApp.NewTaskUseCase(taskStorage, taskQueue, recognizerMock, docStorage)
+			orchestrator := process.NewOrchestrator(servConfig.Orchestrator, storageUseCase, taskUseCase)
+			orchestrator.LaunchListener(ctx)
+
+			msg := mapping.MessageFromTask(taskDomain.CreateNewTask(TestBucketName, TestObjectID))
+			msg.Ctx = ctx
+			taskQueue.Ch <- msg
+
+			select {
+			case task := <-finishedCh:
+				assert.Equal(t, TestObjectID, task.ObjectID)
+				assert.Equal(t, testCase.ExpectedLocation, task.Location)
+			case <-time.After(5 * time.Second):
+				t.Fatal("task has not been processed")
+			}
+
+			objectStorage.AssertCalled(t, "CopyObject", TestBucketName, mock.MatchedBy(func(params *domain.CopyObjectParams) bool {
+				if testCase.ExpectedMetadata == nil {
+					return params.Metadata == nil
+				}
+				return assert.ObjectsAreEqual(testCase.ExpectedMetadata, params.Metadata)
+			}))
+
+			if testCase.ExpectedDocPath == "" {
+				docStorage.AssertNotCalled(t, "StoreDocument", mock.Anything)
+				return
+			}
+
+			// Document is indexed by final location of moved object.
+			docStorage.AssertCalled(t, "StoreDocument", mock.MatchedBy(func(doc *docstorage.Document) bool {
+				return doc.Path == testCase.ExpectedDocPath &&
+					assert.ObjectsAreEqual(map[string]string{"department": "legal"}, doc.Metadata)
+			}))
+		})
+	}
+}
@@ -0,0 +1,169 @@
+package process_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"watchtower/cmd"
+	"watchtower/internal/core/cloud/domain"
+	"watchtower/internal/process"
+	"watchtower/tests/common/mocks"
+
+	cloudApp "watchtower/internal/core/cloud/application"
+	taskApp "watchtower/internal/support/task/application"
+)
+
+const TestPresignedContent = "presigned upload content"
+
+// nolint
+func TestPresignedUploads(t *testing.T) {
+	ctx := context.Background()
+
+	servConfig, err := cmd.InitConfig()
+	assert.NoError(t, err, "failed to read config file")
+
+	servConfig.Orchestrator.PresignedUploads = process.PresignedUploadsConfig{
+		Enabled:           true,
+		MaxSize:           1024,
+		Expiration:        60,
+		CompletionTimeout: 60,
+	}
+	servConfig.Orchestrator.Events.StageConfig = process.StageConfig{Enabled: true}
+
+	md5Sum := md5.Sum([]byte(TestPresignedContent))
+	md5Checksum := hex.EncodeToString(md5Sum[:])
+	sha256Sum := sha256.Sum256([]byte(TestPresignedContent))
+	sha256Checksum := hex.EncodeToString(sha256Sum[:])
+
+	uploadURL, _ := url.Parse("http://localhost:9000/watchtower-test-bucket/incoming/report.txt")
+	presigned := &domain.PresignedUpload{Method: domain.PresignPutMethod, URL: uploadURL}
+
+	initOrchestrator := func(objectStorage *mocks.MockObjectStorage, tickets *mocks.MockTicketStorage) (*process.Orchestrator, *mocks.MockTaskQueue) {
+		taskStorage := new(mocks.MockTaskStorage)
+		taskQueue := new(mocks.MockTaskQueue)
+		taskStorage.On("UpdateTask", mock.Anything).Return(nil)
+		taskQueue.On("Publish", mock.Anything).Return(nil)
+
+		storageUseCase := cloudApp.NewStorageUseCase(objectStorage)
+		taskUseCase := taskApp.NewTaskUseCase(
+			taskStorage, taskQueue, nil, nil,
+			taskApp.WithUploadTickets(tickets),
+			taskApp.WithStoredObjects(mocks.NewMockStoredObjectStorage()),
+		)
+		return process.NewOrchestrator(servConfig.Orchestrator, storageUseCase, taskUseCase), taskQueue
+	}
+
+	createUploadURL := func(orchestrator *process.Orchestrator, checksum string) error {
+		_, err := orchestrator.CreateUploadURL(ctx, TestBucketName, &process.CreateUploadURLParams{
+			FilePath: "incoming/report.txt",
+			Size:     int64(len(TestPresignedContent)),
+			Checksum: checksum,
+		})
+		return err
+	}
+
+	t.Run("Upload URL is not signed with watchtower mark", func(t *testing.T) {
+		objectStorage := new(mocks.MockObjectStorage)
+		tickets := mocks.NewMockTicketStorage()
+		orchestrator, _ := initOrchestrator(objectStorage, tickets)
+
+		objectStorage.
+			On("GenUploadURL", TestBucketName, mock.MatchedBy(func(params *domain.PresignUploadParams) bool {
+				_, marked := params.Metadata[process.UploadedByMetadataKey]
+				return params.Method == domain.PresignPutMethod && !marked
+			})).
+			Return(presigned, nil)
+
+		assert.NoError(t, createUploadURL(orchestrator, md5Checksum))
+		assert.Equal(t, 1, tickets.TicketsCount())
+
+		assert.ErrorIs(t, createUploadURL(orchestrator, "not-a-checksum"), process.ErrInvalidChecksum)
+
+		_, err := orchestrator.CreateUploadURL(ctx, TestBucketName, &process.CreateUploadURLParams{
+			FilePath: "incoming/huge.bin",
+			Size:     1025,
+		})
+		assert.ErrorIs(t, err, process.ErrUploadTooLarge)
+		assert.Equal(t, 1, tickets.TicketsCount())
+	})
+
+	t.Run("Complete upload verified by etag", func(t *testing.T) {
+		objectStorage := new(mocks.MockObjectStorage)
+		tickets := mocks.NewMockTicketStorage()
+		orchestrator, taskQueue := initOrchestrator(objectStorage, tickets)
+
+		objectStorage.On("GenUploadURL", TestBucketName, mock.Anything).Return(presigned, nil)
+		objectStorage.
+			On("GetObjectInfo", TestBucketName, "incoming/report.txt").
+			Return(domain.Object{}, assert.AnError).
+			Once()
+		objectStorage.
+			On("GetObjectInfo", TestBucketName, "incoming/report.txt").
+			Return(domain.Object{Size: int64(len(TestPresignedContent)), ETag: `"` + md5Checksum + `"`}, nil)
+
+		assert.NoError(t, createUploadURL(orchestrator, md5Checksum))
+
+		_, err := orchestrator.CompleteUploadURL(ctx, TestBucketName, "incoming/report.txt")
+		assert.ErrorIs(t, err, process.ErrUploadNotReceived)
+		assert.Equal(t, 1, tickets.TicketsCount(), "ticket must be kept until object is received")
+
+		task, err := orchestrator.CompleteUploadURL(ctx, TestBucketName, "incoming/report.txt")
+		assert.NoError(t, err, "failed to complete upload")
+		assert.Equal(t, "incoming/report.txt", task.ObjectID)
+		taskQueue.AssertNumberOfCalls(t, "Publish", 1)
+		objectStorage.AssertNotCalled(t, "GetObjectData", mock.Anything, mock.Anything)
+
+		event := &domain.ObjectEvent{
+			Type:     domain.ObjectCreated,
+			BucketID: TestBucketName,
+			ObjectID: "incoming/report.txt",
+		}
+		assert.NoError(t, orchestrator.HandleObjectEvent(ctx, event))
+		taskQueue.AssertNumberOfCalls(t, "Publish", 1)
+	})
+
+	t.Run("Storage event completes upload verified by content", func(t *testing.T) {
+		objectStorage := new(mocks.MockObjectStorage)
+		tickets := mocks.NewMockTicketStorage()
+		orchestrator, taskQueue := initOrchestrator(objectStorage, tickets)
+
+		objectStorage.On("GenUploadURL", TestBucketName, mock.Anything).Return(presigned, nil)
+		objectStorage.
+			On("GetObjectInfo", TestBucketName, "incoming/report.txt").
+			Return(domain.Object{Size: int64(len(TestPresignedContent)), ETag: "multipart-etag-2"}, nil)
+		objectStorage.
+			On("GetObjectData", TestBucketName, "incoming/report.txt").
+			Return(bytes.NewBufferString("presigned upload CONTENT"), nil).
+			Once()
+		objectStorage.
+			On("GetObjectData", TestBucketName, "incoming/report.txt").
+			Return(bytes.NewBufferString(TestPresignedContent), nil)
+
+		assert.NoError(t, createUploadURL(orchestrator, sha256Checksum))
+
+		event := &domain.ObjectEvent{
+			Type:     domain.ObjectCreated,
+			BucketID: TestBucketName,
+			ObjectID: "incoming/report.txt",
+		}
+
+		assert.NoError(t, orchestrator.HandleObjectEvent(ctx, event), "mismatched object must be rejected")
+		taskQueue.AssertNotCalled(t, "Publish", mock.Anything)
+		assert.Equal(t, 1, tickets.TicketsCount(), "ticket must be kept for reupload")
+
+		assert.NoError(t, orchestrator.HandleObjectEvent(ctx, event))
+		taskQueue.AssertNumberOfCalls(t, "Publish", 1)
+		assert.Equal(t, 0, tickets.TicketsCount())
+
+		_, err := orchestrator.CompleteUploadURL(ctx, TestBucketName, "incoming/report.txt")
+		assert.Error(t, err, "upload must be completed once")
+	})
+}
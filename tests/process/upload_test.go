@@ -0,0 +1,215 @@
+package process_test
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"watchtower/cmd"
+	"watchtower/internal/core/cloud/domain"
+	"watchtower/internal/process"
+	"watchtower/internal/support/task/application/service/upload"
+	"watchtower/tests/common/mocks"
+
+	cloudApp "watchtower/internal/core/cloud/application"
+	taskApp "watchtower/internal/support/task/application"
+)
+
+const TestMultipartID = "multipart-upload-id"
+
+// nolint
+func TestWriteUpload(t *testing.T) {
+	ctx := context.Background()
+
+	servConfig, err := cmd.InitConfig()
+	assert.NoError(t, err, "failed to read config file")
+
+	servConfig.Orchestrator.Uploads = process.UploadsConfig{
+		Enabled:    true,
+		MaxSize:    64,
+		PartSize:   4,
+		Expiration: 60,
+	}
+
+	objectStorage := new(mocks.MockObjectStorage)
+	taskStorage := new(mocks.MockTaskStorage)
+	taskQueue := new(mocks.MockTaskQueue)
+	uploads := mocks.NewMockUploadStorage()
+
+	objectStorage.
+		On("CreateMultipartUpload", TestBucketName, mock.MatchedBy(func(params *domain.UploadObjectParams) bool {
+			return params.FilePath == "incoming/report.txt" && params.ContentType == "text/plain"
+		})).
+		Return(TestMultipartID, nil)
+	objectStorage.
+		On("GetUploadedPart", TestBucketName, "incoming/report.txt", TestMultipartID, mock.Anything).
+		Return(nil, nil)
+	objectStorage.
+		On("UploadPart", TestBucketName, "incoming/report.txt", TestMultipartID, 1, "hello").
+		Return(domain.MultipartPart{Number: 1, ETag: "first"}, nil)
+	objectStorage.
+		On("UploadPart", TestBucketName, "incoming/report.txt", TestMultipartID, 2, " world").
+		Return(domain.MultipartPart{Number: 2, ETag: "second"}, nil)
+	objectStorage.
+		On("CompleteMultipartUpload", TestBucketName, "incoming/report.txt", TestMultipartID, []domain.MultipartPart{
+			{Number: 1, ETag: "first"},
+			{Number: 2, ETag: "second"},
+		}).
+		Return(nil)
+	taskQueue.On("Publish", mock.Anything).Return(nil)
+	taskStorage.On("UpdateTask", mock.Anything).Return(nil)
+
+	storageUseCase := cloudApp.NewStorageUseCase(objectStorage)
+	taskUseCase := taskApp.NewTaskUseCase(taskStorage, taskQueue, nil, nil, taskApp.WithUploads(uploads))
+	orchestrator := process.NewOrchestrator(servConfig.Orchestrator, storageUseCase, taskUseCase)
+
+	session, err := orchestrator.CreateUpload(ctx, TestBucketName, &process.CreateUploadParams{
+		FilePath:    "incoming/report.txt",
+		Length:      11,
+		ContentType: "text/plain",
+	})
+	assert.NoError(t, err, "failed to create upload")
+
+	_, err = orchestrator.CreateUpload(ctx, TestBucketName, &process.CreateUploadParams{
+		FilePath: "incoming/huge.txt",
+		Length:   65,
+	})
+	assert.ErrorIs(t, err, process.ErrUploadTooLarge)
+
+	session, err = orchestrator.WriteUpload(ctx, TestBucketName, session.ID, 0, []byte("hel"))
+	assert.NoError(t, err, "failed to write first chunk")
+	assert.Equal(t, int64(3), session.Offset)
+	objectStorage.AssertNotCalled(t, "UploadPart", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+
+	_, err = orchestrator.WriteUpload(ctx, TestBucketName, session.ID, 0, []byte("hel"))
+	assert.ErrorIs(t, err, process.ErrUploadOffsetMismatch)
+
+	_, err = orchestrator.WriteUpload(ctx, TestBucketName, session.ID, 3, []byte("lo world and more"))
+	assert.ErrorIs(t, err, process.ErrUploadTooLarge)
+
+	session, err = orchestrator.WriteUpload(ctx, TestBucketName, session.ID, 3, []byte("lo"))
+	assert.NoError(t, err, "failed to write second chunk")
+	assert.Len(t, session.Parts, 1)
+
+	session, err = orchestrator.WriteUpload(ctx, TestBucketName, session.ID, 5, []byte(" world"))
+	assert.NoError(t, err, "failed to write last chunk")
+	assert.True(t, session.IsCompleted())
+
+	objectStorage.AssertExpectations(t)
+	taskQueue.AssertNumberOfCalls(t, "Publish", 1)
+	assert.Equal(t, 0, uploads.SessionsCount(), "completed upload must be removed")
+
+	_, err = orchestrator.GetUpload(ctx, TestBucketName, session.ID)
+	assert.ErrorIs(t, err, upload.ErrSessionNotFound)
+}
+
+// nolint
+func TestWriteUploadRetry(t *testing.T) {
+	ctx := context.Background()
+
+	servConfig, err := cmd.InitConfig()
+	assert.NoError(t, err, "failed to read config file")
+
+	servConfig.Orchestrator.Uploads = process.UploadsConfig{
+		Enabled:    true,
+		MaxSize:    64,
+		PartSize:   5,
+		Expiration: 60,
+	}
+
+	// Part has been uploaded by request which failed to store session.
+	checksum := md5.Sum([]byte("hello"))
+	uploadedPart := &domain.MultipartPart{Number: 1, ETag: hex.EncodeToString(checksum[:])}
+
+	objectStorage := new(mocks.MockObjectStorage)
+	uploads := mocks.NewMockUploadStorage()
+	objectStorage.On("CreateMultipartUpload", TestBucketName, mock.Anything).Return(TestMultipartID, nil)
+	objectStorage.
+		On("GetUploadedPart", TestBucketName, "incoming/report.txt", TestMultipartID, 1).
+		Return(uploadedPart, nil)
+
+	storageUseCase := cloudApp.NewStorageUseCase(objectStorage)
+	taskUseCase := taskApp.NewTaskUseCase(nil, nil, nil, nil, taskApp.WithUploads(uploads))
+	orchestrator := process.NewOrchestrator(servConfig.Orchestrator, storageUseCase, taskUseCase)
+
+	session, err := orchestrator.CreateUpload(ctx, TestBucketName, &process.CreateUploadParams{
+		FilePath: "incoming/report.txt",
+		Length:   11,
+	})
+	assert.NoError(t, err, "failed to create upload")
+
+	token, err := uploads.LockSession(ctx, session.ID, time.Minute)
+	assert.NoError(t, err, "failed to lock upload")
+	_, err = orchestrator.WriteUpload(ctx, TestBucketName, session.ID, 0, []byte("hello"))
+	assert.ErrorIs(t, err, upload.ErrSessionLocked)
+	assert.NoError(t, uploads.UnlockSession(ctx, session.ID, token))
+
+	session, err = orchestrator.WriteUpload(ctx, TestBucketName, session.ID, 0, []byte("hello"))
+	assert.NoError(t, err, "failed to write chunk")
+	assert.Equal(t, []upload.Part{{Number: 1, ETag: uploadedPart.ETag}}, session.Parts)
+	objectStorage.AssertNotCalled(t, "UploadPart", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+// nolint
+func TestCleanupUploads(t *testing.T) {
+	ctx := context.Background()
+
+	servConfig, err := cmd.InitConfig()
+	assert.NoError(t, err, "failed to read config file")
+
+	expired := upload.CreateNewSession(TestBucketName, "expired.txt", 10)
+	expired.MultipartID = TestMultipartID
+	expired.Prolong(-time.Minute)
+
+	active := upload.CreateNewSession(TestBucketName, "active.txt", 10)
+	active.Prolong(time.Hour)
+
+	// Expired upload being written is not aborted.
+	writing := upload.CreateNewSession(TestBucketName, "writing.txt", 10)
+	writing.Prolong(-time.Minute)
+
+	uploads := mocks.NewMockUploadStorage()
+	_ = uploads.StoreSession(ctx, expired)
+	_ = uploads.StoreSession(ctx, active)
+	_ = uploads.StoreSession(ctx, writing)
+	_, err = uploads.LockSession(ctx, writing.ID, time.Minute)
+	assert.NoError(t, err, "failed to lock upload")
+
+	objectStorage := new(mocks.MockObjectStorage)
+	objectStorage.On("AbortMultipartUpload", TestBucketName, "expired.txt", TestMultipartID).Return(nil)
+
+	storageUseCase := cloudApp.NewStorageUseCase(objectStorage)
+	taskUseCase := taskApp.NewTaskUseCase(nil, nil, nil, nil, taskApp.WithUploads(uploads))
+	orchestrator := process.NewOrchestrator(servConfig.Orchestrator, storageUseCase, taskUseCase)
+
+	aborted, err := orchestrator.CleanupUploads(ctx)
+	assert.NoError(t, err, "failed to cleanup uploads")
+	assert.Equal(t, 1, aborted)
+	assert.Equal(t, 2, uploads.SessionsCount())
+	objectStorage.AssertExpectations(t)
+
+	err = orchestrator.TerminateUpload(ctx, TestBucketName, writing.ID)
+	assert.ErrorIs(t, err, upload.ErrSessionLocked)
+	assert.Equal(t, 2, uploads.SessionsCount())
+
+	objectStorage.On("AbortMultipartUpload", TestBucketName, "active.txt", mock.Anything).Return(nil)
+	assert.NoError(t, orchestrator.TerminateUpload(ctx, TestBucketName, active.ID))
+	assert.Equal(t, 1, uploads.SessionsCount())
+}
+
+func TestUploadsPartSize(t *testing.T) {
+	config := process.UploadsConfig{Enabled: true, PartSize: 5}
+	assert.NoError(t, config.ValidatePartSize(0))
+	assert.Error(t, config.ValidatePartSize(domain.MinMultipartPartSize))
+
+	config.PartSize = domain.MinMultipartPartSize
+	assert.NoError(t, config.ValidatePartSize(domain.MinMultipartPartSize))
+
+	config.PartSize = 0
+	assert.Error(t, config.ValidatePartSize(0))
+}
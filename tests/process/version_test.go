@@ -0,0 +1,116 @@
+package process_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"watchtower/cmd"
+	"watchtower/internal/core/cloud/domain"
+	"watchtower/internal/process"
+	"watchtower/internal/support/task/application/mapping"
+	"watchtower/internal/support/task/application/service/docstorage"
+	"watchtower/internal/support/task/application/service/recognizer"
+	"watchtower/tests/common/mocks"
+
+	cloudApp "watchtower/internal/core/cloud/application"
+	taskApp "watchtower/internal/support/task/application"
+	taskDomain "watchtower/internal/support/task/domain"
+)
+
+const TestVersionID = "3HL4kqtJlcpXroDTDmJ"
+
+// nolint
+func TestVersionedObjectProcessing(t *testing.T) {
+	servConfig, err := cmd.InitConfig()
+	assert.NoError(t, err, "failed to read config file")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	objectStorage := new(mocks.MockObjectStorage)
+	taskStorage := new(mocks.MockTaskStorage)
+	taskQueue := &mocks.MockTaskQueue{Ch: make(chan taskDomain.Message)}
+	recognizerMock := new(mocks.MockRecognizer)
+	docStorage := new(mocks.MockDocStorage)
+
+	objectStorage.
+		On("GetObjectInfo", TestBucketName, TestObjectID).
+		Return(domain.Object{ContentType: "text/plain", VersionID: TestVersionID}, nil)
+
+	objectStorage.
+		On("GetObjectVersionData", TestBucketName, TestObjectID, TestVersionID).
+		Return(bytes.NewBufferString(TestFileData), nil)
+
+	recognizerMock.
+		On("Recognize", mock.Anything).
+		Return(&recognizer.Recognized{Text: TestFileData}, nil)
+
+	docStorage.
+		On("StoreDocument", mock.Anything).
+		Return("document-id", nil)
+
+	finishedCh := make(chan *taskDomain.Task, 1)
+	taskStorage.
+		On("UpdateTask", mock.Anything).
+		Run(func(args mock.Arguments) {
+			task := args.Get(0).(*taskDomain.Task)
+			if task.Status == taskDomain.Successful {
+				finishedCh <- task
+			}
+		}).
+		Return(nil)
+
+	storageUseCase := cloudApp.NewStorageUseCase(objectStorage)
+	taskUseCase := taskApp.NewTaskUseCase(taskStorage, taskQueue, recognizerMock, docStorage)
+	orchestrator := process.NewOrchestrator(servConfig.Orchestrator, storageUseCase, taskUseCase)
+	orchestrator.LaunchListener(ctx)
+
+	msg := mapping.MessageFromTask(taskDomain.CreateNewTask(TestBucketName, TestObjectID))
+	msg.Ctx = ctx
+	taskQueue.Ch <- msg
+
+	select {
+	case task := <-finishedCh:
+		assert.Equal(t, TestVersionID, task.VersionID)
+	case <-time.After(5 * time.Second):
+		t.Fatal("task has not been processed")
+	}
+
+	objectStorage.AssertNotCalled(t, "GetObjectData", mock.Anything, mock.Anything)
+	docStorage.AssertCalled(t, "StoreDocument", mock.MatchedBy(func(doc *docstorage.Document) bool {
+		return doc.VersionID == TestVersionID
+	}))
+}
+
+// nolint
+func TestRestoreObjectVersion(t *testing.T) {
+	servConfig, err := cmd.InitConfig()
+	assert.NoError(t, err, "failed to read config file")
+
+	objectStorage := new(mocks.MockObjectStorage)
+	taskStorage := new(mocks.MockTaskStorage)
+	taskQueue := &mocks.MockTaskQueue{Ch: make(chan taskDomain.Message)}
+
+	objectStorage.
+		On("RestoreObjectVersion", TestBucketName, &domain.RestoreObjectParams{
+			FilePath:  TestObjectID,
+			VersionID: TestVersionID,
+		}).
+		Return("restored-version", nil)
+	taskQueue.On("Publish", mock.Anything).Return(nil)
+	taskStorage.On("UpdateTask", mock.Anything).Return(nil)
+
+	storageUseCase := cloudApp.NewStorageUseCase(objectStorage)
+	taskUseCase := taskApp.NewTaskUseCase(taskStorage, taskQueue, nil, nil)
+	orchestrator := process.NewOrchestrator(servConfig.Orchestrator, storageUseCase, taskUseCase)
+
+	task, err := orchestrator.RestoreObjectVersion(context.Background(), TestBucketName, "./"+TestObjectID, TestVersionID)
+	assert.NoError(t, err)
+	assert.Equal(t, TestObjectID, task.ObjectID)
+	taskQueue.AssertNumberOfCalls(t, "Publish", 1)
+}
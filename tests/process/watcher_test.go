@@ -0,0 +1,125 @@
+package process_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"watchtower/cmd"
+	"watchtower/internal/core/cloud/domain"
+	"watchtower/internal/process"
+	"watchtower/tests/common/mocks"
+
+	cloudApp "watchtower/internal/core/cloud/application"
+	taskApp "watchtower/internal/support/task/application"
+	taskDomain "watchtower/internal/support/task/domain"
+)
+
+func TestScanWatcher(t *testing.T) {
+	ctx := context.Background()
+
+	servConfig, err := cmd.InitConfig()
+	assert.NoError(t, err, "failed to read config file")
+
+	lastScanAt := time.Now().Add(-time.Minute)
+	watcher := taskDomain.CreateNewWatcher(TestBucketName, "./incoming")
+	watcher.SetLastScanAt(lastScanAt)
+
+	objects := []domain.Object{
+		{Path: "incoming/archive/", IsDirectory: true},
+		{Path: "incoming/old.pdf", LastModified: lastScanAt.Add(-time.Hour)},
+		{Path: "incoming/new.pdf", LastModified: lastScanAt.Add(10 * time.Second)},
+		{Path: "incoming/changed.pdf", LastModified: lastScanAt.Add(20 * time.Second)},
+		{Path: "incoming/uploaded.pdf", LastModified: lastScanAt.Add(30 * time.Second)},
+	}
+
+	changedTask := taskDomain.CreateNewTask(TestBucketName, "incoming/changed.pdf")
+	changedTask.CreatedAt = lastScanAt.Add(-time.Hour)
+	uploadedTask := taskDomain.CreateNewTask(TestBucketName, "incoming/uploaded.pdf")
+	uploadedTask.CreatedAt = lastScanAt.Add(30 * time.Second)
+
+	objectStorage := new(mocks.MockObjectStorage)
+	taskStorage := new(mocks.MockTaskStorage)
+	taskQueue := new(mocks.MockTaskQueue)
+
+	objectStorage.
+		On("WalkBucketObjects", TestBucketName, "incoming/").
+		Return(objects, nil)
+
+	taskStorage.
+		On("GetAllBucketTasks", TestBucketName).
+		Return([]*taskDomain.Task{changedTask, nil, uploadedTask}, nil)
+
+	taskStorage.On("UpdateTask", mock.Anything).Return(nil)
+	taskStorage.On("UpdateWatcherScanTime", watcher.ID, mock.Anything).Return(nil)
+	taskQueue.On("Publish", mock.Anything).Return(nil)
+
+	storageUseCase := cloudApp.NewStorageUseCase(objectStorage)
+	taskUseCase := taskApp.NewTaskUseCase(taskStorage, taskQueue, nil, nil)
+	orchestrator := process.NewOrchestrator(servConfig.Orchestrator, storageUseCase, taskUseCase)
+
+	tasks, err := orchestrator.ScanWatcher(ctx, watcher)
+	assert.NoError(t, err, "failed to scan watcher")
+
+	var objIDs []string
+	for _, task := range tasks {
+		objIDs = append(objIDs, task.ObjectID)
+	}
+	assert.Equal(t, []string{"incoming/new.pdf", "incoming/changed.pdf"}, objIDs)
+	assert.True(t, watcher.LastScanAt.After(lastScanAt))
+
+	taskQueue.AssertNumberOfCalls(t, "Publish", 2)
+	taskStorage.AssertNumberOfCalls(t, "UpdateWatcherScanTime", 1)
+	taskStorage.AssertNotCalled(t, "UpdateWatcher", mock.Anything)
+}
+
+func TestScanWatcherSubfolders(t *testing.T) {
+	ctx := context.Background()
+
+	servConfig, err := cmd.InitConfig()
+	assert.NoError(t, err, "failed to read config file")
+
+	servConfig.Orchestrator.PostProcess = process.PostProcessConfig{
+		StageConfig:        process.StageConfig{Enabled: true},
+		ProcessedPrefix:    "processed",
+		UnrecognizedPrefix: "unrecognized",
+	}
+
+	lastScanAt := time.Now().Add(-time.Minute)
+	watcher := taskDomain.CreateNewWatcher(TestBucketName, "./")
+	watcher.SetLastScanAt(lastScanAt)
+
+	modifiedAt := lastScanAt.Add(10 * time.Second)
+	objects := []domain.Object{
+		{Path: "processed/reports/done.pdf", LastModified: modifiedAt},
+		{Path: "reports/2026/new.pdf", LastModified: modifiedAt},
+		{Path: "unrecognized/scan.bin", LastModified: modifiedAt},
+	}
+
+	objectStorage := new(mocks.MockObjectStorage)
+	taskStorage := new(mocks.MockTaskStorage)
+	taskQueue := new(mocks.MockTaskQueue)
+
+	objectStorage.On("WalkBucketObjects", TestBucketName, "").Return(objects, nil)
+	taskStorage.On("GetAllBucketTasks", TestBucketName).Return([]*taskDomain.Task{}, nil)
+	taskStorage.On("UpdateTask", mock.Anything).Return(nil)
+	taskStorage.
+		On("UpdateWatcherScanTime", watcher.ID, mock.Anything).
+		Return(fmt.Errorf("redis error: %w", taskDomain.ErrWatcherNotFound))
+	taskQueue.On("Publish", mock.Anything).Return(nil)
+
+	storageUseCase := cloudApp.NewStorageUseCase(objectStorage)
+	taskUseCase := taskApp.NewTaskUseCase(taskStorage, taskQueue, nil, nil)
+	orchestrator := process.NewOrchestrator(servConfig.Orchestrator, storageUseCase, taskUseCase)
+
+	// Watcher removed during scan is not stored again.
+	tasks, err := orchestrator.ScanWatcher(ctx, watcher)
+	assert.NoError(t, err, "failed to scan watcher")
+	taskStorage.AssertNotCalled(t, "UpdateWatcher", mock.Anything)
+	assert.Len(t, tasks, 1)
+	assert.Equal(t, "reports/2026/new.pdf", tasks[0].ObjectID)
+}
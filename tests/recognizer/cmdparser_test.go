@@ -0,0 +1,106 @@
+//go:build unix
+
+package recognizer_test
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"watchtower/internal/support/task/application/service/recognizer"
+	"watchtower/internal/support/task/infrastructure/cmdparser"
+)
+
+const (
+	FakeEchoScript    = "#!/bin/sh\ncat\n"
+	FakeFailScript    = "#!/bin/sh\necho 'broken pdf header' >&2\nexit 3\n"
+	FakeHangScript    = "#!/bin/sh\nsleep 30 &\nwait\n"
+	FakeVerboseScript = "#!/bin/sh\nwhile true; do echo 'verbose output line'; done\n"
+)
+
+func writeFakeScript(t *testing.T, name, content string) string {
+	scriptPath := filepath.Join(t.TempDir(), name)
+	err := os.WriteFile(scriptPath, []byte(content), 0o755)
+	assert.NoError(t, err, "failed to write fake script")
+	return scriptPath
+}
+
+func TestCmdParser(t *testing.T) {
+	ctx := context.Background()
+
+	var cmdParserTestCases = []struct {
+		Name          string
+		Script        string
+		FileName      string
+		ExpectedText  string
+		ExpectedError string
+	}{
+		{
+			Name:         "Command output is recognized text",
+			Script:       FakeEchoScript,
+			FileName:     "scan.pdf",
+			ExpectedText: "recognized pdf content",
+		},
+		{
+			Name:          "Non-zero exit code is mapped to error",
+			Script:        FakeFailScript,
+			FileName:      "scan.pdf",
+			ExpectedError: "exited with code 3: broken pdf header",
+		},
+		{
+			Name:          "Hanging command is killed by timeout",
+			Script:        FakeHangScript,
+			FileName:      "scan.pdf",
+			ExpectedError: "timed out",
+		},
+		{
+			Name:          "Output size is capped",
+			Script:        FakeVerboseScript,
+			FileName:      "scan.pdf",
+			ExpectedError: cmdparser.ErrOutputTooLarge.Error(),
+		},
+		{
+			Name:          "There is no command for mime type",
+			Script:        FakeEchoScript,
+			FileName:      "table.xlsx",
+			ExpectedError: "there is no command",
+		},
+	}
+
+	for _, testCase := range cmdParserTestCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			config := cmdparser.Config{
+				Timeout:       1,
+				MaxOutputSize: 1024,
+				Commands: []cmdparser.Command{
+					{
+						MimeTypes: []string{"application/pdf"},
+						Path:      writeFakeScript(t, "fake-parser.sh", testCase.Script),
+					},
+				},
+			}
+
+			params := &recognizer.RecognizeParams{
+				FileName: testCase.FileName,
+				FileData: bytes.NewBufferString("recognized pdf content"),
+			}
+
+			instant := time.Now()
+			recData, err := cmdparser.New(config).Recognize(ctx, params)
+			assert.Less(t, time.Since(instant), 5*time.Second, "command has not been killed")
+
+			if testCase.ExpectedError != "" {
+				assert.ErrorContains(t, err, testCase.ExpectedError)
+				return
+			}
+
+			assert.NoError(t, err, "failed to recognize file")
+			assert.Equal(t, testCase.ExpectedText, recData.Text)
+		})
+	}
+}
@@ -0,0 +1,58 @@
+package recognizer_test
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"watchtower/internal/support/task/application/service/recognizer"
+	"watchtower/internal/support/task/infrastructure/docparser"
+)
+
+const TestParsedContent = `{
+	"parsed_text": "first page second page",
+	"pages": [
+		{"page_number": 1, "text": "first page"},
+		{"page_number": 2, "text": "second page"}
+	],
+	"language": "en",
+	"confidence": 0.42,
+	"metadata": {"title": "Scanned contract"}
+}`
+
+func TestDocParserRichResult(t *testing.T) {
+	ctx := context.Background()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, docparser.RecognitionURL, r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(TestParsedContent))
+	}))
+	defer server.Close()
+
+	docParser := docparser.New(docparser.Config{Address: server.URL, Timeout: 10})
+	params := &recognizer.RecognizeParams{
+		FileName: "scan.pdf",
+		FileData: bytes.NewBufferString("%PDF-1.4"),
+	}
+
+	recData, err := docParser.Recognize(ctx, params)
+	assert.NoError(t, err, "failed to recognize document")
+
+	expectedPages := []recognizer.Page{
+		{Number: 1, Text: "first page"},
+		{Number: 2, Text: "second page"},
+	}
+
+	assert.Equal(t, "first page second page", recData.Text)
+	assert.Equal(t, expectedPages, recData.Pages)
+	assert.Equal(t, 2, recData.PageCount)
+	assert.Equal(t, "en", recData.Language)
+	assert.Equal(t, "Scanned contract", recData.Metadata["title"])
+	assert.True(t, recData.IsLowConfidence(0.6))
+	assert.False(t, recData.IsLowConfidence(0.4))
+}
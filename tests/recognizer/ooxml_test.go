@@ -0,0 +1,147 @@
+package recognizer_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"watchtower/internal/support/task/application/service/recognizer"
+	"watchtower/internal/support/task/infrastructure/ooxml"
+)
+
+const (
+	TestCoreProperties = `<cp:coreProperties xmlns:cp="http://schemas.openxmlformats.org/package/2006/metadata/core-properties" xmlns:dc="http://purl.org/dc/elements/1.1/" xmlns:dcterms="http://purl.org/dc/terms/">
+<dc:title>Supply contract</dc:title><dc:creator>Ivan</dc:creator><dcterms:created>2024-01-02T10:00:00Z</dcterms:created>
+</cp:coreProperties>`
+
+	TestWordDocument = `<w:document xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main"><w:body>
+<w:p><w:r><w:t>Contract</w:t><w:tab/><w:t>No 1</w:t></w:r></w:p>
+<w:tbl><w:tr><w:tc><w:p><w:r><w:t>Party</w:t></w:r></w:p></w:tc><w:tc><w:p><w:r><w:t>Acme</w:t></w:r></w:p></w:tc></w:tr></w:tbl>
+<w:p><w:r><w:instrText>PAGE</w:instrText></w:r></w:p>
+</w:body></w:document>`
+
+	TestWorkbook = `<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">
+<sheets><sheet name="Prices" sheetId="1" r:id="rId1"/></sheets></workbook>`
+
+	TestWorkbookRels = `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/></Relationships>`
+
+	TestSharedStrings = `<sst xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">
+<si><t>Item</t></si><si><r><t>Pr</t></r><r><t>ice</t></r></si></sst>`
+
+	TestWorksheet = `<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>
+<row r="1"><c r="A1" t="s"><v>0</v></c><c r="B1" t="s"><v>1</v></c></row>
+<row r="2"><c r="A2" t="inlineStr"><is><t>Bolt</t></is></c><c r="B2"><v>10</v></c></row>
+</sheetData></worksheet>`
+
+	TestPresentation = `<p:presentation xmlns:p="http://schemas.openxmlformats.org/presentationml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">
+<p:sldIdLst><p:sldId id="256" r:id="rId2"/></p:sldIdLst></p:presentation>`
+
+	TestPresentationRels = `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+<Relationship Id="rId2" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/slide" Target="slides/slide1.xml"/></Relationships>`
+
+	TestSlide = `<p:sld xmlns:p="http://schemas.openxmlformats.org/presentationml/2006/main" xmlns:a="http://schemas.openxmlformats.org/drawingml/2006/main">
+<p:cSld><p:spTree><p:sp><p:txBody><a:p><a:r><a:t>Quarter results</a:t></a:r></a:p></p:txBody></p:sp></p:spTree></p:cSld></p:sld>`
+
+	TestSlideRels = `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/notesSlide" Target="../notesSlides/notesSlide1.xml"/></Relationships>`
+
+	TestNotesSlide = `<p:notes xmlns:p="http://schemas.openxmlformats.org/presentationml/2006/main" xmlns:a="http://schemas.openxmlformats.org/drawingml/2006/main">
+<p:cSld><p:spTree><p:sp><p:txBody><a:p><a:r><a:t>Speak slowly</a:t></a:r></a:p></p:txBody></p:sp></p:spTree></p:cSld></p:notes>`
+)
+
+func buildTestPackage(t *testing.T, parts map[string]string) *bytes.Buffer {
+	buffer := bytes.NewBuffer(nil)
+	zipWriter := zip.NewWriter(buffer)
+	for name, content := range parts {
+		partWriter, err := zipWriter.Create(name)
+		assert.NoError(t, err, "failed to create package part")
+		_, err = partWriter.Write([]byte(content))
+		assert.NoError(t, err, "failed to write package part")
+	}
+
+	assert.NoError(t, zipWriter.Close(), "failed to close package")
+	return buffer
+}
+
+func TestOoxmlParser(t *testing.T) {
+	ctx := context.Background()
+	ooxmlParser := ooxml.New()
+
+	var ooxmlTestCases = []struct {
+		Name             string
+		FileName         string
+		Parts            map[string]string
+		ExpectedText     string
+		ExpectedMetadata map[string]string
+		IsFailed         bool
+	}{
+		{
+			Name:     "Word document",
+			FileName: "contract.docx",
+			Parts: map[string]string{
+				"word/document.xml": TestWordDocument,
+				"docProps/core.xml": TestCoreProperties,
+			},
+			ExpectedText: "Contract\tNo 1\nParty\tAcme",
+			ExpectedMetadata: map[string]string{
+				"title":   "Supply contract",
+				"author":  "Ivan",
+				"created": "2024-01-02T10:00:00Z",
+			},
+		},
+		{
+			Name:     "Spreadsheet",
+			FileName: "prices.xlsx",
+			Parts: map[string]string{
+				"xl/workbook.xml":            TestWorkbook,
+				"xl/_rels/workbook.xml.rels": TestWorkbookRels,
+				"xl/sharedStrings.xml":       TestSharedStrings,
+				"xl/worksheets/sheet1.xml":   TestWorksheet,
+			},
+			ExpectedText:     "Sheet: Prices\nItem\tPrice\nBolt\t10",
+			ExpectedMetadata: map[string]string{},
+		},
+		{
+			Name:     "Presentation with notes",
+			FileName: "results.pptx",
+			Parts: map[string]string{
+				"ppt/presentation.xml":             TestPresentation,
+				"ppt/_rels/presentation.xml.rels":  TestPresentationRels,
+				"ppt/slides/slide1.xml":            TestSlide,
+				"ppt/slides/_rels/slide1.xml.rels": TestSlideRels,
+				"ppt/notesSlides/notesSlide1.xml":  TestNotesSlide,
+			},
+			ExpectedText:     "Slide 1\nQuarter results\nNotes:\nSpeak slowly",
+			ExpectedMetadata: map[string]string{},
+		},
+		{
+			Name:     "Unknown package",
+			FileName: "archive.zip",
+			Parts:    map[string]string{"readme.txt": "text"},
+			IsFailed: true,
+		},
+	}
+
+	for _, testCase := range ooxmlTestCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			params := &recognizer.RecognizeParams{
+				FileName: testCase.FileName,
+				FileData: buildTestPackage(t, testCase.Parts),
+			}
+
+			recData, err := ooxmlParser.Recognize(ctx, params)
+			if testCase.IsFailed {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err, "failed to recognize package")
+			assert.Equal(t, testCase.ExpectedText, recData.Text)
+			assert.Equal(t, testCase.ExpectedMetadata, recData.Metadata)
+		})
+	}
+}
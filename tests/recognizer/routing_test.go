@@ -0,0 +1,122 @@
+package recognizer_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"watchtower/internal/support/task/application/service/recognizer"
+	"watchtower/internal/support/task/infrastructure/routing"
+	"watchtower/tests/common/mocks"
+)
+
+const (
+	LocalRecognizerName  = "local"
+	RemoteRecognizerName = "remote"
+	RecognizeMethodName  = "Recognize"
+)
+
+var TestRoutingConfig = routing.Config{
+	Fallback: []string{RemoteRecognizerName},
+	Routes: []routing.Route{
+		{
+			MimeTypes:   []string{"text/*", "application/json"},
+			Recognizers: []string{LocalRecognizerName, RemoteRecognizerName},
+		},
+	},
+}
+
+func TestRoutingRecognizer(t *testing.T) {
+	ctx := context.Background()
+
+	var routingTestCases = []struct {
+		Name                string
+		FileName            string
+		LocalError          error
+		RemoteError         error
+		ExpectedText        string
+		ExpectedLocalCalls  int
+		ExpectedRemoteCalls int
+		IsFailed            bool
+	}{
+		{
+			Name:                "Text file is recognized locally",
+			FileName:            "notes.txt",
+			ExpectedText:        LocalRecognizerName,
+			ExpectedLocalCalls:  1,
+			ExpectedRemoteCalls: 0,
+		},
+		{
+			Name:                "Text file falls back to remote",
+			FileName:            "notes.txt",
+			LocalError:          errors.New("broken encoding"),
+			ExpectedText:        RemoteRecognizerName,
+			ExpectedLocalCalls:  1,
+			ExpectedRemoteCalls: 1,
+		},
+		{
+			Name:                "PDF file is routed to fallback",
+			FileName:            "scan.pdf",
+			ExpectedText:        RemoteRecognizerName,
+			ExpectedLocalCalls:  0,
+			ExpectedRemoteCalls: 1,
+		},
+		{
+			Name:                "All recognizers failed",
+			FileName:            "notes.txt",
+			LocalError:          errors.New("broken encoding"),
+			RemoteError:         errors.New("service unavailable"),
+			ExpectedLocalCalls:  1,
+			ExpectedRemoteCalls: 1,
+			IsFailed:            true,
+		},
+	}
+
+	for _, testCase := range routingTestCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			localRecognizer := new(mocks.MockRecognizer)
+			localRecognizer.
+				On(RecognizeMethodName, mock.Anything).
+				Return(&recognizer.Recognized{Text: LocalRecognizerName}, testCase.LocalError)
+
+			remoteRecognizer := new(mocks.MockRecognizer)
+			remoteRecognizer.
+				On(RecognizeMethodName, mock.Anything).
+				Return(&recognizer.Recognized{Text: RemoteRecognizerName}, testCase.RemoteError)
+
+			recognizers := map[string]recognizer.IRecognizer{
+				LocalRecognizerName:  localRecognizer,
+				RemoteRecognizerName: remoteRecognizer,
+			}
+
+			routingRecognizer, err := routing.New(TestRoutingConfig, recognizers)
+			assert.NoError(t, err, "failed to create routing recognizer")
+
+			params := &recognizer.RecognizeParams{
+				FileName: testCase.FileName,
+				FileData: bytes.NewBufferString("test file content"),
+			}
+
+			recData, err := routingRecognizer.Recognize(ctx, params)
+			if testCase.IsFailed {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err, "failed to recognize file")
+				assert.Equal(t, testCase.ExpectedText, recData.Text)
+			}
+
+			localRecognizer.AssertNumberOfCalls(t, RecognizeMethodName, testCase.ExpectedLocalCalls)
+			remoteRecognizer.AssertNumberOfCalls(t, RecognizeMethodName, testCase.ExpectedRemoteCalls)
+		})
+	}
+
+	t.Run("Unknown recognizer in config", func(t *testing.T) {
+		config := routing.Config{Fallback: []string{"unknown"}}
+		_, err := routing.New(config, map[string]recognizer.IRecognizer{})
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,128 @@
+package recognizer_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/encoding/charmap"
+
+	"watchtower/internal/support/task/application/service/recognizer"
+	"watchtower/internal/support/task/infrastructure/textparser"
+)
+
+const TestCyrillicText = "Договор поставки оборудования"
+
+const TestEmailMessage = "From: =?UTF-8?B?0JjQstCw0L0=?= <ivan@example.com>\r\n" +
+	"To: team@example.com\r\n" +
+	"Subject: =?KOI8-R?B?9MXT1A==?=\r\n" +
+	"MIME-Version: 1.0\r\n" +
+	"Content-Type: multipart/alternative; boundary=\"boundary\"\r\n" +
+	"\r\n" +
+	"--boundary\r\n" +
+	"Content-Type: text/plain; charset=utf-8\r\n" +
+	"Content-Transfer-Encoding: base64\r\n" +
+	"\r\n" +
+	"0J/RgNC40LLQtdGCINC40Lcg0L/QuNGB0YzQvNCw\r\n" +
+	"--boundary\r\n" +
+	"Content-Type: text/html; charset=utf-8\r\n" +
+	"\r\n" +
+	"<p>html part</p>\r\n" +
+	"--boundary--\r\n"
+
+func encodeText(t *testing.T, encoder *charmap.Charmap, text string) []byte {
+	encoded, err := encoder.NewEncoder().Bytes([]byte(text))
+	assert.NoError(t, err, "failed to encode test text")
+	return encoded
+}
+
+func TestTextParser(t *testing.T) {
+	ctx := context.Background()
+	textParser := textparser.New()
+
+	var textParserTestCases = []struct {
+		Name         string
+		FileName     string
+		ContentType  string
+		FileData     []byte
+		ExpectedText string
+		IsFailed     bool
+	}{
+		{
+			Name:         "UTF-8 plain text",
+			FileName:     "contract.txt",
+			ContentType:  "text/plain; charset=utf-8",
+			FileData:     []byte(TestCyrillicText),
+			ExpectedText: TestCyrillicText,
+		},
+		{
+			Name:         "Windows-1251 sniffed as utf-8",
+			FileName:     "contract.txt",
+			ContentType:  "text/plain; charset=utf-8",
+			FileData:     encodeText(t, charmap.Windows1251, TestCyrillicText),
+			ExpectedText: TestCyrillicText,
+		},
+		{
+			Name:         "KOI8-R markdown",
+			FileName:     "contract.md",
+			ContentType:  "",
+			FileData:     encodeText(t, charmap.KOI8R, TestCyrillicText),
+			ExpectedText: TestCyrillicText,
+		},
+		{
+			Name:         "HTML document",
+			FileName:     "page.html",
+			ContentType:  "text/html",
+			FileData:     []byte("<html><head><title>Title</title><style>p{}</style></head><body><p>First &amp; second</p><script>alert(1)</script><div>Third</div></body></html>"),
+			ExpectedText: "Title\nFirst & second\nThird",
+		},
+		{
+			Name:         "JSON document",
+			FileName:     "data.json",
+			ContentType:  "text/plain; charset=utf-8",
+			FileData:     []byte(`{"name": "contract", "parties": [{"title": "Acme"}], "sum": 100}`),
+			ExpectedText: "name: contract\nparties.title: Acme\nsum: 100\n",
+		},
+		{
+			Name:         "Email message",
+			FileName:     "letter.eml",
+			ContentType:  "message/rfc822",
+			FileData:     []byte(TestEmailMessage),
+			ExpectedText: "Subject: Тест\nFrom: Иван <ivan@example.com>\nTo: team@example.com\n\nПривет из письма",
+		},
+		{
+			Name:        "Invalid JSON document",
+			FileName:    "data.json",
+			ContentType: "application/json",
+			FileData:    []byte(`{"name": `),
+			IsFailed:    true,
+		},
+		{
+			Name:        "Unsupported binary document",
+			FileName:    "scan.pdf",
+			ContentType: "application/pdf",
+			FileData:    []byte("%PDF-1.4"),
+			IsFailed:    true,
+		},
+	}
+
+	for _, testCase := range textParserTestCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			params := &recognizer.RecognizeParams{
+				FileName:    testCase.FileName,
+				FileData:    bytes.NewBuffer(testCase.FileData),
+				ContentType: testCase.ContentType,
+			}
+
+			recData, err := textParser.Recognize(ctx, params)
+			if testCase.IsFailed {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err, "failed to recognize text")
+			assert.Equal(t, testCase.ExpectedText, recData.Text)
+		})
+	}
+}
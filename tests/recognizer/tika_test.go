@@ -0,0 +1,126 @@
+package recognizer_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"watchtower/internal/support/task/application/service/recognizer"
+	"watchtower/internal/support/task/infrastructure/tika"
+)
+
+const TestTikaMetadata = `{
+	"Content-Type": "application/pdf",
+	"dc:title": "Supply contract",
+	"dc:creator": ["Ivan", "Petr"],
+	"dc:language": "ru",
+	"xmpTPg:NPages": "3"
+}`
+
+type fakeTikaRequest struct {
+	Accept             string
+	ContentType        string
+	ContentDisposition string
+	Body               string
+}
+
+func newFakeTikaServer(t *testing.T, text string, requests map[string]fakeTikaRequest) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPut, r.Method)
+
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err, "failed to read request body")
+
+		requests[r.URL.Path] = fakeTikaRequest{
+			Accept:             r.Header.Get("Accept"),
+			ContentType:        r.Header.Get("Content-Type"),
+			ContentDisposition: r.Header.Get("Content-Disposition"),
+			Body:               string(body),
+		}
+
+		switch r.URL.Path {
+		case tika.TextURL:
+			_, _ = w.Write([]byte(text))
+		case tika.MetadataURL:
+			_, _ = w.Write([]byte(TestTikaMetadata))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func TestTikaClient(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Extract text and metadata", func(t *testing.T) {
+		requests := make(map[string]fakeTikaRequest)
+		server := newFakeTikaServer(t, "\nSupply contract text\n", requests)
+		defer server.Close()
+
+		tikaClient := tika.New(tika.Config{Address: server.URL, Timeout: 10, ExtractMetadata: true})
+		params := &recognizer.RecognizeParams{
+			FileName:    "docs/contract.pdf",
+			FileData:    bytes.NewBufferString("%PDF-1.4"),
+			ContentType: "application/pdf",
+		}
+
+		recData, err := tikaClient.Recognize(ctx, params)
+		assert.NoError(t, err, "failed to recognize document")
+
+		assert.Equal(t, "Supply contract text", recData.Text)
+		assert.Equal(t, "ru", recData.Language)
+		assert.Equal(t, 3, recData.PageCount)
+		assert.Equal(t, "Supply contract", recData.Metadata["title"])
+		assert.Equal(t, "Ivan, Petr", recData.Metadata["author"])
+
+		textRequest := requests[tika.TextURL]
+		assert.Equal(t, "text/plain", textRequest.Accept)
+		assert.Equal(t, "application/pdf", textRequest.ContentType)
+		assert.Equal(t, "attachment; filename=contract.pdf", textRequest.ContentDisposition)
+		assert.Equal(t, "%PDF-1.4", textRequest.Body)
+
+		metaRequest := requests[tika.MetadataURL]
+		assert.Equal(t, "application/json", metaRequest.Accept)
+		assert.Equal(t, "%PDF-1.4", metaRequest.Body)
+	})
+
+	t.Run("Skip metadata and content type hint", func(t *testing.T) {
+		requests := make(map[string]fakeTikaRequest)
+		server := newFakeTikaServer(t, "scanned text", requests)
+		defer server.Close()
+
+		tikaClient := tika.New(tika.Config{Address: server.URL, Timeout: 10, ExtractMetadata: false})
+		params := &recognizer.RecognizeParams{
+			FileName: "scan",
+			FileData: bytes.NewBufferString("binary data"),
+		}
+
+		recData, err := tikaClient.Recognize(ctx, params)
+		assert.NoError(t, err, "failed to recognize document")
+		assert.Equal(t, "scanned text", recData.Text)
+		assert.Empty(t, recData.Metadata)
+
+		assert.Empty(t, requests[tika.TextURL].ContentType)
+		assert.NotContains(t, requests, tika.MetadataURL)
+	})
+
+	t.Run("Empty content is error", func(t *testing.T) {
+		requests := make(map[string]fakeTikaRequest)
+		server := newFakeTikaServer(t, " \n", requests)
+		defer server.Close()
+
+		tikaClient := tika.New(tika.Config{Address: server.URL, Timeout: 10})
+		params := &recognizer.RecognizeParams{
+			FileName: "empty.pdf",
+			FileData: bytes.NewBufferString("%PDF-1.4"),
+		}
+
+		_, err := tikaClient.Recognize(ctx, params)
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,118 @@
+package routes_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"watchtower/cmd"
+	"watchtower/cmd/watchtower/httpserver/form"
+	"watchtower/internal/core/cloud/domain"
+	"watchtower/internal/support/task/infrastructure/clamd"
+	"watchtower/tests/common"
+
+	taskApp "watchtower/internal/support/task/application"
+	taskDomain "watchtower/internal/support/task/domain"
+)
+
+// nolint
+func TestAntivirusUploadRoutes(t *testing.T) {
+	servConfig, err := cmd.InitConfig()
+	assert.NoError(t, err, "failed to read config file")
+
+	fakeClamd, err := common.NewFakeClamd("tcp", "127.0.0.1:0", 1024*1024)
+	assert.NoError(t, err, "failed to launch fake clamd")
+	defer fakeClamd.Close()
+
+	servConfig.Orchestrator.Antivirus.Enabled = true
+	servConfig.Orchestrator.Antivirus.ScanOnUpload = true
+	servConfig.Orchestrator.Antivirus.QuarantinePrefix = "quarantine"
+
+	scanner := clamd.New(clamd.Config{Network: "tcp", Address: fakeClamd.Address(), Timeout: 10})
+
+	var uploadScannedTestCases = []struct {
+		Name               string
+		FileData           string
+		ExpectedStoredPath string
+		ExpectedStatus     taskDomain.TaskStatus
+		ExpectedPublished  int
+	}{
+		{
+			Name:               "Clean file is uploaded",
+			FileData:           "quarterly report",
+			ExpectedStoredPath: "reports/report.txt",
+			ExpectedStatus:     taskDomain.Received,
+			ExpectedPublished:  1,
+		},
+		{
+			Name:               "Infected file is quarantined",
+			FileData:           common.EicarTestData,
+			ExpectedStoredPath: "quarantine/reports/report.txt",
+			ExpectedStatus:     taskDomain.Quarantined,
+			ExpectedPublished:  0,
+		},
+	}
+
+	for _, testCase := range uploadScannedTestCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			ctx := context.Background()
+
+			testEnv := common.InitTestAppEnvironment()
+			appServer, err := testEnv.BuildAppServer(servConfig, taskApp.WithAntivirus(scanner))
+			assert.NoError(t, err, "failed to build app server")
+
+			testEnv.ObjectStorage.
+				On(IsBucketExistsMethodName, TestBucketName).
+				Return(true, nil)
+
+			testEnv.ObjectStorage.
+				On(StoreObjectMethodName, TestBucketName, mock.MatchedBy(func(params *domain.UploadObjectParams) bool {
+					return params.FilePath == testCase.ExpectedStoredPath
+				})).
+				Return(testCase.ExpectedStoredPath, nil)
+
+			testEnv.TaskStorage.
+				On("UpdateTask", mock.Anything).
+				Return(nil)
+
+			testEnv.TaskQueue.
+				On("Publish", mock.Anything).
+				Return(nil)
+
+			buffer := bytes.NewBuffer(nil)
+			writer := multipart.NewWriter(buffer)
+			assert.NoError(t, writer.WriteField("prefix", "reports"))
+			fileWriter, err := writer.CreateFormFile("files", "report.txt")
+			assert.NoError(t, err, "failed to create form file")
+			_, err = fileWriter.Write([]byte(testCase.FileData))
+			assert.NoError(t, err, "failed to write form file")
+			assert.NoError(t, writer.Close())
+
+			targetURL := fmt.Sprintf("/api/v1/cloud/%s/file/upload", TestBucketName)
+			req := httptest.NewRequestWithContext(ctx, http.MethodPut, targetURL, buffer)
+			req.Header.Set("Content-Type", writer.FormDataContentType())
+
+			resp, respErr := appServer.Server.Test(req, -1)
+			assert.NoError(t, respErr, "failed to upload file")
+			assert.Equal(t, http.StatusOK, resp.StatusCode, "unexpected http status code")
+
+			var tasks []form.TaskSchema
+			err = json.NewDecoder(resp.Body).Decode(&tasks)
+			assert.NoError(t, err, "failed to decode response body")
+			assert.Len(t, tasks, 1)
+			assert.Equal(t, int(testCase.ExpectedStatus), tasks[0].Status)
+			assert.Equal(t, testCase.ExpectedStoredPath, tasks[0].ObjectID)
+
+			testEnv.ObjectStorage.AssertNumberOfCalls(t, StoreObjectMethodName, 1)
+			testEnv.TaskQueue.AssertNumberOfCalls(t, "Publish", testCase.ExpectedPublished)
+		})
+	}
+}
@@ -0,0 +1,150 @@
+package routes_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"watchtower/cmd"
+	"watchtower/cmd/watchtower/httpserver/form"
+	"watchtower/tests/common"
+
+	taskDomain "watchtower/internal/support/task/domain"
+)
+
+const TestArchiveName = "contracts.zip"
+
+func buildTestZipArchive(t *testing.T, entries map[string]string) []byte {
+	buffer := bytes.NewBuffer(nil)
+	zipWriter := zip.NewWriter(buffer)
+	for name, content := range entries {
+		entryWriter, err := zipWriter.Create(name)
+		assert.NoError(t, err, "failed to create zip entry")
+		_, err = entryWriter.Write([]byte(content))
+		assert.NoError(t, err, "failed to write zip entry")
+	}
+
+	assert.NoError(t, zipWriter.Close(), "failed to close zip archive")
+	return buffer.Bytes()
+}
+
+// nolint
+func TestArchiveUploadRoutes(t *testing.T) {
+	servConfig, err := cmd.InitConfig()
+	assert.NoError(t, err, "failed to read config file")
+
+	servConfig.Orchestrator.Archive.Enabled = true
+	servConfig.Orchestrator.Archive.KeepOriginal = false
+	servConfig.Orchestrator.Archive.MaxEntries = 3
+
+	var uploadArchiveTestCases = []struct {
+		Entries               map[string]string
+		ExpectedStoredObjects int
+		ExpectedChildren      int
+		ExpectedDeletedTimes  int
+		ExpectedStatus        taskDomain.TaskStatus
+	}{
+		{
+			Entries: map[string]string{
+				"contract-1.txt":        "first contract",
+				"scans/contract-2.txt":  "second contract",
+				"__MACOSX/._contract-1": "service entry",
+			},
+			ExpectedStoredObjects: 3,
+			ExpectedChildren:      2,
+			ExpectedDeletedTimes:  1,
+			ExpectedStatus:        taskDomain.Successful,
+		},
+		{
+			Entries: map[string]string{
+				"../../etc/passwd": "unsafe entry",
+			},
+			ExpectedStoredObjects: 1,
+			ExpectedChildren:      0,
+			ExpectedDeletedTimes:  0,
+			ExpectedStatus:        taskDomain.Failed,
+		},
+		{
+			Entries: map[string]string{
+				"contract-1.txt": "first contract",
+				"contract-2.txt": "second contract",
+				"contract-3.txt": "third contract",
+				"contract-4.txt": "fourth contract",
+			},
+			ExpectedStoredObjects: 1,
+			ExpectedChildren:      0,
+			ExpectedDeletedTimes:  0,
+			ExpectedStatus:        taskDomain.Failed,
+		},
+	}
+
+	t.Run("Upload archive", func(t *testing.T) {
+		ctx := context.Background()
+
+		for index, testCase := range uploadArchiveTestCases {
+			testCaseName := fmt.Sprintf("Upload archive case %d", index)
+			t.Run(testCaseName, func(t *testing.T) {
+				testEnv := common.InitTestAppEnvironment()
+				appServer, err := testEnv.BuildAppServer(servConfig)
+				assert.NoError(t, err, "failed to build app server")
+
+				testEnv.ObjectStorage.
+					On(IsBucketExistsMethodName, TestBucketName).
+					Return(true, nil)
+
+				testEnv.ObjectStorage.
+					On(StoreObjectMethodName, TestBucketName, mock.Anything).
+					Return(TestArchiveName, nil)
+
+				testEnv.ObjectStorage.
+					On(DeleteObjectMethodName, TestBucketName, TestArchiveName).
+					Return(nil)
+
+				testEnv.TaskStorage.
+					On("UpdateTask", mock.Anything).
+					Return(nil)
+
+				testEnv.TaskQueue.
+					On("Publish", mock.Anything).
+					Return(nil)
+
+				buffer := bytes.NewBuffer(nil)
+				writer := multipart.NewWriter(buffer)
+				fileWriter, err := writer.CreateFormFile("files", TestArchiveName)
+				assert.NoError(t, err, "failed to create form file")
+				_, err = fileWriter.Write(buildTestZipArchive(t, testCase.Entries))
+				assert.NoError(t, err, "failed to write form file")
+				assert.NoError(t, writer.Close())
+
+				targetURL := fmt.Sprintf("/api/v1/cloud/%s/file/upload", TestBucketName)
+				req := httptest.NewRequestWithContext(ctx, http.MethodPut, targetURL, buffer)
+				req.Header.Set("Content-Type", writer.FormDataContentType())
+
+				resp, respErr := appServer.Server.Test(req, -1)
+				assert.NoError(t, respErr, "failed to upload archive")
+				assert.Equal(t, http.StatusOK, resp.StatusCode, "unexpected http status code")
+
+				var tasks []form.TaskSchema
+				err = json.NewDecoder(resp.Body).Decode(&tasks)
+				assert.NoError(t, err, "failed to decode response body")
+				assert.Len(t, tasks, 1)
+				assert.Equal(t, TestArchiveName, tasks[0].ObjectID)
+				assert.Equal(t, int(testCase.ExpectedStatus), tasks[0].Status)
+				assert.Len(t, tasks[0].Children, testCase.ExpectedChildren)
+
+				testEnv.ObjectStorage.AssertNumberOfCalls(t, StoreObjectMethodName, testCase.ExpectedStoredObjects)
+				testEnv.ObjectStorage.AssertNumberOfCalls(t, DeleteObjectMethodName, testCase.ExpectedDeletedTimes)
+				testEnv.TaskQueue.AssertNumberOfCalls(t, "Publish", testCase.ExpectedChildren)
+			})
+		}
+	})
+}
@@ -0,0 +1,144 @@
+package routes_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"watchtower/cmd"
+	"watchtower/cmd/watchtower/httpserver/form"
+	"watchtower/internal/core/cloud/domain"
+	"watchtower/tests/common"
+)
+
+// nolint
+func TestBucketConfigRoutes(t *testing.T) {
+	servConfig, err := cmd.InitConfig()
+	assert.NoError(t, err, "failed to read config file")
+
+	versioning := true
+	storedConfig := domain.BucketConfig{
+		Versioning: &versioning,
+		Lifecycle:  []domain.LifecycleRule{{ID: "expire-tmp/", Prefix: "tmp/", ExpirationDays: 7}},
+		ObjectLock: &domain.ObjectLockConfig{Enabled: true, Mode: domain.RetentionGovernance, RetentionDays: 30},
+	}
+
+	var configTestCases = []struct {
+		Name               string
+		Method             string
+		TargetURL          string
+		Body               string
+		BucketExists       bool
+		StorageError       error
+		ExpectedStatusCode int
+	}{
+		{
+			Name:               "Get bucket config",
+			Method:             http.MethodGet,
+			TargetURL:          fmt.Sprintf("/api/v1/cloud/%s/config", TestBucketName),
+			BucketExists:       true,
+			ExpectedStatusCode: http.StatusOK,
+		},
+		{
+			Name:               "Get config of unknown bucket",
+			Method:             http.MethodGet,
+			TargetURL:          fmt.Sprintf("/api/v1/cloud/%s/config", TestBucketName),
+			ExpectedStatusCode: http.StatusNotFound,
+		},
+		{
+			Name:               "Set lifecycle rules",
+			Method:             http.MethodPut,
+			TargetURL:          fmt.Sprintf("/api/v1/cloud/%s/config", TestBucketName),
+			Body:               `{"lifecycle": [{"prefix": "tmp/", "expiration_days": 7}]}`,
+			BucketExists:       true,
+			ExpectedStatusCode: http.StatusOK,
+		},
+		{
+			Name:               "Set lifecycle rule expiring nothing",
+			Method:             http.MethodPut,
+			TargetURL:          fmt.Sprintf("/api/v1/cloud/%s/config", TestBucketName),
+			Body:               `{"lifecycle": [{"prefix": "tmp/"}]}`,
+			BucketExists:       true,
+			ExpectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			Name:               "Set unsupported quota",
+			Method:             http.MethodPut,
+			TargetURL:          fmt.Sprintf("/api/v1/cloud/%s/config", TestBucketName),
+			Body:               `{"quota_bytes": 1024}`,
+			BucketExists:       true,
+			StorageError:       domain.ErrBucketConfigNotSupported,
+			ExpectedStatusCode: http.StatusNotImplemented,
+		},
+		{
+			Name:               "Create bucket with object lock",
+			Method:             http.MethodPut,
+			TargetURL:          "/api/v1/cloud/bucket",
+			Body:               `{"bucket_name": "locked-bucket", "config": {"versioning": true, "object_lock": {"enabled": true}}}`,
+			ExpectedStatusCode: http.StatusCreated,
+		},
+		{
+			Name:               "Create bucket with retention of disabled object lock",
+			Method:             http.MethodPut,
+			TargetURL:          "/api/v1/cloud/bucket",
+			Body:               `{"bucket_name": "locked-bucket", "config": {"object_lock": {"mode": "COMPLIANCE", "retention_days": 1}}}`,
+			ExpectedStatusCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, testCase := range configTestCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			testEnv := common.InitTestAppEnvironment()
+			appServer, err := testEnv.BuildAppServer(servConfig)
+			assert.NoError(t, err, "failed to build app server")
+
+			testEnv.ObjectStorage.
+				On(IsBucketExistsMethodName, mock.Anything).
+				Return(testCase.BucketExists, nil)
+			testEnv.ObjectStorage.
+				On("GetBucketConfig", TestBucketName).
+				Return(storedConfig, nil)
+			testEnv.ObjectStorage.
+				On("SetBucketConfig", TestBucketName, mock.MatchedBy(func(config *domain.BucketConfig) bool {
+					return config.Versioning == nil && config.ObjectLock == nil
+				})).
+				Return(testCase.StorageError)
+			testEnv.ObjectStorage.
+				On("CreateBucketWithConfig", "locked-bucket", mock.MatchedBy(func(config *domain.BucketConfig) bool {
+					return config.ObjectLock != nil && config.ObjectLock.Enabled
+				})).
+				Return(nil)
+
+			var body *bytes.Buffer
+			if testCase.Body != "" {
+				body = bytes.NewBufferString(testCase.Body)
+			} else {
+				body = &bytes.Buffer{}
+			}
+
+			req := httptest.NewRequestWithContext(context.Background(), testCase.Method, testCase.TargetURL, body)
+			req.Header.Set("Content-Type", "application/json")
+
+			resp, respErr := appServer.Server.Test(req, -1)
+			assert.NoError(t, respErr, "failed to send request")
+			assert.Equal(t, testCase.ExpectedStatusCode, resp.StatusCode, "unexpected http status code")
+
+			if testCase.Method == http.MethodGet && resp.StatusCode == http.StatusOK {
+				var schema form.BucketConfigSchema
+				err = json.NewDecoder(resp.Body).Decode(&schema)
+				assert.NoError(t, err, "failed to decode response body")
+				assert.True(t, *schema.Versioning)
+				assert.Equal(t, "tmp/", schema.Lifecycle[0].Prefix)
+				assert.Equal(t, domain.RetentionGovernance, schema.ObjectLock.Mode)
+				assert.Nil(t, schema.QuotaBytes)
+			}
+		})
+	}
+}
@@ -0,0 +1,163 @@
+package routes_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"watchtower/cmd"
+	"watchtower/cmd/watchtower/httpserver/form"
+	"watchtower/internal/core/cloud/domain"
+	"watchtower/tests/common"
+)
+
+const (
+	TestDstBucketName = "watchtower-archive-bucket"
+
+	MoveDocumentsMethodName = "MoveDocuments"
+)
+
+// nolint
+func TestCrossBucketCopyRoutes(t *testing.T) {
+	servConfig, err := cmd.InitConfig()
+	assert.NoError(t, err, "failed to read config file")
+
+	prefixObjects := []domain.Object{
+		{Name: "a.txt", Path: "reports/a.txt", Metadata: map[string]string{"department": "legal"}},
+		{Name: "b.txt", Path: "reports/2025/b.txt"},
+	}
+
+	var copyTestCases = []struct {
+		Name               string
+		Body               string
+		DstBucketExists    bool
+		ExpectedStatusCode int
+		ExpectedDstBucket  string
+		ExpectedCopies     map[string]string
+		ExpectedTasks      []string
+		ExpectedCleanups   int
+		ExpectedMoves      int
+	}{
+		{
+			Name:               "Copy file into another bucket",
+			Body:               `{"src_path": "reports/a.txt", "dst_bucket": "watchtower-archive-bucket"}`,
+			DstBucketExists:    true,
+			ExpectedStatusCode: http.StatusOK,
+			ExpectedDstBucket:  TestDstBucketName,
+			ExpectedCopies:     map[string]string{"reports/a.txt": "reports/a.txt"},
+			ExpectedTasks:      []string{"reports/a.txt"},
+		},
+		{
+			Name:               "Move prefix into another bucket",
+			Body:               `{"src_path": "reports/", "dst_path": "archive", "dst_bucket": "watchtower-archive-bucket", "with_remove": true}`,
+			DstBucketExists:    true,
+			ExpectedStatusCode: http.StatusOK,
+			ExpectedDstBucket:  TestDstBucketName,
+			ExpectedCopies: map[string]string{
+				"reports/a.txt":      "archive/a.txt",
+				"reports/2025/b.txt": "archive/2025/b.txt",
+			},
+			ExpectedTasks:    []string{"archive/a.txt", "archive/2025/b.txt"},
+			ExpectedCleanups: 2,
+		},
+		{
+			Name:               "Copy prefix inside bucket is indexed by tasks",
+			Body:               `{"src_path": "reports/", "dst_path": "archive"}`,
+			ExpectedStatusCode: http.StatusOK,
+			ExpectedCopies: map[string]string{
+				"reports/a.txt":      "archive/a.txt",
+				"reports/2025/b.txt": "archive/2025/b.txt",
+			},
+			ExpectedTasks: []string{"archive/a.txt", "archive/2025/b.txt"},
+		},
+		{
+			Name:               "Move prefix inside bucket moves documents",
+			Body:               `{"src_path": "reports/", "dst_path": "archive", "with_remove": true}`,
+			ExpectedStatusCode: http.StatusOK,
+			ExpectedCopies: map[string]string{
+				"reports/a.txt":      "archive/a.txt",
+				"reports/2025/b.txt": "archive/2025/b.txt",
+			},
+			ExpectedMoves: 2,
+		},
+		{
+			Name:               "Destination bucket not found",
+			Body:               `{"src_path": "reports/a.txt", "dst_bucket": "watchtower-archive-bucket"}`,
+			DstBucketExists:    false,
+			ExpectedStatusCode: http.StatusNotFound,
+		},
+		{
+			Name:               "Destination path is required inside bucket",
+			Body:               `{"src_path": "reports/a.txt"}`,
+			ExpectedStatusCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, testCase := range copyTestCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			testEnv := common.InitTestAppEnvironment()
+			appServer, err := testEnv.BuildAppServer(servConfig)
+			assert.NoError(t, err, "failed to build app server")
+
+			testEnv.ObjectStorage.On(IsBucketExistsMethodName, TestBucketName).Return(true, nil)
+			testEnv.ObjectStorage.On(IsBucketExistsMethodName, TestDstBucketName).Return(testCase.DstBucketExists, nil)
+			testEnv.ObjectStorage.
+				On("WalkBucketObjects", TestBucketName, "reports/").
+				Return(prefixObjects, nil)
+			testEnv.ObjectStorage.On(CopyObjectMethodName, TestBucketName, mock.Anything).Return(nil)
+			testEnv.DocStorage.On(DeleteDocumentsMethodName, TestBucketName, mock.Anything).Return(nil)
+			testEnv.DocStorage.On(MoveDocumentsMethodName, TestBucketName, mock.Anything, mock.Anything).Return(nil)
+			testEnv.TaskQueue.On("Publish", mock.Anything).Return(nil)
+			testEnv.TaskStorage.On("UpdateTask", mock.Anything).Return(nil)
+
+			targetURL := fmt.Sprintf("/api/v1/cloud/%s/file", TestBucketName)
+			body := bytes.NewBufferString(testCase.Body)
+			req := httptest.NewRequestWithContext(context.Background(), http.MethodPatch, targetURL, body)
+			req.Header.Set("Content-Type", "application/json")
+
+			resp, respErr := appServer.Server.Test(req, -1)
+			assert.NoError(t, respErr, "failed to send request")
+			assert.Equal(t, testCase.ExpectedStatusCode, resp.StatusCode, "unexpected http status code")
+
+			testEnv.ObjectStorage.AssertNumberOfCalls(t, CopyObjectMethodName, len(testCase.ExpectedCopies))
+			for srcPath, dstPath := range testCase.ExpectedCopies {
+				testEnv.ObjectStorage.AssertCalled(t, CopyObjectMethodName, TestBucketName,
+					mock.MatchedBy(func(params *domain.CopyObjectParams) bool {
+						return params.SourcePath == srcPath &&
+							params.DestinationPath == dstPath &&
+							params.DestinationBucket == testCase.ExpectedDstBucket &&
+							params.Metadata == nil
+					}))
+			}
+
+			testEnv.DocStorage.AssertNumberOfCalls(t, DeleteDocumentsMethodName, testCase.ExpectedCleanups)
+			testEnv.DocStorage.AssertNumberOfCalls(t, MoveDocumentsMethodName, testCase.ExpectedMoves)
+			testEnv.TaskQueue.AssertNumberOfCalls(t, "Publish", len(testCase.ExpectedTasks))
+
+			if testCase.ExpectedStatusCode == http.StatusOK && testCase.ExpectedDstBucket == "" {
+				respData, err := io.ReadAll(resp.Body)
+				assert.NoError(t, err, "failed to read response body")
+				assert.Equal(t, "Ok", string(respData))
+			}
+
+			if testCase.ExpectedStatusCode == http.StatusOK && testCase.ExpectedDstBucket != "" {
+				var tasks []form.TaskSchema
+				err = json.NewDecoder(resp.Body).Decode(&tasks)
+				assert.NoError(t, err, "failed to decode response body")
+				assert.Len(t, tasks, len(testCase.ExpectedTasks))
+				for index, task := range tasks {
+					assert.Equal(t, TestDstBucketName, task.BucketID)
+					assert.Equal(t, testCase.ExpectedTasks[index], task.ObjectID)
+				}
+			}
+		})
+	}
+}
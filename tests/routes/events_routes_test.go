@@ -0,0 +1,191 @@
+package routes_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"watchtower/cmd"
+	"watchtower/internal/core/cloud/domain"
+	"watchtower/internal/process"
+	"watchtower/tests/common"
+	"watchtower/tests/common/mocks"
+
+	taskApp "watchtower/internal/support/task/application"
+)
+
+const (
+	TestWebhookToken = "webhook-secret"
+
+	DeleteDocumentsMethodName = "DeleteDocuments"
+	GetObjectInfoMethodName   = "GetObjectInfo"
+)
+
+func buildNotification(eventName, objKey string, userMetadata string) string {
+	return fmt.Sprintf(`{"EventName": "%s", "Records": [{"eventName": "%s", "s3": {
+		"bucket": {"name": "%s"},
+		"object": {"key": "%s", "size": 16, "userMetadata": {%s}}
+	}}]}`, eventName, eventName, TestBucketName, objKey, userMetadata)
+}
+
+// nolint
+func TestEventsWebhookRoutes(t *testing.T) {
+	servConfig, err := cmd.InitConfig()
+	assert.NoError(t, err, "failed to read config file")
+
+	servConfig.Orchestrator.Events = process.EventsConfig{
+		StageConfig:  process.StageConfig{Enabled: true},
+		Source:       process.WebhookEventSource,
+		Prefixes:     []string{"incoming/"},
+		WebhookToken: TestWebhookToken,
+	}
+	servConfig.Orchestrator.PostProcess = process.PostProcessConfig{
+		StageConfig:        process.StageConfig{Enabled: true},
+		ProcessedPrefix:    "processed",
+		UnrecognizedPrefix: "unrecognized",
+	}
+
+	var eventsTestCases = []struct {
+		Name               string
+		Token              string
+		Body               string
+		MovedObject        bool
+		StoredObject       bool
+		ExpectedStatusCode int
+		ExpectedPublished  int
+		ExpectedCleanups   int
+	}{
+		{
+			Name:               "Created object is processed",
+			Token:              "Bearer " + TestWebhookToken,
+			Body:               buildNotification("s3:ObjectCreated:Put", "incoming%2Freport.pdf", ""),
+			ExpectedStatusCode: http.StatusOK,
+			ExpectedPublished:  1,
+		},
+		{
+			Name:               "Own upload is skipped",
+			Token:              TestWebhookToken,
+			Body:               buildNotification("s3:ObjectCreated:Put", "incoming%2Freport.pdf", ""),
+			StoredObject:       true,
+			ExpectedStatusCode: http.StatusOK,
+		},
+		{
+			Name:               "Copy of own upload is processed",
+			Token:              TestWebhookToken,
+			Body:               buildNotification("s3:ObjectCreated:Copy", "incoming%2Freport.pdf", `"X-Amz-Meta-Uploaded-By": "watchtower"`),
+			ExpectedStatusCode: http.StatusOK,
+			ExpectedPublished:  1,
+		},
+		{
+			Name:               "Object out of prefixes is skipped",
+			Token:              TestWebhookToken,
+			Body:               buildNotification("s3:ObjectCreated:Put", "other%2Freport.pdf", ""),
+			ExpectedStatusCode: http.StatusOK,
+		},
+		{
+			Name:               "Removed object is cleaned up from index",
+			Token:              TestWebhookToken,
+			Body:               buildNotification("s3:ObjectRemoved:Delete", "incoming%2Freport.pdf", ""),
+			ExpectedStatusCode: http.StatusOK,
+			ExpectedCleanups:   1,
+		},
+		{
+			Name:               "Moved object is not cleaned up",
+			Token:              TestWebhookToken,
+			Body:               buildNotification("s3:ObjectRemoved:Delete", "incoming%2Freport.pdf", ""),
+			MovedObject:        true,
+			ExpectedStatusCode: http.StatusOK,
+		},
+		{
+			Name:               "Removed processed object is cleaned up from index",
+			Token:              TestWebhookToken,
+			Body:               buildNotification("s3:ObjectRemoved:Delete", "processed%2Fincoming%2Freport.pdf", ""),
+			ExpectedStatusCode: http.StatusOK,
+			ExpectedCleanups:   1,
+		},
+		{
+			Name:               "Created processed object is skipped",
+			Token:              TestWebhookToken,
+			Body:               buildNotification("s3:ObjectCreated:Put", "processed%2Fincoming%2Freport.pdf", ""),
+			ExpectedStatusCode: http.StatusOK,
+		},
+		{
+			Name:               "Invalid token",
+			Token:              "Bearer wrong",
+			Body:               buildNotification("s3:ObjectCreated:Put", "incoming%2Freport.pdf", ""),
+			ExpectedStatusCode: http.StatusUnauthorized,
+		},
+		{
+			Name:               "Malformed notification",
+			Token:              TestWebhookToken,
+			Body:               `{"Records": "none"}`,
+			ExpectedStatusCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, testCase := range eventsTestCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			storedObjects := mocks.NewMockStoredObjectStorage()
+			if testCase.StoredObject {
+				assert.NoError(t, storedObjects.MarkStored(context.Background(), TestBucketName, "incoming/report.pdf"))
+			}
+
+			testEnv := common.InitTestAppEnvironment()
+			appServer, err := testEnv.BuildAppServer(servConfig, taskApp.WithStoredObjects(storedObjects))
+			assert.NoError(t, err, "failed to build app server")
+
+			var movedErr error
+			if !testCase.MovedObject {
+				movedErr = fmt.Errorf("object not found")
+			}
+
+			testEnv.ObjectStorage.
+				On(GetObjectInfoMethodName, TestBucketName, "processed/incoming/report.pdf").
+				Return(domain.Object{}, movedErr)
+			testEnv.ObjectStorage.
+				On(GetObjectInfoMethodName, TestBucketName, "unrecognized/incoming/report.pdf").
+				Return(domain.Object{}, fmt.Errorf("object not found"))
+
+			testEnv.TaskQueue.On("Publish", mock.Anything).Return(nil)
+			testEnv.TaskStorage.On("UpdateTask", mock.Anything).Return(nil)
+			testEnv.DocStorage.On(DeleteDocumentsMethodName, TestBucketName, "incoming/report.pdf").Return(nil)
+			testEnv.DocStorage.On(DeleteDocumentsMethodName, TestBucketName, "processed/incoming/report.pdf").Return(nil)
+
+			body := bytes.NewBufferString(testCase.Body)
+			req := httptest.NewRequestWithContext(context.Background(), http.MethodPost, "/api/v1/events/s3", body)
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("Authorization", testCase.Token)
+
+			resp, respErr := appServer.Server.Test(req, -1)
+			assert.NoError(t, respErr, "failed to send events")
+			assert.Equal(t, testCase.ExpectedStatusCode, resp.StatusCode, "unexpected http status code")
+
+			testEnv.TaskQueue.AssertNumberOfCalls(t, "Publish", testCase.ExpectedPublished)
+			testEnv.DocStorage.AssertNumberOfCalls(t, DeleteDocumentsMethodName, testCase.ExpectedCleanups)
+			assert.Zero(t, storedObjects.StoredCount(), "mark of stored object must be taken")
+		})
+	}
+
+	t.Run("Disabled webhook", func(t *testing.T) {
+		disabledConfig := *servConfig
+		disabledConfig.Orchestrator.Events.Source = process.AMQPEventSource
+
+		testEnv := common.InitTestAppEnvironment()
+		appServer, err := testEnv.BuildAppServer(&disabledConfig)
+		assert.NoError(t, err, "failed to build app server")
+
+		body := bytes.NewBufferString(buildNotification("s3:ObjectCreated:Put", "incoming%2Freport.pdf", ""))
+		req := httptest.NewRequestWithContext(context.Background(), http.MethodPost, "/api/v1/events/s3", body)
+		req.Header.Set("Authorization", TestWebhookToken)
+
+		resp, respErr := appServer.Server.Test(req, -1)
+		assert.NoError(t, respErr, "failed to send events")
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode, "unexpected http status code")
+	})
+}
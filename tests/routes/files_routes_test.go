@@ -0,0 +1,135 @@
+package routes_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"watchtower/cmd"
+	"watchtower/cmd/watchtower/httpserver/form"
+	"watchtower/internal/core/cloud/domain"
+	"watchtower/tests/common"
+)
+
+const (
+	GetBucketObjectsMethodName     = "GetBucketObjects"
+	GetBucketObjectsPageMethodName = "GetBucketObjectsPage"
+)
+
+func encodePageToken(position string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(position))
+}
+
+// nolint
+func TestGetFilesRoutes(t *testing.T) {
+	servConfig, err := cmd.InitConfig()
+	assert.NoError(t, err, "failed to read config file")
+
+	modifiedAt := time.Now().Truncate(time.Second)
+	listedObjects := []domain.Object{
+		{Name: "a.txt", Path: "incoming/a.txt", Size: 30, LastModified: modifiedAt.Add(-time.Hour)},
+		{Name: "b.txt", Path: "incoming/b.txt", Size: 10, LastModified: modifiedAt},
+		{Name: "c.txt", Path: "incoming/c.txt", Size: 20, LastModified: modifiedAt.Add(-time.Minute)},
+		{Name: ".keeper", Path: "incoming/.keeper"},
+	}
+
+	pathToken := encodePageToken("path:incoming/0.txt")
+	offsetToken := encodePageToken("offset:2")
+
+	var getFilesTestCases = []struct {
+		Name               string
+		Body               string
+		ExpectedStatusCode int
+		ExpectedPaths      []string
+		ExpectedNextToken  string
+	}{
+		{
+			Name:               "Page ordered by name is listed by storage",
+			Body:               fmt.Sprintf(`{"directory": "incoming/", "limit": 2, "continuation_token": "%s"}`, pathToken),
+			ExpectedStatusCode: http.StatusOK,
+			ExpectedPaths:      []string{"incoming/a.txt", "incoming/b.txt"},
+			ExpectedNextToken:  encodePageToken("path:incoming/b.txt"),
+		},
+		{
+			Name:               "Page ordered by size descending",
+			Body:               `{"directory": "incoming/", "limit": 2, "sort_by": "size", "sort_desc": true}`,
+			ExpectedStatusCode: http.StatusOK,
+			ExpectedPaths:      []string{"incoming/a.txt", "incoming/c.txt"},
+			ExpectedNextToken:  offsetToken,
+		},
+		{
+			// Keeper file is skipped before paging, so it does not shift offset.
+			Name:               "Last page ordered by modified time",
+			Body:               fmt.Sprintf(`{"directory": "incoming/", "limit": 2, "sort_by": "modified", "continuation_token": "%s"}`, offsetToken),
+			ExpectedStatusCode: http.StatusOK,
+			ExpectedPaths:      []string{"incoming/b.txt"},
+		},
+		{
+			Name:               "Token of listing ordered by name is rejected by sorted listing",
+			Body:               fmt.Sprintf(`{"directory": "incoming/", "sort_by": "size", "continuation_token": "%s"}`, pathToken),
+			ExpectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			Name:               "Raw offset token is rejected",
+			Body:               `{"directory": "incoming/", "sort_by": "size", "continuation_token": "2"}`,
+			ExpectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			Name:               "Unsupported sorting",
+			Body:               `{"directory": "incoming/", "sort_by": "owner"}`,
+			ExpectedStatusCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, testCase := range getFilesTestCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			testEnv := common.InitTestAppEnvironment()
+			appServer, err := testEnv.BuildAppServer(servConfig)
+			assert.NoError(t, err, "failed to build app server")
+
+			testEnv.ObjectStorage.
+				On(GetBucketObjectsMethodName, TestBucketName, mock.Anything).
+				Return(slices.Clone(listedObjects), nil)
+			testEnv.ObjectStorage.
+				On(GetBucketObjectsPageMethodName, TestBucketName, mock.MatchedBy(func(params *domain.GetObjectsParams) bool {
+					return params.ContinuationToken == "incoming/0.txt" && params.Limit == 2 &&
+						slices.Equal(params.SkipNames, []string{".keeper"})
+				})).
+				Return(domain.ObjectsPage{Objects: listedObjects[:2], NextToken: "incoming/b.txt"}, nil)
+
+			targetURL := fmt.Sprintf("/api/v1/cloud/%s/files", TestBucketName)
+			body := bytes.NewBufferString(testCase.Body)
+			req := httptest.NewRequestWithContext(context.Background(), http.MethodPost, targetURL, body)
+			req.Header.Set("Content-Type", "application/json")
+
+			resp, respErr := appServer.Server.Test(req, -1)
+			assert.NoError(t, respErr, "failed to get files")
+			assert.Equal(t, testCase.ExpectedStatusCode, resp.StatusCode, "unexpected http status code")
+
+			if resp.StatusCode != http.StatusOK {
+				return
+			}
+
+			var page form.ObjectsPageSchema
+			err = json.NewDecoder(resp.Body).Decode(&page)
+			assert.NoError(t, err, "failed to decode response body")
+
+			paths := make([]string, len(page.Objects))
+			for index, object := range page.Objects {
+				paths[index] = object.Path
+			}
+			assert.Equal(t, testCase.ExpectedPaths, paths)
+			assert.Equal(t, testCase.ExpectedNextToken, page.NextToken)
+		})
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -66,6 +67,12 @@ var (
 		return filePathFlag
 	})
 
+	MatchedUploadFileParams = mock.MatchedBy(func(params *domain.UploadObjectParams) bool {
+		contentTypeFlag := params.ContentType == TestObjectContentType
+		metadataFlag := params.Metadata["author"] == "tester" && params.Metadata["project"] == "watchtower"
+		return params.FilePath == TestObjectName && contentTypeFlag && metadataFlag
+	})
+
 	MatchedCopyFilesParams = mock.MatchedBy(func(params *domain.CopyObjectParams) bool {
 		srcPathFlag := params.SourcePath == TestObjectPath
 		dstPathFlag := params.DestinationPath == TestObjectNewPath
@@ -152,6 +159,8 @@ func TestObjectAPIRoutes(t *testing.T) {
 				testEnv.ObjectStorage.
 					On(testCase.MockMethodName, TestBucketName, MatchedCopyFilesParams).
 					Return(testCase.ReturnedError)
+				testEnv.TaskStorage.On("UpdateTask", mock.Anything).Return(nil)
+				testEnv.TaskQueue.On("Publish", mock.Anything).Return(nil)
 
 				var buffer = bytes.NewBuffer(nil)
 				if testCase.RequestPayload != nil {
@@ -265,6 +274,82 @@ func TestObjectAPIRoutes(t *testing.T) {
 		}
 	})
 
+	var uploadFileTestCases = []struct {
+		TargetURL           string
+		HttpMethod          string
+		IsBucketExists      bool
+		ReturnedError       error
+		ExpectedCalledTimes int
+		ExpectedStatusCode  int
+	}{
+		{
+			TargetURL:           fmt.Sprintf("/api/v1/cloud/%s/file/upload", TestBucketName),
+			HttpMethod:          http.MethodPut,
+			IsBucketExists:      true,
+			ReturnedError:       nil,
+			ExpectedCalledTimes: 1,
+			ExpectedStatusCode:  http.StatusOK,
+		},
+		{
+			TargetURL:           fmt.Sprintf("/api/v1/cloud/%s/file/upload", TestBucketName),
+			HttpMethod:          http.MethodPut,
+			IsBucketExists:      false,
+			ReturnedError:       nil,
+			ExpectedCalledTimes: 0,
+			ExpectedStatusCode:  http.StatusNotFound,
+		},
+	}
+
+	//nolint
+	t.Run("Upload file with metadata", func(t *testing.T) {
+		ctx := context.Background()
+
+		for index, testCase := range uploadFileTestCases {
+			testCaseName := fmt.Sprintf("Upload file case %d", index)
+			t.Run(testCaseName, func(t *testing.T) {
+				testEnv := common.InitTestAppEnvironment()
+				appServer, err := testEnv.BuildAppServer(servConfig)
+				assert.NoError(t, err, "failed to build app server")
+
+				testEnv.ObjectStorage.
+					On(IsBucketExistsMethodName, TestBucketName).
+					Return(testCase.IsBucketExists, nil)
+
+				testEnv.ObjectStorage.
+					On(StoreObjectMethodName, TestBucketName, MatchedUploadFileParams).
+					Return(TestObjectName, testCase.ReturnedError)
+
+				testEnv.TaskStorage.
+					On("UpdateTask", mock.Anything).
+					Return(nil)
+
+				testEnv.TaskQueue.
+					On("Publish", mock.Anything).
+					Return(nil)
+
+				buffer := bytes.NewBuffer(nil)
+				writer := multipart.NewWriter(buffer)
+				assert.NoError(t, writer.WriteField("content_type", TestObjectContentType))
+				assert.NoError(t, writer.WriteField("x-meta-project", "watchtower"))
+				fileWriter, err := writer.CreateFormFile("files", TestObjectName)
+				assert.NoError(t, err, "failed to create form file")
+				_, err = fileWriter.Write([]byte("test file content"))
+				assert.NoError(t, err, "failed to write form file")
+				assert.NoError(t, writer.Close())
+
+				req := httptest.NewRequestWithContext(ctx, testCase.HttpMethod, testCase.TargetURL, buffer)
+				req.Header.Set("Content-Type", writer.FormDataContentType())
+				req.Header.Set("X-Meta-Author", "tester")
+
+				resp, respErr := appServer.Server.Test(req, -1)
+				assert.NoError(t, respErr, "failed to upload file")
+				assert.Equal(t, testCase.ExpectedStatusCode, resp.StatusCode, "unexpected http status code")
+
+				testEnv.ObjectStorage.AssertNumberOfCalls(t, StoreObjectMethodName, testCase.ExpectedCalledTimes)
+			})
+		}
+	})
+
 	var deleteFolderTestCases = []struct {
 		TargetURL           string
 		HttpMethod          string
@@ -0,0 +1,132 @@
+package routes_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"watchtower/cmd"
+	"watchtower/cmd/watchtower/httpserver/form"
+	"watchtower/internal/core/cloud/domain"
+	"watchtower/tests/common"
+	"watchtower/tests/common/mocks"
+
+	taskApp "watchtower/internal/support/task/application"
+)
+
+// nolint
+func TestPresignedUploadRoutes(t *testing.T) {
+	servConfig, err := cmd.InitConfig()
+	assert.NoError(t, err, "failed to read config file")
+
+	uploadURL, _ := url.Parse("http://localhost:9000/watchtower-test-bucket/incoming/report.txt")
+	presigned := &domain.PresignedUpload{
+		Method:  domain.PresignPutMethod,
+		URL:     uploadURL,
+		Headers: map[string]string{"X-Amz-Meta-Uploaded-By": "watchtower"},
+	}
+
+	var presignTestCases = []struct {
+		Name               string
+		TargetURL          string
+		Body               string
+		StoredObject       domain.Object
+		PresignError       error
+		ExpectedStatusCode int
+	}{
+		{
+			Name:               "Get upload url",
+			TargetURL:          "upload-url",
+			Body:               `{"file_path": "incoming/report.txt", "size": 6}`,
+			ExpectedStatusCode: http.StatusOK,
+		},
+		{
+			Name:               "Unsupported upload method",
+			TargetURL:          "upload-url",
+			Body:               `{"file_path": "incoming/report.txt", "method": "PATCH", "size": 6}`,
+			ExpectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			Name:               "Storage does not support upload urls",
+			TargetURL:          "upload-url",
+			Body:               `{"file_path": "incoming/report.txt", "size": 6}`,
+			PresignError:       domain.ErrPresignNotSupported,
+			ExpectedStatusCode: http.StatusNotImplemented,
+		},
+		{
+			Name:               "Complete upload",
+			TargetURL:          "upload-complete",
+			Body:               `{"file_path": "incoming/report.txt"}`,
+			StoredObject:       domain.Object{Size: 6},
+			ExpectedStatusCode: http.StatusCreated,
+		},
+		{
+			Name:               "Complete upload of mismatched size",
+			TargetURL:          "upload-complete",
+			Body:               `{"file_path": "incoming/report.txt"}`,
+			StoredObject:       domain.Object{Size: 7},
+			ExpectedStatusCode: http.StatusUnprocessableEntity,
+		},
+		{
+			Name:               "Complete unknown upload",
+			TargetURL:          "upload-complete",
+			Body:               `{"file_path": "incoming/other.txt"}`,
+			ExpectedStatusCode: http.StatusNotFound,
+		},
+	}
+
+	for _, testCase := range presignTestCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			testEnv := common.InitTestAppEnvironment()
+			tickets := mocks.NewMockTicketStorage()
+			appServer, err := testEnv.BuildAppServer(servConfig, taskApp.WithUploadTickets(tickets))
+			assert.NoError(t, err, "failed to build app server")
+
+			testEnv.ObjectStorage.
+				On(IsBucketExistsMethodName, TestBucketName).
+				Return(true, nil)
+			testEnv.ObjectStorage.
+				On("GenUploadURL", TestBucketName, mock.Anything).
+				Return(presigned, testCase.PresignError)
+			testEnv.ObjectStorage.
+				On(GetObjectInfoMethodName, TestBucketName, "incoming/report.txt").
+				Return(testCase.StoredObject, nil)
+			testEnv.TaskQueue.On("Publish", mock.Anything).Return(nil)
+			testEnv.TaskStorage.On("UpdateTask", mock.Anything).Return(nil)
+
+			if testCase.TargetURL == "upload-complete" {
+				ticketURL := fmt.Sprintf("/api/v1/cloud/%s/file/upload-url", TestBucketName)
+				body := bytes.NewBufferString(`{"file_path": "incoming/report.txt", "size": 6}`)
+				req := httptest.NewRequestWithContext(context.Background(), http.MethodPost, ticketURL, body)
+				req.Header.Set("Content-Type", "application/json")
+				_, respErr := appServer.Server.Test(req, -1)
+				assert.NoError(t, respErr, "failed to get upload url")
+			}
+
+			targetURL := fmt.Sprintf("/api/v1/cloud/%s/file/%s", TestBucketName, testCase.TargetURL)
+			body := bytes.NewBufferString(testCase.Body)
+			req := httptest.NewRequestWithContext(context.Background(), http.MethodPost, targetURL, body)
+			req.Header.Set("Content-Type", "application/json")
+
+			resp, respErr := appServer.Server.Test(req, -1)
+			assert.NoError(t, respErr, "failed to send request")
+			assert.Equal(t, testCase.ExpectedStatusCode, resp.StatusCode, "unexpected http status code")
+
+			if resp.StatusCode == http.StatusOK {
+				var schema form.PresignedUploadSchema
+				err = json.NewDecoder(resp.Body).Decode(&schema)
+				assert.NoError(t, err, "failed to decode response body")
+				assert.Equal(t, uploadURL.String(), schema.URL)
+				assert.Equal(t, "watchtower", schema.Headers["X-Amz-Meta-Uploaded-By"])
+			}
+		})
+	}
+}
@@ -0,0 +1,147 @@
+package routes_test
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"watchtower/cmd"
+	"watchtower/cmd/watchtower/httpserver/form"
+	"watchtower/internal/core/cloud/domain"
+	"watchtower/tests/common"
+)
+
+const WalkBucketObjectsMethodName = "WalkBucketObjects"
+
+// nolint
+func TestSearchFilesRoutes(t *testing.T) {
+	servConfig, err := cmd.InitConfig()
+	assert.NoError(t, err, "failed to read config file")
+
+	modifiedAt := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	walkedObjects := []domain.Object{
+		{Name: "a.pdf", Path: "incoming/a.pdf", Size: 300, LastModified: modifiedAt, ContentType: "application/pdf"},
+		{Name: ".keeper", Path: "incoming/nested/.keeper", LastModified: modifiedAt},
+		{Name: "b.pdf", Path: "incoming/nested/b.pdf", Size: 100, LastModified: modifiedAt.AddDate(0, 1, 0)},
+		{Name: "c.txt", Path: "incoming/nested/c.txt", Size: 200, LastModified: modifiedAt, ContentType: "text/plain"},
+	}
+
+	var searchTestCases = []struct {
+		Name               string
+		Body               string
+		BucketExists       bool
+		WalkError          error
+		ExpectedStatusCode int
+		ExpectedPaths      []string
+		ExpectedError      bool
+	}{
+		{
+			Name:               "Search files by glob",
+			Body:               `{"prefix": "incoming/", "glob": "*.pdf"}`,
+			BucketExists:       true,
+			ExpectedStatusCode: http.StatusOK,
+			ExpectedPaths:      []string{"incoming/a.pdf", "incoming/nested/b.pdf"},
+		},
+		{
+			Name:               "Search files by size and modified time",
+			Body:               `{"prefix": "incoming/", "min_size": 150, "modified_before": "2025-06-02T00:00:00Z"}`,
+			BucketExists:       true,
+			ExpectedStatusCode: http.StatusOK,
+			ExpectedPaths:      []string{"incoming/a.pdf", "incoming/nested/c.txt"},
+		},
+		{
+			Name:               "Search files by content type requests missing info",
+			Body:               `{"prefix": "incoming/", "content_type": "application/pdf", "limit": 1, "regex": "nested/"}`,
+			BucketExists:       true,
+			ExpectedStatusCode: http.StatusOK,
+			ExpectedPaths:      []string{"incoming/nested/b.pdf"},
+		},
+		{
+			Name:               "Failed search is reported by last line",
+			Body:               `{"prefix": "incoming/"}`,
+			BucketExists:       true,
+			WalkError:          assert.AnError,
+			ExpectedStatusCode: http.StatusOK,
+			ExpectedPaths:      []string{},
+			ExpectedError:      true,
+		},
+		{
+			Name:               "Invalid regex",
+			Body:               `{"prefix": "incoming/", "regex": "a.pdf("}`,
+			BucketExists:       true,
+			ExpectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			Name:               "Bucket not found",
+			Body:               `{"prefix": "incoming/"}`,
+			ExpectedStatusCode: http.StatusNotFound,
+		},
+	}
+
+	for _, testCase := range searchTestCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			testEnv := common.InitTestAppEnvironment()
+			appServer, err := testEnv.BuildAppServer(servConfig)
+			assert.NoError(t, err, "failed to build app server")
+
+			testEnv.ObjectStorage.
+				On(IsBucketExistsMethodName, TestBucketName).
+				Return(testCase.BucketExists, nil)
+			testEnv.ObjectStorage.
+				On(WalkBucketObjectsMethodName, TestBucketName, "incoming/").
+				Return(walkedObjects, testCase.WalkError)
+			testEnv.ObjectStorage.
+				On(GetObjectInfoMethodName, TestBucketName, "incoming/nested/b.pdf").
+				Return(domain.Object{ContentType: "application/pdf", Metadata: map[string]string{}}, nil)
+			testEnv.ObjectStorage.
+				On(GetObjectInfoMethodName, TestBucketName, mock.Anything).
+				Return(domain.Object{}, nil)
+
+			targetURL := fmt.Sprintf("/api/v1/cloud/%s/search", TestBucketName)
+			body := bytes.NewBufferString(testCase.Body)
+			req := httptest.NewRequestWithContext(context.Background(), http.MethodPost, targetURL, body)
+			req.Header.Set("Content-Type", "application/json")
+
+			resp, respErr := appServer.Server.Test(req, -1)
+			assert.NoError(t, respErr, "failed to search files")
+			assert.Equal(t, testCase.ExpectedStatusCode, resp.StatusCode, "unexpected http status code")
+
+			if resp.StatusCode != http.StatusOK {
+				return
+			}
+
+			assert.Equal(t, "application/x-ndjson", resp.Header.Get("Content-Type"))
+
+			paths := make([]string, 0)
+			var searchErr string
+			scanner := bufio.NewScanner(resp.Body)
+			for scanner.Scan() {
+				var line map[string]any
+				err = json.Unmarshal(scanner.Bytes(), &line)
+				assert.NoError(t, err, "failed to decode response line")
+
+				if message, ok := line["error"].(string); ok {
+					searchErr = message
+					continue
+				}
+
+				var object form.ObjectSchema
+				err = json.Unmarshal(scanner.Bytes(), &object)
+				assert.NoError(t, err, "failed to decode found object")
+				paths = append(paths, object.Path)
+			}
+
+			assert.Equal(t, testCase.ExpectedPaths, paths)
+			assert.Equal(t, testCase.ExpectedError, searchErr != "")
+		})
+	}
+}
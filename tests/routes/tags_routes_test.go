@@ -0,0 +1,134 @@
+package routes_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"watchtower/cmd"
+	"watchtower/cmd/watchtower/httpserver/form"
+	"watchtower/tests/common"
+)
+
+// nolint
+func TestFileTagsRoutes(t *testing.T) {
+	servConfig, err := cmd.InitConfig()
+	assert.NoError(t, err, "failed to read config file")
+
+	storedTags := map[string]string{"project": "alpha"}
+
+	var tagsTestCases = []struct {
+		Name               string
+		Method             string
+		TargetURL          string
+		Body               string
+		ExpectedStatusCode int
+		ExpectedPublished  bool
+	}{
+		{
+			Name:               "Get file tags",
+			Method:             http.MethodPost,
+			TargetURL:          "tags",
+			Body:               `{"file_path": "incoming/report.txt"}`,
+			ExpectedStatusCode: http.StatusOK,
+		},
+		{
+			Name:               "Set file tags",
+			Method:             http.MethodPut,
+			TargetURL:          "tags",
+			Body:               `{"file_path": "incoming/report.txt", "tags": {"project": "alpha"}}`,
+			ExpectedStatusCode: http.StatusOK,
+		},
+		{
+			Name:               "Set file tags with reindex",
+			Method:             http.MethodPut,
+			TargetURL:          "tags",
+			Body:               `{"file_path": "incoming/report.txt", "tags": {"project": "alpha"}, "reindex": true}`,
+			ExpectedStatusCode: http.StatusCreated,
+			ExpectedPublished:  true,
+		},
+		{
+			Name:               "Set invalid file tags",
+			Method:             http.MethodPut,
+			TargetURL:          "tags",
+			Body:               `{"file_path": "incoming/report.txt", "tags": {"": "empty key"}}`,
+			ExpectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			Name:               "Delete file tags with reindex",
+			Method:             http.MethodDelete,
+			TargetURL:          "tags",
+			Body:               `{"file_path": "incoming/report.txt", "reindex": true}`,
+			ExpectedStatusCode: http.StatusCreated,
+			ExpectedPublished:  true,
+		},
+		{
+			Name:               "Replace file metadata",
+			Method:             http.MethodPut,
+			TargetURL:          "metadata",
+			Body:               `{"file_path": "incoming/report.txt", "metadata": {"department": "legal"}}`,
+			ExpectedStatusCode: http.StatusOK,
+		},
+		{
+			Name:               "File path is required",
+			Method:             http.MethodPut,
+			TargetURL:          "metadata",
+			Body:               `{"metadata": {"department": "legal"}}`,
+			ExpectedStatusCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, testCase := range tagsTestCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			testEnv := common.InitTestAppEnvironment()
+			appServer, err := testEnv.BuildAppServer(servConfig)
+			assert.NoError(t, err, "failed to build app server")
+
+			testEnv.ObjectStorage.
+				On("GetObjectTags", TestBucketName, "incoming/report.txt").
+				Return(storedTags, nil)
+			testEnv.ObjectStorage.
+				On("SetObjectTags", TestBucketName, "incoming/report.txt", storedTags).
+				Return(nil)
+			testEnv.ObjectStorage.
+				On("DeleteObjectTags", TestBucketName, "incoming/report.txt").
+				Return(nil)
+			testEnv.ObjectStorage.
+				On("ReplaceObjectMetadata", TestBucketName, "incoming/report.txt", map[string]string{
+					"department": "legal",
+				}).
+				Return(nil)
+			testEnv.TaskQueue.On("Publish", mock.Anything).Return(nil)
+			testEnv.TaskStorage.On("UpdateTask", mock.Anything).Return(nil)
+
+			targetURL := fmt.Sprintf("/api/v1/cloud/%s/file/%s", TestBucketName, testCase.TargetURL)
+			body := bytes.NewBufferString(testCase.Body)
+			req := httptest.NewRequestWithContext(context.Background(), testCase.Method, targetURL, body)
+			req.Header.Set("Content-Type", "application/json")
+
+			resp, respErr := appServer.Server.Test(req, -1)
+			assert.NoError(t, respErr, "failed to send request")
+			assert.Equal(t, testCase.ExpectedStatusCode, resp.StatusCode, "unexpected http status code")
+
+			if testCase.ExpectedPublished {
+				testEnv.TaskQueue.AssertNumberOfCalls(t, "Publish", 1)
+			} else {
+				testEnv.TaskQueue.AssertNotCalled(t, "Publish", mock.Anything)
+			}
+
+			if testCase.Name == "Get file tags" {
+				var schema form.ObjectTagsSchema
+				err = json.NewDecoder(resp.Body).Decode(&schema)
+				assert.NoError(t, err, "failed to decode response body")
+				assert.Equal(t, storedTags, schema.Tags)
+			}
+		})
+	}
+}
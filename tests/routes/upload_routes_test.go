@@ -0,0 +1,233 @@
+package routes_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"watchtower/cmd"
+	"watchtower/internal/core/cloud/domain"
+	"watchtower/tests/common"
+	"watchtower/tests/common/mocks"
+
+	taskApp "watchtower/internal/support/task/application"
+)
+
+const (
+	TestUploadMultipartID = "multipart-upload-id"
+	TusVersion            = "1.0.0"
+)
+
+func encodeUploadMetadata(pairs ...string) string {
+	values := make([]string, 0, len(pairs)/2)
+	for i := 0; i+1 < len(pairs); i += 2 {
+		encoded := base64.StdEncoding.EncodeToString([]byte(pairs[i+1]))
+		values = append(values, fmt.Sprintf("%s %s", pairs[i], encoded))
+	}
+
+	return strings.Join(values, ",")
+}
+
+// nolint
+func TestUploadAPIRoutes(t *testing.T) {
+	servConfig, err := cmd.InitConfig()
+	assert.NoError(t, err, "failed to read config file")
+
+	servConfig.Orchestrator.Uploads.PartSize = 4
+
+	uploadsURL := fmt.Sprintf("/api/v1/uploads/%s", TestBucketName)
+
+	t.Run("Upload file by chunks", func(t *testing.T) {
+		testEnv := common.InitTestAppEnvironment()
+		uploads := mocks.NewMockUploadStorage()
+		appServer, err := testEnv.BuildAppServer(servConfig, taskApp.WithUploads(uploads))
+		assert.NoError(t, err, "failed to build app server")
+
+		testEnv.ObjectStorage.
+			On(IsBucketExistsMethodName, TestBucketName).
+			Return(true, nil)
+		testEnv.ObjectStorage.
+			On("CreateMultipartUpload", TestBucketName, mock.MatchedBy(func(params *domain.UploadObjectParams) bool {
+				return params.FilePath == "incoming/report.txt" && params.Metadata["author"] == "tester"
+			})).
+			Return(TestUploadMultipartID, nil)
+		testEnv.ObjectStorage.
+			On("GetUploadedPart", TestBucketName, "incoming/report.txt", TestUploadMultipartID, mock.Anything).
+			Return(nil, nil)
+		testEnv.ObjectStorage.
+			On("UploadPart", TestBucketName, "incoming/report.txt", TestUploadMultipartID, 1, "hello").
+			Return(domain.MultipartPart{Number: 1, ETag: "first"}, nil)
+		testEnv.ObjectStorage.
+			On("UploadPart", TestBucketName, "incoming/report.txt", TestUploadMultipartID, 2, " world").
+			Return(domain.MultipartPart{Number: 2, ETag: "second"}, nil)
+		testEnv.ObjectStorage.
+			On("CompleteMultipartUpload", TestBucketName, "incoming/report.txt", TestUploadMultipartID, mock.Anything).
+			Return(nil)
+		testEnv.TaskQueue.On("Publish", mock.Anything).Return(nil)
+		testEnv.TaskStorage.On("UpdateTask", mock.Anything).Return(nil)
+
+		req := httptest.NewRequestWithContext(context.Background(), http.MethodPost, uploadsURL, nil)
+		req.Header.Set("Tus-Resumable", TusVersion)
+		req.Header.Set("Upload-Length", "11")
+		req.Header.Set("Upload-Metadata", encodeUploadMetadata(
+			"filename", "report.txt",
+			"prefix", "incoming",
+			"author", "tester",
+		))
+
+		resp, respErr := appServer.Server.Test(req, -1)
+		assert.NoError(t, respErr, "failed to create upload")
+		assert.Equal(t, http.StatusCreated, resp.StatusCode, "unexpected http status code")
+		assert.Equal(t, TusVersion, resp.Header.Get("Tus-Resumable"))
+
+		location := resp.Header.Get("Location")
+		assert.True(t, strings.HasPrefix(location, uploadsURL+"/"), "unexpected upload location")
+
+		chunks := []struct {
+			Offset         string
+			Data           string
+			ExpectedStatus int
+			ExpectedOffset string
+		}{
+			{Offset: "0", Data: "hel", ExpectedStatus: http.StatusNoContent, ExpectedOffset: "3"},
+			{Offset: "0", Data: "hel", ExpectedStatus: http.StatusConflict},
+			{Offset: "3", Data: "lo", ExpectedStatus: http.StatusNoContent, ExpectedOffset: "5"},
+			{Offset: "5", Data: " world!", ExpectedStatus: http.StatusRequestEntityTooLarge},
+			{Offset: "5", Data: " world", ExpectedStatus: http.StatusNoContent, ExpectedOffset: "11"},
+		}
+
+		for _, chunk := range chunks {
+			req = httptest.NewRequestWithContext(context.Background(), http.MethodPatch, location, bytes.NewBufferString(chunk.Data))
+			req.Header.Set("Tus-Resumable", TusVersion)
+			req.Header.Set("Content-Type", "application/offset+octet-stream")
+			req.Header.Set("Upload-Offset", chunk.Offset)
+
+			resp, respErr = appServer.Server.Test(req, -1)
+			assert.NoError(t, respErr, "failed to write chunk")
+			assert.Equal(t, chunk.ExpectedStatus, resp.StatusCode, "unexpected http status code")
+			if chunk.ExpectedOffset != "" {
+				assert.Equal(t, chunk.ExpectedOffset, resp.Header.Get("Upload-Offset"))
+			}
+		}
+
+		testEnv.ObjectStorage.AssertExpectations(t)
+		testEnv.TaskQueue.AssertNumberOfCalls(t, "Publish", 1)
+
+		req = httptest.NewRequestWithContext(context.Background(), http.MethodHead, location, nil)
+		req.Header.Set("Tus-Resumable", TusVersion)
+		resp, respErr = appServer.Server.Test(req, -1)
+		assert.NoError(t, respErr, "failed to get upload offset")
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode, "completed upload must be removed")
+	})
+
+	var uploadTestCases = []struct {
+		Name               string
+		HttpMethod         string
+		TargetURL          string
+		Headers            map[string]string
+		ExpectedStatusCode int
+	}{
+		{
+			Name:               "Get uploads options",
+			HttpMethod:         http.MethodOptions,
+			TargetURL:          uploadsURL,
+			ExpectedStatusCode: http.StatusNoContent,
+		},
+		{
+			Name:               "Unsupported tus version",
+			HttpMethod:         http.MethodPost,
+			TargetURL:          uploadsURL,
+			Headers:            map[string]string{"Tus-Resumable": "0.2.2", "Upload-Length": "10"},
+			ExpectedStatusCode: http.StatusPreconditionFailed,
+		},
+		{
+			Name:               "Upload length is missing",
+			HttpMethod:         http.MethodPost,
+			TargetURL:          uploadsURL,
+			Headers:            map[string]string{"Tus-Resumable": TusVersion},
+			ExpectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			Name:       "Filename is missing",
+			HttpMethod: http.MethodPost,
+			TargetURL:  uploadsURL,
+			Headers: map[string]string{
+				"Tus-Resumable":   TusVersion,
+				"Upload-Length":   "10",
+				"Upload-Metadata": encodeUploadMetadata("filetype", "text/plain"),
+			},
+			ExpectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			Name:       "Upload is too large",
+			HttpMethod: http.MethodPost,
+			TargetURL:  uploadsURL,
+			Headers: map[string]string{
+				"Tus-Resumable":   TusVersion,
+				"Upload-Length":   fmt.Sprintf("%d", servConfig.Orchestrator.Uploads.MaxSize+1),
+				"Upload-Metadata": encodeUploadMetadata("filename", "huge.bin"),
+			},
+			ExpectedStatusCode: http.StatusRequestEntityTooLarge,
+		},
+		{
+			Name:       "Chunk of unknown upload",
+			HttpMethod: http.MethodPatch,
+			TargetURL:  fmt.Sprintf("%s/%s", uploadsURL, uuid.New()),
+			Headers: map[string]string{
+				"Tus-Resumable": TusVersion,
+				"Content-Type":  "application/offset+octet-stream",
+				"Upload-Offset": "0",
+			},
+			ExpectedStatusCode: http.StatusNotFound,
+		},
+		{
+			Name:       "Chunk of unsupported content type",
+			HttpMethod: http.MethodPatch,
+			TargetURL:  fmt.Sprintf("%s/%s", uploadsURL, uuid.New()),
+			Headers: map[string]string{
+				"Tus-Resumable": TusVersion,
+				"Content-Type":  "application/octet-stream",
+				"Upload-Offset": "0",
+			},
+			ExpectedStatusCode: http.StatusUnsupportedMediaType,
+		},
+		{
+			Name:               "Terminate unknown upload",
+			HttpMethod:         http.MethodDelete,
+			TargetURL:          fmt.Sprintf("%s/%s", uploadsURL, uuid.New()),
+			Headers:            map[string]string{"Tus-Resumable": TusVersion},
+			ExpectedStatusCode: http.StatusNotFound,
+		},
+	}
+
+	for _, testCase := range uploadTestCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			testEnv := common.InitTestAppEnvironment()
+			appServer, err := testEnv.BuildAppServer(servConfig, taskApp.WithUploads(mocks.NewMockUploadStorage()))
+			assert.NoError(t, err, "failed to build app server")
+
+			testEnv.ObjectStorage.
+				On(IsBucketExistsMethodName, TestBucketName).
+				Return(true, nil)
+
+			req := httptest.NewRequestWithContext(context.Background(), testCase.HttpMethod, testCase.TargetURL, nil)
+			for key, value := range testCase.Headers {
+				req.Header.Set(key, value)
+			}
+
+			resp, respErr := appServer.Server.Test(req, -1)
+			assert.NoError(t, respErr, "failed to send request")
+			assert.Equal(t, testCase.ExpectedStatusCode, resp.StatusCode, "unexpected http status code")
+			assert.Equal(t, TusVersion, resp.Header.Get("Tus-Resumable"))
+		})
+	}
+}
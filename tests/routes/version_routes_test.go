@@ -0,0 +1,149 @@
+package routes_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"watchtower/cmd"
+	"watchtower/cmd/watchtower/httpserver/form"
+	"watchtower/internal/core/cloud/domain"
+	"watchtower/tests/common"
+)
+
+// nolint
+func TestFileVersionsRoutes(t *testing.T) {
+	servConfig, err := cmd.InitConfig()
+	assert.NoError(t, err, "failed to read config file")
+
+	storedVersions := []domain.ObjectVersion{
+		{VersionID: "v2", Size: 10, LastModified: time.Now(), IsLatest: true},
+		{VersionID: "v1", Size: 8, LastModified: time.Now().Add(-time.Hour)},
+	}
+
+	var versionsTestCases = []struct {
+		Name               string
+		TargetURL          string
+		Body               string
+		Versions           []domain.ObjectVersion
+		StorageErr         error
+		ExpectedStatusCode int
+		ExpectedPublished  bool
+	}{
+		{
+			Name:               "Get file versions",
+			TargetURL:          "versions",
+			Body:               `{"file_path": "incoming/report.txt"}`,
+			Versions:           storedVersions,
+			ExpectedStatusCode: http.StatusOK,
+		},
+		{
+			Name:               "Get versions of missing file",
+			TargetURL:          "versions",
+			Body:               `{"file_path": "incoming/report.txt"}`,
+			Versions:           []domain.ObjectVersion{},
+			ExpectedStatusCode: http.StatusNotFound,
+		},
+		{
+			Name:               "Versions are not supported",
+			TargetURL:          "versions",
+			Body:               `{"file_path": "incoming/report.txt"}`,
+			Versions:           []domain.ObjectVersion{},
+			StorageErr:         domain.ErrVersioningNotSupported,
+			ExpectedStatusCode: http.StatusNotImplemented,
+		},
+		{
+			Name:               "Download file version",
+			TargetURL:          "version/download",
+			Body:               `{"file_path": "incoming/report.txt", "version_id": "v1"}`,
+			ExpectedStatusCode: http.StatusOK,
+		},
+		{
+			Name:               "Download missing file version",
+			TargetURL:          "version/download",
+			Body:               `{"file_path": "incoming/report.txt", "version_id": "v1"}`,
+			StorageErr:         domain.ErrVersionNotFound,
+			ExpectedStatusCode: http.StatusNotFound,
+		},
+		{
+			Name:               "Version id is required",
+			TargetURL:          "version/download",
+			Body:               `{"file_path": "incoming/report.txt"}`,
+			ExpectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			Name:               "Restore file version",
+			TargetURL:          "version/restore",
+			Body:               `{"file_path": "incoming/report.txt", "version_id": "v1"}`,
+			ExpectedStatusCode: http.StatusCreated,
+			ExpectedPublished:  true,
+		},
+		{
+			Name:               "Restore missing file version",
+			TargetURL:          "version/restore",
+			Body:               `{"file_path": "incoming/report.txt", "version_id": "v1"}`,
+			StorageErr:         domain.ErrVersionNotFound,
+			ExpectedStatusCode: http.StatusNotFound,
+		},
+	}
+
+	for _, testCase := range versionsTestCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			testEnv := common.InitTestAppEnvironment()
+			appServer, err := testEnv.BuildAppServer(servConfig)
+			assert.NoError(t, err, "failed to build app server")
+
+			testEnv.ObjectStorage.
+				On("GetObjectVersions", TestBucketName, "incoming/report.txt").
+				Return(testCase.Versions, testCase.StorageErr)
+			testEnv.ObjectStorage.
+				On("GetObjectVersionData", TestBucketName, "incoming/report.txt", "v1").
+				Return(bytes.NewBufferString("old content"), testCase.StorageErr)
+			testEnv.ObjectStorage.
+				On("RestoreObjectVersion", TestBucketName, mock.MatchedBy(func(params *domain.RestoreObjectParams) bool {
+					return params.FilePath == "incoming/report.txt" && params.VersionID == "v1"
+				})).
+				Return("v3", testCase.StorageErr)
+			testEnv.TaskQueue.On("Publish", mock.Anything).Return(nil)
+			testEnv.TaskStorage.On("UpdateTask", mock.Anything).Return(nil)
+
+			targetURL := fmt.Sprintf("/api/v1/cloud/%s/file/%s", TestBucketName, testCase.TargetURL)
+			body := bytes.NewBufferString(testCase.Body)
+			req := httptest.NewRequestWithContext(context.Background(), http.MethodPost, targetURL, body)
+			req.Header.Set("Content-Type", "application/json")
+
+			resp, respErr := appServer.Server.Test(req, -1)
+			assert.NoError(t, respErr, "failed to send request")
+			assert.Equal(t, testCase.ExpectedStatusCode, resp.StatusCode, "unexpected http status code")
+
+			if testCase.ExpectedPublished {
+				testEnv.TaskQueue.AssertNumberOfCalls(t, "Publish", 1)
+			} else {
+				testEnv.TaskQueue.AssertNotCalled(t, "Publish", mock.Anything)
+			}
+
+			switch testCase.Name {
+			case "Get file versions":
+				var versions []form.ObjectVersionSchema
+				err = json.NewDecoder(resp.Body).Decode(&versions)
+				assert.NoError(t, err, "failed to decode response body")
+				assert.Len(t, versions, 2)
+				assert.Equal(t, "v2", versions[0].VersionID)
+				assert.True(t, versions[0].IsLatest)
+			case "Download file version":
+				data, err := io.ReadAll(resp.Body)
+				assert.NoError(t, err, "failed to read response body")
+				assert.Equal(t, "old content", string(data))
+			}
+		})
+	}
+}
@@ -0,0 +1,223 @@
+package routes_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"watchtower/cmd"
+	"watchtower/cmd/watchtower/httpserver/form"
+	"watchtower/internal/support/task/domain"
+	"watchtower/tests/common"
+)
+
+const (
+	GetWatcherMethod     = "GetWatcher"
+	LoadWatchersMethod   = "GetAllWatchers"
+	UpdateWatcherMethod  = "UpdateWatcher"
+	DeleteWatcherMethod  = "DeleteWatcher"
+	TestWatcherDirectory = "./incoming"
+)
+
+// nolint
+func TestWatcherAPIRoutes(t *testing.T) {
+	servConfig, err := cmd.InitConfig()
+	assert.NoError(t, err, "failed to read config file")
+
+	testWatcher := domain.CreateNewWatcher(TestBucketName, TestWatcherDirectory)
+
+	t.Run("Create watcher", func(t *testing.T) {
+		var createWatcherTestCases = []struct {
+			Name               string
+			Body               string
+			BucketExists       bool
+			ExpectedStatusCode int
+			ExpectedStored     int
+		}{
+			{
+				Name:               "Watcher is registered",
+				Body:               fmt.Sprintf(`{"bucket": "%s", "suffix": "%s"}`, TestBucketName, TestWatcherDirectory),
+				BucketExists:       true,
+				ExpectedStatusCode: http.StatusCreated,
+				ExpectedStored:     1,
+			},
+			{
+				Name:               "Bucket does not exist",
+				Body:               fmt.Sprintf(`{"bucket": "%s", "suffix": "%s"}`, TestBucketName, TestWatcherDirectory),
+				BucketExists:       false,
+				ExpectedStatusCode: http.StatusNotFound,
+			},
+			{
+				Name:               "Bucket is missing",
+				Body:               `{"suffix": "incoming"}`,
+				ExpectedStatusCode: http.StatusBadRequest,
+			},
+		}
+
+		for _, testCase := range createWatcherTestCases {
+			t.Run(testCase.Name, func(t *testing.T) {
+				testEnv := common.InitTestAppEnvironment()
+				appServer, err := testEnv.BuildAppServer(servConfig)
+				assert.NoError(t, err, "failed to build app server")
+
+				testEnv.ObjectStorage.
+					On(IsBucketExistsMethodName, TestBucketName).
+					Return(testCase.BucketExists, nil)
+
+				testEnv.TaskStorage.
+					On(UpdateWatcherMethod, mock.MatchedBy(func(watcher *domain.Watcher) bool {
+						return watcher.BucketID == TestBucketName && watcher.Prefix == "incoming/"
+					})).
+					Return(nil)
+
+				body := bytes.NewBufferString(testCase.Body)
+				req := httptest.NewRequestWithContext(context.Background(), http.MethodPost, "/api/v1/watchers", body)
+				req.Header.Set("Content-Type", "application/json")
+
+				resp, respErr := appServer.Server.Test(req, -1)
+				assert.NoError(t, respErr, "failed to create watcher")
+				assert.Equal(t, testCase.ExpectedStatusCode, resp.StatusCode, "unexpected http status code")
+				testEnv.TaskStorage.AssertNumberOfCalls(t, UpdateWatcherMethod, testCase.ExpectedStored)
+
+				if resp.StatusCode == http.StatusCreated {
+					var watcher form.WatcherSchema
+					err = json.NewDecoder(resp.Body).Decode(&watcher)
+					assert.NoError(t, err, "failed to decode response body")
+					assert.Equal(t, "incoming/", watcher.Prefix)
+				}
+			})
+		}
+	})
+
+	var watcherTestCases = []struct {
+		Name                string
+		TargetURL           string
+		HttpMethod          string
+		Body                string
+		MockMethodName      string
+		ReturnedData        interface{}
+		ReturnedError       error
+		ExpectedCalledTimes int
+		ExpectedStatusCode  int
+	}{
+		{
+			Name:                "Load all watchers",
+			TargetURL:           "/api/v1/watchers",
+			HttpMethod:          http.MethodGet,
+			MockMethodName:      LoadWatchersMethod,
+			ReturnedData:        []*domain.Watcher{testWatcher},
+			ExpectedCalledTimes: 1,
+			ExpectedStatusCode:  http.StatusOK,
+		},
+		{
+			Name:                "Load watcher by id",
+			TargetURL:           fmt.Sprintf("/api/v1/watchers/%s", testWatcher.ID),
+			HttpMethod:          http.MethodGet,
+			MockMethodName:      GetWatcherMethod,
+			ReturnedData:        testWatcher,
+			ExpectedCalledTimes: 1,
+			ExpectedStatusCode:  http.StatusOK,
+		},
+		{
+			Name:                "Load unknown watcher",
+			TargetURL:           fmt.Sprintf("/api/v1/watchers/%s", uuid.New()),
+			HttpMethod:          http.MethodGet,
+			MockMethodName:      GetWatcherMethod,
+			ReturnedData:        (*domain.Watcher)(nil),
+			ReturnedError:       fmt.Errorf("redis error: %w", domain.ErrWatcherNotFound),
+			ExpectedCalledTimes: 1,
+			ExpectedStatusCode:  http.StatusNotFound,
+		},
+		{
+			Name:                "Load watcher by incorrect id",
+			TargetURL:           "/api/v1/watchers/incorrect-watcher-id",
+			HttpMethod:          http.MethodGet,
+			MockMethodName:      GetWatcherMethod,
+			ReturnedData:        testWatcher,
+			ExpectedCalledTimes: 0,
+			ExpectedStatusCode:  http.StatusBadRequest,
+		},
+		{
+			Name:                "Remove watcher",
+			TargetURL:           fmt.Sprintf("/api/v1/watchers/%s", testWatcher.ID),
+			HttpMethod:          http.MethodDelete,
+			MockMethodName:      DeleteWatcherMethod,
+			ExpectedCalledTimes: 1,
+			ExpectedStatusCode:  http.StatusOK,
+		},
+		{
+			Name:                "Remove unknown watcher",
+			TargetURL:           fmt.Sprintf("/api/v1/watchers/%s", uuid.New()),
+			HttpMethod:          http.MethodDelete,
+			MockMethodName:      DeleteWatcherMethod,
+			ReturnedError:       fmt.Errorf("redis error: %w", domain.ErrWatcherNotFound),
+			ExpectedCalledTimes: 1,
+			ExpectedStatusCode:  http.StatusNotFound,
+		},
+	}
+
+	for _, testCase := range watcherTestCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			testEnv := common.InitTestAppEnvironment()
+			appServer, err := testEnv.BuildAppServer(servConfig)
+			assert.NoError(t, err, "failed to build app server")
+
+			returned := []interface{}{testCase.ReturnedError}
+			if testCase.ReturnedData != nil {
+				returned = []interface{}{testCase.ReturnedData, testCase.ReturnedError}
+			}
+
+			var arguments []interface{}
+			if testCase.MockMethodName != LoadWatchersMethod {
+				arguments = append(arguments, mock.Anything)
+			}
+
+			testEnv.TaskStorage.
+				On(testCase.MockMethodName, arguments...).
+				Return(returned...)
+
+			req := httptest.NewRequestWithContext(context.Background(), testCase.HttpMethod, testCase.TargetURL, nil)
+			resp, respErr := appServer.Server.Test(req, -1)
+			assert.NoError(t, respErr, "failed to send request")
+			assert.Equal(t, testCase.ExpectedStatusCode, resp.StatusCode, "unexpected http status code")
+			testEnv.TaskStorage.AssertNumberOfCalls(t, testCase.MockMethodName, testCase.ExpectedCalledTimes)
+		})
+	}
+
+	t.Run("Update watcher directory", func(t *testing.T) {
+		testEnv := common.InitTestAppEnvironment()
+		appServer, err := testEnv.BuildAppServer(servConfig)
+		assert.NoError(t, err, "failed to build app server")
+
+		storedWatcher := *testWatcher
+		testEnv.ObjectStorage.
+			On(IsBucketExistsMethodName, TestBucketName).
+			Return(true, nil)
+		testEnv.TaskStorage.
+			On(GetWatcherMethod, testWatcher.ID).
+			Return(&storedWatcher, nil)
+		testEnv.TaskStorage.
+			On(UpdateWatcherMethod, mock.MatchedBy(func(watcher *domain.Watcher) bool {
+				return watcher.ID == testWatcher.ID && watcher.Prefix == "archive/2024/"
+			})).
+			Return(nil)
+
+		targetURL := fmt.Sprintf("/api/v1/watchers/%s", testWatcher.ID)
+		body := bytes.NewBufferString(fmt.Sprintf(`{"bucket": "%s", "suffix": "/archive/2024/"}`, TestBucketName))
+		req := httptest.NewRequestWithContext(context.Background(), http.MethodPut, targetURL, body)
+		req.Header.Set("Content-Type", "application/json")
+
+		resp, respErr := appServer.Server.Test(req, -1)
+		assert.NoError(t, respErr, "failed to update watcher")
+		assert.Equal(t, http.StatusOK, resp.StatusCode, "unexpected http status code")
+		testEnv.TaskStorage.AssertNumberOfCalls(t, UpdateWatcherMethod, 1)
+	})
+}
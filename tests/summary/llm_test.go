@@ -0,0 +1,150 @@
+package summary_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"watchtower/internal/support/task/application/service/summary"
+	"watchtower/internal/support/task/infrastructure/llm"
+)
+
+const (
+	TestChunkPrompt   = "summarize part"
+	TestSummaryPrompt = "summarize document"
+
+	TestSummaryAnswer = "```json\n" +
+		`{"summary": "Supply contract between Acme and Globex.", "labels": ["Contract", "unknown", "contract", "invoice"]}` +
+		"\n```"
+)
+
+type fakeLlmServer struct {
+	mu       sync.Mutex
+	requests []llm.ChatCompletionForm
+	apiKeys  []string
+}
+
+func (fs *fakeLlmServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var form llm.ChatCompletionForm
+	if err := json.NewDecoder(r.Body).Decode(&form); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	fs.mu.Lock()
+	fs.requests = append(fs.requests, form)
+	fs.apiKeys = append(fs.apiKeys, r.Header.Get("Authorization"))
+	partNumber := len(fs.requests)
+	fs.mu.Unlock()
+
+	answer := TestSummaryAnswer
+	if form.Messages[0].Content == TestChunkPrompt {
+		answer = fmt.Sprintf("summary of part %d", partNumber)
+	}
+
+	completion := llm.ChatCompletion{
+		Choices: []llm.ChatCompletionChoice{
+			{Message: llm.ChatMessage{Role: "assistant", Content: answer}, FinishReason: "stop"},
+		},
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(completion)
+}
+
+func TestLlmSummarizer(t *testing.T) {
+	ctx := context.Background()
+	taxonomy := []string{"contract", "invoice", "report"}
+
+	t.Run("Summarize short document by single request", func(t *testing.T) {
+		fakeServer := &fakeLlmServer{}
+		server := httptest.NewServer(fakeServer)
+		defer server.Close()
+
+		summarizer := llm.New(llm.Config{
+			Address:       server.URL,
+			APIKey:        "secret",
+			Model:         "test-model",
+			MaxTokens:     256,
+			Timeout:       10,
+			ChunkSize:     1000,
+			ChunkPrompt:   TestChunkPrompt,
+			SummaryPrompt: TestSummaryPrompt,
+		})
+
+		params := &summary.SummarizeParams{
+			FileName: "contract.pdf",
+			Text:     "Supply contract between Acme and Globex.",
+			Taxonomy: taxonomy,
+		}
+
+		summarized, err := summarizer.Summarize(ctx, params)
+		assert.NoError(t, err, "failed to summarize document")
+		assert.Equal(t, "Supply contract between Acme and Globex.", summarized.Text)
+		assert.Equal(t, []string{"contract", "invoice"}, summarized.Labels)
+
+		assert.Len(t, fakeServer.requests, 1)
+		request := fakeServer.requests[0]
+		assert.Equal(t, "test-model", request.Model)
+		assert.Equal(t, 256, request.MaxTokens)
+		assert.Equal(t, TestSummaryPrompt, request.Messages[0].Content)
+		assert.Contains(t, request.Messages[1].Content, "contract, invoice, report")
+		assert.Contains(t, request.Messages[1].Content, params.Text)
+		assert.Equal(t, "Bearer secret", fakeServer.apiKeys[0])
+	})
+
+	t.Run("Map-reduce long document with truncation", func(t *testing.T) {
+		fakeServer := &fakeLlmServer{}
+		server := httptest.NewServer(fakeServer)
+		defer server.Close()
+
+		summarizer := llm.New(llm.Config{
+			Address:       server.URL,
+			Timeout:       10,
+			ChunkSize:     100,
+			MaxChunks:     3,
+			ChunkPrompt:   TestChunkPrompt,
+			SummaryPrompt: TestSummaryPrompt,
+		})
+
+		params := &summary.SummarizeParams{
+			FileName: "report.txt",
+			Text:     strings.Repeat("long report line\n", 50),
+			Taxonomy: taxonomy,
+		}
+
+		summarized, err := summarizer.Summarize(ctx, params)
+		assert.NoError(t, err, "failed to summarize document")
+		assert.Equal(t, []string{"contract", "invoice"}, summarized.Labels)
+
+		assert.Len(t, fakeServer.requests, 4)
+		for _, request := range fakeServer.requests[:3] {
+			assert.Equal(t, TestChunkPrompt, request.Messages[0].Content)
+			assert.LessOrEqual(t, len([]rune(request.Messages[1].Content)), 100)
+		}
+
+		reduceMessage := fakeServer.requests[3].Messages[1].Content
+		assert.Contains(t, reduceMessage, "summary of part 1\n\nsummary of part 2\n\nsummary of part 3")
+		assert.Empty(t, fakeServer.apiKeys[3])
+	})
+
+	t.Run("Service error is returned", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusTooManyRequests)
+		}))
+		defer server.Close()
+
+		summarizer := llm.New(llm.Config{Address: server.URL, Timeout: 10})
+		params := &summary.SummarizeParams{FileName: "contract.pdf", Text: "text"}
+
+		_, err := summarizer.Summarize(ctx, params)
+		assert.Error(t, err)
+	})
+}